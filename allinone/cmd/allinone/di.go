@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+
+	"github.com/gorilla/mux"
+	"github.com/tsmweb/allinone/pkg/memkafka"
+	"github.com/tsmweb/broker-service/broker"
+	brokerdi "github.com/tsmweb/broker-service/di"
+	"github.com/tsmweb/broker-service/infra/db"
+	"github.com/tsmweb/chat-server/pkg/revocation"
+	chatdi "github.com/tsmweb/chat-service/di"
+	"github.com/tsmweb/go-helper-api/kafka"
+)
+
+// Provider wires chat-service and broker-service together, sharing the in-memory
+// Kafka and cache instead of Apache Kafka and Redis.
+type Provider struct {
+	chat   *chatdi.Provider
+	broker *brokerdi.Provider
+}
+
+func CreateProvider(ctx context.Context) *Provider {
+	k := memkafka.New()
+	return &Provider{
+		// The revoked tokens are kept in memory, like the cache.
+		chat:   chatdi.CreateProviderWith(ctx, k, revocation.NewMemoryStore()),
+		broker: brokerdi.CreateProviderWith(ctx, k, db.NewMemoryCacheDB()),
+	}
+}
+
+func (p *Provider) BrokerProvider() *broker.Broker {
+	return p.broker.BrokerProvider()
+}
+
+func (p *Provider) NewKafkaProducer(topic string) kafka.Producer {
+	return p.chat.NewKafkaProducer(topic)
+}
+
+func (p *Provider) ChatRouter(mr *mux.Router) error {
+	if err := p.chat.ChatRouter(mr); err != nil {
+		return err
+	}
+	if err := p.chat.AdminRouter(mr); err != nil {
+		return err
+	}
+	return p.chat.MessageRouter(mr)
+}
+
+func (p *Provider) MetricsRouter(mr *mux.Router) {
+	p.chat.MetricsRouter(mr)
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/gorilla/mux"
+	brokerconfig "github.com/tsmweb/broker-service/config"
+	chatconfig "github.com/tsmweb/chat-service/config"
+	"github.com/tsmweb/go-helper-api/middleware"
+	"github.com/tsmweb/go-helper-api/observability/event"
+	"github.com/urfave/negroni"
+)
+
+func main() {
+	log.Println("[INFO] starting all-in-one server")
+
+	// Working directory, both services read their settings from the same .env file.
+	workDir, _ := os.Getwd()
+	if err := chatconfig.Load(workDir); err != nil {
+		panic(err)
+	}
+	if err := brokerconfig.Load(workDir); err != nil {
+		panic(err)
+	}
+
+	// Increase resources limitations
+	var rLimit syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &rLimit); err != nil {
+		panic(err)
+	}
+
+	rLimit.Cur = rLimit.Max
+	if err := syscall.Setrlimit(syscall.RLIMIT_NOFILE, &rLimit); err != nil {
+		panic(err)
+	}
+
+	ctx, cancelFunc := signal.NotifyContext(context.Background(), os.Interrupt)
+	go func() {
+		<-ctx.Done()
+		log.Println("[INFO] stopping all-in-one server...")
+		cancelFunc()
+	}()
+
+	provider := CreateProvider(ctx)
+
+	// Initializes the services' event producer.
+	producerEvents := provider.NewKafkaProducer(chatconfig.KafkaEventsTopic())
+	if err := event.Init(producerEvents); err != nil {
+		log.Fatalf("[ERROR] Could not start events collects. Error: %s\n", err.Error())
+	}
+	defer event.Close()
+
+	// Start broker service
+	go provider.BrokerProvider().Start()
+
+	// Enable the metrics on an internal listener, they are not exposed on the public router.
+	if port := chatconfig.MetricsPort(); port > 0 {
+		metricsRouter := mux.NewRouter()
+		provider.MetricsRouter(metricsRouter)
+
+		log.Printf("[INFO] starting metrics server on :%d\n", port)
+		go func() {
+			log.Printf("[ERROR] metrics server error: %v\n",
+				http.ListenAndServe(fmt.Sprintf(":%d", port), metricsRouter))
+		}()
+	}
+
+	// starts chat API server
+	router := mux.NewRouter()
+	if err := provider.ChatRouter(router); err != nil {
+		log.Fatalf("[ERROR] error when starting server: %s\n", err.Error())
+	}
+
+	handler := middleware.GZIP(router)
+	handler = middleware.CORS(handler)
+
+	nr := negroni.New()
+	nr.Use(negroni.NewLogger())
+	nr.UseHandler(handler)
+
+	nr.Run(fmt.Sprintf(":%d", chatconfig.ServerPort()))
+}
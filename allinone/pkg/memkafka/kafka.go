@@ -0,0 +1,250 @@
+package memkafka
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/tsmweb/go-helper-api/kafka"
+)
+
+// DefaultQueueSize is the number of events kept for each consumer group by New.
+const DefaultQueueSize = 100000
+
+var droppedEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "memkafka",
+	Name:      "dropped_events_total",
+	Help:      "Total number of events dropped from the full queue of a consumer group.",
+}, []string{"topic", "group"})
+
+// memKafka is an in-memory implementation of the kafka.Kafka interface, to run several
+// services in a single process without an Apache Kafka cluster.
+//
+// As in Apache Kafka, each consumer group receives every event published on the topic,
+// and the consumers of the same group share the events among themselves. Events published
+// on a topic are only kept for the consumer groups already created on it.
+//
+// Each consumer group keeps up to queueSize events. As the retention of Apache Kafka, when
+// the queue is full the oldest event is dropped to keep the new one, so a slow consumer never
+// blocks the publishers, and the drop is counted in memkafka_dropped_events_total.
+type memKafka struct {
+	mu        sync.Mutex
+	topics    map[string]*topic
+	queueSize int
+	debug     bool
+}
+
+// New creates an instance of kafka.Kafka that keeps the events in memory, up to
+// DefaultQueueSize events for each consumer group.
+func New() kafka.Kafka {
+	return NewWithQueueSize(DefaultQueueSize)
+}
+
+// NewWithQueueSize creates an instance of kafka.Kafka that keeps up to queueSize events in
+// memory for each consumer group.
+func NewWithQueueSize(queueSize int) kafka.Kafka {
+	if queueSize < 1 {
+		queueSize = 1
+	}
+	return &memKafka{
+		topics:    make(map[string]*topic),
+		queueSize: queueSize,
+	}
+}
+
+// NewProducer creates a kafka.Producer that publishes on the topic.
+func (k *memKafka) NewProducer(topic string) kafka.Producer {
+	return &producer{
+		kafka: k,
+		topic: k.topic(topic),
+	}
+}
+
+// NewConsumer creates a kafka.Consumer of the topic that belongs to the groupID consumer group.
+func (k *memKafka) NewConsumer(groupID, topic string) kafka.Consumer {
+	return &consumer{
+		queue: k.topic(topic).queue(groupID),
+		done:  make(chan struct{}),
+	}
+}
+
+// Debug enables the logging of the published events.
+func (k *memKafka) Debug(debug bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.debug = debug
+}
+
+func (k *memKafka) isDebug() bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.debug
+}
+
+func (k *memKafka) topic(name string) *topic {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	t, ok := k.topics[name]
+	if !ok {
+		t = &topic{
+			name:      name,
+			groups:    make(map[string]*queue),
+			queueSize: k.queueSize,
+		}
+		k.topics[name] = t
+	}
+	return t
+}
+
+type topic struct {
+	mu        sync.Mutex
+	name      string
+	groups    map[string]*queue
+	queueSize int
+}
+
+func (t *topic) queue(groupID string) *queue {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	q, ok := t.groups[groupID]
+	if !ok {
+		q = &queue{
+			size:    t.queueSize,
+			notify:  make(chan struct{}, 1),
+			dropped: droppedEvents.WithLabelValues(t.name, groupID),
+		}
+		t.groups[groupID] = q
+	}
+	return q
+}
+
+func (t *topic) publish(event *kafka.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, q := range t.groups {
+		q.push(event)
+	}
+}
+
+// queue holds the events of a consumer group that have not yet been consumed, up to size.
+type queue struct {
+	mu      sync.Mutex
+	events  []*kafka.Event
+	size    int
+	notify  chan struct{}
+	dropped prometheus.Counter
+}
+
+// push adds the event to the queue, dropping the oldest event if the queue is full.
+func (q *queue) push(event *kafka.Event) {
+	q.mu.Lock()
+	if len(q.events) >= q.size {
+		q.events[0] = nil
+		q.events = q.events[1:]
+		q.dropped.Inc()
+	}
+	q.events = append(q.events, event)
+	q.mu.Unlock()
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (q *queue) pop() *kafka.Event {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.events) == 0 {
+		return nil
+	}
+	event := q.events[0]
+	q.events[0] = nil
+	q.events = q.events[1:]
+
+	// Wakes up another consumer of the group if there are events left.
+	if len(q.events) > 0 {
+		select {
+		case q.notify <- struct{}{}:
+		default:
+		}
+	}
+	return event
+}
+
+type producer struct {
+	kafka *memKafka
+	topic *topic
+}
+
+// Publish publishes the values on the topic with the given key.
+func (p *producer) Publish(ctx context.Context, key []byte, values ...[]byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for _, value := range values {
+		event := &kafka.Event{
+			Topic: p.topic.name,
+			Key:   append([]byte(nil), key...),
+			Value: append([]byte(nil), value...),
+			Time:  time.Now(),
+		}
+		if p.kafka.isDebug() {
+			log.Printf("[DEBUG] memkafka: publish topic=%s key=%s size=%d\n",
+				event.Topic, event.Key, len(event.Value))
+		}
+		p.topic.publish(event)
+	}
+	return nil
+}
+
+// Close does nothing, there is no connection to release.
+func (p *producer) Close() {}
+
+type consumer struct {
+	queue     *queue
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Subscribe calls callbackFn for each event of the topic, until ctx is done or
+// the consumer is closed.
+func (c *consumer) Subscribe(ctx context.Context, callbackFn func(event *kafka.Event, err error)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.done:
+			return
+		default:
+		}
+
+		if event := c.queue.pop(); event != nil {
+			callbackFn(event, nil)
+			continue
+		}
+
+		select {
+		case <-c.queue.notify:
+		case <-ctx.Done():
+			return
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// Close stops the consumer subscription.
+func (c *consumer) Close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+	})
+}
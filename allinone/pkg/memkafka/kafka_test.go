@@ -0,0 +1,130 @@
+package memkafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/tsmweb/go-helper-api/kafka"
+)
+
+func TestKafka_Publish(t *testing.T) {
+	//t.Parallel()
+
+	t.Run("when each group receives all events", func(t *testing.T) {
+		//t.Parallel()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		k := New()
+		chA := subscribe(ctx, k.NewConsumer("A", "TOPIC"))
+		chB := subscribe(ctx, k.NewConsumer("B", "TOPIC"))
+
+		err := k.NewProducer("TOPIC").Publish(ctx, []byte("key"), []byte("v1"), []byte("v2"))
+		assert.Nil(t, err)
+
+		for _, ch := range []<-chan *kafka.Event{chA, chB} {
+			for _, want := range []string{"v1", "v2"} {
+				event := <-ch
+				assert.Equal(t, "TOPIC", event.Topic)
+				assert.Equal(t, "key", string(event.Key))
+				assert.Equal(t, want, string(event.Value))
+			}
+		}
+	})
+
+	t.Run("when consumers of the same group share the events", func(t *testing.T) {
+		//t.Parallel()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		k := New()
+		ch1 := subscribe(ctx, k.NewConsumer("A", "TOPIC"))
+		ch2 := subscribe(ctx, k.NewConsumer("A", "TOPIC"))
+
+		producer := k.NewProducer("TOPIC")
+		for i := 0; i < 10; i++ {
+			assert.Nil(t, producer.Publish(ctx, nil, []byte{byte(i)}))
+		}
+
+		received := 0
+		for received < 10 {
+			select {
+			case <-ch1:
+			case <-ch2:
+			case <-ctx.Done():
+				t.Fatalf("received %d of 10 events", received)
+			}
+			received++
+		}
+
+		select {
+		case <-ch1:
+			t.Fatal("event received twice")
+		case <-ch2:
+			t.Fatal("event received twice")
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("when events are published before subscribe", func(t *testing.T) {
+		//t.Parallel()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		k := New()
+		consumer := k.NewConsumer("A", "TOPIC")
+		assert.Nil(t, k.NewProducer("TOPIC").Publish(ctx, nil, []byte("v1")))
+
+		event := <-subscribe(ctx, consumer)
+		assert.Equal(t, "v1", string(event.Value))
+	})
+
+	t.Run("when the queue of the group is full", func(t *testing.T) {
+		//t.Parallel()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		k := NewWithQueueSize(2)
+		consumer := k.NewConsumer("FULL", "TOPIC")
+		dropped := testutil.ToFloat64(droppedEvents.WithLabelValues("TOPIC", "FULL"))
+
+		err := k.NewProducer("TOPIC").Publish(ctx, nil, []byte("v1"), []byte("v2"), []byte("v3"))
+		assert.Nil(t, err)
+		assert.Equal(t, dropped+1, testutil.ToFloat64(droppedEvents.WithLabelValues("TOPIC", "FULL")))
+
+		ch := subscribe(ctx, consumer)
+		for _, want := range []string{"v2", "v3"} {
+			event := <-ch
+			assert.Equal(t, want, string(event.Value))
+		}
+	})
+
+	t.Run("when consumer is closed", func(t *testing.T) {
+		//t.Parallel()
+		consumer := New().NewConsumer("A", "TOPIC")
+
+		done := make(chan struct{})
+		go func() {
+			consumer.Subscribe(context.Background(), func(*kafka.Event, error) {})
+			close(done)
+		}()
+		consumer.Close()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Subscribe did not return after Close")
+		}
+	})
+}
+
+func subscribe(ctx context.Context, consumer kafka.Consumer) <-chan *kafka.Event {
+	ch := make(chan *kafka.Event, 16)
+	go consumer.Subscribe(ctx, func(event *kafka.Event, err error) {
+		ch <- event
+	})
+	return ch
+}
@@ -0,0 +1,32 @@
+package adapter
+
+import (
+	"time"
+
+	"github.com/tsmweb/auth-service/app/account"
+	"github.com/tsmweb/auth-service/app/user"
+	"github.com/tsmweb/auth-service/infra/protobuf"
+	"google.golang.org/protobuf/proto"
+)
+
+// AccountEventMarshal is a account.Event encoder for protobuf.AccountEvent.
+func AccountEventMarshal(e *account.Event) ([]byte, error) {
+	epb := &protobuf.AccountEvent{
+		UserId:    e.UserID,
+		Event:     protobuf.AccountEventType(protobuf.AccountEventType_value[e.Event]),
+		EventDate: e.EventDate.Unix(),
+	}
+	return proto.Marshal(epb)
+}
+
+// DeletionReportUnmarshal is a protobuf.DeletionReport decoder for user.DeletionReport.
+func DeletionReportUnmarshal(in []byte, r *user.DeletionReport) error {
+	rpb := new(protobuf.DeletionReport)
+	if err := proto.Unmarshal(in, rpb); err != nil {
+		return err
+	}
+	r.UserID = rpb.GetUserId()
+	r.Service = rpb.GetService()
+	r.CompletedAt = time.Unix(rpb.GetCompletedAt(), 0).UTC()
+	return nil
+}
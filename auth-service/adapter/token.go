@@ -0,0 +1,43 @@
+package adapter
+
+import (
+	"time"
+
+	"github.com/tsmweb/auth-service/app/login"
+	"github.com/tsmweb/auth-service/infra/protobuf"
+	"google.golang.org/protobuf/proto"
+)
+
+// TokenRevocationMarshal is a login.TokenRevocation encoder for protobuf.TokenRevocation.
+func TokenRevocationMarshal(r *login.TokenRevocation) ([]byte, error) {
+	rpb := protobufFromTokenRevocation(r)
+	return proto.Marshal(rpb)
+}
+
+// TokenRevocationUnmarshal is a protobuf.TokenRevocation decoder for login.TokenRevocation.
+func TokenRevocationUnmarshal(in []byte, r *login.TokenRevocation) error {
+	rpb := new(protobuf.TokenRevocation)
+	if err := proto.Unmarshal(in, rpb); err != nil {
+		return err
+	}
+	protobufToTokenRevocation(rpb, r)
+	return nil
+}
+
+func protobufFromTokenRevocation(r *login.TokenRevocation) *protobuf.TokenRevocation {
+	return &protobuf.TokenRevocation{
+		UserId:    r.UserID,
+		TokenId:   r.TokenID,
+		SessionId: r.SessionID,
+		Reason:    protobuf.RevocationReason(protobuf.RevocationReason_value[r.Reason]),
+		RevokedAt: r.RevokedAt.Unix(),
+	}
+}
+
+func protobufToTokenRevocation(rpb *protobuf.TokenRevocation, r *login.TokenRevocation) {
+	r.UserID = rpb.GetUserId()
+	r.TokenID = rpb.GetTokenId()
+	r.SessionID = rpb.GetSessionId()
+	r.Reason = rpb.GetReason().String()
+	r.RevokedAt = time.Unix(rpb.GetRevokedAt(), 0)
+}
@@ -0,0 +1,80 @@
+package account
+
+import (
+	"context"
+	"time"
+
+	"github.com/tsmweb/go-helper-api/kafka"
+)
+
+// EventType represents the event type of the user account ("user deleted", "user created",
+// "user updated", "password changed").
+type EventType int
+
+const (
+	// EventUserDeleted represents the account delete event.
+	EventUserDeleted EventType = iota
+
+	// EventUserCreated represents the account create event.
+	EventUserCreated
+
+	// EventUserUpdated represents the profile update event.
+	EventUserUpdated
+
+	// EventPasswordChanged represents the password change event.
+	EventPasswordChanged
+)
+
+var eventTypeText = map[EventType]string{
+	EventUserDeleted:     "UserDeleted",
+	EventUserCreated:     "UserCreated",
+	EventUserUpdated:     "UserUpdated",
+	EventPasswordChanged: "PasswordChanged",
+}
+
+// String return the name of the EventType.
+func (e EventType) String() string {
+	return eventTypeText[e]
+}
+
+// Event represents the events of the user account.
+type Event struct {
+	UserID    string
+	Event     string
+	EventDate time.Time
+}
+
+// NewEvent return an instance of Event.
+func NewEvent(userID string, event EventType) *Event {
+	return &Event{
+		UserID:    userID,
+		Event:     event.String(),
+		EventDate: time.Now().UTC(),
+	}
+}
+
+// EventEncoder is a Event encoder for byte slice.
+type EventEncoder interface {
+	Marshal(e *Event) ([]byte, error)
+}
+
+// The EventEncoderFunc type is an adapter to allow the use of ordinary functions as encoders of
+// Event for byte slice.
+// If f is a function with the appropriate signature, EventEncoderFunc(f) is a EventEncoder that
+// calls f.
+type EventEncoderFunc func(e *Event) ([]byte, error)
+
+// Marshal calls f(e).
+func (f EventEncoderFunc) Marshal(e *Event) ([]byte, error) {
+	return f(e)
+}
+
+// Publish encodes the Event and publishes it keyed by the user ID, so that the events of a user
+// are consumed in order.
+func Publish(ctx context.Context, encoder EventEncoder, producer kafka.Producer, e *Event) error {
+	epb, err := encoder.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return producer.Publish(ctx, []byte(e.UserID), epb)
+}
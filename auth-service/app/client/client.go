@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/tsmweb/chat-server/pkg/scope"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+var (
+	ErrClientNotFound = errors.New("client not found")
+	ErrInvalidClient  = errors.New("invalid client")
+	ErrInvalidScope   = errors.New("invalid scope")
+
+	ErrNameValidateModel  = &cerror.ErrValidateModel{Msg: "required name"}
+	ErrScopeValidateModel = &cerror.ErrValidateModel{Msg: "required valid scopes"}
+)
+
+// IDPrefix distinguishes the ID of the service accounts from the phone numbers of the users.
+const IDPrefix = "svc_"
+
+// Client is a service account used by bots and integrations, which obtains access tokens
+// restricted to its scopes with the OAuth2 client credentials grant. Its ID is also a user
+// ID, so that it can take part in contacts and groups, but it has no password and can not
+// log in. The secret, the API key of the account, is not stored, only its hash.
+type Client struct {
+	ID         string
+	Name       string
+	SecretHash string
+	Scopes     []string
+	CreatedBy  string
+	CreatedAt  time.Time
+	RevokedAt  time.Time
+}
+
+// NewClient creates a service account with the scopes, returning it along with the secret
+// to be sent to its owner.
+func NewClient(name string, scopes []string, createdBy string) (*Client, string, error) {
+	c := &Client{
+		Name:      name,
+		Scopes:    scopes,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := c.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	ID, err := randomString(12)
+	if err != nil {
+		return nil, "", err
+	}
+	c.ID = IDPrefix + ID
+
+	secret, err := randomString(32)
+	if err != nil {
+		return nil, "", err
+	}
+	c.SecretHash = HashSecret(secret)
+
+	return c, secret, nil
+}
+
+// Validate model Client.
+func (c *Client) Validate() error {
+	if c.Name == "" {
+		return ErrNameValidateModel
+	}
+	if len(c.Scopes) == 0 {
+		return ErrScopeValidateModel
+	}
+	for _, s := range c.Scopes {
+		if !scope.Valid(s) {
+			return ErrScopeValidateModel
+		}
+	}
+	return nil
+}
+
+// IsRevoked reports whether the service account was revoked.
+func (c *Client) IsRevoked() bool {
+	return !c.RevokedAt.IsZero()
+}
+
+// VerifySecret reports whether the secret belongs to the service account.
+func (c *Client) VerifySecret(secret string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashSecret(secret)), []byte(c.SecretHash)) == 1
+}
+
+// GrantScopes returns the scopes granted to a token, all the scopes of the service account
+// when requested is empty, or else the scopes requested if the account has all of them.
+func (c *Client) GrantScopes(requested string) (scope.Set, error) {
+	granted := scope.Set{}
+	for _, s := range c.Scopes {
+		granted[s] = true
+	}
+	if requested == "" {
+		return granted, nil
+	}
+
+	set := scope.Parse(requested)
+	for s := range set {
+		if !granted[s] {
+			return nil, ErrInvalidScope
+		}
+	}
+	return set, nil
+}
+
+// HashSecret returns the hash that identifies the secret in the data source.
+func HashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Repository interface for service account data source.
+type Repository interface {
+	// Create stores the service account along with its user.
+	Create(ctx context.Context, c *Client) error
+	// Get returns the service account by ID, or cerror.ErrNotFound.
+	Get(ctx context.Context, ID string) (*Client, error)
+	// GetAll returns the service accounts not revoked.
+	GetAll(ctx context.Context) ([]*Client, error)
+	// Revoke revokes the service account, returning false if it was not found or already
+	// revoked.
+	Revoke(ctx context.Context, ID string, revokedAt time.Time) (bool, error)
+	// SetWebhook stores the webhook of the service account, replacing the previous one.
+	SetWebhook(ctx context.Context, w *Webhook) error
+	// DeleteWebhook removes the webhook of the service account, returning false if it was
+	// not found.
+	DeleteWebhook(ctx context.Context, clientID string) (bool, error)
+}
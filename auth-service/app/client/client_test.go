@@ -0,0 +1,56 @@
+package client
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsmweb/chat-server/pkg/scope"
+)
+
+func TestNewClient(t *testing.T) {
+	//t.Parallel()
+	t.Run("when validate fails", func(t *testing.T) {
+		//t.Parallel()
+		_, _, err := NewClient("", []string{scope.MessagesSend}, "+5518977777777")
+		assert.Equal(t, ErrNameValidateModel, err)
+
+		_, _, err = NewClient("Bot", nil, "+5518977777777")
+		assert.Equal(t, ErrScopeValidateModel, err)
+
+		_, _, err = NewClient("Bot", []string{"messages:delete"}, "+5518977777777")
+		assert.Equal(t, ErrScopeValidateModel, err)
+	})
+
+	t.Run("when client is created", func(t *testing.T) {
+		//t.Parallel()
+		c, secret, err := NewClient("Bot", []string{scope.Group("G1")}, "+5518977777777")
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(c.ID, IDPrefix))
+		assert.NotEmpty(t, secret)
+		assert.Equal(t, HashSecret(secret), c.SecretHash)
+		assert.True(t, c.VerifySecret(secret))
+		assert.False(t, c.VerifySecret("other"))
+		assert.False(t, c.IsRevoked())
+
+		other, otherSecret, _ := NewClient("Bot", []string{scope.Group("G1")}, "+5518977777777")
+		assert.NotEqual(t, c.ID, other.ID)
+		assert.NotEqual(t, secret, otherSecret)
+	})
+}
+
+func TestClient_GrantScopes(t *testing.T) {
+	//t.Parallel()
+	c := &Client{Scopes: []string{scope.Group("G1"), scope.ContactsRead}}
+
+	s, err := c.GrantScopes("")
+	assert.Nil(t, err)
+	assert.Equal(t, "contacts:read messages:send:group:G1", s.String())
+
+	s, err = c.GrantScopes(scope.ContactsRead)
+	assert.Nil(t, err)
+	assert.Equal(t, "contacts:read", s.String())
+
+	_, err = c.GrantScopes(scope.ContactsRead + " " + scope.MessagesSend)
+	assert.Equal(t, ErrInvalidScope, err)
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+
+	"github.com/tsmweb/auth-service/common/service"
+)
+
+// CreateUseCase creates a service account with the scopes, returning it along with its
+// secret, otherwise an error is returned.
+type CreateUseCase interface {
+	Execute(ctx context.Context, name string, scopes []string, createdBy string) (*Client, string, error)
+}
+
+type createUseCase struct {
+	tag        string
+	repository Repository
+}
+
+// NewCreateUseCase create a new instance of CreateUseCase.
+func NewCreateUseCase(repository Repository) CreateUseCase {
+	return &createUseCase{
+		tag:        "client::CreateUseCase",
+		repository: repository,
+	}
+}
+
+// Execute executes the create use case.
+func (u *createUseCase) Execute(ctx context.Context, name string, scopes []string,
+	createdBy string) (*Client, string, error) {
+	c, secret, err := NewClient(name, scopes, createdBy)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err = u.repository.Create(ctx, c); err != nil {
+		service.Error(createdBy, u.tag, err)
+		return nil, "", err
+	}
+
+	return c, secret, nil
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/chat-server/pkg/scope"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+func TestCreateUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+	scopes := []string{scope.MessagesSend}
+
+	t.Run("when use case fails with ErrValidateModel", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+
+		_, _, err := NewCreateUseCase(r).Execute(ctx, "", scopes, "+5518977777777")
+
+		var errValidateModel *cerror.ErrValidateModel
+		assert.ErrorAs(t, err, &errValidateModel)
+		r.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Create", mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Once()
+
+		_, _, err := NewCreateUseCase(r).Execute(ctx, "Bot", scopes, "+5518977777777")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when use case succeeds", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Create", mock.Anything, mock.MatchedBy(func(c *Client) bool {
+			return c.Name == "Bot" && c.CreatedBy == "+5518977777777"
+		})).
+			Return(nil).
+			Once()
+
+		c, secret, err := NewCreateUseCase(r).Execute(ctx, "Bot", scopes, "+5518977777777")
+		assert.Nil(t, err)
+		assert.True(t, c.VerifySecret(secret))
+		r.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+
+	"github.com/tsmweb/auth-service/common/service"
+)
+
+// DeleteWebhookUseCase removes the webhook of the service account, which stops receiving
+// its messages, otherwise an error is returned.
+type DeleteWebhookUseCase interface {
+	Execute(ctx context.Context, clientID string) error
+}
+
+type deleteWebhookUseCase struct {
+	tag        string
+	repository Repository
+}
+
+// NewDeleteWebhookUseCase create a new instance of DeleteWebhookUseCase.
+func NewDeleteWebhookUseCase(repository Repository) DeleteWebhookUseCase {
+	return &deleteWebhookUseCase{
+		tag:        "client::DeleteWebhookUseCase",
+		repository: repository,
+	}
+}
+
+// Execute executes the delete webhook use case.
+func (u *deleteWebhookUseCase) Execute(ctx context.Context, clientID string) error {
+	ok, err := u.repository.DeleteWebhook(ctx, clientID)
+	if err != nil {
+		service.Error(clientID, u.tag, err)
+		return err
+	}
+	if !ok {
+		return ErrWebhookNotFound
+	}
+
+	return nil
+}
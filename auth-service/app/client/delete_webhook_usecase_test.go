@@ -0,0 +1,37 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeleteWebhookUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+	ID := IDPrefix + "A1B2C3"
+
+	t.Run("when use case fails with ErrWebhookNotFound", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("DeleteWebhook", mock.Anything, ID).
+			Return(false, nil).
+			Once()
+
+		err := NewDeleteWebhookUseCase(r).Execute(ctx, ID)
+		assert.Equal(t, ErrWebhookNotFound, err)
+	})
+
+	t.Run("when use case succeeds", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("DeleteWebhook", mock.Anything, ID).
+			Return(true, nil).
+			Once()
+
+		err := NewDeleteWebhookUseCase(r).Execute(ctx, ID)
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+
+	"github.com/tsmweb/auth-service/common/service"
+)
+
+// GetAllUseCase returns the service accounts not revoked, otherwise an error is returned.
+type GetAllUseCase interface {
+	Execute(ctx context.Context) ([]*Client, error)
+}
+
+type getAllUseCase struct {
+	tag        string
+	repository Repository
+}
+
+// NewGetAllUseCase create a new instance of GetAllUseCase.
+func NewGetAllUseCase(repository Repository) GetAllUseCase {
+	return &getAllUseCase{
+		tag:        "client::GetAllUseCase",
+		repository: repository,
+	}
+}
+
+// Execute executes the get all use case.
+func (u *getAllUseCase) Execute(ctx context.Context) ([]*Client, error) {
+	clients, err := u.repository.GetAll(ctx)
+	if err != nil {
+		service.Error("", u.tag, err)
+		return nil, err
+	}
+	return clients, nil
+}
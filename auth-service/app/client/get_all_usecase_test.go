@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetAllUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("GetAll", mock.Anything).
+			Return(nil, errors.New("error")).
+			Once()
+
+		_, err := NewGetAllUseCase(r).Execute(ctx)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when use case succeeds", func(t *testing.T) {
+		//t.Parallel()
+		clients := []*Client{{ID: IDPrefix + "A1", Name: "Bot"}}
+
+		r := new(mockRepository)
+		r.On("GetAll", mock.Anything).
+			Return(clients, nil).
+			Once()
+
+		result, err := NewGetAllUseCase(r).Execute(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, clients, result)
+	})
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// mockRepository injects mock dependency into UseCase layer.
+type mockRepository struct {
+	mock.Mock
+}
+
+// Create represents the simulated method for the Create feature in the Repository layer.
+func (m *mockRepository) Create(ctx context.Context, c *Client) error {
+	args := m.Called(ctx, c)
+	return args.Error(0)
+}
+
+// Get represents the simulated method for the Get feature in the Repository layer.
+func (m *mockRepository) Get(ctx context.Context, ID string) (*Client, error) {
+	args := m.Called(ctx, ID)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Client), nil
+}
+
+// GetAll represents the simulated method for the GetAll feature in the Repository layer.
+func (m *mockRepository) GetAll(ctx context.Context) ([]*Client, error) {
+	args := m.Called(ctx)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Client), nil
+}
+
+// Revoke represents the simulated method for the Revoke feature in the Repository layer.
+func (m *mockRepository) Revoke(ctx context.Context, ID string, revokedAt time.Time) (bool, error) {
+	args := m.Called(ctx, ID, revokedAt)
+	if args.Error(1) != nil {
+		return false, args.Error(1)
+	}
+	return args.Bool(0), nil
+}
+
+// SetWebhook represents the simulated method for the SetWebhook feature in the Repository layer.
+func (m *mockRepository) SetWebhook(ctx context.Context, w *Webhook) error {
+	args := m.Called(ctx, w)
+	return args.Error(0)
+}
+
+// DeleteWebhook represents the simulated method for the DeleteWebhook feature in the Repository layer.
+func (m *mockRepository) DeleteWebhook(ctx context.Context, clientID string) (bool, error) {
+	args := m.Called(ctx, clientID)
+	if args.Error(1) != nil {
+		return false, args.Error(1)
+	}
+	return args.Bool(0), nil
+}
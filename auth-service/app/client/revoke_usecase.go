@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/tsmweb/auth-service/app/login"
+	"github.com/tsmweb/auth-service/common/service"
+	"github.com/tsmweb/auth-service/config"
+	"github.com/tsmweb/chat-server/pkg/revocation"
+	"github.com/tsmweb/go-helper-api/kafka"
+)
+
+// RevokeUseCase revokes a service account along with its access tokens, disconnecting it,
+// otherwise an error is returned.
+type RevokeUseCase interface {
+	Execute(ctx context.Context, ID string) error
+}
+
+type revokeUseCase struct {
+	tag        string
+	repository Repository
+	store      revocation.Store
+	encoder    login.TokenRevocationEncoder
+	producer   kafka.Producer
+}
+
+// NewRevokeUseCase create a new instance of RevokeUseCase.
+func NewRevokeUseCase(
+	repository Repository,
+	store revocation.Store,
+	encoder login.TokenRevocationEncoder,
+	producer kafka.Producer,
+) RevokeUseCase {
+	return &revokeUseCase{
+		tag:        "client::RevokeUseCase",
+		repository: repository,
+		store:      store,
+		encoder:    encoder,
+		producer:   producer,
+	}
+}
+
+// Execute executes the revoke use case.
+func (u *revokeUseCase) Execute(ctx context.Context, ID string) error {
+	revoked := login.NewTokenRevocation(ID, login.RevocationClientRevoked)
+
+	ok, err := u.repository.Revoke(ctx, ID, revoked.RevokedAt)
+	if err != nil {
+		service.Error(ID, u.tag, err)
+		return err
+	}
+	if !ok {
+		return ErrClientNotFound
+	}
+
+	ttl := time.Duration(config.ExpireToken()) * time.Hour
+	if err = u.store.RevokeUser(ctx, ID, revoked.RevokedAt, ttl); err != nil {
+		service.Error(ID, u.tag, err)
+		return err
+	}
+
+	rpb, err := u.encoder.Marshal(revoked)
+	if err != nil {
+		service.Error(ID, u.tag, err)
+		return err
+	}
+	if err = u.producer.Publish(ctx, []byte(ID), rpb); err != nil {
+		service.Error(ID, u.tag, err)
+		return &login.ErrEventNotification{Msg: err.Error()}
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/login"
+	"github.com/tsmweb/auth-service/common"
+)
+
+func TestRevokeUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+	ID := IDPrefix + "A1B2C3"
+
+	var revoked *login.TokenRevocation
+	encoder := login.TokenRevocationEncoderFunc(func(r *login.TokenRevocation) ([]byte, error) {
+		revoked = r
+		return []byte(r.UserID), nil
+	})
+
+	t.Run("when use case fails with ErrClientNotFound", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Revoke", mock.Anything, ID, mock.Anything).
+			Return(false, nil).
+			Once()
+		s := new(mockRevocationStore)
+
+		err := NewRevokeUseCase(r, s, encoder, new(common.MockKafkaProducer)).Execute(ctx, ID)
+		assert.Equal(t, ErrClientNotFound, err)
+		s.AssertNotCalled(t, "RevokeUser", mock.Anything, mock.Anything, mock.Anything,
+			mock.Anything)
+	})
+
+	t.Run("when use case fails with ErrEventNotification", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Revoke", mock.Anything, ID, mock.Anything).
+			Return(true, nil).
+			Once()
+		s := new(mockRevocationStore)
+		s.On("RevokeUser", mock.Anything, ID, mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		p := new(common.MockKafkaProducer)
+		p.On("Publish", mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Once()
+
+		err := NewRevokeUseCase(r, s, encoder, p).Execute(ctx, ID)
+
+		var errEventNotification *login.ErrEventNotification
+		assert.ErrorAs(t, err, &errEventNotification)
+	})
+
+	t.Run("when use case succeeds", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Revoke", mock.Anything, ID, mock.Anything).
+			Return(true, nil).
+			Once()
+		s := new(mockRevocationStore)
+		s.On("RevokeUser", mock.Anything, ID, mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		p := new(common.MockKafkaProducer)
+		p.On("Publish", mock.Anything, []byte(ID), [][]byte{[]byte(ID)}).
+			Return(nil).
+			Once()
+
+		err := NewRevokeUseCase(r, s, encoder, p).Execute(ctx, ID)
+		assert.Nil(t, err)
+		assert.Equal(t, "ClientRevoked", revoked.Reason)
+		s.AssertExpectations(t)
+		p.AssertExpectations(t)
+	})
+}
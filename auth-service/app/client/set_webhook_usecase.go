@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tsmweb/auth-service/common/service"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+// SetWebhookUseCase registers the URL where the service account receives its messages,
+// returning the webhook along with the secret that signs the deliveries, otherwise an
+// error is returned.
+type SetWebhookUseCase interface {
+	Execute(ctx context.Context, clientID, url string) (*Webhook, error)
+}
+
+type setWebhookUseCase struct {
+	tag        string
+	repository Repository
+}
+
+// NewSetWebhookUseCase create a new instance of SetWebhookUseCase.
+func NewSetWebhookUseCase(repository Repository) SetWebhookUseCase {
+	return &setWebhookUseCase{
+		tag:        "client::SetWebhookUseCase",
+		repository: repository,
+	}
+}
+
+// Execute executes the set webhook use case.
+func (u *setWebhookUseCase) Execute(ctx context.Context, clientID, url string) (*Webhook, error) {
+	w, err := NewWebhook(clientID, url)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := u.repository.Get(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, cerror.ErrNotFound) {
+			return nil, ErrClientNotFound
+		}
+		service.Error(clientID, u.tag, err)
+		return nil, err
+	}
+	if c.IsRevoked() {
+		return nil, ErrClientNotFound
+	}
+
+	if err = u.repository.SetWebhook(ctx, w); err != nil {
+		service.Error(clientID, u.tag, err)
+		return nil, err
+	}
+
+	return w, nil
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+func TestSetWebhookUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+	ID := IDPrefix + "A1B2C3"
+	url := "https://bot.example.com/webhook"
+
+	t.Run("when use case fails with ErrURLValidateModel", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+
+		_, err := NewSetWebhookUseCase(r).Execute(ctx, ID, "ftp://bot.example.com")
+		assert.Equal(t, ErrURLValidateModel, err)
+		r.AssertNotCalled(t, "SetWebhook", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with ErrURLAddressValidateModel", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		uc := NewSetWebhookUseCase(r)
+
+		for _, u := range []string{
+			"http://localhost:8080/webhook",
+			"http://127.0.0.1/webhook",
+			"https://10.0.0.1/webhook",
+			"http://169.254.169.254/latest/meta-data",
+			"http://[::1]:8080/webhook",
+		} {
+			_, err := uc.Execute(ctx, ID, u)
+			assert.Equal(t, ErrURLAddressValidateModel, err, u)
+		}
+		r.AssertNotCalled(t, "SetWebhook", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with ErrClientNotFound", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, ID).
+			Return(nil, cerror.ErrNotFound).
+			Once()
+
+		_, err := NewSetWebhookUseCase(r).Execute(ctx, ID, url)
+		assert.Equal(t, ErrClientNotFound, err)
+	})
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, ID).
+			Return(&Client{ID: ID}, nil).
+			Once()
+		r.On("SetWebhook", mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Once()
+
+		_, err := NewSetWebhookUseCase(r).Execute(ctx, ID, url)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when use case succeeds", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, ID).
+			Return(&Client{ID: ID}, nil).
+			Once()
+		r.On("SetWebhook", mock.Anything, mock.MatchedBy(func(w *Webhook) bool {
+			return w.ClientID == ID && w.URL == url
+		})).
+			Return(nil).
+			Once()
+
+		w, err := NewSetWebhookUseCase(r).Execute(ctx, ID, url)
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(w.Secret, WebhookSecretPrefix))
+		r.AssertExpectations(t)
+	})
+}
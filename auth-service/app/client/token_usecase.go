@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/tsmweb/auth-service/app/token"
+	"github.com/tsmweb/auth-service/common/service"
+	"github.com/tsmweb/auth-service/config"
+	"github.com/tsmweb/chat-server/pkg/revocation"
+	"github.com/tsmweb/chat-server/pkg/scope"
+	"github.com/tsmweb/go-helper-api/auth"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+// TokenUseCase exchanges the credentials of a service account for an access token restricted
+// to its scopes, or to the scopes requested, otherwise an error is returned. No refresh token
+// is issued, the service account requests a new access token when it expires.
+type TokenUseCase interface {
+	Execute(ctx context.Context, ID, secret, requestedScope string) (*token.Token, scope.Set, error)
+}
+
+type tokenUseCase struct {
+	tag        string
+	repository Repository
+	jwt        auth.JWT
+}
+
+// NewTokenUseCase create a new instance of TokenUseCase.
+func NewTokenUseCase(repository Repository, jwt auth.JWT) TokenUseCase {
+	return &tokenUseCase{
+		tag:        "client::TokenUseCase",
+		repository: repository,
+		jwt:        jwt,
+	}
+}
+
+// Execute executes the token use case.
+func (u *tokenUseCase) Execute(ctx context.Context, ID, secret,
+	requestedScope string) (*token.Token, scope.Set, error) {
+	c, err := u.repository.Get(ctx, ID)
+	if err != nil {
+		if errors.Is(err, cerror.ErrNotFound) {
+			return nil, nil, ErrInvalidClient
+		}
+		service.Error(ID, u.tag, err)
+		return nil, nil, err
+	}
+	if c.IsRevoked() || !c.VerifySecret(secret) {
+		return nil, nil, ErrInvalidClient
+	}
+
+	scopes, err := c.GrantScopes(requestedScope)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokenID, err := randomString(16)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	payload := map[string]interface{}{
+		"id":    c.ID,
+		"jti":   tokenID,
+		"iat":   revocation.IssuedAt(time.Now()),
+		"scope": scopes.String(),
+	}
+
+	accessToken, err := u.jwt.GenerateToken(payload, config.ExpireToken())
+	if err != nil {
+		service.Error(ID, u.tag, err)
+		return nil, nil, err
+	}
+	if len(accessToken) == 0 {
+		return nil, nil, cerror.ErrUnauthorized
+	}
+
+	return &token.Token{
+		AccessToken: accessToken,
+		ExpiresIn:   config.ExpireToken() * int(time.Hour/time.Second),
+	}, scopes, nil
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/common"
+	"github.com/tsmweb/auth-service/config"
+	"github.com/tsmweb/chat-server/pkg/scope"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+func TestTokenUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+	c, secret, _ := NewClient("Bot", []string{scope.Group("G1"), scope.ContactsRead},
+		"+5518977777777")
+
+	t.Run("when use case fails with ErrInvalidClient", func(t *testing.T) {
+		//t.Parallel()
+		revoked, revokedSecret, _ := NewClient("Bot", []string{scope.MessagesSend}, "+5518977777777")
+		revoked.RevokedAt = time.Now().UTC()
+
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "unknown").
+			Return(nil, cerror.ErrNotFound).
+			Once()
+		r.On("Get", mock.Anything, c.ID).
+			Return(c, nil).
+			Once()
+		r.On("Get", mock.Anything, revoked.ID).
+			Return(revoked, nil).
+			Once()
+		j := new(common.MockJWT)
+		uc := NewTokenUseCase(r, j)
+
+		_, _, err := uc.Execute(ctx, "unknown", secret, "")
+		assert.Equal(t, ErrInvalidClient, err)
+
+		_, _, err = uc.Execute(ctx, c.ID, "wrong", "")
+		assert.Equal(t, ErrInvalidClient, err)
+
+		_, _, err = uc.Execute(ctx, revoked.ID, revokedSecret, "")
+		assert.Equal(t, ErrInvalidClient, err)
+		j.AssertNotCalled(t, "GenerateToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with ErrInvalidScope", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, c.ID).
+			Return(c, nil).
+			Once()
+
+		_, _, err := NewTokenUseCase(r, new(common.MockJWT)).
+			Execute(ctx, c.ID, secret, scope.MessagesSend)
+		assert.Equal(t, ErrInvalidScope, err)
+	})
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, c.ID).
+			Return(c, nil).
+			Once()
+		j := new(common.MockJWT)
+		j.On("GenerateToken", mock.Anything, config.ExpireToken()).
+			Return("", errors.New("error")).
+			Once()
+
+		_, _, err := NewTokenUseCase(r, j).Execute(ctx, c.ID, secret, "")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when use case succeeds", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, c.ID).
+			Return(c, nil).
+			Once()
+		j := new(common.MockJWT)
+		j.On("GenerateToken", mock.MatchedBy(func(payload map[string]interface{}) bool {
+			jti, _ := payload["jti"].(string)
+			_, sid := payload["sid"]
+			return payload["id"] == c.ID && payload["scope"] == scope.ContactsRead &&
+				jti != "" && !sid
+		}), config.ExpireToken()).
+			Return("A1B2C3D4E5F6", nil).
+			Once()
+
+		tk, scopes, err := NewTokenUseCase(r, j).Execute(ctx, c.ID, secret, scope.ContactsRead)
+		assert.Nil(t, err)
+		assert.Equal(t, "A1B2C3D4E5F6", tk.AccessToken)
+		assert.Empty(t, tk.RefreshToken)
+		assert.Equal(t, scope.ContactsRead, scopes.String())
+		j.AssertExpectations(t)
+	})
+}
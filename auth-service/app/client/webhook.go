@@ -0,0 +1,65 @@
+package client
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/tsmweb/auth-service/config"
+	"github.com/tsmweb/chat-server/pkg/netguard"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+var (
+	ErrWebhookNotFound = errors.New("webhook not found")
+
+	ErrURLValidateModel        = &cerror.ErrValidateModel{Msg: "required valid http or https url"}
+	ErrURLAddressValidateModel = &cerror.ErrValidateModel{Msg: "url must not address the internal network"}
+)
+
+// WebhookSecretPrefix identifies the secrets used to sign the webhook deliveries.
+const WebhookSecretPrefix = "whsec_"
+
+// Webhook is the URL where the broker delivers the messages addressed to a service account,
+// or posted in the groups it belongs to, as HTTP POSTs signed with the secret. Unlike the
+// secret of the service account, the secret of the webhook is stored as is, since the broker
+// needs it to sign the deliveries.
+type Webhook struct {
+	ClientID  string
+	URL       string
+	Secret    string
+	CreatedAt time.Time
+}
+
+// NewWebhook creates the webhook of the service account with a new secret.
+func NewWebhook(clientID, rawURL string) (*Webhook, error) {
+	w := &Webhook{
+		ClientID:  clientID,
+		URL:       rawURL,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+
+	secret, err := randomString(32)
+	if err != nil {
+		return nil, err
+	}
+	w.Secret = WebhookSecretPrefix + secret
+
+	return w, nil
+}
+
+// Validate model Webhook. Unless config.WebhookAllowPrivate, the URLs addressing loopback,
+// private or link-local addresses are refused.
+func (w *Webhook) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrURLValidateModel
+	}
+	if !config.WebhookAllowPrivate() && netguard.CheckHost(u.Hostname()) != nil {
+		return ErrURLAddressValidateModel
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package login
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/twofactor"
+)
+
+// mockChallenger injects mock dependency into UseCase layer.
+type mockChallenger struct {
+	mock.Mock
+}
+
+// Challenge represents the simulated method for the Challenge feature in the twofactor.Challenger.
+func (m *mockChallenger) Challenge(ctx context.Context, userID string) (*twofactor.ChallengeToken, error) {
+	args := m.Called(ctx, userID)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	if args.Get(0) == nil {
+		return nil, nil
+	}
+	return args.Get(0).(*twofactor.ChallengeToken), nil
+}
@@ -0,0 +1,18 @@
+package login
+
+import "github.com/stretchr/testify/mock"
+
+// mockTokenRevocationEncoder injects mock login.TokenRevocationEncoder dependency.
+type mockTokenRevocationEncoder struct {
+	mock.Mock
+}
+
+// Marshal represents the simulated method for the Marshal feature in the
+// login.TokenRevocationEncoder layer.
+func (m *mockTokenRevocationEncoder) Marshal(r *TokenRevocation) ([]byte, error) {
+	args := m.Called(r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), nil
+}
@@ -2,9 +2,32 @@ package login
 
 import (
 	"errors"
+	"fmt"
+	"time"
 )
 
 var (
 	ErrUserNotFound        = errors.New("user not found")
 	ErrOperationNotAllowed = errors.New("operation not allowed")
 )
+
+// ErrEventNotification error thrown if publishing an event results in an error.
+type ErrEventNotification struct {
+	Msg string
+}
+
+// Error implements interface Error
+func (e *ErrEventNotification) Error() string {
+	return e.Msg
+}
+
+// ErrLocked error thrown while the login of the user or of the client is delayed or locked
+// out after too many failed attempts.
+type ErrLocked struct {
+	RetryAfter time.Duration
+}
+
+// Error implements interface Error
+func (e *ErrLocked) Error() string {
+	return fmt.Sprintf("too many failed login attempts, retry after %s", e.RetryAfter.Round(time.Second))
+}
@@ -0,0 +1,22 @@
+package login
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/token"
+)
+
+// mockIssuer injects mock dependency into UseCase layer.
+type mockIssuer struct {
+	mock.Mock
+}
+
+// Issue represents the simulated method for the Issue feature in the token.Issuer.
+func (m *mockIssuer) Issue(ctx context.Context, userID, familyID string) (*token.Token, error) {
+	args := m.Called(ctx, userID, familyID)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*token.Token), nil
+}
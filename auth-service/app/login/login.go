@@ -2,8 +2,8 @@ package login
 
 import (
 	"context"
+	"github.com/tsmweb/auth-service/common/password"
 	"github.com/tsmweb/go-helper-api/cerror"
-	"github.com/tsmweb/go-helper-api/util/hashutil"
 	"time"
 )
 
@@ -42,7 +42,7 @@ func NewLogin(ID, password string) (*Login, error) {
 
 // ApplyHashPassword hashes the password in plain text.
 func (l *Login) ApplyHashPassword() error {
-	pwd, err := hashutil.HashSHA256(l.Password)
+	pwd, err := password.Hash(l.Password)
 	if err != nil {
 		return err
 	}
@@ -65,6 +65,9 @@ func (l *Login) Validate() error {
 
 // Repository interface for login data source.
 type Repository interface {
-	Login(ctx context.Context, login *Login) (bool, error)
+	// GetPassword returns the password hash of the user, or cerror.ErrNotFound.
+	GetPassword(ctx context.Context, ID string) (string, error)
+	// Rehash replaces the password hash of the user if it is still oldHash.
+	Rehash(ctx context.Context, ID, oldHash, newHash string) (bool, error)
 	Update(ctx context.Context, login *Login) (bool, error)
 }
@@ -2,65 +2,137 @@ package login
 
 import (
 	"context"
+	"errors"
 
+	"github.com/tsmweb/auth-service/app/token"
+	"github.com/tsmweb/auth-service/app/twofactor"
+	"github.com/tsmweb/auth-service/common"
+	"github.com/tsmweb/auth-service/common/password"
 	"github.com/tsmweb/auth-service/common/service"
-	"github.com/tsmweb/auth-service/config"
-	"github.com/tsmweb/go-helper-api/auth"
 	"github.com/tsmweb/go-helper-api/cerror"
 )
 
-// LoginUseCase returns a token if the credentials are valid, otherwise an error
-// is returned.
+// LoginUseCase returns the access and refresh tokens if the credentials are valid,
+// otherwise an error is returned. When the two-factor authentication of the user is
+// enabled, a challenge token is returned instead of the tokens, to be exchanged for them
+// along with a valid code by the twofactor.VerifyUseCase. The failed attempts of the user
+// and of the client IP, informed in the context by common.ClientIPContextKey, delay the
+// next logins and lock them out after too many, returning *ErrLocked.
 type LoginUseCase interface {
-	Execute(ctx context.Context, ID, password string) (string, error)
+	Execute(ctx context.Context, ID, password string) (*token.Token, *twofactor.ChallengeToken, error)
 }
 
 type loginUseCase struct {
 	tag        string
 	repository Repository
-	jwt        auth.JWT
+	issuer     token.Issuer
+	challenger twofactor.Challenger
+	throttle   Throttle
 }
 
 // NewLoginUseCase create a new instance of LoginUseCase.
-func NewLoginUseCase(repository Repository, jwt auth.JWT) LoginUseCase {
+func NewLoginUseCase(repository Repository, issuer token.Issuer,
+	challenger twofactor.Challenger, throttle Throttle) LoginUseCase {
 	return &loginUseCase{
 		tag:        "login::LoginUseCase",
 		repository: repository,
-		jwt:        jwt,
+		issuer:     issuer,
+		challenger: challenger,
+		throttle:   throttle,
 	}
 }
 
 // Execute executes the login use case.
-func (u *loginUseCase) Execute(ctx context.Context, ID, password string) (string, error) {
-	l, err := NewLogin(ID, password)
-	if err != nil {
-		return "", err
+func (u *loginUseCase) Execute(ctx context.Context, ID, pwd string) (*token.Token,
+	*twofactor.ChallengeToken, error) {
+	l := &Login{ID: ID, Password: pwd}
+	if err := l.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	ip, _ := ctx.Value(common.ClientIPContextKey).(string)
+	if err := u.throttle.Attempt(ctx, ID, ip); err != nil {
+		var errLocked *ErrLocked
+		if errors.As(err, &errLocked) {
+			service.Warn(ID, u.tag, err.Error())
+		} else {
+			service.Error(ID, u.tag, err)
+		}
+		return nil, nil, err
 	}
 
-	ok, err := u.repository.Login(ctx, l)
+	ok, err := u.verifyPassword(ctx, l)
 	if err != nil {
 		service.Error(ID, u.tag, err)
-		return "", err
+		u.refund(ctx, ID, ip)
+		return nil, nil, err
 	}
-	if !ok {
+	if !ok { // the failed attempt stays counted
 		service.Warn(ID, u.tag, cerror.ErrUnauthorized.Error())
-		return "", cerror.ErrUnauthorized
+		return nil, nil, cerror.ErrUnauthorized
 	}
 
-	payload := map[string]interface{}{
-		"id": ID,
+	// the login is still valid when the attempts are not forgotten.
+	u.refund(ctx, ID, ip)
+	if err = u.throttle.Reset(ctx, ID); err != nil {
+		service.Error(ID, u.tag, err)
 	}
 
-	token, err := u.jwt.GenerateToken(payload, config.ExpireToken())
+	challenge, err := u.challenger.Challenge(ctx, ID)
 	if err != nil {
 		service.Error(ID, u.tag, err)
-		return "", err
+		return nil, nil, err
+	}
+	if challenge != nil {
+		return nil, challenge, nil
 	}
 
-	if len(token) == 0 {
-		service.Warn(ID, u.tag, cerror.ErrUnauthorized.Error())
-		return "", cerror.ErrUnauthorized
+	t, err := u.issuer.Issue(ctx, ID, "")
+	if err != nil {
+		if errors.Is(err, cerror.ErrUnauthorized) {
+			service.Warn(ID, u.tag, err.Error())
+		} else {
+			service.Error(ID, u.tag, err)
+		}
+		return nil, nil, err
+	}
+
+	return t, nil, nil
+}
+
+// refund removes the attempt that did not fail from the throttle.
+func (u *loginUseCase) refund(ctx context.Context, ID, ip string) {
+	if err := u.throttle.Refund(ctx, ID, ip); err != nil {
+		service.Error(ID, u.tag, err)
+	}
+}
+
+// verifyPassword checks the password against the hash stored, replacing the hash when it
+// uses the legacy format or outdated cost parameters.
+func (u *loginUseCase) verifyPassword(ctx context.Context, l *Login) (bool, error) {
+	hash, err := u.repository.GetPassword(ctx, l.ID)
+	if err != nil {
+		if errors.Is(err, cerror.ErrNotFound) {
+			password.VerifyDummy(l.Password)
+			return false, nil
+		}
+		return false, err
+	}
+
+	ok, rehash, err := password.Verify(l.Password, hash)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	if rehash {
+		newHash, err := password.Hash(l.Password)
+		if err == nil {
+			_, err = u.repository.Rehash(ctx, l.ID, hash, newHash)
+		}
+		if err != nil { // the login is still valid with the previous hash
+			service.Error(l.ID, u.tag, err)
+		}
 	}
 
-	return token, nil
+	return true, nil
 }
@@ -5,12 +5,25 @@ import (
 	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/token"
+	"github.com/tsmweb/auth-service/app/twofactor"
 	"github.com/tsmweb/auth-service/common"
-	"github.com/tsmweb/auth-service/config"
+	"github.com/tsmweb/auth-service/common/password"
 	"github.com/tsmweb/go-helper-api/cerror"
+	"github.com/tsmweb/go-helper-api/util/hashutil"
 	"testing"
+	"time"
 )
 
+// newMockThrottle returns a mockThrottle that allows the logins.
+func newMockThrottle() *mockThrottle {
+	th := new(mockThrottle)
+	th.On("Attempt", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	th.On("Refund", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	th.On("Reset", mock.Anything, mock.Anything).Return(nil)
+	return th
+}
+
 func TestLoginUseCase_Execute(t *testing.T) {
 	//t.Parallel()
 	ctx := context.Background()
@@ -18,75 +31,259 @@ func TestLoginUseCase_Execute(t *testing.T) {
 	t.Run("when use case fails with ErrValidateModel", func(t *testing.T) {
 		//t.Parallel()
 		r := new(mockRepository)
-		j := new(common.MockJWT)
-		uc := NewLoginUseCase(r, j)
-		_, err := uc.Execute(ctx, "+5518999999999", "")
+		i := new(mockIssuer)
+		c := new(mockChallenger)
+		c.On("Challenge", mock.Anything, mock.Anything).
+			Return(nil, nil)
+		th := newMockThrottle()
+		uc := NewLoginUseCase(r, i, c, th)
+		_, _, err := uc.Execute(ctx, "+5518999999999", "")
 
 		assert.Equal(t, ErrPasswordValidateModel, err)
 	})
 
 	t.Run("when use case fails with ErrUnauthorized", func(t *testing.T) {
 		//t.Parallel()
+		hash, _ := password.Hash("123456")
+
 		r := new(mockRepository)
-		r.On("Login", mock.Anything, mock.Anything).
-			Return(false, nil).
+		r.On("GetPassword", mock.Anything, "+5518999999999").
+			Return("", cerror.ErrNotFound).
 			Once()
-		j := new(common.MockJWT)
-		uc := NewLoginUseCase(r, j)
-		_, err := uc.Execute(ctx, "+5518999999999", "123456")
+		i := new(mockIssuer)
+		c := new(mockChallenger)
+		c.On("Challenge", mock.Anything, mock.Anything).
+			Return(nil, nil)
+		th := newMockThrottle()
+		uc := NewLoginUseCase(r, i, c, th)
+		_, _, err := uc.Execute(ctx, "+5518999999999", "123456")
 
 		assert.Equal(t, cerror.ErrUnauthorized, err)
 
-		r.On("Login", mock.Anything, mock.Anything).
-			Return(true, nil).
+		r.On("GetPassword", mock.Anything, "+5518999999999").
+			Return(hash, nil).
 			Once()
-		j.On("GenerateToken", map[string]interface{}{"id": "+5518999999999"}, config.ExpireToken()).
-			Return("", nil).
+		_, _, err = uc.Execute(ctx, "+5518999999999", "654321")
+
+		assert.Equal(t, cerror.ErrUnauthorized, err)
+
+		r.On("GetPassword", mock.Anything, "+5518999999999").
+			Return(hash, nil).
 			Once()
-		_, err = uc.Execute(ctx, "+5518999999999", "123456")
+		i.On("Issue", mock.Anything, "+5518999999999", "").
+			Return(nil, cerror.ErrUnauthorized).
+			Once()
+		_, _, err = uc.Execute(ctx, "+5518999999999", "123456")
 
 		assert.Equal(t, cerror.ErrUnauthorized, err)
+		r.AssertNotCalled(t, "Rehash", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("when use case fails with Error", func(t *testing.T) {
 		//t.Parallel()
+		hash, _ := password.Hash("123456")
+
 		r := new(mockRepository)
-		r.On("Login", mock.Anything, mock.Anything).
-			Return(false, errors.New("error")).
+		r.On("GetPassword", mock.Anything, mock.Anything).
+			Return("", errors.New("error")).
 			Once()
-		j := new(common.MockJWT)
-		uc := NewLoginUseCase(r, j)
-		_, err := uc.Execute(ctx, "+5518999999999", "123456")
+		i := new(mockIssuer)
+		c := new(mockChallenger)
+		c.On("Challenge", mock.Anything, mock.Anything).
+			Return(nil, nil)
+		th := newMockThrottle()
+		uc := NewLoginUseCase(r, i, c, th)
+		_, _, err := uc.Execute(ctx, "+5518999999999", "123456")
 
 		assert.NotNil(t, err)
 
-		r.On("Login", mock.Anything, mock.Anything).
-			Return(true, nil).
+		r.On("GetPassword", mock.Anything, mock.Anything).
+			Return("invalid", nil).
+			Once()
+		_, _, err = uc.Execute(ctx, "+5518999999999", "123456")
+
+		assert.Equal(t, password.ErrInvalidHash, err)
+
+		r.On("GetPassword", mock.Anything, mock.Anything).
+			Return(hash, nil).
+			Once()
+		i.On("Issue", mock.Anything, "+5518999999999", "").
+			Return(nil, errors.New("error")).
+			Once()
+		_, _, err = uc.Execute(ctx, "+5518999999999", "123456")
+
+		assert.NotNil(t, err)
+
+		r.On("GetPassword", mock.Anything, mock.Anything).
+			Return(hash, nil).
 			Once()
-		j.On("GenerateToken", map[string]interface{}{"id": "+5518999999999"}, config.ExpireToken()).
+		c = new(mockChallenger)
+		c.On("Challenge", mock.Anything, "+5518999999999").
 			Return(nil, errors.New("error")).
 			Once()
-		_, err = uc.Execute(ctx, "+5518999999999", "123456")
+		_, _, err = NewLoginUseCase(r, i, c, newMockThrottle()).Execute(ctx, "+5518999999999", "123456")
 
 		assert.NotNil(t, err)
 	})
 
+	t.Run("when use case fails with ErrLocked", func(t *testing.T) {
+		//t.Parallel()
+		ctx := context.WithValue(ctx, common.ClientIPContextKey, "10.0.0.1")
+		locked := &ErrLocked{RetryAfter: time.Minute}
+
+		r := new(mockRepository)
+		i := new(mockIssuer)
+		c := new(mockChallenger)
+		th := new(mockThrottle)
+		th.On("Attempt", mock.Anything, "+5518999999999", "10.0.0.1").
+			Return(locked).
+			Once()
+
+		_, _, err := NewLoginUseCase(r, i, c, th).Execute(ctx, "+5518999999999", "123456")
+		assert.Equal(t, locked, err)
+		r.AssertNotCalled(t, "GetPassword", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when failed attempts are tracked", func(t *testing.T) {
+		//t.Parallel()
+		ctx := context.WithValue(ctx, common.ClientIPContextKey, "10.0.0.1")
+		tk := &token.Token{AccessToken: "A1B2C3D4E5F6", RefreshToken: "F6E5D4C3B2A1"}
+		hash, _ := password.Hash("123456")
+
+		r := new(mockRepository)
+		r.On("GetPassword", mock.Anything, "+5518999999999").
+			Return(hash, nil).
+			Twice()
+		i := new(mockIssuer)
+		i.On("Issue", mock.Anything, "+5518999999999", "").
+			Return(tk, nil).
+			Once()
+		c := new(mockChallenger)
+		c.On("Challenge", mock.Anything, mock.Anything).
+			Return(nil, nil)
+		th := new(mockThrottle)
+		th.On("Attempt", mock.Anything, "+5518999999999", "10.0.0.1").
+			Return(nil)
+		th.On("Refund", mock.Anything, "+5518999999999", "10.0.0.1").
+			Return(errors.New("error")).
+			Twice()
+		th.On("Reset", mock.Anything, "+5518999999999").
+			Return(nil).
+			Once()
+		uc := NewLoginUseCase(r, i, c, th)
+
+		// the failed attempt is not refunded.
+		_, _, err := uc.Execute(ctx, "+5518999999999", "654321")
+		assert.Equal(t, cerror.ErrUnauthorized, err)
+		th.AssertNotCalled(t, "Refund", mock.Anything, mock.Anything, mock.Anything)
+		th.AssertNotCalled(t, "Reset", mock.Anything, mock.Anything)
+
+		// a failure to refund the attempt does not change the result.
+		tokenUC, _, err := uc.Execute(ctx, "+5518999999999", "123456")
+		assert.Nil(t, err)
+		assert.Equal(t, tk, tokenUC)
+
+		// the attempt is refunded when the password cannot be checked.
+		r.On("GetPassword", mock.Anything, "+5518999999999").
+			Return("", errors.New("error")).
+			Once()
+		_, _, err = uc.Execute(ctx, "+5518999999999", "123456")
+		assert.NotNil(t, err)
+		th.AssertExpectations(t)
+	})
+
+	t.Run("when two-factor authentication is enabled", func(t *testing.T) {
+		//t.Parallel()
+		challenge := &twofactor.ChallengeToken{Token: "C1H2A3L4", ExpiresIn: 300}
+		hash, _ := password.Hash("123456")
+
+		r := new(mockRepository)
+		r.On("GetPassword", mock.Anything, "+5518999999999").
+			Return(hash, nil).
+			Once()
+		i := new(mockIssuer)
+		c := new(mockChallenger)
+		c.On("Challenge", mock.Anything, "+5518999999999").
+			Return(challenge, nil).
+			Once()
+		th := newMockThrottle()
+		tokenUC, challengeUC, err := NewLoginUseCase(r, i, c, th).Execute(ctx, "+5518999999999", "123456")
+
+		assert.Nil(t, err)
+		assert.Nil(t, tokenUC)
+		assert.Equal(t, challenge, challengeUC)
+		i.AssertNotCalled(t, "Issue", mock.Anything, mock.Anything, mock.Anything)
+	})
+
 	t.Run("when use case success", func(t *testing.T) {
 		//t.Parallel()
-		token := "A1B2C3D4E5F6"
+		tk := &token.Token{AccessToken: "A1B2C3D4E5F6", RefreshToken: "F6E5D4C3B2A1"}
+		hash, _ := password.Hash("123456")
 
 		r := new(mockRepository)
-		r.On("Login", mock.Anything, mock.Anything).
+		r.On("GetPassword", mock.Anything, "+5518999999999").
+			Return(hash, nil).
+			Once()
+		i := new(mockIssuer)
+		i.On("Issue", mock.Anything, "+5518999999999", "").
+			Return(tk, nil).
+			Once()
+		c := new(mockChallenger)
+		c.On("Challenge", mock.Anything, mock.Anything).
+			Return(nil, nil)
+		th := newMockThrottle()
+		uc := NewLoginUseCase(r, i, c, th)
+		tokenUC, _, err := uc.Execute(ctx, "+5518999999999", "123456")
+
+		assert.Nil(t, err)
+		assert.Equal(t, tk, tokenUC)
+		r.AssertNotCalled(t, "Rehash", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case success with legacy hash", func(t *testing.T) {
+		//t.Parallel()
+		tk := &token.Token{AccessToken: "A1B2C3D4E5F6", RefreshToken: "F6E5D4C3B2A1"}
+		legacy, _ := hashutil.HashSHA256("123456")
+
+		r := new(mockRepository)
+		r.On("GetPassword", mock.Anything, "+5518999999999").
+			Return(legacy, nil).
+			Once()
+		r.On("Rehash", mock.Anything, "+5518999999999", legacy,
+			mock.MatchedBy(func(newHash string) bool {
+				ok, rehash, _ := password.Verify("123456", newHash)
+				return ok && !rehash
+			})).
 			Return(true, nil).
 			Once()
-		j := new(common.MockJWT)
-		j.On("GenerateToken", map[string]interface{}{"id": "+5518999999999"}, config.ExpireToken()).
-			Return(token, nil).
+		i := new(mockIssuer)
+		i.On("Issue", mock.Anything, "+5518999999999", "").
+			Return(tk, nil).
+			Once()
+		c := new(mockChallenger)
+		c.On("Challenge", mock.Anything, mock.Anything).
+			Return(nil, nil)
+		th := newMockThrottle()
+		uc := NewLoginUseCase(r, i, c, th)
+		tokenUC, _, err := uc.Execute(ctx, "+5518999999999", "123456")
+
+		assert.Nil(t, err)
+		assert.Equal(t, tk, tokenUC)
+		r.AssertExpectations(t)
+
+		// a failure to rehash does not prevent the login
+		r.On("GetPassword", mock.Anything, "+5518999999999").
+			Return(legacy, nil).
+			Once()
+		r.On("Rehash", mock.Anything, "+5518999999999", legacy, mock.Anything).
+			Return(false, errors.New("error")).
+			Once()
+		i.On("Issue", mock.Anything, "+5518999999999", "").
+			Return(tk, nil).
 			Once()
-		uc := NewLoginUseCase(r, j)
-		tokenUC, err := uc.Execute(ctx, "+5518999999999", "123456")
+		tokenUC, _, err = uc.Execute(ctx, "+5518999999999", "123456")
 
 		assert.Nil(t, err)
-		assert.Equal(t, token, tokenUC)
+		assert.Equal(t, tk, tokenUC)
 	})
 }
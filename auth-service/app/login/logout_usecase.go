@@ -0,0 +1,118 @@
+package login
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/tsmweb/auth-service/app/token"
+	"github.com/tsmweb/auth-service/common/service"
+	"github.com/tsmweb/auth-service/config"
+	"github.com/tsmweb/chat-server/pkg/revocation"
+	"github.com/tsmweb/go-helper-api/cerror"
+	"github.com/tsmweb/go-helper-api/kafka"
+)
+
+// LogoutUseCase revokes the access token presented by the user, along with the refresh
+// token informed, or all the tokens of the user when logging out everywhere, otherwise
+// an error will be returned.
+type LogoutUseCase interface {
+	Execute(ctx context.Context, t *revocation.Token, refreshToken string, everywhere bool) error
+}
+
+type logoutUseCase struct {
+	tag        string
+	repository token.Repository
+	store      revocation.Store
+	encoder    TokenRevocationEncoder
+	producer   kafka.Producer
+}
+
+// NewLogoutUseCase create a new instance of LogoutUseCase.
+func NewLogoutUseCase(
+	repository token.Repository,
+	store revocation.Store,
+	encoder TokenRevocationEncoder,
+	producer kafka.Producer,
+) LogoutUseCase {
+	return &logoutUseCase{
+		tag:        "login::LogoutUseCase",
+		repository: repository,
+		store:      store,
+		encoder:    encoder,
+		producer:   producer,
+	}
+}
+
+// Execute executes the logout use case.
+func (u *logoutUseCase) Execute(ctx context.Context, t *revocation.Token, refreshToken string,
+	everywhere bool) error {
+	var revoked *TokenRevocation
+	var err error
+
+	// Tokens issued before logout support can only be revoked along with the others.
+	if everywhere || t.ID == "" || t.IssuedAt.IsZero() {
+		revoked, err = u.logoutEverywhere(ctx, t.UserID)
+	} else {
+		revoked, err = u.logout(ctx, t, refreshToken)
+	}
+	if err != nil {
+		service.Error(t.UserID, u.tag, err)
+		return err
+	}
+
+	if err = u.notify(ctx, revoked); err != nil {
+		service.Error(t.UserID, u.tag, err)
+		return &ErrEventNotification{Msg: err.Error()}
+	}
+
+	return nil
+}
+
+func (u *logoutUseCase) logout(ctx context.Context, t *revocation.Token,
+	refreshToken string) (*TokenRevocation, error) {
+	expiresAt := t.IssuedAt.Add(time.Duration(config.ExpireToken()) * time.Hour)
+	if err := u.store.RevokeToken(ctx, t.ID, expiresAt); err != nil {
+		return nil, err
+	}
+
+	if refreshToken != "" {
+		rt, err := u.repository.Get(ctx, token.HashRefreshToken(refreshToken))
+		if err != nil && !errors.Is(err, cerror.ErrNotFound) {
+			return nil, err
+		}
+		// ignores the refresh tokens unknown or of other users.
+		if rt != nil && rt.UserID == t.UserID {
+			if err = u.repository.RevokeFamily(ctx, rt.FamilyID, time.Now().UTC()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	revoked := NewTokenRevocation(t.UserID, RevocationLogout)
+	revoked.TokenID = t.ID
+	return revoked, nil
+}
+
+func (u *logoutUseCase) logoutEverywhere(ctx context.Context, userID string) (*TokenRevocation, error) {
+	revoked := NewTokenRevocation(userID, RevocationLogoutEverywhere)
+
+	ttl := time.Duration(config.ExpireToken()) * time.Hour
+	if err := u.store.RevokeUser(ctx, userID, revoked.RevokedAt, ttl); err != nil {
+		return nil, err
+	}
+	if err := u.repository.RevokeUser(ctx, userID, revoked.RevokedAt); err != nil {
+		return nil, err
+	}
+
+	return revoked, nil
+}
+
+func (u *logoutUseCase) notify(ctx context.Context, revoked *TokenRevocation) error {
+	rpb, err := u.encoder.Marshal(revoked)
+	if err != nil {
+		return err
+	}
+
+	return u.producer.Publish(ctx, []byte(revoked.UserID), rpb)
+}
@@ -0,0 +1,136 @@
+package login
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/token"
+	"github.com/tsmweb/auth-service/common"
+	"github.com/tsmweb/chat-server/pkg/revocation"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+func TestLogoutUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+	tk := &revocation.Token{
+		ID:       "A1B2C3",
+		UserID:   "+5518999999999",
+		IssuedAt: time.Now().UTC(),
+	}
+
+	encode := new(mockTokenRevocationEncoder)
+	encode.On("Marshal", mock.Anything).
+		Return([]byte{}, nil)
+
+	producer := new(common.MockKafkaProducer)
+	producer.On("Publish", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockTokenRepository)
+		s := new(mockRevocationStore)
+		s.On("RevokeToken", mock.Anything, "A1B2C3", mock.Anything).
+			Return(errors.New("error")).
+			Once()
+		s.On("RevokeUser", mock.Anything, "+5518999999999", mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Once()
+		uc := NewLogoutUseCase(r, s, encode, producer)
+
+		err := uc.Execute(ctx, tk, "", false)
+		assert.NotNil(t, err)
+
+		err = uc.Execute(ctx, tk, "", true)
+		assert.NotNil(t, err)
+		r.AssertNotCalled(t, "RevokeUser", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with ErrEventNotification", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockTokenRepository)
+		s := new(mockRevocationStore)
+		s.On("RevokeToken", mock.Anything, "A1B2C3", mock.Anything).
+			Return(nil).
+			Once()
+		p := new(common.MockKafkaProducer)
+		p.On("Publish", mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Once()
+
+		err := NewLogoutUseCase(r, s, encode, p).Execute(ctx, tk, "", false)
+
+		var errEventNotification *ErrEventNotification
+		assert.ErrorAs(t, err, &errEventNotification)
+	})
+
+	t.Run("when use case logs out the token", func(t *testing.T) {
+		//t.Parallel()
+		rt, value, _ := token.NewRefreshToken("+5518999999999", "family", time.Hour)
+		other, otherValue, _ := token.NewRefreshToken("+5518977777777", "other", time.Hour)
+
+		r := new(mockTokenRepository)
+		r.On("Get", mock.Anything, rt.ID).
+			Return(rt, nil).
+			Once()
+		r.On("Get", mock.Anything, other.ID).
+			Return(other, nil).
+			Once()
+		r.On("Get", mock.Anything, token.HashRefreshToken("unknown")).
+			Return(nil, cerror.ErrNotFound).
+			Once()
+		r.On("RevokeFamily", mock.Anything, "family", mock.Anything).
+			Return(nil).
+			Once()
+		s := new(mockRevocationStore)
+		s.On("RevokeToken", mock.Anything, "A1B2C3", mock.Anything).
+			Return(nil).
+			Times(3)
+		e := new(mockTokenRevocationEncoder)
+		e.On("Marshal", mock.MatchedBy(func(r *TokenRevocation) bool {
+			return r.UserID == "+5518999999999" && r.TokenID == "A1B2C3" && r.Reason == "Logout"
+		})).
+			Return([]byte{}, nil).
+			Times(3)
+		uc := NewLogoutUseCase(r, s, e, producer)
+
+		assert.Nil(t, uc.Execute(ctx, tk, value, false))
+		assert.Nil(t, uc.Execute(ctx, tk, otherValue, false))
+		assert.Nil(t, uc.Execute(ctx, tk, "unknown", false))
+		r.AssertExpectations(t)
+		s.AssertExpectations(t)
+		e.AssertExpectations(t)
+	})
+
+	t.Run("when use case logs out everywhere", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockTokenRepository)
+		r.On("RevokeUser", mock.Anything, "+5518999999999", mock.Anything).
+			Return(nil).
+			Twice()
+		s := new(mockRevocationStore)
+		s.On("RevokeUser", mock.Anything, "+5518999999999", mock.Anything, mock.Anything).
+			Return(nil).
+			Twice()
+		e := new(mockTokenRevocationEncoder)
+		e.On("Marshal", mock.MatchedBy(func(r *TokenRevocation) bool {
+			return r.UserID == "+5518999999999" && r.TokenID == "" && r.Reason == "LogoutEverywhere"
+		})).
+			Return([]byte{}, nil).
+			Twice()
+		uc := NewLogoutUseCase(r, s, e, producer)
+
+		assert.Nil(t, uc.Execute(ctx, tk, "", true))
+
+		// tokens without ID are logged out everywhere.
+		assert.Nil(t, uc.Execute(ctx, &revocation.Token{UserID: "+5518999999999"}, "", false))
+		r.AssertExpectations(t)
+		s.AssertExpectations(t)
+		s.AssertNotCalled(t, "RevokeToken", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -10,13 +10,22 @@ type mockRepository struct {
 	mock.Mock
 }
 
-// Login represents the simulated method for the Login feature in the Repository layer.
-func (m *mockRepository) Login(ctx context.Context, l *Login) (bool, error) {
-	args := m.Called(ctx, l)
-	if args.Get(1) != nil {
-		return false, args.Error(1)
+// GetPassword represents the simulated method for the GetPassword feature in the
+// Repository layer.
+func (m *mockRepository) GetPassword(ctx context.Context, ID string) (string, error) {
+	args := m.Called(ctx, ID)
+	if args.Error(1) != nil {
+		return "", args.Error(1)
 	}
+	return args.String(0), nil
+}
 
+// Rehash represents the simulated method for the Rehash feature in the Repository layer.
+func (m *mockRepository) Rehash(ctx context.Context, ID, oldHash, newHash string) (bool, error) {
+	args := m.Called(ctx, ID, oldHash, newHash)
+	if args.Error(1) != nil {
+		return false, args.Error(1)
+	}
 	return args.Get(0).(bool), nil
 }
 
@@ -0,0 +1,74 @@
+package login
+
+import "time"
+
+// RevocationReason represents the reason why the user's tokens were revoked ("password changed",
+// "logout", "logout everywhere", "session revoked", "client revoked").
+type RevocationReason int
+
+const (
+	// RevocationPasswordChanged represents the revocation due to the password change.
+	RevocationPasswordChanged = iota
+
+	// RevocationLogout represents the revocation of a single token due to the logout.
+	RevocationLogout
+
+	// RevocationLogoutEverywhere represents the revocation due to the logout on all devices.
+	RevocationLogoutEverywhere
+
+	// RevocationSessionRevoked represents the revocation of the tokens of a session revoked by
+	// the user.
+	RevocationSessionRevoked
+
+	// RevocationClientRevoked represents the revocation of the tokens of a service account
+	// revoked by an administrator.
+	RevocationClientRevoked
+)
+
+var revocationReasonText = map[RevocationReason]string{
+	RevocationPasswordChanged:  "PasswordChanged",
+	RevocationLogout:           "Logout",
+	RevocationLogoutEverywhere: "LogoutEverywhere",
+	RevocationSessionRevoked:   "SessionRevoked",
+	RevocationClientRevoked:    "ClientRevoked",
+}
+
+// String return the name of the RevocationReason.
+func (r RevocationReason) String() string {
+	return revocationReasonText[r]
+}
+
+// TokenRevocation represents the revocation of all tokens issued to the user until RevokedAt,
+// or only of the token with TokenID or of the session with SessionID when it is informed.
+type TokenRevocation struct {
+	UserID    string
+	TokenID   string
+	SessionID string
+	Reason    string
+	RevokedAt time.Time
+}
+
+// NewTokenRevocation return an instance of TokenRevocation.
+func NewTokenRevocation(userID string, reason RevocationReason) *TokenRevocation {
+	return &TokenRevocation{
+		UserID:    userID,
+		Reason:    reason.String(),
+		RevokedAt: time.Now().UTC(),
+	}
+}
+
+// TokenRevocationEncoder is a TokenRevocation encoder for byte slice.
+type TokenRevocationEncoder interface {
+	Marshal(r *TokenRevocation) ([]byte, error)
+}
+
+// The TokenRevocationEncoderFunc type is an adapter to allow the use of ordinary functions as
+// encoders of TokenRevocation for byte slice.
+// If f is a function with the appropriate signature, TokenRevocationEncoderFunc(f) is a
+// TokenRevocationEncoder that calls f.
+type TokenRevocationEncoderFunc func(r *TokenRevocation) ([]byte, error)
+
+// Marshal calls f(r).
+func (f TokenRevocationEncoderFunc) Marshal(r *TokenRevocation) ([]byte, error) {
+	return f(r)
+}
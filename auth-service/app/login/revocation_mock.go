@@ -0,0 +1,90 @@
+package login
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/token"
+	"github.com/tsmweb/chat-server/pkg/revocation"
+)
+
+// mockRevocationStore injects mock revocation.Store dependency.
+type mockRevocationStore struct {
+	mock.Mock
+}
+
+// IsRevoked represents the simulated method for the IsRevoked feature in the revocation.Store.
+func (m *mockRevocationStore) IsRevoked(ctx context.Context, t *revocation.Token) (bool, error) {
+	args := m.Called(ctx, t)
+	if args.Error(1) != nil {
+		return false, args.Error(1)
+	}
+	return args.Bool(0), nil
+}
+
+// RevokeToken represents the simulated method for the RevokeToken feature in the
+// revocation.Store.
+func (m *mockRevocationStore) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	args := m.Called(ctx, tokenID, expiresAt)
+	return args.Error(0)
+}
+
+// RevokeSession represents the simulated method for the RevokeSession feature in the
+// revocation.Store.
+func (m *mockRevocationStore) RevokeSession(ctx context.Context, sessionID string,
+	ttl time.Duration) error {
+	args := m.Called(ctx, sessionID, ttl)
+	return args.Error(0)
+}
+
+// RevokeUser represents the simulated method for the RevokeUser feature in the
+// revocation.Store.
+func (m *mockRevocationStore) RevokeUser(ctx context.Context, userID string, revokedAt time.Time,
+	ttl time.Duration) error {
+	args := m.Called(ctx, userID, revokedAt, ttl)
+	return args.Error(0)
+}
+
+// mockTokenRepository injects mock token.Repository dependency.
+type mockTokenRepository struct {
+	mock.Mock
+}
+
+// Create represents the simulated method for the Create feature in the token.Repository.
+func (m *mockTokenRepository) Create(ctx context.Context, t *token.RefreshToken) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+// Get represents the simulated method for the Get feature in the token.Repository.
+func (m *mockTokenRepository) Get(ctx context.Context, ID string) (*token.RefreshToken, error) {
+	args := m.Called(ctx, ID)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*token.RefreshToken), nil
+}
+
+// Use represents the simulated method for the Use feature in the token.Repository.
+func (m *mockTokenRepository) Use(ctx context.Context, ID string, usedAt time.Time) (bool, error) {
+	args := m.Called(ctx, ID, usedAt)
+	if args.Error(1) != nil {
+		return false, args.Error(1)
+	}
+	return args.Bool(0), nil
+}
+
+// RevokeFamily represents the simulated method for the RevokeFamily feature in the
+// token.Repository.
+func (m *mockTokenRepository) RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	args := m.Called(ctx, familyID, revokedAt)
+	return args.Error(0)
+}
+
+// RevokeUser represents the simulated method for the RevokeUser feature in the
+// token.Repository.
+func (m *mockTokenRepository) RevokeUser(ctx context.Context, userID string, revokedAt time.Time) error {
+	args := m.Called(ctx, userID, revokedAt)
+	return args.Error(0)
+}
@@ -0,0 +1,90 @@
+package login
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/tsmweb/auth-service/app/session"
+	"github.com/tsmweb/auth-service/common/service"
+	"github.com/tsmweb/auth-service/config"
+	"github.com/tsmweb/chat-server/pkg/revocation"
+	"github.com/tsmweb/go-helper-api/cerror"
+	"github.com/tsmweb/go-helper-api/kafka"
+)
+
+// RevokeSessionUseCase revokes a session of the user along with its tokens, disconnecting the
+// device, otherwise an error will be returned.
+type RevokeSessionUseCase interface {
+	Execute(ctx context.Context, userID, sessionID string) error
+}
+
+type revokeSessionUseCase struct {
+	tag        string
+	repository session.Repository
+	store      revocation.Store
+	encoder    TokenRevocationEncoder
+	producer   kafka.Producer
+}
+
+// NewRevokeSessionUseCase create a new instance of RevokeSessionUseCase.
+func NewRevokeSessionUseCase(
+	repository session.Repository,
+	store revocation.Store,
+	encoder TokenRevocationEncoder,
+	producer kafka.Producer,
+) RevokeSessionUseCase {
+	return &revokeSessionUseCase{
+		tag:        "login::RevokeSessionUseCase",
+		repository: repository,
+		store:      store,
+		encoder:    encoder,
+		producer:   producer,
+	}
+}
+
+// Execute executes the revoke session use case.
+func (u *revokeSessionUseCase) Execute(ctx context.Context, userID, sessionID string) error {
+	s, err := u.repository.Get(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, cerror.ErrNotFound) {
+			return session.ErrSessionNotFound
+		}
+		service.Error(userID, u.tag, err)
+		return err
+	}
+	// the sessions of other users are not disclosed.
+	if s.UserID != userID || s.IsRevoked() {
+		return session.ErrSessionNotFound
+	}
+
+	revoked := NewTokenRevocation(userID, RevocationSessionRevoked)
+	revoked.SessionID = sessionID
+
+	ok, err := u.repository.Revoke(ctx, sessionID, revoked.RevokedAt)
+	if err != nil {
+		service.Error(userID, u.tag, err)
+		return err
+	}
+	if !ok {
+		return session.ErrSessionNotFound
+	}
+
+	ttl := time.Duration(config.ExpireToken()) * time.Hour
+	if err = u.store.RevokeSession(ctx, sessionID, ttl); err != nil {
+		service.Error(userID, u.tag, err)
+		return err
+	}
+
+	rpb, err := u.encoder.Marshal(revoked)
+	if err != nil {
+		service.Error(userID, u.tag, err)
+		return err
+	}
+	if err = u.producer.Publish(ctx, []byte(userID), rpb); err != nil {
+		service.Error(userID, u.tag, err)
+		return &ErrEventNotification{Msg: err.Error()}
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package login
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/session"
+	"github.com/tsmweb/auth-service/common"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+func TestRevokeSessionUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+	userID := "+5518999999999"
+
+	encode := new(mockTokenRevocationEncoder)
+	encode.On("Marshal", mock.Anything).
+		Return([]byte{}, nil)
+
+	producer := new(common.MockKafkaProducer)
+	producer.On("Publish", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	t.Run("when use case fails with ErrSessionNotFound", func(t *testing.T) {
+		//t.Parallel()
+		other := session.NewSession("other", "+5518977777777", nil)
+		revoked := session.NewSession("revoked", userID, nil)
+		revoked.RevokedAt = time.Now().UTC()
+
+		r := new(mockSessionRepository)
+		r.On("Get", mock.Anything, "unknown").
+			Return(nil, cerror.ErrNotFound).
+			Once()
+		r.On("Get", mock.Anything, "other").
+			Return(other, nil).
+			Once()
+		r.On("Get", mock.Anything, "revoked").
+			Return(revoked, nil).
+			Once()
+		s := new(mockRevocationStore)
+		uc := NewRevokeSessionUseCase(r, s, encode, producer)
+
+		assert.Equal(t, session.ErrSessionNotFound, uc.Execute(ctx, userID, "unknown"))
+		assert.Equal(t, session.ErrSessionNotFound, uc.Execute(ctx, userID, "other"))
+		assert.Equal(t, session.ErrSessionNotFound, uc.Execute(ctx, userID, "revoked"))
+		r.AssertNotCalled(t, "Revoke", mock.Anything, mock.Anything, mock.Anything)
+		s.AssertNotCalled(t, "RevokeSession", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockSessionRepository)
+		r.On("Get", mock.Anything, "S1").
+			Return(session.NewSession("S1", userID, nil), nil).
+			Once()
+		r.On("Revoke", mock.Anything, "S1", mock.Anything).
+			Return(false, errors.New("error")).
+			Once()
+		s := new(mockRevocationStore)
+
+		err := NewRevokeSessionUseCase(r, s, encode, producer).Execute(ctx, userID, "S1")
+		assert.NotNil(t, err)
+		s.AssertNotCalled(t, "RevokeSession", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with ErrEventNotification", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockSessionRepository)
+		r.On("Get", mock.Anything, "S1").
+			Return(session.NewSession("S1", userID, nil), nil).
+			Once()
+		r.On("Revoke", mock.Anything, "S1", mock.Anything).
+			Return(true, nil).
+			Once()
+		s := new(mockRevocationStore)
+		s.On("RevokeSession", mock.Anything, "S1", mock.Anything).
+			Return(nil).
+			Once()
+		p := new(common.MockKafkaProducer)
+		p.On("Publish", mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Once()
+
+		err := NewRevokeSessionUseCase(r, s, encode, p).Execute(ctx, userID, "S1")
+
+		var errEventNotification *ErrEventNotification
+		assert.ErrorAs(t, err, &errEventNotification)
+	})
+
+	t.Run("when use case succeeds", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockSessionRepository)
+		r.On("Get", mock.Anything, "S1").
+			Return(session.NewSession("S1", userID, nil), nil).
+			Once()
+		r.On("Revoke", mock.Anything, "S1", mock.Anything).
+			Return(true, nil).
+			Once()
+		s := new(mockRevocationStore)
+		s.On("RevokeSession", mock.Anything, "S1", mock.Anything).
+			Return(nil).
+			Once()
+		e := new(mockTokenRevocationEncoder)
+		e.On("Marshal", mock.MatchedBy(func(r *TokenRevocation) bool {
+			return r.UserID == userID && r.SessionID == "S1" && r.Reason == "SessionRevoked"
+		})).
+			Return([]byte{}, nil).
+			Once()
+
+		err := NewRevokeSessionUseCase(r, s, e, producer).Execute(ctx, userID, "S1")
+		assert.Nil(t, err)
+		r.AssertExpectations(t)
+		s.AssertExpectations(t)
+		e.AssertExpectations(t)
+	})
+}
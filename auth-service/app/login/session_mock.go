@@ -0,0 +1,53 @@
+package login
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/session"
+)
+
+// mockSessionRepository injects mock session.Repository dependency.
+type mockSessionRepository struct {
+	mock.Mock
+}
+
+// Create represents the simulated method for the Create feature in the session.Repository layer.
+func (m *mockSessionRepository) Create(ctx context.Context, s *session.Session) error {
+	args := m.Called(ctx, s)
+	return args.Error(0)
+}
+
+// Get represents the simulated method for the Get feature in the session.Repository layer.
+func (m *mockSessionRepository) Get(ctx context.Context, ID string) (*session.Session, error) {
+	args := m.Called(ctx, ID)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*session.Session), nil
+}
+
+// GetAll represents the simulated method for the GetAll feature in the session.Repository layer.
+func (m *mockSessionRepository) GetAll(ctx context.Context, userID string) ([]*session.Session, error) {
+	args := m.Called(ctx, userID)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*session.Session), nil
+}
+
+// Touch represents the simulated method for the Touch feature in the session.Repository layer.
+func (m *mockSessionRepository) Touch(ctx context.Context, ID string, lastSeenAt time.Time) error {
+	args := m.Called(ctx, ID, lastSeenAt)
+	return args.Error(0)
+}
+
+// Revoke represents the simulated method for the Revoke feature in the session.Repository layer.
+func (m *mockSessionRepository) Revoke(ctx context.Context, ID string, revokedAt time.Time) (bool, error) {
+	args := m.Called(ctx, ID, revokedAt)
+	if args.Error(1) != nil {
+		return false, args.Error(1)
+	}
+	return args.Bool(0), nil
+}
@@ -0,0 +1,166 @@
+package login
+
+import (
+	"context"
+	"time"
+
+	"github.com/tsmweb/auth-service/common/service"
+)
+
+// Policy of the delays and lockout applied to the failed logins of a user or client.
+type Policy struct {
+	FreeAttempts int           // failed attempts allowed without delay
+	Delay        time.Duration // delay after the free attempts, doubled on each failed attempt
+	MaxAttempts  int           // failed attempts until the lockout
+	Lockout      time.Duration
+}
+
+// Wait returns how long a new login must wait after the last of the failed attempts.
+func (p Policy) Wait(failures int) time.Duration {
+	if failures >= p.MaxAttempts {
+		return p.Lockout
+	}
+	if failures < p.FreeAttempts {
+		return 0
+	}
+
+	wait := p.Delay
+	for i := p.FreeAttempts; i < failures && wait < p.Lockout; i++ {
+		wait *= 2
+	}
+	if wait > p.Lockout {
+		wait = p.Lockout
+	}
+	return wait
+}
+
+// AttemptRepository stores the login attempts, shared by the replicas of auth-service.
+type AttemptRepository interface {
+	// Get returns the attempts of the key and the time of the last one.
+	Get(ctx context.Context, key string) (int, time.Time, error)
+	// Fail records an attempt of the key at the given time, returning the attempts. The
+	// attempts are forgotten after ttl without new ones.
+	Fail(ctx context.Context, key string, at time.Time, ttl time.Duration) (int, error)
+	// Refund removes an attempt of the key, if the attempts were not forgotten meanwhile.
+	Refund(ctx context.Context, key string) error
+	Reset(ctx context.Context, key string) error
+}
+
+// Throttle applies progressive delays and temporary lockouts to the logins of the user IDs
+// and client IPs with failed attempts. The attempts are counted before the credentials are
+// checked, so that concurrent logins cannot exceed the policies, and refunded when they
+// do not fail.
+type Throttle interface {
+	// Attempt records a login of the user from the client, returning *ErrLocked if the
+	// login must wait.
+	Attempt(ctx context.Context, userID, ip string) error
+	// Refund removes the attempt of the user and of the client, when the credentials were
+	// valid or could not be checked.
+	Refund(ctx context.Context, userID, ip string) error
+	// Reset forgets the failed logins of the user.
+	Reset(ctx context.Context, userID string) error
+}
+
+type throttle struct {
+	tag        string
+	repository AttemptRepository
+	user       Policy
+	client     Policy
+}
+
+// NewThrottle create a new instance of Throttle with the policies of the user IDs and of
+// the client IPs.
+func NewThrottle(repository AttemptRepository, user, client Policy) Throttle {
+	return &throttle{
+		tag:        "login::Throttle",
+		repository: repository,
+		user:       user,
+		client:     client,
+	}
+}
+
+func userKey(userID string) string {
+	return "user:" + userID
+}
+
+func clientKey(ip string) string {
+	return "ip:" + ip
+}
+
+// limit is the key of the attempts of the user or of the client and its policy.
+type limit struct {
+	key    string
+	policy Policy
+}
+
+func (t *throttle) limits(userID, ip string) []limit {
+	limits := []limit{{key: userKey(userID), policy: t.user}}
+	if ip != "" {
+		limits = append(limits, limit{key: clientKey(ip), policy: t.client})
+	}
+	return limits
+}
+
+// Attempt returns the longest wait of the user and the client, the attempts made while
+// waiting are not counted. Otherwise the attempt is counted, and refunded again if
+// concurrent attempts were counted meanwhile and must wait.
+func (t *throttle) Attempt(ctx context.Context, userID, ip string) error {
+	now := time.Now()
+	limits := t.limits(userID, ip)
+
+	var wait time.Duration
+	seen := make([]int, len(limits))
+	for i, l := range limits {
+		failures, last, err := t.repository.Get(ctx, l.key)
+		if err != nil {
+			return err
+		}
+		if failures == 0 {
+			continue
+		}
+		seen[i] = failures
+		if w := last.Add(l.policy.Wait(failures)).Sub(now); w > wait {
+			wait = w
+		}
+	}
+	if wait > 0 {
+		return &ErrLocked{RetryAfter: wait}
+	}
+
+	for i, l := range limits {
+		attempts, err := t.repository.Fail(ctx, l.key, now, l.policy.Lockout)
+		if err != nil {
+			t.refund(ctx, userID, limits[:i])
+			return err
+		}
+		if previous := attempts - 1; previous > seen[i] && l.policy.Wait(previous) > 0 {
+			t.refund(ctx, userID, limits[:i+1])
+			return &ErrLocked{RetryAfter: l.policy.Wait(previous)}
+		}
+	}
+	return nil
+}
+
+// refund removes the attempt counted of the limits, logging the failures.
+func (t *throttle) refund(ctx context.Context, userID string, limits []limit) {
+	for _, l := range limits {
+		if err := t.repository.Refund(ctx, l.key); err != nil {
+			service.Error(userID, t.tag, err)
+		}
+	}
+}
+
+// Refund removes the attempt of the user and of the client.
+func (t *throttle) Refund(ctx context.Context, userID, ip string) error {
+	for _, l := range t.limits(userID, ip) {
+		if err := t.repository.Refund(ctx, l.key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reset forgets the failed attempts of the user, the attempts of the clients are kept.
+func (t *throttle) Reset(ctx context.Context, userID string) error {
+	return t.repository.Reset(ctx, userKey(userID))
+}
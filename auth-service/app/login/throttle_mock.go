@@ -0,0 +1,67 @@
+package login
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// mockThrottle injects mock dependency into UseCase layer.
+type mockThrottle struct {
+	mock.Mock
+}
+
+// Attempt represents the simulated method for the Attempt feature in the Throttle.
+func (m *mockThrottle) Attempt(ctx context.Context, userID, ip string) error {
+	args := m.Called(ctx, userID, ip)
+	return args.Error(0)
+}
+
+// Refund represents the simulated method for the Refund feature in the Throttle.
+func (m *mockThrottle) Refund(ctx context.Context, userID, ip string) error {
+	args := m.Called(ctx, userID, ip)
+	return args.Error(0)
+}
+
+// Reset represents the simulated method for the Reset feature in the Throttle.
+func (m *mockThrottle) Reset(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+// mockAttemptRepository injects mock dependency into Throttle.
+type mockAttemptRepository struct {
+	mock.Mock
+}
+
+// Get represents the simulated method for the Get feature in the AttemptRepository.
+func (m *mockAttemptRepository) Get(ctx context.Context, key string) (int, time.Time, error) {
+	args := m.Called(ctx, key)
+	if args.Error(2) != nil {
+		return 0, time.Time{}, args.Error(2)
+	}
+	return args.Int(0), args.Get(1).(time.Time), nil
+}
+
+// Fail represents the simulated method for the Fail feature in the AttemptRepository.
+func (m *mockAttemptRepository) Fail(ctx context.Context, key string, at time.Time,
+	ttl time.Duration) (int, error) {
+	args := m.Called(ctx, key, at, ttl)
+	if args.Error(1) != nil {
+		return 0, args.Error(1)
+	}
+	return args.Int(0), nil
+}
+
+// Refund represents the simulated method for the Refund feature in the AttemptRepository.
+func (m *mockAttemptRepository) Refund(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+// Reset represents the simulated method for the Reset feature in the AttemptRepository.
+func (m *mockAttemptRepository) Reset(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
@@ -0,0 +1,204 @@
+package login
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	testUserPolicy   = Policy{FreeAttempts: 3, Delay: time.Second, MaxAttempts: 6, Lockout: time.Minute}
+	testClientPolicy = Policy{FreeAttempts: 10, Delay: time.Second, MaxAttempts: 20, Lockout: time.Hour}
+)
+
+func TestPolicy_Wait(t *testing.T) {
+	//t.Parallel()
+	assert.Equal(t, time.Duration(0), testUserPolicy.Wait(0))
+	assert.Equal(t, time.Duration(0), testUserPolicy.Wait(2))
+	assert.Equal(t, time.Second, testUserPolicy.Wait(3))
+	assert.Equal(t, 2*time.Second, testUserPolicy.Wait(4))
+	assert.Equal(t, 4*time.Second, testUserPolicy.Wait(5))
+	assert.Equal(t, time.Minute, testUserPolicy.Wait(6))
+	assert.Equal(t, time.Minute, testUserPolicy.Wait(100))
+
+	// the delay never exceeds the lockout.
+	p := Policy{FreeAttempts: 1, Delay: time.Second, MaxAttempts: 100, Lockout: 10 * time.Second}
+	assert.Equal(t, 10*time.Second, p.Wait(50))
+}
+
+func TestThrottle_Attempt(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+
+	t.Run("when login is allowed", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockAttemptRepository)
+		r.On("Get", mock.Anything, "user:+5518999999999").
+			Return(2, time.Now(), nil).
+			Once()
+		r.On("Get", mock.Anything, "ip:10.0.0.1").
+			Return(0, time.Time{}, nil).
+			Once()
+		r.On("Fail", mock.Anything, "user:+5518999999999", mock.Anything, time.Minute).
+			Return(3, nil).
+			Once()
+		r.On("Fail", mock.Anything, "ip:10.0.0.1", mock.Anything, time.Hour).
+			Return(1, nil).
+			Once()
+		th := NewThrottle(r, testUserPolicy, testClientPolicy)
+
+		assert.Nil(t, th.Attempt(ctx, "+5518999999999", "10.0.0.1"))
+
+		// the delay already passed.
+		r.On("Get", mock.Anything, "user:+5518999999999").
+			Return(4, time.Now().Add(-3*time.Second), nil).
+			Once()
+		r.On("Fail", mock.Anything, "user:+5518999999999", mock.Anything, time.Minute).
+			Return(5, nil).
+			Once()
+		assert.Nil(t, th.Attempt(ctx, "+5518999999999", ""))
+		r.AssertExpectations(t)
+		r.AssertNotCalled(t, "Refund", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when login is delayed", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockAttemptRepository)
+		r.On("Get", mock.Anything, "user:+5518999999999").
+			Return(4, time.Now(), nil).
+			Once()
+		r.On("Get", mock.Anything, "ip:10.0.0.1").
+			Return(0, time.Time{}, nil).
+			Once()
+
+		err := NewThrottle(r, testUserPolicy, testClientPolicy).Attempt(ctx, "+5518999999999", "10.0.0.1")
+		var errLocked *ErrLocked
+		assert.True(t, errors.As(err, &errLocked))
+		assert.InDelta(t, 2*time.Second, errLocked.RetryAfter, float64(100*time.Millisecond))
+		r.AssertNotCalled(t, "Fail", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when client is locked out", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockAttemptRepository)
+		r.On("Get", mock.Anything, "user:+5518999999999").
+			Return(0, time.Time{}, nil).
+			Once()
+		r.On("Get", mock.Anything, "ip:10.0.0.1").
+			Return(20, time.Now(), nil).
+			Once()
+
+		err := NewThrottle(r, testUserPolicy, testClientPolicy).Attempt(ctx, "+5518999999999", "10.0.0.1")
+		var errLocked *ErrLocked
+		assert.True(t, errors.As(err, &errLocked))
+		assert.InDelta(t, time.Hour, errLocked.RetryAfter, float64(time.Second))
+		r.AssertNotCalled(t, "Fail", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when concurrent attempts are counted", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockAttemptRepository)
+		r.On("Get", mock.Anything, "user:+5518999999999").
+			Return(2, time.Now(), nil).
+			Once()
+		r.On("Get", mock.Anything, "ip:10.0.0.1").
+			Return(0, time.Time{}, nil).
+			Once()
+		r.On("Fail", mock.Anything, "user:+5518999999999", mock.Anything, time.Minute).
+			Return(3, nil).
+			Once()
+		// another attempt of the client was counted after the check.
+		r.On("Fail", mock.Anything, "ip:10.0.0.1", mock.Anything, time.Hour).
+			Return(12, nil).
+			Once()
+		r.On("Refund", mock.Anything, "user:+5518999999999").
+			Return(nil).
+			Once()
+		r.On("Refund", mock.Anything, "ip:10.0.0.1").
+			Return(nil).
+			Once()
+
+		err := NewThrottle(r, testUserPolicy, testClientPolicy).Attempt(ctx, "+5518999999999", "10.0.0.1")
+		var errLocked *ErrLocked
+		assert.True(t, errors.As(err, &errLocked))
+		assert.Equal(t, 2*time.Second, errLocked.RetryAfter)
+		r.AssertExpectations(t)
+	})
+
+	t.Run("when repository fails", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockAttemptRepository)
+		r.On("Get", mock.Anything, "user:+5518999999999").
+			Return(0, time.Time{}, errors.New("error")).
+			Once()
+		th := NewThrottle(r, testUserPolicy, testClientPolicy)
+
+		err := th.Attempt(ctx, "+5518999999999", "10.0.0.1")
+		assert.NotNil(t, err)
+		var errLocked *ErrLocked
+		assert.False(t, errors.As(err, &errLocked))
+
+		r.On("Get", mock.Anything, mock.Anything).
+			Return(0, time.Time{}, nil)
+		r.On("Fail", mock.Anything, "user:+5518999999999", mock.Anything, mock.Anything).
+			Return(1, nil).
+			Once()
+		r.On("Fail", mock.Anything, "ip:10.0.0.1", mock.Anything, mock.Anything).
+			Return(0, errors.New("error")).
+			Once()
+		r.On("Refund", mock.Anything, "user:+5518999999999").
+			Return(nil).
+			Once()
+
+		err = th.Attempt(ctx, "+5518999999999", "10.0.0.1")
+		assert.NotNil(t, err)
+		r.AssertExpectations(t)
+	})
+}
+
+func TestThrottle_Refund(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+
+	t.Run("when attempt is refunded", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockAttemptRepository)
+		r.On("Refund", mock.Anything, "user:+5518999999999").
+			Return(nil).
+			Once()
+		r.On("Refund", mock.Anything, "ip:10.0.0.1").
+			Return(nil).
+			Once()
+		th := NewThrottle(r, testUserPolicy, testClientPolicy)
+
+		assert.Nil(t, th.Refund(ctx, "+5518999999999", "10.0.0.1"))
+		r.AssertExpectations(t)
+	})
+
+	t.Run("when repository fails", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockAttemptRepository)
+		r.On("Refund", mock.Anything, "user:+5518999999999").
+			Return(errors.New("error")).
+			Once()
+
+		err := NewThrottle(r, testUserPolicy, testClientPolicy).Refund(ctx, "+5518999999999", "10.0.0.1")
+		assert.NotNil(t, err)
+	})
+}
+
+func TestThrottle_Reset(t *testing.T) {
+	//t.Parallel()
+	r := new(mockAttemptRepository)
+	r.On("Reset", mock.Anything, "user:+5518999999999").
+		Return(nil).
+		Once()
+
+	err := NewThrottle(r, testUserPolicy, testClientPolicy).Reset(context.Background(), "+5518999999999")
+	assert.Nil(t, err)
+	r.AssertExpectations(t)
+}
@@ -0,0 +1,41 @@
+package login
+
+import (
+	"context"
+
+	"github.com/tsmweb/auth-service/common/service"
+)
+
+// UnlockUseCase forgets the failed login attempts of the user, lifting the delays and the
+// lockout, otherwise an error is returned.
+type UnlockUseCase interface {
+	Execute(ctx context.Context, userID string) error
+}
+
+type unlockUseCase struct {
+	tag      string
+	throttle Throttle
+}
+
+// NewUnlockUseCase create a new instance of UnlockUseCase.
+func NewUnlockUseCase(throttle Throttle) UnlockUseCase {
+	return &unlockUseCase{
+		tag:      "login::UnlockUseCase",
+		throttle: throttle,
+	}
+}
+
+// Execute executes the unlock use case.
+func (u *unlockUseCase) Execute(ctx context.Context, userID string) error {
+	if userID == "" {
+		return ErrIDValidateModel
+	}
+
+	if err := u.throttle.Reset(ctx, userID); err != nil {
+		service.Error(userID, u.tag, err)
+		return err
+	}
+
+	service.Warn(userID, u.tag, "account unlocked")
+	return nil
+}
@@ -0,0 +1,47 @@
+package login
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUnlockUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+
+	t.Run("when use case fails with ErrValidateModel", func(t *testing.T) {
+		//t.Parallel()
+		th := new(mockThrottle)
+
+		err := NewUnlockUseCase(th).Execute(ctx, "")
+		assert.Equal(t, ErrIDValidateModel, err)
+		th.AssertNotCalled(t, "Reset", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		th := new(mockThrottle)
+		th.On("Reset", mock.Anything, "+5518999999999").
+			Return(errors.New("error")).
+			Once()
+
+		err := NewUnlockUseCase(th).Execute(ctx, "+5518999999999")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when use case succeeds", func(t *testing.T) {
+		//t.Parallel()
+		th := new(mockThrottle)
+		th.On("Reset", mock.Anything, "+5518999999999").
+			Return(nil).
+			Once()
+
+		err := NewUnlockUseCase(th).Execute(ctx, "+5518999999999")
+		assert.Nil(t, err)
+		th.AssertExpectations(t)
+	})
+}
@@ -4,26 +4,53 @@ import (
 	"context"
 	"time"
 
+	"github.com/tsmweb/auth-service/app/account"
+	"github.com/tsmweb/auth-service/app/token"
 	"github.com/tsmweb/auth-service/common"
 	"github.com/tsmweb/auth-service/common/service"
+	"github.com/tsmweb/auth-service/config"
+	"github.com/tsmweb/chat-server/pkg/revocation"
 	"github.com/tsmweb/go-helper-api/cerror"
+	"github.com/tsmweb/go-helper-api/kafka"
 )
 
-// UpdateUseCase updates the login password, otherwise an error will be returned.
+// UpdateUseCase updates the login password, revokes the access and refresh tokens issued until
+// then and publishes
+// the EventPasswordChanged, otherwise an error will be returned.
 type UpdateUseCase interface {
 	Execute(ctx context.Context, login *Login) error
 }
 
 type updateUseCase struct {
-	tag        string
-	repository Repository
+	tag             string
+	repository      Repository
+	store           revocation.Store
+	tokenRepository token.Repository
+	encoder         TokenRevocationEncoder
+	producer        kafka.Producer
+	eventEncoder    account.EventEncoder
+	eventProducer   kafka.Producer
 }
 
 // NewUpdateUseCase create a new instance of UpdateUseCase.
-func NewUpdateUseCase(r Repository) UpdateUseCase {
+func NewUpdateUseCase(
+	r Repository,
+	store revocation.Store,
+	tokenRepository token.Repository,
+	encoder TokenRevocationEncoder,
+	producer kafka.Producer,
+	eventEncoder account.EventEncoder,
+	eventProducer kafka.Producer,
+) UpdateUseCase {
 	return &updateUseCase{
-		tag:        "login::UpdateUseCase",
-		repository: r,
+		tag:             "login::UpdateUseCase",
+		repository:      r,
+		store:           store,
+		tokenRepository: tokenRepository,
+		encoder:         encoder,
+		producer:        producer,
+		eventEncoder:    eventEncoder,
+		eventProducer:   eventProducer,
 	}
 }
 
@@ -55,9 +82,39 @@ func (u *updateUseCase) Execute(ctx context.Context, login *Login) error {
 		return ErrUserNotFound
 	}
 
+	revoked := NewTokenRevocation(login.ID, RevocationPasswordChanged)
+	ttl := time.Duration(config.ExpireToken()) * time.Hour
+	if err = u.store.RevokeUser(ctx, login.ID, revoked.RevokedAt, ttl); err != nil {
+		service.Error(login.ID, u.tag, err)
+		return err
+	}
+	if err = u.tokenRepository.RevokeUser(ctx, login.ID, revoked.RevokedAt); err != nil {
+		service.Error(login.ID, u.tag, err)
+		return err
+	}
+
+	if err = u.notify(ctx, revoked); err != nil {
+		service.Error(login.ID, u.tag, err)
+		return &ErrEventNotification{Msg: err.Error()}
+	}
+
 	return nil
 }
 
+func (u *updateUseCase) notify(ctx context.Context, revoked *TokenRevocation) error {
+	rpb, err := u.encoder.Marshal(revoked)
+	if err != nil {
+		return err
+	}
+
+	if err = u.producer.Publish(ctx, []byte(revoked.UserID), rpb); err != nil {
+		return err
+	}
+
+	return account.Publish(ctx, u.eventEncoder, u.eventProducer,
+		account.NewEvent(revoked.UserID, account.EventPasswordChanged))
+}
+
 func (u *updateUseCase) checkPermission(ctx context.Context, userID string) error {
 	authID := ctx.Value(common.AuthContextKey).(string)
 	// checks if the ID owns the data
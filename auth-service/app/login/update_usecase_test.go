@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/account"
 	"github.com/tsmweb/auth-service/common"
 	"testing"
 )
@@ -13,6 +14,26 @@ func TestUpdateUseCase_Execute(t *testing.T) {
 	//t.Parallel()
 	ctx := context.WithValue(context.Background(), common.AuthContextKey, "+5518999999999")
 
+	encode := new(mockTokenRevocationEncoder)
+	encode.On("Marshal", mock.Anything).
+		Return([]byte{}, nil)
+
+	producer := new(common.MockKafkaProducer)
+	producer.On("Publish", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	eventEncoder := account.EventEncoderFunc(func(e *account.Event) ([]byte, error) {
+		return []byte(e.Event), nil
+	})
+
+	store := new(mockRevocationStore)
+	store.On("RevokeUser", mock.Anything, "+5518999999999", mock.Anything, mock.Anything).
+		Return(nil)
+
+	tokenRepo := new(mockTokenRepository)
+	tokenRepo.On("RevokeUser", mock.Anything, "+5518999999999", mock.Anything).
+		Return(nil)
+
 	t.Run("when use case fails with ErrValidateModel", func(t *testing.T) {
 		//t.Parallel()
 		l := &Login{
@@ -21,7 +42,7 @@ func TestUpdateUseCase_Execute(t *testing.T) {
 		}
 
 		r := new(mockRepository)
-		uc := NewUpdateUseCase(r)
+		uc := NewUpdateUseCase(r, store, tokenRepo, encode, producer, eventEncoder, producer)
 		err := uc.Execute(ctx, l)
 
 		assert.Equal(t, ErrPasswordValidateModel, err)
@@ -35,7 +56,7 @@ func TestUpdateUseCase_Execute(t *testing.T) {
 		}
 
 		r := new(mockRepository)
-		uc := NewUpdateUseCase(r)
+		uc := NewUpdateUseCase(r, store, tokenRepo, encode, producer, eventEncoder, producer)
 		err := uc.Execute(ctx, l)
 
 		assert.Equal(t, ErrOperationNotAllowed, err)
@@ -52,7 +73,7 @@ func TestUpdateUseCase_Execute(t *testing.T) {
 		r.On("Update", mock.Anything, mock.Anything).
 			Return(false, nil).
 			Once()
-		uc := NewUpdateUseCase(r)
+		uc := NewUpdateUseCase(r, store, tokenRepo, encode, producer, eventEncoder, producer)
 		err := uc.Execute(ctx, l)
 
 		assert.Equal(t, ErrUserNotFound, err)
@@ -69,10 +90,78 @@ func TestUpdateUseCase_Execute(t *testing.T) {
 		r.On("Update", mock.Anything, mock.Anything).
 			Return(false, errors.New("error")).
 			Once()
-		uc := NewUpdateUseCase(r)
+		uc := NewUpdateUseCase(r, store, tokenRepo, encode, producer, eventEncoder, producer)
+		err := uc.Execute(ctx, l)
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when use case fails revoking the tokens", func(t *testing.T) {
+		//t.Parallel()
+		l := &Login{
+			ID: "+5518999999999",
+			Password: "123456",
+		}
+
+		r := new(mockRepository)
+		r.On("Update", mock.Anything, mock.Anything).
+			Return(true, nil).
+			Once()
+		s := new(mockRevocationStore)
+		s.On("RevokeUser", mock.Anything, "+5518999999999", mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Once()
+		p := new(common.MockKafkaProducer)
+		uc := NewUpdateUseCase(r, s, tokenRepo, encode, p, eventEncoder, p)
 		err := uc.Execute(ctx, l)
 
 		assert.NotNil(t, err)
+		p.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails revoking the refresh tokens", func(t *testing.T) {
+		//t.Parallel()
+		l := &Login{
+			ID: "+5518999999999",
+			Password: "123456",
+		}
+
+		r := new(mockRepository)
+		r.On("Update", mock.Anything, mock.Anything).
+			Return(true, nil).
+			Once()
+		tr := new(mockTokenRepository)
+		tr.On("RevokeUser", mock.Anything, "+5518999999999", mock.Anything).
+			Return(errors.New("error")).
+			Once()
+		p := new(common.MockKafkaProducer)
+		uc := NewUpdateUseCase(r, store, tr, encode, p, eventEncoder, p)
+		err := uc.Execute(ctx, l)
+
+		assert.NotNil(t, err)
+		p.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with ErrEventNotification", func(t *testing.T) {
+		//t.Parallel()
+		l := &Login{
+			ID: "+5518999999999",
+			Password: "123456",
+		}
+
+		r := new(mockRepository)
+		r.On("Update", mock.Anything, mock.Anything).
+			Return(true, nil).
+			Once()
+		p := new(common.MockKafkaProducer)
+		p.On("Publish", mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Once()
+		uc := NewUpdateUseCase(r, store, tokenRepo, encode, p, eventEncoder, p)
+		err := uc.Execute(ctx, l)
+
+		var errEventNotification *ErrEventNotification
+		assert.ErrorAs(t, err, &errEventNotification)
 	})
 
 	t.Run("when use case success", func(t *testing.T) {
@@ -86,9 +175,20 @@ func TestUpdateUseCase_Execute(t *testing.T) {
 		r.On("Update", mock.Anything, mock.Anything).
 			Return(true, nil).
 			Once()
-		uc := NewUpdateUseCase(r)
+		ep := new(common.MockKafkaProducer)
+		ep.On("Publish", mock.Anything, []byte("+5518999999999"),
+			[][]byte{[]byte(account.EventPasswordChanged.String())}).
+			Return(nil).
+			Once()
+		tr := new(mockTokenRepository)
+		tr.On("RevokeUser", mock.Anything, "+5518999999999", mock.Anything).
+			Return(nil).
+			Once()
+		uc := NewUpdateUseCase(r, store, tr, encode, producer, eventEncoder, ep)
 		err := uc.Execute(ctx, l)
 
 		assert.Nil(t, err)
+		ep.AssertExpectations(t)
+		tr.AssertExpectations(t)
 	})
 }
@@ -0,0 +1,136 @@
+package reset
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/tsmweb/auth-service/app/account"
+	"github.com/tsmweb/auth-service/app/login"
+	"github.com/tsmweb/auth-service/app/token"
+	"github.com/tsmweb/auth-service/common/service"
+	"github.com/tsmweb/auth-service/config"
+	"github.com/tsmweb/chat-server/pkg/revocation"
+	"github.com/tsmweb/go-helper-api/cerror"
+	"github.com/tsmweb/go-helper-api/kafka"
+)
+
+// ConfirmUseCase sets the new password of the user who owns the reset token, revokes all
+// the access and refresh tokens issued to the user until then and publishes the
+// EventPasswordChanged, otherwise an error will be returned.
+type ConfirmUseCase interface {
+	Execute(ctx context.Context, resetToken, password string) error
+}
+
+type confirmUseCase struct {
+	tag             string
+	repository      Repository
+	loginRepository login.Repository
+	tokenRepository token.Repository
+	store           revocation.Store
+	encoder         login.TokenRevocationEncoder
+	producer        kafka.Producer
+	eventEncoder    account.EventEncoder
+	eventProducer   kafka.Producer
+}
+
+// NewConfirmUseCase create a new instance of ConfirmUseCase.
+func NewConfirmUseCase(
+	repository Repository,
+	loginRepository login.Repository,
+	tokenRepository token.Repository,
+	store revocation.Store,
+	encoder login.TokenRevocationEncoder,
+	producer kafka.Producer,
+	eventEncoder account.EventEncoder,
+	eventProducer kafka.Producer,
+) ConfirmUseCase {
+	return &confirmUseCase{
+		tag:             "reset::ConfirmUseCase",
+		repository:      repository,
+		loginRepository: loginRepository,
+		tokenRepository: tokenRepository,
+		store:           store,
+		encoder:         encoder,
+		producer:        producer,
+		eventEncoder:    eventEncoder,
+		eventProducer:   eventProducer,
+	}
+}
+
+// Execute executes the confirm use case.
+func (u *confirmUseCase) Execute(ctx context.Context, resetToken, password string) error {
+	if strings.TrimSpace(resetToken) == "" {
+		return ErrTokenValidateModel
+	}
+	if password == "" {
+		return login.ErrPasswordValidateModel
+	}
+
+	r, err := u.repository.Use(ctx, HashToken(resetToken))
+	if err != nil {
+		if errors.Is(err, cerror.ErrNotFound) {
+			return ErrInvalidToken
+		}
+		service.Error("", u.tag, err)
+		return err
+	}
+
+	now := time.Now().UTC()
+	if r.IsExpired(now) {
+		service.Warn(r.UserID, u.tag, ErrInvalidToken.Error())
+		return ErrInvalidToken
+	}
+
+	l := &login.Login{
+		ID:        r.UserID,
+		Password:  password,
+		UpdatedAt: now,
+	}
+	if err = l.ApplyHashPassword(); err != nil {
+		service.Error(r.UserID, u.tag, err)
+		return cerror.ErrInternalServer
+	}
+
+	ok, err := u.loginRepository.Update(ctx, l)
+	if err != nil {
+		service.Error(r.UserID, u.tag, err)
+		return err
+	}
+	if !ok {
+		return login.ErrUserNotFound
+	}
+
+	revoked := login.NewTokenRevocation(r.UserID, login.RevocationPasswordChanged)
+	ttl := time.Duration(config.ExpireToken()) * time.Hour
+	if err = u.store.RevokeUser(ctx, r.UserID, revoked.RevokedAt, ttl); err != nil {
+		service.Error(r.UserID, u.tag, err)
+		return err
+	}
+	if err = u.tokenRepository.RevokeUser(ctx, r.UserID, revoked.RevokedAt); err != nil {
+		service.Error(r.UserID, u.tag, err)
+		return err
+	}
+
+	if err = u.notify(ctx, revoked); err != nil {
+		service.Error(r.UserID, u.tag, err)
+		return &login.ErrEventNotification{Msg: err.Error()}
+	}
+
+	return nil
+}
+
+func (u *confirmUseCase) notify(ctx context.Context, revoked *login.TokenRevocation) error {
+	rpb, err := u.encoder.Marshal(revoked)
+	if err != nil {
+		return err
+	}
+
+	if err = u.producer.Publish(ctx, []byte(revoked.UserID), rpb); err != nil {
+		return err
+	}
+
+	return account.Publish(ctx, u.eventEncoder, u.eventProducer,
+		account.NewEvent(revoked.UserID, account.EventPasswordChanged))
+}
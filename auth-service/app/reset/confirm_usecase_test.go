@@ -0,0 +1,165 @@
+package reset
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/account"
+	"github.com/tsmweb/auth-service/app/login"
+	"github.com/tsmweb/auth-service/common"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+func TestConfirmUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+
+	r, token, err := NewReset("+5518999999999", time.Hour)
+	assert.Nil(t, err)
+
+	encode := new(mockTokenRevocationEncoder)
+	encode.On("Marshal", mock.Anything).
+		Return([]byte{}, nil)
+
+	eventEncoder := account.EventEncoderFunc(func(e *account.Event) ([]byte, error) {
+		return []byte(e.Event), nil
+	})
+
+	newUseCase := func(r Repository, lr login.Repository, tr *mockTokenRepository,
+		s *mockRevocationStore, producer *common.MockKafkaProducer) ConfirmUseCase {
+		return NewConfirmUseCase(r, lr, tr, s, encode, producer, eventEncoder, producer)
+	}
+
+	t.Run("when use case fails with ErrValidateModel", func(t *testing.T) {
+		//t.Parallel()
+		uc := newUseCase(new(mockRepository), new(mockLoginRepository), new(mockTokenRepository),
+			new(mockRevocationStore), new(common.MockKafkaProducer))
+
+		assert.Equal(t, ErrTokenValidateModel, uc.Execute(ctx, " ", "123456"))
+		assert.Equal(t, login.ErrPasswordValidateModel, uc.Execute(ctx, token, ""))
+	})
+
+	t.Run("when use case fails with ErrInvalidToken", func(t *testing.T) {
+		//t.Parallel()
+		expired := *r
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+
+		rr := new(mockRepository)
+		rr.On("Use", mock.Anything, HashToken("unknown")).
+			Return(nil, cerror.ErrNotFound).
+			Once()
+		rr.On("Use", mock.Anything, HashToken(token)).
+			Return(&expired, nil).
+			Once()
+		lr := new(mockLoginRepository)
+		uc := newUseCase(rr, lr, new(mockTokenRepository), new(mockRevocationStore),
+			new(common.MockKafkaProducer))
+
+		assert.Equal(t, ErrInvalidToken, uc.Execute(ctx, "unknown", "123456"))
+		assert.Equal(t, ErrInvalidToken, uc.Execute(ctx, token, "123456"))
+		lr.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		rr := new(mockRepository)
+		rr.On("Use", mock.Anything, HashToken(token)).
+			Return(r, nil)
+		lr := new(mockLoginRepository)
+		lr.On("Update", mock.Anything, mock.Anything).
+			Return(false, errors.New("error")).
+			Once()
+		s := new(mockRevocationStore)
+		s.On("RevokeUser", mock.Anything, "+5518999999999", mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Once()
+		uc := newUseCase(rr, lr, new(mockTokenRepository), s, new(common.MockKafkaProducer))
+
+		err := uc.Execute(ctx, token, "123456")
+		assert.NotNil(t, err)
+
+		lr.On("Update", mock.Anything, mock.Anything).
+			Return(false, nil).
+			Once()
+		err = uc.Execute(ctx, token, "123456")
+		assert.Equal(t, login.ErrUserNotFound, err)
+
+		lr.On("Update", mock.Anything, mock.Anything).
+			Return(true, nil).
+			Once()
+		err = uc.Execute(ctx, token, "123456")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when use case fails with ErrEventNotification", func(t *testing.T) {
+		//t.Parallel()
+		rr := new(mockRepository)
+		rr.On("Use", mock.Anything, HashToken(token)).
+			Return(r, nil).
+			Once()
+		lr := new(mockLoginRepository)
+		lr.On("Update", mock.Anything, mock.Anything).
+			Return(true, nil).
+			Once()
+		tr := new(mockTokenRepository)
+		tr.On("RevokeUser", mock.Anything, "+5518999999999", mock.Anything).
+			Return(nil).
+			Once()
+		s := new(mockRevocationStore)
+		s.On("RevokeUser", mock.Anything, "+5518999999999", mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		producer := new(common.MockKafkaProducer)
+		producer.On("Publish", mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Once()
+
+		err := newUseCase(rr, lr, tr, s, producer).Execute(ctx, token, "123456")
+		assert.IsType(t, &login.ErrEventNotification{}, err)
+	})
+
+	t.Run("when use case succeeds", func(t *testing.T) {
+		//t.Parallel()
+		var updated *login.Login
+
+		rr := new(mockRepository)
+		rr.On("Use", mock.Anything, HashToken(token)).
+			Return(r, nil).
+			Once()
+		lr := new(mockLoginRepository)
+		lr.On("Update", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				updated = args.Get(1).(*login.Login)
+			}).
+			Return(true, nil).
+			Once()
+		tr := new(mockTokenRepository)
+		tr.On("RevokeUser", mock.Anything, "+5518999999999", mock.Anything).
+			Return(nil).
+			Once()
+		s := new(mockRevocationStore)
+		s.On("RevokeUser", mock.Anything, "+5518999999999", mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		producer := new(common.MockKafkaProducer)
+		producer.On("Publish", mock.Anything, []byte("+5518999999999"), mock.Anything).
+			Return(nil).
+			Once()
+		producer.On("Publish", mock.Anything, []byte("+5518999999999"),
+			[][]byte{[]byte(account.EventPasswordChanged.String())}).
+			Return(nil).
+			Once()
+
+		err := newUseCase(rr, lr, tr, s, producer).Execute(ctx, token, "123456")
+		assert.Nil(t, err)
+		assert.Equal(t, "+5518999999999", updated.ID)
+		assert.NotEqual(t, "123456", updated.Password)
+		tr.AssertExpectations(t)
+		s.AssertExpectations(t)
+		producer.AssertExpectations(t)
+	})
+}
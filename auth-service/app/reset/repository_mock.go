@@ -0,0 +1,164 @@
+package reset
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/login"
+	"github.com/tsmweb/auth-service/app/token"
+	"github.com/tsmweb/chat-server/pkg/revocation"
+)
+
+// mockRepository injects mock dependency into UseCase layer.
+type mockRepository struct {
+	mock.Mock
+}
+
+// GetByUser represents the simulated method for the GetByUser feature in the Repository layer.
+func (m *mockRepository) GetByUser(ctx context.Context, userID string) (*Reset, error) {
+	args := m.Called(ctx, userID)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Reset), nil
+}
+
+// Save represents the simulated method for the Save feature in the Repository layer.
+func (m *mockRepository) Save(ctx context.Context, r *Reset) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+// Use represents the simulated method for the Use feature in the Repository layer.
+func (m *mockRepository) Use(ctx context.Context, ID string) (*Reset, error) {
+	args := m.Called(ctx, ID)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Reset), nil
+}
+
+// mockNotifier injects mock Notifier dependency.
+type mockNotifier struct {
+	mock.Mock
+}
+
+// Notify represents the simulated method for the Notify feature in the Notifier.
+func (m *mockNotifier) Notify(ctx context.Context, userID, text string) error {
+	args := m.Called(ctx, userID, text)
+	return args.Error(0)
+}
+
+// mockLoginRepository injects mock login.Repository dependency.
+type mockLoginRepository struct {
+	mock.Mock
+}
+
+// GetPassword represents the simulated method for the GetPassword feature in the
+// login.Repository.
+func (m *mockLoginRepository) GetPassword(ctx context.Context, ID string) (string, error) {
+	args := m.Called(ctx, ID)
+	return args.String(0), args.Error(1)
+}
+
+// Rehash represents the simulated method for the Rehash feature in the login.Repository.
+func (m *mockLoginRepository) Rehash(ctx context.Context, ID, oldHash, newHash string) (bool, error) {
+	args := m.Called(ctx, ID, oldHash, newHash)
+	return args.Bool(0), args.Error(1)
+}
+
+// Update represents the simulated method for the Update feature in the login.Repository.
+func (m *mockLoginRepository) Update(ctx context.Context, l *login.Login) (bool, error) {
+	args := m.Called(ctx, l)
+	return args.Bool(0), args.Error(1)
+}
+
+// mockTokenRepository injects mock token.Repository dependency.
+type mockTokenRepository struct {
+	mock.Mock
+}
+
+// Create represents the simulated method for the Create feature in the token.Repository.
+func (m *mockTokenRepository) Create(ctx context.Context, t *token.RefreshToken) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+// Get represents the simulated method for the Get feature in the token.Repository.
+func (m *mockTokenRepository) Get(ctx context.Context, ID string) (*token.RefreshToken, error) {
+	args := m.Called(ctx, ID)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*token.RefreshToken), nil
+}
+
+// Use represents the simulated method for the Use feature in the token.Repository.
+func (m *mockTokenRepository) Use(ctx context.Context, ID string, usedAt time.Time) (bool, error) {
+	args := m.Called(ctx, ID, usedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+// RevokeFamily represents the simulated method for the RevokeFamily feature in the
+// token.Repository.
+func (m *mockTokenRepository) RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	args := m.Called(ctx, familyID, revokedAt)
+	return args.Error(0)
+}
+
+// RevokeUser represents the simulated method for the RevokeUser feature in the
+// token.Repository.
+func (m *mockTokenRepository) RevokeUser(ctx context.Context, userID string, revokedAt time.Time) error {
+	args := m.Called(ctx, userID, revokedAt)
+	return args.Error(0)
+}
+
+// mockRevocationStore injects mock revocation.Store dependency.
+type mockRevocationStore struct {
+	mock.Mock
+}
+
+// IsRevoked represents the simulated method for the IsRevoked feature in the revocation.Store.
+func (m *mockRevocationStore) IsRevoked(ctx context.Context, t *revocation.Token) (bool, error) {
+	args := m.Called(ctx, t)
+	return args.Bool(0), args.Error(1)
+}
+
+// RevokeToken represents the simulated method for the RevokeToken feature in the
+// revocation.Store.
+func (m *mockRevocationStore) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	args := m.Called(ctx, tokenID, expiresAt)
+	return args.Error(0)
+}
+
+// RevokeSession represents the simulated method for the RevokeSession feature in the
+// revocation.Store.
+func (m *mockRevocationStore) RevokeSession(ctx context.Context, sessionID string,
+	ttl time.Duration) error {
+	args := m.Called(ctx, sessionID, ttl)
+	return args.Error(0)
+}
+
+// RevokeUser represents the simulated method for the RevokeUser feature in the
+// revocation.Store.
+func (m *mockRevocationStore) RevokeUser(ctx context.Context, userID string, revokedAt time.Time,
+	ttl time.Duration) error {
+	args := m.Called(ctx, userID, revokedAt, ttl)
+	return args.Error(0)
+}
+
+// mockTokenRevocationEncoder injects mock login.TokenRevocationEncoder dependency.
+type mockTokenRevocationEncoder struct {
+	mock.Mock
+}
+
+// Marshal represents the simulated method for the Marshal feature in the
+// login.TokenRevocationEncoder.
+func (m *mockTokenRevocationEncoder) Marshal(r *login.TokenRevocation) ([]byte, error) {
+	args := m.Called(r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), nil
+}
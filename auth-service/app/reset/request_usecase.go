@@ -0,0 +1,88 @@
+package reset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tsmweb/auth-service/app/login"
+	"github.com/tsmweb/auth-service/common/service"
+	"github.com/tsmweb/auth-service/config"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+// RequestUseCase sends a password reset token to the user through the Notifier, otherwise
+// an error is returned. To not disclose which users exist, unknown users and requests made
+// before the resend interval are only logged. A new token replaces the previous one.
+type RequestUseCase interface {
+	Execute(ctx context.Context, userID string) error
+}
+
+type requestUseCase struct {
+	tag             string
+	repository      Repository
+	loginRepository login.Repository
+	notifier        Notifier
+}
+
+// NewRequestUseCase create a new instance of RequestUseCase.
+func NewRequestUseCase(repository Repository, loginRepository login.Repository,
+	notifier Notifier) RequestUseCase {
+	return &requestUseCase{
+		tag:             "reset::RequestUseCase",
+		repository:      repository,
+		loginRepository: loginRepository,
+		notifier:        notifier,
+	}
+}
+
+// Execute executes the request use case.
+func (u *requestUseCase) Execute(ctx context.Context, userID string) error {
+	if userID == "" {
+		return ErrIDValidateModel
+	}
+
+	if _, err := u.loginRepository.GetPassword(ctx, userID); err != nil {
+		if errors.Is(err, cerror.ErrNotFound) {
+			service.Warn(userID, u.tag, login.ErrUserNotFound.Error())
+			return nil
+		}
+		service.Error(userID, u.tag, err)
+		return err
+	}
+
+	previous, err := u.repository.GetByUser(ctx, userID)
+	if err != nil && !errors.Is(err, cerror.ErrNotFound) {
+		service.Error(userID, u.tag, err)
+		return err
+	}
+	if previous != nil {
+		interval := time.Duration(config.ResetResendInterval()) * time.Second
+		if time.Now().Before(previous.CreatedAt.Add(interval)) {
+			service.Warn(userID, u.tag, ErrResendTooSoon.Error())
+			return nil
+		}
+	}
+
+	expire := time.Duration(config.ResetExpire()) * time.Minute
+	r, token, err := NewReset(userID, expire)
+	if err != nil {
+		service.Error(userID, u.tag, err)
+		return err
+	}
+
+	if err = u.repository.Save(ctx, r); err != nil {
+		service.Error(userID, u.tag, err)
+		return err
+	}
+
+	text := fmt.Sprintf("Your password reset token is %s. It expires in %d minutes.",
+		token, config.ResetExpire())
+	if err = u.notifier.Notify(ctx, userID, text); err != nil {
+		service.Error(userID, u.tag, err)
+		return err
+	}
+
+	return nil
+}
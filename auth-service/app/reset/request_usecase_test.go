@@ -0,0 +1,130 @@
+package reset
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+func TestRequestUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+
+	t.Run("when use case fails with ErrValidateModel", func(t *testing.T) {
+		//t.Parallel()
+		err := NewRequestUseCase(new(mockRepository), new(mockLoginRepository), new(mockNotifier)).
+			Execute(ctx, "")
+		assert.Equal(t, ErrIDValidateModel, err)
+	})
+
+	t.Run("when user is not found", func(t *testing.T) {
+		//t.Parallel()
+		lr := new(mockLoginRepository)
+		lr.On("GetPassword", mock.Anything, "+5518999999999").
+			Return("", cerror.ErrNotFound).
+			Once()
+		r := new(mockRepository)
+		n := new(mockNotifier)
+
+		err := NewRequestUseCase(r, lr, n).Execute(ctx, "+5518999999999")
+		assert.Nil(t, err)
+		r.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		n.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when token was recently sent", func(t *testing.T) {
+		//t.Parallel()
+		// the resend interval is not loaded in the tests, the token is sent in the future.
+		previous := &Reset{UserID: "+5518999999999", CreatedAt: time.Now().Add(time.Minute)}
+
+		lr := new(mockLoginRepository)
+		lr.On("GetPassword", mock.Anything, "+5518999999999").
+			Return("hash", nil).
+			Once()
+		r := new(mockRepository)
+		r.On("GetByUser", mock.Anything, "+5518999999999").
+			Return(previous, nil).
+			Once()
+		n := new(mockNotifier)
+
+		err := NewRequestUseCase(r, lr, n).Execute(ctx, "+5518999999999")
+		assert.Nil(t, err)
+		r.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		n.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		lr := new(mockLoginRepository)
+		lr.On("GetPassword", mock.Anything, "+5518999999999").
+			Return("", errors.New("error")).
+			Once()
+		r := new(mockRepository)
+		n := new(mockNotifier)
+		n.On("Notify", mock.Anything, "+5518999999999", mock.Anything).
+			Return(errors.New("error")).
+			Once()
+		uc := NewRequestUseCase(r, lr, n)
+
+		err := uc.Execute(ctx, "+5518999999999")
+		assert.NotNil(t, err)
+
+		lr.On("GetPassword", mock.Anything, "+5518999999999").
+			Return("hash", nil)
+		r.On("GetByUser", mock.Anything, "+5518999999999").
+			Return(nil, cerror.ErrNotFound)
+		r.On("Save", mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Once()
+		err = uc.Execute(ctx, "+5518999999999")
+		assert.NotNil(t, err)
+
+		r.On("Save", mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		err = uc.Execute(ctx, "+5518999999999")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when use case succeeds", func(t *testing.T) {
+		//t.Parallel()
+		var stored *Reset
+		var text string
+
+		lr := new(mockLoginRepository)
+		lr.On("GetPassword", mock.Anything, "+5518999999999").
+			Return("hash", nil).
+			Once()
+		r := new(mockRepository)
+		r.On("GetByUser", mock.Anything, "+5518999999999").
+			Return(nil, cerror.ErrNotFound).
+			Once()
+		r.On("Save", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				stored = args.Get(1).(*Reset)
+			}).
+			Return(nil).
+			Once()
+		n := new(mockNotifier)
+		n.On("Notify", mock.Anything, "+5518999999999", mock.Anything).
+			Run(func(args mock.Arguments) {
+				text = args.String(2)
+			}).
+			Return(nil).
+			Once()
+
+		err := NewRequestUseCase(r, lr, n).Execute(ctx, "+5518999999999")
+		assert.Nil(t, err)
+		assert.Equal(t, "+5518999999999", stored.UserID)
+
+		// the token sent matches the hash stored.
+		token := strings.TrimSuffix(strings.Fields(text)[5], ".")
+		assert.Equal(t, stored.ID, HashToken(token))
+	})
+}
@@ -0,0 +1,86 @@
+package reset
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+var (
+	ErrIDValidateModel    = &cerror.ErrValidateModel{Msg: "required id"}
+	ErrTokenValidateModel = &cerror.ErrValidateModel{Msg: "required token"}
+	ErrInvalidToken       = errors.New("invalid reset token")
+	ErrResendTooSoon      = errors.New("reset token recently sent")
+)
+
+// Reset allows the user who forgot the password to set a new one with the single-use token
+// sent by the Notifier. Only the hash of the token is stored.
+type Reset struct {
+	ID        string // hash of the token
+	UserID    string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// NewReset creates a reset of the user valid for the given duration, returning the data
+// to be stored and the token to be sent to the user.
+func NewReset(userID string, expire time.Duration) (*Reset, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", err
+	}
+	value := base64.RawURLEncoding.EncodeToString(b)
+
+	now := time.Now().UTC()
+	r := &Reset{
+		ID:        HashToken(value),
+		UserID:    userID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(expire),
+	}
+	return r, value, nil
+}
+
+// HashToken returns the hash that identifies the reset in the data source.
+func HashToken(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsExpired reports whether the token expired at the given time.
+func (r *Reset) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
+
+// Notifier delivers the reset tokens to the users, such as by SMS to the phone number
+// used as the user ID.
+type Notifier interface {
+	Notify(ctx context.Context, userID, text string) error
+}
+
+// The NotifierFunc type is an adapter to allow the use of ordinary functions as Notifier.
+// If f is a function with the appropriate signature, NotifierFunc(f) is a Notifier that
+// calls f.
+type NotifierFunc func(ctx context.Context, userID, text string) error
+
+// Notify calls f(ctx, userID, text).
+func (f NotifierFunc) Notify(ctx context.Context, userID, text string) error {
+	return f(ctx, userID, text)
+}
+
+// Repository interface for reset data source.
+type Repository interface {
+	// GetByUser returns the last reset requested by the user, or cerror.ErrNotFound.
+	GetByUser(ctx context.Context, userID string) (*Reset, error)
+	// Save stores the reset, replacing the previous one of the user.
+	Save(ctx context.Context, r *Reset) error
+	// Use deletes and returns the reset with the token hash, or cerror.ErrNotFound, so that
+	// each token is accepted only once even by concurrent requests.
+	Use(ctx context.Context, ID string) (*Reset, error)
+}
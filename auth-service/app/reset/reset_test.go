@@ -0,0 +1,24 @@
+package reset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReset(t *testing.T) {
+	//t.Parallel()
+	r, token, err := NewReset("+5518999999999", time.Minute)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, HashToken(token), r.ID)
+	assert.NotEqual(t, token, r.ID)
+	assert.Equal(t, "+5518999999999", r.UserID)
+	assert.False(t, r.IsExpired(time.Now()))
+	assert.True(t, r.IsExpired(time.Now().Add(time.Minute)))
+
+	_, other, err := NewReset("+5518999999999", time.Minute)
+	assert.Nil(t, err)
+	assert.NotEqual(t, token, other)
+}
@@ -0,0 +1,35 @@
+package session
+
+import (
+	"context"
+
+	"github.com/tsmweb/auth-service/common/service"
+)
+
+// ListUseCase returns the active sessions of the user, otherwise an error is returned.
+type ListUseCase interface {
+	Execute(ctx context.Context, userID string) ([]*Session, error)
+}
+
+type listUseCase struct {
+	tag        string
+	repository Repository
+}
+
+// NewListUseCase create a new instance of ListUseCase.
+func NewListUseCase(repository Repository) ListUseCase {
+	return &listUseCase{
+		tag:        "session::ListUseCase",
+		repository: repository,
+	}
+}
+
+// Execute executes the list use case.
+func (u *listUseCase) Execute(ctx context.Context, userID string) ([]*Session, error) {
+	sessions, err := u.repository.GetAll(ctx, userID)
+	if err != nil {
+		service.Error(userID, u.tag, err)
+		return nil, err
+	}
+	return sessions, nil
+}
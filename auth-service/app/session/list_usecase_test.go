@@ -0,0 +1,43 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("GetAll", mock.Anything, "+5518999999999").
+			Return(nil, errors.New("error")).
+			Once()
+
+		_, err := NewListUseCase(r).Execute(ctx, "+5518999999999")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when use case succeeds", func(t *testing.T) {
+		//t.Parallel()
+		sessions := []*Session{
+			NewSession("S1", "+5518999999999", nil),
+			NewSession("S2", "+5518999999999", nil),
+		}
+
+		r := new(mockRepository)
+		r.On("GetAll", mock.Anything, "+5518999999999").
+			Return(sessions, nil).
+			Once()
+
+		result, err := NewListUseCase(r).Execute(ctx, "+5518999999999")
+		assert.Nil(t, err)
+		assert.Equal(t, sessions, result)
+	})
+}
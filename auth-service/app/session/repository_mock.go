@@ -0,0 +1,52 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// mockRepository injects mock dependency into UseCase layer.
+type mockRepository struct {
+	mock.Mock
+}
+
+// Create represents the simulated method for the Create feature in the Repository layer.
+func (m *mockRepository) Create(ctx context.Context, s *Session) error {
+	args := m.Called(ctx, s)
+	return args.Error(0)
+}
+
+// Get represents the simulated method for the Get feature in the Repository layer.
+func (m *mockRepository) Get(ctx context.Context, ID string) (*Session, error) {
+	args := m.Called(ctx, ID)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Session), nil
+}
+
+// GetAll represents the simulated method for the GetAll feature in the Repository layer.
+func (m *mockRepository) GetAll(ctx context.Context, userID string) ([]*Session, error) {
+	args := m.Called(ctx, userID)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Session), nil
+}
+
+// Touch represents the simulated method for the Touch feature in the Repository layer.
+func (m *mockRepository) Touch(ctx context.Context, ID string, lastSeenAt time.Time) error {
+	args := m.Called(ctx, ID, lastSeenAt)
+	return args.Error(0)
+}
+
+// Revoke represents the simulated method for the Revoke feature in the Repository layer.
+func (m *mockRepository) Revoke(ctx context.Context, ID string, revokedAt time.Time) (bool, error) {
+	args := m.Called(ctx, ID, revokedAt)
+	if args.Error(1) != nil {
+		return false, args.Error(1)
+	}
+	return args.Bool(0), nil
+}
@@ -0,0 +1,83 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+const (
+	maxDeviceNameLen = 100
+	maxUserAgentLen  = 255
+)
+
+// Device describes where the user logs in, informed in the context of the login by
+// common.DeviceContextKey.
+type Device struct {
+	Name      string
+	UserAgent string
+	IP        string
+}
+
+// Session is a login of the user on a device, active while its refresh tokens are valid.
+// Its ID is the family of the refresh tokens issued on the login, carried by the access
+// tokens in the "sid" claim, so that revoking the session revokes all of them.
+type Session struct {
+	ID         string
+	UserID     string
+	DeviceName string
+	UserAgent  string
+	IP         string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	RevokedAt  time.Time
+}
+
+// NewSession return an instance of Session of the user logged in on the device, which may be
+// nil when unknown.
+func NewSession(ID, userID string, device *Device) *Session {
+	now := time.Now().UTC()
+	s := &Session{
+		ID:         ID,
+		UserID:     userID,
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}
+	if device != nil {
+		s.DeviceName = truncate(device.Name, maxDeviceNameLen)
+		s.UserAgent = truncate(device.UserAgent, maxUserAgentLen)
+		s.IP = device.IP
+	}
+	return s
+}
+
+// IsRevoked reports whether the session was revoked.
+func (s *Session) IsRevoked() bool {
+	return !s.RevokedAt.IsZero()
+}
+
+// truncate limits s to n runes, as the device data is informed by the client.
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
+
+// Repository interface for session data source.
+type Repository interface {
+	Create(ctx context.Context, s *Session) error
+	// Get returns the session by ID, or cerror.ErrNotFound.
+	Get(ctx context.Context, ID string) (*Session, error)
+	// GetAll returns the active sessions of the user, not revoked and with a refresh token
+	// still valid, the last seen first.
+	GetAll(ctx context.Context, userID string) ([]*Session, error)
+	// Touch updates the last time the session was seen, on each refresh of its tokens.
+	Touch(ctx context.Context, ID string, lastSeenAt time.Time) error
+	// Revoke revokes the session along with its refresh tokens, returning false if it was not
+	// found or already revoked.
+	Revoke(ctx context.Context, ID string, revokedAt time.Time) (bool, error)
+}
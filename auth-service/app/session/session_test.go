@@ -0,0 +1,37 @@
+package session
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSession(t *testing.T) {
+	//t.Parallel()
+	t.Run("when device is unknown", func(t *testing.T) {
+		//t.Parallel()
+		s := NewSession("S1", "+5518999999999", nil)
+		assert.Equal(t, "S1", s.ID)
+		assert.Equal(t, "+5518999999999", s.UserID)
+		assert.Empty(t, s.DeviceName)
+		assert.Equal(t, s.CreatedAt, s.LastSeenAt)
+		assert.False(t, s.IsRevoked())
+	})
+
+	t.Run("when device is informed", func(t *testing.T) {
+		//t.Parallel()
+		s := NewSession("S1", "+5518999999999", &Device{
+			Name:      strings.Repeat("á", 150),
+			UserAgent: strings.Repeat("a", 300),
+			IP:        "10.0.0.1",
+		})
+		assert.Equal(t, strings.Repeat("á", maxDeviceNameLen), s.DeviceName)
+		assert.Len(t, s.UserAgent, maxUserAgentLen)
+		assert.Equal(t, "10.0.0.1", s.IP)
+
+		s.RevokedAt = time.Now().UTC()
+		assert.True(t, s.IsRevoked())
+	})
+}
@@ -0,0 +1,85 @@
+package token
+
+import (
+	"context"
+	"time"
+
+	"github.com/tsmweb/auth-service/app/session"
+	"github.com/tsmweb/auth-service/common"
+	"github.com/tsmweb/auth-service/config"
+	"github.com/tsmweb/chat-server/pkg/revocation"
+	"github.com/tsmweb/go-helper-api/auth"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+// Issuer issues the access and refresh tokens of the user.
+type Issuer interface {
+	// Issue returns a new pair of tokens, the refresh token belongs to the family informed
+	// or to a new family if familyID is empty. A new family starts a session on the device
+	// informed in the context by common.DeviceContextKey.
+	Issue(ctx context.Context, userID, familyID string) (*Token, error)
+}
+
+type issuer struct {
+	repository        Repository
+	sessionRepository session.Repository
+	jwt               auth.JWT
+}
+
+// NewIssuer create a new instance of Issuer.
+func NewIssuer(repository Repository, sessionRepository session.Repository, jwt auth.JWT) Issuer {
+	return &issuer{
+		repository:        repository,
+		sessionRepository: sessionRepository,
+		jwt:               jwt,
+	}
+}
+
+// Issue generates the access token and stores the refresh token.
+func (i *issuer) Issue(ctx context.Context, userID, familyID string) (*Token, error) {
+	tokenID, err := randomString(16)
+	if err != nil {
+		return nil, err
+	}
+
+	expire := time.Duration(config.RefreshTokenExpire()) * time.Hour
+	rt, refreshToken, err := NewRefreshToken(userID, familyID, expire)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"id":  userID,
+		"jti": tokenID,                         // allows revoking the token on logout
+		"sid": rt.FamilyID,                     // allows revoking the tokens of the session
+		"iat": revocation.IssuedAt(time.Now()), // allows revoking the tokens issued until a given date
+	}
+
+	accessToken, err := i.jwt.GenerateToken(payload, config.ExpireToken())
+	if err != nil {
+		return nil, err
+	}
+	if len(accessToken) == 0 {
+		return nil, cerror.ErrUnauthorized
+	}
+
+	if familyID == "" {
+		device, _ := ctx.Value(common.DeviceContextKey).(*session.Device)
+		err = i.sessionRepository.Create(ctx, session.NewSession(rt.FamilyID, userID, device))
+	} else {
+		err = i.sessionRepository.Touch(ctx, familyID, rt.CreatedAt)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = i.repository.Create(ctx, rt); err != nil {
+		return nil, err
+	}
+
+	return &Token{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    config.ExpireToken() * int(time.Hour/time.Second),
+	}, nil
+}
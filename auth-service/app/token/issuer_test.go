@@ -0,0 +1,135 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/session"
+	"github.com/tsmweb/auth-service/common"
+	"github.com/tsmweb/auth-service/config"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+func TestIssuer_Issue(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+
+	t.Run("when jwt fails", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		j := new(common.MockJWT)
+		j.On("GenerateToken", tokenPayload("+5518999999999"), config.ExpireToken()).
+			Return(nil, errors.New("error")).
+			Once()
+		j.On("GenerateToken", tokenPayload("+5518999999999"), config.ExpireToken()).
+			Return("", nil).
+			Once()
+
+		i := NewIssuer(r, new(mockSessionRepository), j)
+		_, err := i.Issue(ctx, "+5518999999999", "")
+		assert.NotNil(t, err)
+
+		_, err = i.Issue(ctx, "+5518999999999", "")
+		assert.Equal(t, cerror.ErrUnauthorized, err)
+		r.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when session repository fails", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		s := new(mockSessionRepository)
+		s.On("Create", mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Once()
+		j := new(common.MockJWT)
+		j.On("GenerateToken", tokenPayload("+5518999999999"), config.ExpireToken()).
+			Return("A1B2C3D4E5F6", nil).
+			Once()
+
+		_, err := NewIssuer(r, s, j).Issue(ctx, "+5518999999999", "")
+		assert.NotNil(t, err)
+		r.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when repository fails", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Create", mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Once()
+		s := new(mockSessionRepository)
+		s.On("Create", mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		j := new(common.MockJWT)
+		j.On("GenerateToken", tokenPayload("+5518999999999"), config.ExpireToken()).
+			Return("A1B2C3D4E5F6", nil).
+			Once()
+
+		_, err := NewIssuer(r, s, j).Issue(ctx, "+5518999999999", "")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when issuer success", func(t *testing.T) {
+		//t.Parallel()
+		var stored *RefreshToken
+
+		r := new(mockRepository)
+		r.On("Create", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				stored = args.Get(1).(*RefreshToken)
+			}).
+			Return(nil).
+			Twice()
+		var created *session.Session
+
+		s := new(mockSessionRepository)
+		s.On("Create", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				created = args.Get(1).(*session.Session)
+			}).
+			Return(nil).
+			Once()
+		s.On("Touch", mock.Anything, "family", mock.Anything).
+			Return(nil).
+			Once()
+		j := new(common.MockJWT)
+		j.On("GenerateToken", tokenPayload("+5518999999999"), config.ExpireToken()).
+			Return("A1B2C3D4E5F6", nil).
+			Twice()
+
+		device := &session.Device{Name: "Phone", UserAgent: "Test/1.0", IP: "10.0.0.1"}
+		i := NewIssuer(r, s, j)
+		tk, err := i.Issue(context.WithValue(ctx, common.DeviceContextKey, device),
+			"+5518999999999", "")
+		assert.Nil(t, err)
+		assert.Equal(t, "A1B2C3D4E5F6", tk.AccessToken)
+		assert.NotEmpty(t, tk.RefreshToken)
+		assert.Equal(t, HashRefreshToken(tk.RefreshToken), stored.ID)
+		assert.Equal(t, "+5518999999999", stored.UserID)
+		assert.NotEmpty(t, stored.FamilyID)
+		assert.Equal(t, stored.FamilyID, created.ID)
+		assert.Equal(t, "+5518999999999", created.UserID)
+		assert.Equal(t, "Phone", created.DeviceName)
+		assert.Equal(t, "10.0.0.1", created.IP)
+
+		tk, err = i.Issue(ctx, "+5518999999999", "family")
+		assert.Nil(t, err)
+		assert.Equal(t, HashRefreshToken(tk.RefreshToken), stored.ID)
+		assert.Equal(t, "family", stored.FamilyID)
+		s.AssertExpectations(t)
+	})
+}
+
+// tokenPayload matches the token payload of the given user.
+func tokenPayload(ID string) interface{} {
+	return mock.MatchedBy(func(payload map[string]interface{}) bool {
+		_, ok := payload["iat"].(float64)
+		jti, _ := payload["jti"].(string)
+		sid, _ := payload["sid"].(string)
+		return payload["id"] == ID && ok && jti != "" && sid != ""
+	})
+}
@@ -0,0 +1,104 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/tsmweb/auth-service/app/session"
+	"github.com/tsmweb/auth-service/common/service"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+// RefreshUseCase exchanges a refresh token for a new pair of tokens, otherwise an error
+// is returned. A refresh token used more than once revokes all tokens of its family, and the
+// session along with its access tokens.
+type RefreshUseCase interface {
+	Execute(ctx context.Context, refreshToken string) (*Token, error)
+}
+
+// SessionRevoker revokes a session of the user along with its access tokens, publishing the
+// revocation, as login.RevokeSessionUseCase.
+type SessionRevoker interface {
+	Execute(ctx context.Context, userID, sessionID string) error
+}
+
+type refreshUseCase struct {
+	tag        string
+	repository Repository
+	issuer     Issuer
+	sessions   SessionRevoker
+}
+
+// NewRefreshUseCase create a new instance of RefreshUseCase.
+func NewRefreshUseCase(repository Repository, issuer Issuer, sessions SessionRevoker) RefreshUseCase {
+	return &refreshUseCase{
+		tag:        "token::RefreshUseCase",
+		repository: repository,
+		issuer:     issuer,
+		sessions:   sessions,
+	}
+}
+
+// Execute executes the refresh use case.
+func (u *refreshUseCase) Execute(ctx context.Context, refreshToken string) (*Token, error) {
+	if strings.TrimSpace(refreshToken) == "" {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	rt, err := u.repository.Get(ctx, HashRefreshToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, cerror.ErrNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		service.Error("", u.tag, err)
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	if rt.IsRevoked() || rt.IsExpired(now) {
+		service.Warn(rt.UserID, u.tag, ErrInvalidRefreshToken.Error())
+		return nil, ErrInvalidRefreshToken
+	}
+	if rt.IsUsed() {
+		return nil, u.revokeFamily(ctx, rt, now)
+	}
+
+	ok, err := u.repository.Use(ctx, rt.ID, now)
+	if err != nil {
+		service.Error(rt.UserID, u.tag, err)
+		return nil, err
+	}
+	if !ok { // used concurrently by another request
+		return nil, u.revokeFamily(ctx, rt, now)
+	}
+
+	token, err := u.issuer.Issue(ctx, rt.UserID, rt.FamilyID)
+	if err != nil {
+		service.Error(rt.UserID, u.tag, err)
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// revokeFamily revokes the tokens of the family of a refresh token presented after it was
+// used, as either the user or an attacker holds a stolen token. The family is the session of
+// the access tokens, which is revoked too.
+func (u *refreshUseCase) revokeFamily(ctx context.Context, rt *RefreshToken, now time.Time) error {
+	service.Warn(rt.UserID, u.tag, ErrRefreshTokenReused.Error())
+
+	if err := u.repository.RevokeFamily(ctx, rt.FamilyID, now); err != nil {
+		service.Error(rt.UserID, u.tag, err)
+		return err
+	}
+	// the session is not found if it was already revoked.
+	err := u.sessions.Execute(ctx, rt.UserID, rt.FamilyID)
+	if err != nil && !errors.Is(err, session.ErrSessionNotFound) {
+		service.Error(rt.UserID, u.tag, err)
+		return err
+	}
+	return ErrRefreshTokenReused
+}
@@ -0,0 +1,195 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/session"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+func TestRefreshUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+
+	newToken := func() (*RefreshToken, string) {
+		rt, value, _ := NewRefreshToken("+5518999999999", "family", time.Hour)
+		return rt, value
+	}
+
+	t.Run("when refresh token is invalid", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		i := new(mockIssuer)
+		uc := NewRefreshUseCase(r, i, new(mockSessionRevoker))
+
+		_, err := uc.Execute(ctx, "")
+		assert.Equal(t, ErrInvalidRefreshToken, err)
+
+		r.On("Get", mock.Anything, HashRefreshToken("unknown")).
+			Return(nil, cerror.ErrNotFound).
+			Once()
+		_, err = uc.Execute(ctx, "unknown")
+		assert.Equal(t, ErrInvalidRefreshToken, err)
+
+		expired, value := newToken()
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+		r.On("Get", mock.Anything, expired.ID).
+			Return(expired, nil).
+			Once()
+		_, err = uc.Execute(ctx, value)
+		assert.Equal(t, ErrInvalidRefreshToken, err)
+
+		revoked, value := newToken()
+		revoked.UsedAt = time.Now()
+		revoked.RevokedAt = time.Now()
+		r.On("Get", mock.Anything, revoked.ID).
+			Return(revoked, nil).
+			Once()
+		_, err = uc.Execute(ctx, value)
+		assert.Equal(t, ErrInvalidRefreshToken, err)
+
+		r.AssertNotCalled(t, "RevokeFamily", mock.Anything, mock.Anything, mock.Anything)
+		i.AssertNotCalled(t, "Issue", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when refresh token is reused", func(t *testing.T) {
+		//t.Parallel()
+		used, value := newToken()
+		used.UsedAt = time.Now()
+
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, used.ID).
+			Return(used, nil).
+			Once()
+		r.On("RevokeFamily", mock.Anything, "family", mock.Anything).
+			Return(nil).
+			Once()
+		i := new(mockIssuer)
+		sr := new(mockSessionRevoker)
+		sr.On("Execute", mock.Anything, "+5518999999999", "family").
+			Return(nil).
+			Once()
+
+		_, err := NewRefreshUseCase(r, i, sr).Execute(ctx, value)
+		assert.Equal(t, ErrRefreshTokenReused, err)
+		r.AssertExpectations(t)
+		sr.AssertExpectations(t)
+		i.AssertNotCalled(t, "Issue", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when refresh token is reused and session is revoked", func(t *testing.T) {
+		//t.Parallel()
+		used, value := newToken()
+		used.UsedAt = time.Now()
+
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, used.ID).
+			Return(used, nil)
+		r.On("RevokeFamily", mock.Anything, "family", mock.Anything).
+			Return(nil)
+		sr := new(mockSessionRevoker)
+		sr.On("Execute", mock.Anything, "+5518999999999", "family").
+			Return(session.ErrSessionNotFound).
+			Once()
+		uc := NewRefreshUseCase(r, new(mockIssuer), sr)
+
+		_, err := uc.Execute(ctx, value)
+		assert.Equal(t, ErrRefreshTokenReused, err)
+
+		sr.On("Execute", mock.Anything, "+5518999999999", "family").
+			Return(errors.New("error")).
+			Once()
+		_, err = uc.Execute(ctx, value)
+		assert.NotNil(t, err)
+		assert.NotEqual(t, ErrRefreshTokenReused, err)
+	})
+
+	t.Run("when refresh token is used concurrently", func(t *testing.T) {
+		//t.Parallel()
+		rt, value := newToken()
+
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, rt.ID).
+			Return(rt, nil).
+			Once()
+		r.On("Use", mock.Anything, rt.ID, mock.Anything).
+			Return(false, nil).
+			Once()
+		r.On("RevokeFamily", mock.Anything, "family", mock.Anything).
+			Return(nil).
+			Once()
+		i := new(mockIssuer)
+		sr := new(mockSessionRevoker)
+		sr.On("Execute", mock.Anything, "+5518999999999", "family").
+			Return(nil).
+			Once()
+
+		_, err := NewRefreshUseCase(r, i, sr).Execute(ctx, value)
+		assert.Equal(t, ErrRefreshTokenReused, err)
+		r.AssertExpectations(t)
+		sr.AssertExpectations(t)
+	})
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		rt, value := newToken()
+
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, rt.ID).
+			Return(nil, errors.New("error")).
+			Once()
+		i := new(mockIssuer)
+		uc := NewRefreshUseCase(r, i, new(mockSessionRevoker))
+
+		_, err := uc.Execute(ctx, value)
+		assert.NotNil(t, err)
+
+		r.On("Get", mock.Anything, rt.ID).
+			Return(rt, nil).
+			Once()
+		r.On("Use", mock.Anything, rt.ID, mock.Anything).
+			Return(false, errors.New("error")).
+			Once()
+		_, err = uc.Execute(ctx, value)
+		assert.NotNil(t, err)
+
+		r.On("Get", mock.Anything, rt.ID).
+			Return(rt, nil).
+			Once()
+		r.On("Use", mock.Anything, rt.ID, mock.Anything).
+			Return(true, nil).
+			Once()
+		i.On("Issue", mock.Anything, "+5518999999999", "family").
+			Return(nil, errors.New("error")).
+			Once()
+		_, err = uc.Execute(ctx, value)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when use case success", func(t *testing.T) {
+		//t.Parallel()
+		rt, value := newToken()
+		tk := &Token{AccessToken: "A1B2C3D4E5F6", RefreshToken: "F6E5D4C3B2A1"}
+
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, rt.ID).
+			Return(rt, nil).
+			Once()
+		r.On("Use", mock.Anything, rt.ID, mock.Anything).
+			Return(true, nil).
+			Once()
+		i := new(mockIssuer)
+		i.On("Issue", mock.Anything, "+5518999999999", "family").
+			Return(tk, nil).
+			Once()
+
+		tokenUC, err := NewRefreshUseCase(r, i, new(mockSessionRevoker)).Execute(ctx, value)
+		assert.Nil(t, err)
+		assert.Equal(t, tk, tokenUC)
+	})
+}
@@ -0,0 +1,121 @@
+package token
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/session"
+)
+
+// mockRepository injects mock dependency into UserCase layer.
+type mockRepository struct {
+	mock.Mock
+}
+
+// Create represents the simulated method for the Create feature in the Repository layer.
+func (m *mockRepository) Create(ctx context.Context, token *RefreshToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+// Get represents the simulated method for the Get feature in the Repository layer.
+func (m *mockRepository) Get(ctx context.Context, ID string) (*RefreshToken, error) {
+	args := m.Called(ctx, ID)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*RefreshToken), nil
+}
+
+// Use represents the simulated method for the Use feature in the Repository layer.
+func (m *mockRepository) Use(ctx context.Context, ID string, usedAt time.Time) (bool, error) {
+	args := m.Called(ctx, ID, usedAt)
+	if args.Error(1) != nil {
+		return false, args.Error(1)
+	}
+	return args.Bool(0), nil
+}
+
+// RevokeFamily represents the simulated method for the RevokeFamily feature in the
+// Repository layer.
+func (m *mockRepository) RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	args := m.Called(ctx, familyID, revokedAt)
+	return args.Error(0)
+}
+
+// RevokeUser represents the simulated method for the RevokeUser feature in the
+// Repository layer.
+func (m *mockRepository) RevokeUser(ctx context.Context, userID string, revokedAt time.Time) error {
+	args := m.Called(ctx, userID, revokedAt)
+	return args.Error(0)
+}
+
+// mockIssuer injects mock dependency into UseCase layer.
+type mockIssuer struct {
+	mock.Mock
+}
+
+// Issue represents the simulated method for the Issue feature in the Issuer.
+func (m *mockIssuer) Issue(ctx context.Context, userID, familyID string) (*Token, error) {
+	args := m.Called(ctx, userID, familyID)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Token), nil
+}
+
+// mockSessionRepository injects mock session.Repository dependency.
+type mockSessionRepository struct {
+	mock.Mock
+}
+
+// Create represents the simulated method for the Create feature in the session.Repository layer.
+func (m *mockSessionRepository) Create(ctx context.Context, s *session.Session) error {
+	args := m.Called(ctx, s)
+	return args.Error(0)
+}
+
+// Get represents the simulated method for the Get feature in the session.Repository layer.
+func (m *mockSessionRepository) Get(ctx context.Context, ID string) (*session.Session, error) {
+	args := m.Called(ctx, ID)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*session.Session), nil
+}
+
+// GetAll represents the simulated method for the GetAll feature in the session.Repository layer.
+func (m *mockSessionRepository) GetAll(ctx context.Context, userID string) ([]*session.Session, error) {
+	args := m.Called(ctx, userID)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*session.Session), nil
+}
+
+// Touch represents the simulated method for the Touch feature in the session.Repository layer.
+func (m *mockSessionRepository) Touch(ctx context.Context, ID string, lastSeenAt time.Time) error {
+	args := m.Called(ctx, ID, lastSeenAt)
+	return args.Error(0)
+}
+
+// Revoke represents the simulated method for the Revoke feature in the session.Repository layer.
+func (m *mockSessionRepository) Revoke(ctx context.Context, ID string, revokedAt time.Time) (bool, error) {
+	args := m.Called(ctx, ID, revokedAt)
+	if args.Error(1) != nil {
+		return false, args.Error(1)
+	}
+	return args.Bool(0), nil
+}
+
+// mockSessionRevoker injects mock SessionRevoker dependency.
+type mockSessionRevoker struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the SessionRevoker.
+func (m *mockSessionRevoker) Execute(ctx context.Context, userID, sessionID string) error {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Error(0)
+}
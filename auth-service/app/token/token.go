@@ -0,0 +1,104 @@
+package token
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reused")
+)
+
+// Token is the pair of tokens issued to the user: a short-lived JWT access token and an
+// opaque refresh token that obtains a new pair when the access token expires.
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int // seconds until the access token expires
+}
+
+// RefreshToken is the data of a refresh token stored server-side. The token itself is
+// not stored, only its hash. Each refresh token can be used once, the token issued in
+// exchange belongs to the same family, which is revoked entirely when a used token is
+// presented again.
+type RefreshToken struct {
+	ID        string // hash of the token
+	FamilyID  string
+	UserID    string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    time.Time
+	RevokedAt time.Time
+}
+
+// NewRefreshToken creates a refresh token of the family valid for the given duration,
+// returning the data to be stored and the token to be sent to the user.
+func NewRefreshToken(userID, familyID string, expire time.Duration) (*RefreshToken, string, error) {
+	value, err := randomString(32)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if familyID == "" {
+		if familyID, err = randomString(16); err != nil {
+			return nil, "", err
+		}
+	}
+
+	now := time.Now().UTC()
+	rt := &RefreshToken{
+		ID:        HashRefreshToken(value),
+		FamilyID:  familyID,
+		UserID:    userID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(expire),
+	}
+	return rt, value, nil
+}
+
+// HashRefreshToken returns the hash that identifies the refresh token in the data source.
+func HashRefreshToken(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsUsed reports whether the refresh token was already exchanged.
+func (t *RefreshToken) IsUsed() bool {
+	return !t.UsedAt.IsZero()
+}
+
+// IsRevoked reports whether the refresh token family was revoked.
+func (t *RefreshToken) IsRevoked() bool {
+	return !t.RevokedAt.IsZero()
+}
+
+// IsExpired reports whether the refresh token expired at the given time.
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Repository interface for refresh token data source.
+type Repository interface {
+	Create(ctx context.Context, token *RefreshToken) error
+	// Get returns the refresh token by its hash, or cerror.ErrNotFound.
+	Get(ctx context.Context, ID string) (*RefreshToken, error)
+	// Use marks the refresh token as used, returning false if it was already used or revoked.
+	Use(ctx context.Context, ID string, usedAt time.Time) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error
+	// RevokeUser revokes all refresh tokens of the user.
+	RevokeUser(ctx context.Context, userID string, revokedAt time.Time) error
+}
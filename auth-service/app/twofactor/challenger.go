@@ -0,0 +1,57 @@
+package twofactor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/tsmweb/auth-service/config"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+// Challenger starts the second step of the login of the users with two-factor
+// authentication enabled.
+type Challenger interface {
+	// Challenge returns the challenge token of the user, or nil if the two-factor
+	// authentication is not enabled.
+	Challenge(ctx context.Context, userID string) (*ChallengeToken, error)
+}
+
+type challenger struct {
+	repository Repository
+}
+
+// NewChallenger create a new instance of Challenger.
+func NewChallenger(repository Repository) Challenger {
+	return &challenger{
+		repository: repository,
+	}
+}
+
+// Challenge stores a new challenge when the two-factor authentication is enabled.
+func (c *challenger) Challenge(ctx context.Context, userID string) (*ChallengeToken, error) {
+	t, err := c.repository.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, cerror.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !t.Enabled {
+		return nil, nil
+	}
+
+	expire := time.Duration(config.TOTPChallengeExpire()) * time.Minute
+	ch, value, err := NewChallenge(userID, expire)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.repository.CreateChallenge(ctx, ch); err != nil {
+		return nil, err
+	}
+
+	return &ChallengeToken{
+		Token:     value,
+		ExpiresIn: int(expire / time.Second),
+	}, nil
+}
@@ -0,0 +1,79 @@
+package twofactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+func TestChallenger_Challenge(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+
+	t.Run("when two-factor authentication is not enabled", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(nil, cerror.ErrNotFound).
+			Once()
+		c := NewChallenger(r)
+
+		ct, err := c.Challenge(ctx, "+5518999999999")
+		assert.Nil(t, err)
+		assert.Nil(t, ct)
+
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(&TwoFactor{ID: "+5518999999999"}, nil).
+			Once()
+		ct, err = c.Challenge(ctx, "+5518999999999")
+		assert.Nil(t, err)
+		assert.Nil(t, ct)
+		r.AssertNotCalled(t, "CreateChallenge", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when repository fails", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(nil, errors.New("error")).
+			Once()
+		c := NewChallenger(r)
+
+		_, err := c.Challenge(ctx, "+5518999999999")
+		assert.NotNil(t, err)
+
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(&TwoFactor{ID: "+5518999999999", Enabled: true}, nil).
+			Once()
+		r.On("CreateChallenge", mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Once()
+		_, err = c.Challenge(ctx, "+5518999999999")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when two-factor authentication is enabled", func(t *testing.T) {
+		//t.Parallel()
+		var stored *Challenge
+
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(&TwoFactor{ID: "+5518999999999", Enabled: true}, nil).
+			Once()
+		r.On("CreateChallenge", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				stored = args.Get(1).(*Challenge)
+			}).
+			Return(nil).
+			Once()
+
+		ct, err := NewChallenger(r).Challenge(ctx, "+5518999999999")
+		assert.Nil(t, err)
+		assert.Equal(t, HashChallengeToken(ct.Token), stored.ID)
+		assert.Equal(t, "+5518999999999", stored.UserID)
+	})
+}
@@ -0,0 +1,76 @@
+package twofactor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/tsmweb/auth-service/common/service"
+	"github.com/tsmweb/auth-service/common/totp"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+// ConfirmUseCase enables the two-factor authentication of the user with the first code
+// generated by the authenticator app, returning the recovery codes, otherwise an error
+// is returned.
+type ConfirmUseCase interface {
+	Execute(ctx context.Context, userID, code string) ([]string, error)
+}
+
+type confirmUseCase struct {
+	tag        string
+	repository Repository
+}
+
+// NewConfirmUseCase create a new instance of ConfirmUseCase.
+func NewConfirmUseCase(repository Repository) ConfirmUseCase {
+	return &confirmUseCase{
+		tag:        "twofactor::ConfirmUseCase",
+		repository: repository,
+	}
+}
+
+// Execute executes the confirm use case.
+func (u *confirmUseCase) Execute(ctx context.Context, userID, code string) ([]string, error) {
+	t, err := u.repository.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, cerror.ErrNotFound) {
+			service.Warn(userID, u.tag, ErrNotEnrolled.Error())
+			return nil, ErrNotEnrolled
+		}
+		service.Error(userID, u.tag, err)
+		return nil, err
+	}
+	if t.Enabled {
+		service.Warn(userID, u.tag, ErrAlreadyEnabled.Error())
+		return nil, ErrAlreadyEnabled
+	}
+
+	step, ok, err := totp.Validate(t.Secret, code, time.Now(), 1)
+	if err != nil {
+		service.Error(userID, u.tag, err)
+		return nil, err
+	}
+	if !ok {
+		service.Warn(userID, u.tag, ErrInvalidCode.Error())
+		return nil, ErrInvalidCode
+	}
+
+	codes, hashes, err := NewRecoveryCodes()
+	if err != nil {
+		service.Error(userID, u.tag, err)
+		return nil, err
+	}
+
+	ok, err = u.repository.Enable(ctx, userID, step, hashes)
+	if err != nil {
+		service.Error(userID, u.tag, err)
+		return nil, err
+	}
+	if !ok {
+		service.Warn(userID, u.tag, ErrAlreadyEnabled.Error())
+		return nil, ErrAlreadyEnabled
+	}
+
+	return codes, nil
+}
@@ -0,0 +1,118 @@
+package twofactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/common/totp"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+func TestConfirmUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+
+	pending, _ := NewTwoFactor("+5518999999999")
+	step := totp.Step(time.Now())
+	code, _ := totp.Code(pending.Secret, step)
+
+	t.Run("when use case fails with ErrNotEnrolled", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(nil, cerror.ErrNotFound).
+			Once()
+
+		_, err := NewConfirmUseCase(r).Execute(ctx, "+5518999999999", code)
+		assert.Equal(t, ErrNotEnrolled, err)
+	})
+
+	t.Run("when use case fails with ErrAlreadyEnabled", func(t *testing.T) {
+		//t.Parallel()
+		enabled := *pending
+		enabled.Enabled = true
+
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(&enabled, nil).
+			Once()
+		uc := NewConfirmUseCase(r)
+
+		_, err := uc.Execute(ctx, "+5518999999999", code)
+		assert.Equal(t, ErrAlreadyEnabled, err)
+
+		// enabled concurrently.
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(pending, nil).
+			Once()
+		r.On("Enable", mock.Anything, "+5518999999999", mock.Anything, mock.Anything).
+			Return(false, nil).
+			Once()
+		_, err = uc.Execute(ctx, "+5518999999999", code)
+		assert.Equal(t, ErrAlreadyEnabled, err)
+	})
+
+	t.Run("when use case fails with ErrInvalidCode", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(pending, nil)
+		uc := NewConfirmUseCase(r)
+
+		_, err := uc.Execute(ctx, "+5518999999999", "")
+		assert.Equal(t, ErrInvalidCode, err)
+
+		old, _ := totp.Code(pending.Secret, step-10)
+		_, err = uc.Execute(ctx, "+5518999999999", old)
+		assert.Equal(t, ErrInvalidCode, err)
+		r.AssertNotCalled(t, "Enable", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(nil, errors.New("error")).
+			Once()
+		uc := NewConfirmUseCase(r)
+
+		_, err := uc.Execute(ctx, "+5518999999999", code)
+		assert.NotNil(t, err)
+
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(pending, nil).
+			Once()
+		r.On("Enable", mock.Anything, "+5518999999999", mock.Anything, mock.Anything).
+			Return(false, errors.New("error")).
+			Once()
+		_, err = uc.Execute(ctx, "+5518999999999", code)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when use case succeeds", func(t *testing.T) {
+		//t.Parallel()
+		var hashes []string
+
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(pending, nil).
+			Once()
+		r.On("Enable", mock.Anything, "+5518999999999", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				hashes = args.Get(3).([]string)
+			}).
+			Return(true, nil).
+			Once()
+
+		codes, err := NewConfirmUseCase(r).Execute(ctx, "+5518999999999", code)
+		assert.Nil(t, err)
+		assert.Len(t, codes, RecoveryCodes)
+		for i, c := range codes {
+			assert.Equal(t, HashRecoveryCode(c), hashes[i])
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package twofactor
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tsmweb/auth-service/common/service"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+// DisableUseCase disables the two-factor authentication of the user with a valid TOTP or
+// recovery code, otherwise an error is returned.
+type DisableUseCase interface {
+	Execute(ctx context.Context, userID, code string) error
+}
+
+type disableUseCase struct {
+	tag        string
+	repository Repository
+}
+
+// NewDisableUseCase create a new instance of DisableUseCase.
+func NewDisableUseCase(repository Repository) DisableUseCase {
+	return &disableUseCase{
+		tag:        "twofactor::DisableUseCase",
+		repository: repository,
+	}
+}
+
+// Execute executes the disable use case.
+func (u *disableUseCase) Execute(ctx context.Context, userID, code string) error {
+	t, err := u.repository.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, cerror.ErrNotFound) {
+			service.Warn(userID, u.tag, ErrNotEnabled.Error())
+			return ErrNotEnabled
+		}
+		service.Error(userID, u.tag, err)
+		return err
+	}
+	if !t.Enabled {
+		service.Warn(userID, u.tag, ErrNotEnabled.Error())
+		return ErrNotEnabled
+	}
+
+	ok, err := verifyCode(ctx, u.repository, t, code)
+	if err != nil {
+		service.Error(userID, u.tag, err)
+		return err
+	}
+	if !ok {
+		service.Warn(userID, u.tag, ErrInvalidCode.Error())
+		return ErrInvalidCode
+	}
+
+	if err = u.repository.Delete(ctx, userID); err != nil {
+		service.Error(userID, u.tag, err)
+		return err
+	}
+
+	return nil
+}
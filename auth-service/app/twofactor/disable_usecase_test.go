@@ -0,0 +1,99 @@
+package twofactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/common/totp"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+func TestDisableUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+
+	enabled, _ := NewTwoFactor("+5518999999999")
+	enabled.Enabled = true
+	code, _ := totp.Code(enabled.Secret, totp.Step(time.Now()))
+
+	t.Run("when use case fails with ErrNotEnabled", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(nil, cerror.ErrNotFound).
+			Once()
+		uc := NewDisableUseCase(r)
+
+		err := uc.Execute(ctx, "+5518999999999", code)
+		assert.Equal(t, ErrNotEnabled, err)
+
+		pending := *enabled
+		pending.Enabled = false
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(&pending, nil).
+			Once()
+		err = uc.Execute(ctx, "+5518999999999", code)
+		assert.Equal(t, ErrNotEnabled, err)
+	})
+
+	t.Run("when use case fails with ErrInvalidCode", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(enabled, nil)
+		uc := NewDisableUseCase(r)
+
+		err := uc.Execute(ctx, "+5518999999999", "")
+		assert.Equal(t, ErrInvalidCode, err)
+
+		// code already used.
+		r.On("UseStep", mock.Anything, "+5518999999999", mock.Anything).
+			Return(false, nil).
+			Once()
+		err = uc.Execute(ctx, "+5518999999999", code)
+		assert.Equal(t, ErrInvalidCode, err)
+
+		r.On("UseRecoveryCode", mock.Anything, "+5518999999999", HashRecoveryCode("abcd-efgh")).
+			Return(false, nil).
+			Once()
+		err = uc.Execute(ctx, "+5518999999999", "abcd-efgh")
+		assert.Equal(t, ErrInvalidCode, err)
+		r.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(enabled, nil)
+		r.On("UseStep", mock.Anything, "+5518999999999", mock.Anything).
+			Return(true, nil)
+		r.On("Delete", mock.Anything, "+5518999999999").
+			Return(errors.New("error")).
+			Once()
+
+		err := NewDisableUseCase(r).Execute(ctx, "+5518999999999", code)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when use case succeeds", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(enabled, nil)
+		r.On("UseRecoveryCode", mock.Anything, "+5518999999999", HashRecoveryCode("abcd-efgh")).
+			Return(true, nil).
+			Once()
+		r.On("Delete", mock.Anything, "+5518999999999").
+			Return(nil).
+			Once()
+
+		err := NewDisableUseCase(r).Execute(ctx, "+5518999999999", "ABCD-EFGH")
+		assert.Nil(t, err)
+		r.AssertExpectations(t)
+	})
+}
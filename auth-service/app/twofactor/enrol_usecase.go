@@ -0,0 +1,59 @@
+package twofactor
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tsmweb/auth-service/common/service"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+// EnrolUseCase generates a new TOTP secret for the user, which is enabled by the
+// ConfirmUseCase, otherwise an error is returned. A pending enrolment is replaced.
+type EnrolUseCase interface {
+	Execute(ctx context.Context, userID string) (*TwoFactor, error)
+}
+
+type enrolUseCase struct {
+	tag        string
+	repository Repository
+}
+
+// NewEnrolUseCase create a new instance of EnrolUseCase.
+func NewEnrolUseCase(repository Repository) EnrolUseCase {
+	return &enrolUseCase{
+		tag:        "twofactor::EnrolUseCase",
+		repository: repository,
+	}
+}
+
+// Execute executes the enrol use case.
+func (u *enrolUseCase) Execute(ctx context.Context, userID string) (*TwoFactor, error) {
+	current, err := u.repository.Get(ctx, userID)
+	if err != nil && !errors.Is(err, cerror.ErrNotFound) {
+		service.Error(userID, u.tag, err)
+		return nil, err
+	}
+	if current != nil && current.Enabled {
+		service.Warn(userID, u.tag, ErrAlreadyEnabled.Error())
+		return nil, ErrAlreadyEnabled
+	}
+
+	t, err := NewTwoFactor(userID)
+	if err != nil {
+		service.Error(userID, u.tag, err)
+		return nil, err
+	}
+
+	ok, err := u.repository.Save(ctx, t)
+	if err != nil {
+		service.Error(userID, u.tag, err)
+		return nil, err
+	}
+	if !ok {
+		service.Warn(userID, u.tag, ErrAlreadyEnabled.Error())
+		return nil, ErrAlreadyEnabled
+	}
+
+	return t, nil
+}
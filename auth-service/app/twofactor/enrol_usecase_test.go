@@ -0,0 +1,80 @@
+package twofactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+func TestEnrolUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+
+	t.Run("when use case fails with ErrAlreadyEnabled", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(&TwoFactor{ID: "+5518999999999", Enabled: true}, nil).
+			Once()
+		uc := NewEnrolUseCase(r)
+
+		_, err := uc.Execute(ctx, "+5518999999999")
+		assert.Equal(t, ErrAlreadyEnabled, err)
+		r.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+
+		// enabled concurrently.
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(nil, cerror.ErrNotFound).
+			Once()
+		r.On("Save", mock.Anything, mock.Anything).
+			Return(false, nil).
+			Once()
+		_, err = uc.Execute(ctx, "+5518999999999")
+		assert.Equal(t, ErrAlreadyEnabled, err)
+	})
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(nil, errors.New("error")).
+			Once()
+		uc := NewEnrolUseCase(r)
+
+		_, err := uc.Execute(ctx, "+5518999999999")
+		assert.NotNil(t, err)
+
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(nil, cerror.ErrNotFound).
+			Once()
+		r.On("Save", mock.Anything, mock.Anything).
+			Return(false, errors.New("error")).
+			Once()
+		_, err = uc.Execute(ctx, "+5518999999999")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when use case succeeds", func(t *testing.T) {
+		//t.Parallel()
+		pending := &TwoFactor{ID: "+5518999999999", Secret: "GEZDGNBVGY3TQOJQ"}
+
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(pending, nil).
+			Once()
+		r.On("Save", mock.Anything, mock.Anything).
+			Return(true, nil).
+			Once()
+
+		tf, err := NewEnrolUseCase(r).Execute(ctx, "+5518999999999")
+		assert.Nil(t, err)
+		assert.Equal(t, "+5518999999999", tf.ID)
+		assert.NotEqual(t, pending.Secret, tf.Secret, "a pending enrolment is replaced")
+		assert.False(t, tf.Enabled)
+		r.AssertExpectations(t)
+	})
+}
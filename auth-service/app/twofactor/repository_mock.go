@@ -0,0 +1,146 @@
+package twofactor
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/token"
+)
+
+// mockRepository injects mock dependency into UseCase layer.
+type mockRepository struct {
+	mock.Mock
+}
+
+// Get represents the simulated method for the Get feature in the Repository layer.
+func (m *mockRepository) Get(ctx context.Context, ID string) (*TwoFactor, error) {
+	args := m.Called(ctx, ID)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*TwoFactor), nil
+}
+
+// Save represents the simulated method for the Save feature in the Repository layer.
+func (m *mockRepository) Save(ctx context.Context, t *TwoFactor) (bool, error) {
+	args := m.Called(ctx, t)
+	if args.Error(1) != nil {
+		return false, args.Error(1)
+	}
+	return args.Bool(0), nil
+}
+
+// Enable represents the simulated method for the Enable feature in the Repository layer.
+func (m *mockRepository) Enable(ctx context.Context, ID string, step int64,
+	recoveryCodes []string) (bool, error) {
+	args := m.Called(ctx, ID, step, recoveryCodes)
+	if args.Error(1) != nil {
+		return false, args.Error(1)
+	}
+	return args.Bool(0), nil
+}
+
+// UseStep represents the simulated method for the UseStep feature in the Repository layer.
+func (m *mockRepository) UseStep(ctx context.Context, ID string, step int64) (bool, error) {
+	args := m.Called(ctx, ID, step)
+	if args.Error(1) != nil {
+		return false, args.Error(1)
+	}
+	return args.Bool(0), nil
+}
+
+// UseRecoveryCode represents the simulated method for the UseRecoveryCode feature in the Repository layer.
+func (m *mockRepository) UseRecoveryCode(ctx context.Context, ID, codeHash string) (bool, error) {
+	args := m.Called(ctx, ID, codeHash)
+	if args.Error(1) != nil {
+		return false, args.Error(1)
+	}
+	return args.Bool(0), nil
+}
+
+// Delete represents the simulated method for the Delete feature in the Repository layer.
+func (m *mockRepository) Delete(ctx context.Context, ID string) error {
+	args := m.Called(ctx, ID)
+	return args.Error(0)
+}
+
+// CreateChallenge represents the simulated method for the CreateChallenge feature in the Repository layer.
+func (m *mockRepository) CreateChallenge(ctx context.Context, c *Challenge) error {
+	args := m.Called(ctx, c)
+	return args.Error(0)
+}
+
+// GetChallenge represents the simulated method for the GetChallenge feature in the Repository layer.
+func (m *mockRepository) GetChallenge(ctx context.Context, ID string) (*Challenge, error) {
+	args := m.Called(ctx, ID)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Challenge), nil
+}
+
+// AttemptChallenge represents the simulated method for the AttemptChallenge feature in the Repository layer.
+func (m *mockRepository) AttemptChallenge(ctx context.Context, ID string, maxAttempts int) (bool, error) {
+	args := m.Called(ctx, ID, maxAttempts)
+	if args.Error(1) != nil {
+		return false, args.Error(1)
+	}
+	return args.Bool(0), nil
+}
+
+// DeleteChallenge represents the simulated method for the DeleteChallenge feature in the Repository layer.
+func (m *mockRepository) DeleteChallenge(ctx context.Context, ID string) error {
+	args := m.Called(ctx, ID)
+	return args.Error(0)
+}
+
+// mockIssuer injects mock dependency into UseCase layer.
+type mockIssuer struct {
+	mock.Mock
+}
+
+// Issue represents the simulated method for the Issue feature in the token.Issuer.
+func (m *mockIssuer) Issue(ctx context.Context, userID, familyID string) (*token.Token, error) {
+	args := m.Called(ctx, userID, familyID)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*token.Token), nil
+}
+
+// mockAttemptRepository injects mock AttemptRepository dependency.
+type mockAttemptRepository struct {
+	mock.Mock
+}
+
+// Get represents the simulated method for the Get feature in the AttemptRepository.
+func (m *mockAttemptRepository) Get(ctx context.Context, key string) (int, time.Time, error) {
+	args := m.Called(ctx, key)
+	if args.Error(2) != nil {
+		return 0, time.Time{}, args.Error(2)
+	}
+	return args.Int(0), args.Get(1).(time.Time), nil
+}
+
+// Fail represents the simulated method for the Fail feature in the AttemptRepository.
+func (m *mockAttemptRepository) Fail(ctx context.Context, key string, at time.Time,
+	ttl time.Duration) (int, error) {
+	args := m.Called(ctx, key, at, ttl)
+	if args.Error(1) != nil {
+		return 0, args.Error(1)
+	}
+	return args.Int(0), nil
+}
+
+// Refund represents the simulated method for the Refund feature in the AttemptRepository.
+func (m *mockAttemptRepository) Refund(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+// Reset represents the simulated method for the Reset feature in the AttemptRepository.
+func (m *mockAttemptRepository) Reset(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
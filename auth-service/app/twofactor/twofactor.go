@@ -0,0 +1,192 @@
+package twofactor
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/tsmweb/auth-service/common/totp"
+	"github.com/tsmweb/auth-service/config"
+)
+
+// RecoveryCodes is the number of recovery codes generated on enrolment.
+const RecoveryCodes = 10
+
+var (
+	ErrAlreadyEnabled   = errors.New("two-factor authentication already enabled")
+	ErrNotEnrolled      = errors.New("two-factor authentication not enrolled")
+	ErrNotEnabled       = errors.New("two-factor authentication not enabled")
+	ErrInvalidCode      = errors.New("invalid code")
+	ErrInvalidChallenge = errors.New("invalid challenge token")
+	ErrTooManyAttempts  = errors.New("too many attempts")
+)
+
+// TwoFactor is the TOTP secret of the user. It is enabled once the user confirms the
+// first code generated by the authenticator app.
+type TwoFactor struct {
+	ID        string // user ID
+	Secret    string
+	Enabled   bool
+	LastStep  int64 // time step of the last code accepted, a code is accepted only once
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewTwoFactor creates the pending enrolment of the user with a new secret.
+func NewTwoFactor(userID string) (*TwoFactor, error) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	return &TwoFactor{
+		ID:        userID,
+		Secret:    secret,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// URI returns the otpauth URI to be added to the authenticator app.
+func (t *TwoFactor) URI() string {
+	return totp.URI(config.TOTPIssuer(), t.ID, t.Secret)
+}
+
+// Challenge is the second step of the login of a user with two-factor authentication
+// enabled, stored server-side by the hash of the challenge token.
+type Challenge struct {
+	ID        string // hash of the token
+	UserID    string
+	Attempts  int
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// NewChallenge creates a challenge of the user valid for the given duration, returning
+// the data to be stored and the token to be sent to the user.
+func NewChallenge(userID string, expire time.Duration) (*Challenge, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", err
+	}
+	value := base64.RawURLEncoding.EncodeToString(b)
+
+	now := time.Now().UTC()
+	c := &Challenge{
+		ID:        hash(value),
+		UserID:    userID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(expire),
+	}
+	return c, value, nil
+}
+
+// IsExpired reports whether the challenge expired at the given time.
+func (c *Challenge) IsExpired(now time.Time) bool {
+	return !now.Before(c.ExpiresAt)
+}
+
+// ChallengeToken is returned by the password login of the users with two-factor
+// authentication enabled, to be exchanged for the tokens along with a valid code.
+type ChallengeToken struct {
+	Token     string
+	ExpiresIn int // seconds
+}
+
+// NewRecoveryCodes returns the recovery codes to be shown to the user and their hashes
+// to be stored. Each code replaces a TOTP code once.
+func NewRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, RecoveryCodes)
+	hashes := make([]string, RecoveryCodes)
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+
+	for i := range codes {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(encoding.EncodeToString(b))
+		codes[i] = code[:4] + "-" + code[4:]
+		hashes[i] = HashRecoveryCode(codes[i])
+	}
+	return codes, hashes, nil
+}
+
+// HashRecoveryCode returns the hash that identifies the recovery code in the data source,
+// ignoring the case and the separators typed by the user.
+func HashRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.NewReplacer("-", "", " ", "").Replace(code)
+	return hash(code)
+}
+
+// HashChallengeToken returns the hash that identifies the challenge in the data source.
+func HashChallengeToken(value string) string {
+	return hash(value)
+}
+
+func hash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// verifyCode checks a TOTP or recovery code of the user, consuming it.
+func verifyCode(ctx context.Context, r Repository, t *TwoFactor, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return false, nil
+	}
+
+	if len(code) == totp.Digits {
+		step, ok, err := totp.Validate(t.Secret, code, time.Now(), 1)
+		if err != nil || !ok {
+			return false, err
+		}
+		return r.UseStep(ctx, t.ID, step)
+	}
+
+	return r.UseRecoveryCode(ctx, t.ID, HashRecoveryCode(code))
+}
+
+// Repository interface for two-factor authentication data source.
+type Repository interface {
+	// Get returns the two-factor authentication of the user, or cerror.ErrNotFound.
+	Get(ctx context.Context, ID string) (*TwoFactor, error)
+	// Save stores the pending enrolment, returning false if it is already enabled.
+	Save(ctx context.Context, t *TwoFactor) (bool, error)
+	// Enable enables the pending enrolment with the time step of the code confirmed and
+	// replaces the recovery codes, returning false if it is already enabled.
+	Enable(ctx context.Context, ID string, step int64, recoveryCodes []string) (bool, error)
+	// UseStep records the time step of the code accepted, returning false if a code of
+	// the same or a later step was already accepted.
+	UseStep(ctx context.Context, ID string, step int64) (bool, error)
+	// UseRecoveryCode deletes the recovery code, returning false if it does not exist.
+	UseRecoveryCode(ctx context.Context, ID, codeHash string) (bool, error)
+	// Delete disables the two-factor authentication, deleting the recovery codes.
+	Delete(ctx context.Context, ID string) error
+
+	CreateChallenge(ctx context.Context, c *Challenge) error
+	// GetChallenge returns the challenge by its hash, or cerror.ErrNotFound.
+	GetChallenge(ctx context.Context, ID string) (*Challenge, error)
+	// AttemptChallenge counts an attempt, returning false if the attempts are exhausted.
+	AttemptChallenge(ctx context.Context, ID string, maxAttempts int) (bool, error)
+	DeleteChallenge(ctx context.Context, ID string) error
+}
+
+// AttemptRepository stores the failed codes of the users, shared by the replicas of
+// auth-service. It is implemented by the login.AttemptRepository.
+type AttemptRepository interface {
+	// Get returns the attempts of the key and the time of the last one.
+	Get(ctx context.Context, key string) (int, time.Time, error)
+	// Fail records an attempt of the key at the given time, returning the attempts. The
+	// attempts are forgotten after ttl without new ones.
+	Fail(ctx context.Context, key string, at time.Time, ttl time.Duration) (int, error)
+	// Refund removes an attempt of the key, if the attempts were not forgotten meanwhile.
+	Refund(ctx context.Context, key string) error
+	Reset(ctx context.Context, key string) error
+}
@@ -0,0 +1,54 @@
+package twofactor
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsmweb/auth-service/common/totp"
+)
+
+func TestNewTwoFactor(t *testing.T) {
+	//t.Parallel()
+	tf, err := NewTwoFactor("+5518999999999")
+	assert.Nil(t, err)
+	assert.Equal(t, "+5518999999999", tf.ID)
+	assert.False(t, tf.Enabled)
+
+	_, err = totp.Code(tf.Secret, 1)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(tf.URI(), "otpauth://totp/"))
+	assert.Contains(t, tf.URI(), "secret="+tf.Secret)
+}
+
+func TestNewChallenge(t *testing.T) {
+	//t.Parallel()
+	c, value, err := NewChallenge("+5518999999999", time.Minute)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, value)
+	assert.Equal(t, HashChallengeToken(value), c.ID)
+	assert.Equal(t, "+5518999999999", c.UserID)
+	assert.False(t, c.IsExpired(time.Now()))
+	assert.True(t, c.IsExpired(time.Now().Add(time.Minute)))
+}
+
+func TestNewRecoveryCodes(t *testing.T) {
+	//t.Parallel()
+	codes, hashes, err := NewRecoveryCodes()
+	assert.Nil(t, err)
+	assert.Len(t, codes, RecoveryCodes)
+	assert.Len(t, hashes, RecoveryCodes)
+
+	seen := make(map[string]bool)
+	for i, code := range codes {
+		assert.Len(t, code, 9)
+		assert.Equal(t, hashes[i], HashRecoveryCode(code))
+		assert.False(t, seen[code])
+		seen[code] = true
+	}
+
+	// the case and the separators typed by the user are ignored.
+	typed := strings.ToUpper(strings.Replace(codes[0], "-", " ", 1))
+	assert.Equal(t, hashes[0], HashRecoveryCode(typed))
+}
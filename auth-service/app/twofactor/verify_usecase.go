@@ -0,0 +1,160 @@
+package twofactor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/tsmweb/auth-service/app/token"
+	"github.com/tsmweb/auth-service/common/service"
+	"github.com/tsmweb/auth-service/config"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+// VerifyUseCase exchanges the challenge token returned by the login and a valid TOTP or
+// recovery code for the access and refresh tokens, otherwise an error is returned. Besides
+// the attempts of each challenge, the failed codes of the user are counted across the
+// challenges, locking out the verification after too many.
+type VerifyUseCase interface {
+	Execute(ctx context.Context, challengeToken, code string) (*token.Token, error)
+}
+
+// Lockout of the verification of a user after too many failed codes.
+type Lockout struct {
+	MaxAttempts int // failed codes until the lockout
+	Duration    time.Duration
+}
+
+type verifyUseCase struct {
+	tag        string
+	repository Repository
+	issuer     token.Issuer
+	attempts   AttemptRepository
+	lockout    Lockout
+}
+
+// NewVerifyUseCase create a new instance of VerifyUseCase.
+func NewVerifyUseCase(repository Repository, issuer token.Issuer, attempts AttemptRepository,
+	lockout Lockout) VerifyUseCase {
+	return &verifyUseCase{
+		tag:        "twofactor::VerifyUseCase",
+		repository: repository,
+		issuer:     issuer,
+		attempts:   attempts,
+		lockout:    lockout,
+	}
+}
+
+func attemptKey(userID string) string {
+	return "totp:user:" + userID
+}
+
+// Execute executes the verify use case.
+func (u *verifyUseCase) Execute(ctx context.Context, challengeToken, code string) (*token.Token, error) {
+	if challengeToken == "" {
+		return nil, ErrInvalidChallenge
+	}
+
+	ch, err := u.repository.GetChallenge(ctx, HashChallengeToken(challengeToken))
+	if err != nil {
+		if errors.Is(err, cerror.ErrNotFound) {
+			service.Warn("", u.tag, ErrInvalidChallenge.Error())
+			return nil, ErrInvalidChallenge
+		}
+		service.Error("", u.tag, err)
+		return nil, err
+	}
+	if ch.IsExpired(time.Now()) {
+		service.Warn(ch.UserID, u.tag, ErrInvalidChallenge.Error())
+		return nil, ErrInvalidChallenge
+	}
+
+	ok, err := u.repository.AttemptChallenge(ctx, ch.ID, config.TOTPMaxAttempts())
+	if err != nil {
+		service.Error(ch.UserID, u.tag, err)
+		return nil, err
+	}
+	if !ok {
+		service.Warn(ch.UserID, u.tag, ErrTooManyAttempts.Error())
+		return nil, ErrTooManyAttempts
+	}
+
+	if err = u.attempt(ctx, ch.UserID); err != nil {
+		return nil, err
+	}
+
+	t, err := u.repository.Get(ctx, ch.UserID)
+	if err != nil && !errors.Is(err, cerror.ErrNotFound) {
+		service.Error(ch.UserID, u.tag, err)
+		u.refund(ctx, ch.UserID)
+		return nil, err
+	}
+	if t == nil || !t.Enabled { // disabled after the login
+		service.Warn(ch.UserID, u.tag, ErrInvalidChallenge.Error())
+		u.refund(ctx, ch.UserID)
+		return nil, ErrInvalidChallenge
+	}
+
+	ok, err = verifyCode(ctx, u.repository, t, code)
+	if err != nil {
+		service.Error(ch.UserID, u.tag, err)
+		u.refund(ctx, ch.UserID)
+		return nil, err
+	}
+	if !ok { // the failed code stays counted
+		service.Warn(ch.UserID, u.tag, ErrInvalidCode.Error())
+		return nil, ErrInvalidCode
+	}
+
+	if err = u.attempts.Reset(ctx, attemptKey(ch.UserID)); err != nil { // the code is still valid
+		service.Error(ch.UserID, u.tag, err)
+	}
+
+	if err = u.repository.DeleteChallenge(ctx, ch.ID); err != nil {
+		service.Error(ch.UserID, u.tag, err)
+		return nil, err
+	}
+
+	tk, err := u.issuer.Issue(ctx, ch.UserID, "")
+	if err != nil {
+		service.Error(ch.UserID, u.tag, err)
+		return nil, err
+	}
+
+	return tk, nil
+}
+
+// attempt counts the attempt of the user before the code is checked, so that concurrent
+// attempts cannot exceed the maximum, returning ErrTooManyAttempts while locked out.
+func (u *verifyUseCase) attempt(ctx context.Context, userID string) error {
+	key := attemptKey(userID)
+
+	failures, _, err := u.attempts.Get(ctx, key)
+	if err != nil {
+		service.Error(userID, u.tag, err)
+		return err
+	}
+	if failures >= u.lockout.MaxAttempts { // the attempts made while locked out are not counted
+		service.Warn(userID, u.tag, ErrTooManyAttempts.Error())
+		return ErrTooManyAttempts
+	}
+
+	attempts, err := u.attempts.Fail(ctx, key, time.Now(), u.lockout.Duration)
+	if err != nil {
+		service.Error(userID, u.tag, err)
+		return err
+	}
+	if attempts > u.lockout.MaxAttempts {
+		service.Warn(userID, u.tag, ErrTooManyAttempts.Error())
+		u.refund(ctx, userID)
+		return ErrTooManyAttempts
+	}
+	return nil
+}
+
+// refund removes the attempt of the user that did not fail.
+func (u *verifyUseCase) refund(ctx context.Context, userID string) {
+	if err := u.attempts.Refund(ctx, attemptKey(userID)); err != nil {
+		service.Error(userID, u.tag, err)
+	}
+}
@@ -0,0 +1,255 @@
+package twofactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/token"
+	"github.com/tsmweb/auth-service/common/totp"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+var testLockout = Lockout{MaxAttempts: 10, Duration: 15 * time.Minute}
+
+// newMockAttempts returns a mockAttemptRepository of a user without failed codes.
+func newMockAttempts() *mockAttemptRepository {
+	a := new(mockAttemptRepository)
+	a.On("Get", mock.Anything, mock.Anything).Return(0, time.Time{}, nil)
+	a.On("Fail", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(1, nil)
+	a.On("Refund", mock.Anything, mock.Anything).Return(nil)
+	a.On("Reset", mock.Anything, mock.Anything).Return(nil)
+	return a
+}
+
+func TestVerifyUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+
+	enabled, _ := NewTwoFactor("+5518999999999")
+	enabled.Enabled = true
+	code, _ := totp.Code(enabled.Secret, totp.Step(time.Now()))
+	ch, challengeToken, _ := NewChallenge("+5518999999999", time.Minute)
+
+	t.Run("when use case fails with ErrInvalidChallenge", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("GetChallenge", mock.Anything, "unknown").
+			Return(nil, cerror.ErrNotFound)
+		i := new(mockIssuer)
+		uc := NewVerifyUseCase(r, i, newMockAttempts(), testLockout)
+
+		_, err := uc.Execute(ctx, "", code)
+		assert.Equal(t, ErrInvalidChallenge, err)
+
+		r.On("GetChallenge", mock.Anything, HashChallengeToken("unknown")).
+			Return(nil, cerror.ErrNotFound).
+			Once()
+		_, err = uc.Execute(ctx, "unknown", code)
+		assert.Equal(t, ErrInvalidChallenge, err)
+
+		expired := *ch
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+		r.On("GetChallenge", mock.Anything, ch.ID).
+			Return(&expired, nil).
+			Once()
+		_, err = uc.Execute(ctx, challengeToken, code)
+		assert.Equal(t, ErrInvalidChallenge, err)
+
+		// disabled after the login.
+		r.On("GetChallenge", mock.Anything, ch.ID).
+			Return(ch, nil).
+			Once()
+		r.On("AttemptChallenge", mock.Anything, ch.ID, mock.Anything).
+			Return(true, nil).
+			Once()
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(nil, cerror.ErrNotFound).
+			Once()
+		_, err = uc.Execute(ctx, challengeToken, code)
+		assert.Equal(t, ErrInvalidChallenge, err)
+		i.AssertNotCalled(t, "Issue", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with ErrTooManyAttempts", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("GetChallenge", mock.Anything, ch.ID).
+			Return(ch, nil).
+			Once()
+		r.On("AttemptChallenge", mock.Anything, ch.ID, mock.Anything).
+			Return(false, nil).
+			Once()
+		i := new(mockIssuer)
+
+		_, err := NewVerifyUseCase(r, i, newMockAttempts(), testLockout).Execute(ctx, challengeToken, code)
+		assert.Equal(t, ErrTooManyAttempts, err)
+		r.AssertNotCalled(t, "UseStep", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with ErrInvalidCode", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("GetChallenge", mock.Anything, ch.ID).
+			Return(ch, nil)
+		r.On("AttemptChallenge", mock.Anything, ch.ID, mock.Anything).
+			Return(true, nil)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(enabled, nil)
+		r.On("UseStep", mock.Anything, "+5518999999999", mock.Anything).
+			Return(false, nil).
+			Once()
+		i := new(mockIssuer)
+		uc := NewVerifyUseCase(r, i, newMockAttempts(), testLockout)
+
+		_, err := uc.Execute(ctx, challengeToken, "")
+		assert.Equal(t, ErrInvalidCode, err)
+
+		// code already used.
+		_, err = uc.Execute(ctx, challengeToken, code)
+		assert.Equal(t, ErrInvalidCode, err)
+		r.AssertNotCalled(t, "DeleteChallenge", mock.Anything, mock.Anything)
+		i.AssertNotCalled(t, "Issue", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("GetChallenge", mock.Anything, ch.ID).
+			Return(nil, errors.New("error")).
+			Once()
+		i := new(mockIssuer)
+		uc := NewVerifyUseCase(r, i, newMockAttempts(), testLockout)
+
+		_, err := uc.Execute(ctx, challengeToken, code)
+		assert.NotNil(t, err)
+
+		r.On("GetChallenge", mock.Anything, ch.ID).
+			Return(ch, nil)
+		r.On("AttemptChallenge", mock.Anything, ch.ID, mock.Anything).
+			Return(true, nil)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(enabled, nil)
+		r.On("UseStep", mock.Anything, "+5518999999999", mock.Anything).
+			Return(true, nil)
+		r.On("DeleteChallenge", mock.Anything, ch.ID).
+			Return(nil)
+		i.On("Issue", mock.Anything, "+5518999999999", "").
+			Return(nil, errors.New("error")).
+			Once()
+		_, err = uc.Execute(ctx, challengeToken, code)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when use case succeeds", func(t *testing.T) {
+		//t.Parallel()
+		tk := &token.Token{AccessToken: "A1B2C3D4E5F6", RefreshToken: "F6E5D4C3B2A1"}
+
+		r := new(mockRepository)
+		r.On("GetChallenge", mock.Anything, ch.ID).
+			Return(ch, nil).
+			Once()
+		r.On("AttemptChallenge", mock.Anything, ch.ID, mock.Anything).
+			Return(true, nil).
+			Once()
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(enabled, nil).
+			Once()
+		r.On("UseRecoveryCode", mock.Anything, "+5518999999999", HashRecoveryCode("abcd-efgh")).
+			Return(true, nil).
+			Once()
+		r.On("DeleteChallenge", mock.Anything, ch.ID).
+			Return(nil).
+			Once()
+		i := new(mockIssuer)
+		i.On("Issue", mock.Anything, "+5518999999999", "").
+			Return(tk, nil).
+			Once()
+
+		tokenUC, err := NewVerifyUseCase(r, i, newMockAttempts(), testLockout).Execute(ctx, challengeToken, "abcd-efgh")
+		assert.Nil(t, err)
+		assert.Equal(t, tk, tokenUC)
+		r.AssertExpectations(t)
+	})
+	t.Run("when user is locked out", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("GetChallenge", mock.Anything, ch.ID).
+			Return(ch, nil)
+		r.On("AttemptChallenge", mock.Anything, ch.ID, mock.Anything).
+			Return(true, nil)
+		i := new(mockIssuer)
+		a := new(mockAttemptRepository)
+		a.On("Get", mock.Anything, "totp:user:+5518999999999").
+			Return(10, time.Now(), nil).
+			Once()
+		uc := NewVerifyUseCase(r, i, a, testLockout)
+
+		_, err := uc.Execute(ctx, challengeToken, code)
+		assert.Equal(t, ErrTooManyAttempts, err)
+		a.AssertNotCalled(t, "Fail", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+		// a concurrent attempt reached the maximum after the check.
+		a.On("Get", mock.Anything, "totp:user:+5518999999999").
+			Return(9, time.Now(), nil).
+			Once()
+		a.On("Fail", mock.Anything, "totp:user:+5518999999999", mock.Anything, 15*time.Minute).
+			Return(11, nil).
+			Once()
+		a.On("Refund", mock.Anything, "totp:user:+5518999999999").
+			Return(nil).
+			Once()
+		_, err = uc.Execute(ctx, challengeToken, code)
+		assert.Equal(t, ErrTooManyAttempts, err)
+		a.AssertExpectations(t)
+		r.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when failed codes are counted", func(t *testing.T) {
+		//t.Parallel()
+		tk := &token.Token{AccessToken: "A1B2C3D4E5F6", RefreshToken: "F6E5D4C3B2A1"}
+
+		r := new(mockRepository)
+		r.On("GetChallenge", mock.Anything, ch.ID).
+			Return(ch, nil)
+		r.On("AttemptChallenge", mock.Anything, ch.ID, mock.Anything).
+			Return(true, nil)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(enabled, nil)
+		r.On("UseRecoveryCode", mock.Anything, "+5518999999999", HashRecoveryCode("abcd-efgh")).
+			Return(false, nil).
+			Once()
+		r.On("UseRecoveryCode", mock.Anything, "+5518999999999", HashRecoveryCode("abcd-efgh")).
+			Return(true, nil).
+			Once()
+		r.On("DeleteChallenge", mock.Anything, ch.ID).
+			Return(nil)
+		i := new(mockIssuer)
+		i.On("Issue", mock.Anything, "+5518999999999", "").
+			Return(tk, nil).
+			Once()
+		a := new(mockAttemptRepository)
+		a.On("Get", mock.Anything, "totp:user:+5518999999999").
+			Return(0, time.Time{}, nil)
+		a.On("Fail", mock.Anything, "totp:user:+5518999999999", mock.Anything, 15*time.Minute).
+			Return(1, nil)
+		a.On("Reset", mock.Anything, "totp:user:+5518999999999").
+			Return(nil).
+			Once()
+		uc := NewVerifyUseCase(r, i, a, testLockout)
+
+		// the failed code is not refunded.
+		_, err := uc.Execute(ctx, challengeToken, "abcd-efgh")
+		assert.Equal(t, ErrInvalidCode, err)
+		a.AssertNotCalled(t, "Refund", mock.Anything, mock.Anything)
+		a.AssertNotCalled(t, "Reset", mock.Anything, mock.Anything)
+
+		tokenUC, err := uc.Execute(ctx, challengeToken, "abcd-efgh")
+		assert.Nil(t, err)
+		assert.Equal(t, tk, tokenUC)
+		a.AssertExpectations(t)
+	})
+}
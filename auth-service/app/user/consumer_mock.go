@@ -0,0 +1,38 @@
+package user
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/go-helper-api/kafka"
+)
+
+// mockKafkaConsumer injects mock kafka.Consumer dependency.
+type mockKafkaConsumer struct {
+	mock.Mock
+	events []*kafka.Event
+}
+
+// Subscribe represents the simulated method for the Subscribe feature in the kafka.Consumer
+// layer, delivering the events informed to the callback.
+func (m *mockKafkaConsumer) Subscribe(ctx context.Context, callbackFn func(event *kafka.Event, err error)) {
+	for _, e := range m.events {
+		callbackFn(e, nil)
+	}
+}
+
+// Close represents the simulated method for the Close feature in the kafka.Consumer layer.
+func (m *mockKafkaConsumer) Close() {
+	m.Called()
+}
+
+// mockReportUseCase injects mock ReportUseCase dependency.
+type mockReportUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the ReportUseCase.
+func (m *mockReportUseCase) Execute(ctx context.Context, report *DeletionReport) error {
+	args := m.Called(ctx, report)
+	return args.Error(0)
+}
@@ -4,35 +4,64 @@ import (
 	"context"
 	"errors"
 
+	"github.com/tsmweb/auth-service/app/account"
+	"github.com/tsmweb/auth-service/app/verification"
 	"github.com/tsmweb/auth-service/common/service"
 	"github.com/tsmweb/go-helper-api/cerror"
+	"github.com/tsmweb/go-helper-api/kafka"
 )
 
-// CreateUseCase creates a new User, otherwise an error is returned.
+// CreateUseCase creates a new User once the code sent to its phone number is verified and
+// publishes the EventUserCreated, otherwise an error is returned.
 type CreateUseCase interface {
-	Execute(ctx context.Context, ID, name, lastname, password string) error
+	Execute(ctx context.Context, ID, name, lastname, password, code string) error
 }
 
 type createUseCase struct {
 	tag        string
 	repository Repository
+	verifier   verification.Verifier
+	encoder    account.EventEncoder
+	producer   kafka.Producer
 }
 
 // NewCreateUseCase create a new instance of CreateUseCase.
-func NewCreateUseCase(repository Repository) CreateUseCase {
+func NewCreateUseCase(
+	repository Repository,
+	verifier verification.Verifier,
+	encoder account.EventEncoder,
+	producer kafka.Producer,
+) CreateUseCase {
 	return &createUseCase{
 		tag:        "user::CreateUseCase",
 		repository: repository,
+		verifier:   verifier,
+		encoder:    encoder,
+		producer:   producer,
 	}
 }
 
 // Execute executes the creation use case.
-func (u *createUseCase) Execute(ctx context.Context, ID, name, lastname, password string) error {
+func (u *createUseCase) Execute(ctx context.Context, ID, name, lastname, password, code string) error {
+	if err := verification.ValidatePhone(ID); err != nil {
+		return err
+	}
+
 	user, err := NewUser(ID, name, lastname, password)
 	if err != nil {
 		return err
 	}
 
+	if err = u.verifier.Verify(ctx, ID, code); err != nil {
+		if errors.Is(err, verification.ErrInvalidCode) ||
+			errors.Is(err, verification.ErrTooManyAttempts) {
+			service.Warn(ID, u.tag, err.Error())
+		} else {
+			service.Error(ID, u.tag, err)
+		}
+		return err
+	}
+
 	if err = u.repository.Create(ctx, user); err != nil {
 		if errors.Is(err, cerror.ErrRecordAlreadyRegistered) {
 			service.Warn(ID, u.tag, err.Error())
@@ -43,5 +72,11 @@ func (u *createUseCase) Execute(ctx context.Context, ID, name, lastname, passwor
 		}
 	}
 
+	event := account.NewEvent(ID, account.EventUserCreated)
+	if err = account.Publish(ctx, u.encoder, u.producer, event); err != nil {
+		service.Error(ID, u.tag, err)
+		return &ErrEventNotification{Msg: err.Error()}
+	}
+
 	return nil
 }
@@ -5,6 +5,9 @@ import (
 	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/account"
+	"github.com/tsmweb/auth-service/app/verification"
+	"github.com/tsmweb/auth-service/common"
 	"github.com/tsmweb/go-helper-api/cerror"
 	"testing"
 )
@@ -13,13 +16,40 @@ func TestCreateUseCase_Execute(t *testing.T) {
 	//t.Parallel()
 	ctx := context.Background()
 
+	v := new(mockVerifier)
+	v.On("Verify", mock.Anything, "+5518999999999", "123456").
+		Return(nil)
+
+	encoder := account.EventEncoderFunc(func(e *account.Event) ([]byte, error) {
+		return []byte(e.Event), nil
+	})
+
 	t.Run("when use case fails with ErrValidateModel", func(t *testing.T) {
 		//t.Parallel()
 		r := new(mockRepository)
-		uc := NewCreateUseCase(r)
-		err := uc.Execute(ctx,"+5518999999999", "Steve", "Jobs", "")
+		uc := NewCreateUseCase(r, v, encoder, new(common.MockKafkaProducer))
+		err := uc.Execute(ctx, "+5518999999999", "Steve", "Jobs", "", "123456")
 
 		assert.Equal(t, ErrPasswordValidateModel, err)
+
+		err = uc.Execute(ctx, "18999999999", "Steve", "Jobs", "123456", "123456")
+
+		assert.Equal(t, verification.ErrPhoneValidateModel, err)
+	})
+
+	t.Run("when use case fails with ErrInvalidCode", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		vf := new(mockVerifier)
+		vf.On("Verify", mock.Anything, "+5518999999999", "654321").
+			Return(verification.ErrInvalidCode).
+			Once()
+
+		uc := NewCreateUseCase(r, vf, encoder, new(common.MockKafkaProducer))
+		err := uc.Execute(ctx, "+5518999999999", "Steve", "Jobs", "123456", "654321")
+
+		assert.Equal(t, verification.ErrInvalidCode, err)
+		r.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 	})
 
 	t.Run("when use case fails with ErrUserAlreadyExists", func(t *testing.T) {
@@ -29,8 +59,8 @@ func TestCreateUseCase_Execute(t *testing.T) {
 			Return(cerror.ErrRecordAlreadyRegistered).
 			Once()
 
-		uc := NewCreateUseCase(r)
-		err := uc.Execute(ctx, "+5518999999999", "Steve", "Jobs", "123456")
+		uc := NewCreateUseCase(r, v, encoder, new(common.MockKafkaProducer))
+		err := uc.Execute(ctx, "+5518999999999", "Steve", "Jobs", "123456", "123456")
 
 		assert.Equal(t, ErrUserAlreadyExists, err)
 	})
@@ -42,22 +72,46 @@ func TestCreateUseCase_Execute(t *testing.T) {
 			Return(errors.New("error")).
 			Once()
 
-		uc := NewCreateUseCase(r)
-		err := uc.Execute(ctx, "+5518999999999", "Steve", "Jobs", "123456")
+		uc := NewCreateUseCase(r, v, encoder, new(common.MockKafkaProducer))
+		err := uc.Execute(ctx, "+5518999999999", "Steve", "Jobs", "123456", "123456")
 
 		assert.NotNil(t, err)
 	})
 
+	t.Run("when use case fails with ErrEventNotification", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Create", mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		p := new(common.MockKafkaProducer)
+		p.On("Publish", mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Once()
+
+		uc := NewCreateUseCase(r, v, encoder, p)
+		err := uc.Execute(ctx, "+5518999999999", "Steve", "Jobs", "123456", "123456")
+
+		var errEventNotification *ErrEventNotification
+		assert.ErrorAs(t, err, &errEventNotification)
+	})
+
 	t.Run("when use case succeeds", func(t *testing.T) {
 		//t.Parallel()
 		r := new(mockRepository)
 		r.On("Create", mock.Anything, mock.Anything).
 			Return(nil).
 			Once()
+		p := new(common.MockKafkaProducer)
+		p.On("Publish", mock.Anything, []byte("+5518999999999"),
+			[][]byte{[]byte(account.EventUserCreated.String())}).
+			Return(nil).
+			Once()
 
-		uc := NewCreateUseCase(r)
-		err := uc.Execute(ctx, "+5518999999999", "Steve", "Jobs", "123456")
+		uc := NewCreateUseCase(r, v, encoder, p)
+		err := uc.Execute(ctx, "+5518999999999", "Steve", "Jobs", "123456", "123456")
 
 		assert.Nil(t, err)
+		p.AssertExpectations(t)
 	})
 }
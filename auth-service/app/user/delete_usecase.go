@@ -0,0 +1,191 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/tsmweb/auth-service/app/account"
+	"github.com/tsmweb/auth-service/app/login"
+	"github.com/tsmweb/auth-service/common"
+	"github.com/tsmweb/auth-service/common/password"
+	"github.com/tsmweb/auth-service/common/service"
+	"github.com/tsmweb/auth-service/config"
+	"github.com/tsmweb/chat-server/pkg/revocation"
+	"github.com/tsmweb/go-helper-api/cerror"
+	"github.com/tsmweb/go-helper-api/kafka"
+)
+
+// DeleteUseCase deletes the account of the user, revoking all its tokens and publishing the
+// EventUserDeleted so that the other services remove the data of the user, otherwise an error
+// is returned. The user is purged once every service reports its step of the Deletion. The
+// current password of the user is required, returning cerror.ErrUnauthorized if it is wrong,
+// and its failed attempts are throttled along with the logins, returning *login.ErrLocked.
+type DeleteUseCase interface {
+	Execute(ctx context.Context, ID, password string) error
+}
+
+type deleteUseCase struct {
+	tag             string
+	repository      Repository
+	loginRepository login.Repository
+	throttle        login.Throttle
+	publisher       *deletionPublisher
+}
+
+// NewDeleteUseCase create a new instance of DeleteUseCase.
+func NewDeleteUseCase(
+	repository Repository,
+	loginRepository login.Repository,
+	throttle login.Throttle,
+	store revocation.Store,
+	revocationEncoder login.TokenRevocationEncoder,
+	tokenProducer kafka.Producer,
+	encoder account.EventEncoder,
+	producer kafka.Producer,
+) DeleteUseCase {
+	return &deleteUseCase{
+		tag:             "user::DeleteUseCase",
+		repository:      repository,
+		loginRepository: loginRepository,
+		throttle:        throttle,
+		publisher: &deletionPublisher{
+			store:             store,
+			revocationEncoder: revocationEncoder,
+			tokenProducer:     tokenProducer,
+			encoder:           encoder,
+			producer:          producer,
+		},
+	}
+}
+
+// Execute executes the delete use case.
+func (u *deleteUseCase) Execute(ctx context.Context, ID, pwd string) error {
+	if strings.TrimSpace(pwd) == "" {
+		return login.ErrPasswordValidateModel
+	}
+
+	if err := u.checkPermission(ctx, ID); err != nil {
+		service.Warn(ID, u.tag, err.Error())
+		return err
+	}
+
+	if err := u.checkPassword(ctx, ID, pwd); err != nil {
+		return err
+	}
+
+	// the data kept by auth-service is removed along with the login.
+	d := NewDeletion(ID)
+	d.CompleteStep(ServiceName, d.RequestedAt)
+
+	ok, err := u.repository.Delete(ctx, d)
+	if err != nil {
+		service.Error(ID, u.tag, err)
+		return err
+	}
+	if !ok {
+		return ErrUserNotFound
+	}
+
+	if err = u.publisher.publish(ctx, ID); err != nil {
+		service.Error(ID, u.tag, err)
+		return err
+	}
+
+	service.Info(ID, u.tag, "account deletion requested")
+	return nil
+}
+
+func (u *deleteUseCase) checkPermission(ctx context.Context, userID string) error {
+	authID := ctx.Value(common.AuthContextKey).(string)
+	// checks if the ID owns the account
+	if userID != authID {
+		return ErrOperationNotAllowed
+	}
+	return nil
+}
+
+// checkPassword checks the current password of the user, counting the attempt in the
+// throttle of the logins unless the password is right or could not be checked.
+func (u *deleteUseCase) checkPassword(ctx context.Context, ID, pwd string) error {
+	ip, _ := ctx.Value(common.ClientIPContextKey).(string)
+	if err := u.throttle.Attempt(ctx, ID, ip); err != nil {
+		var errLocked *login.ErrLocked
+		if errors.As(err, &errLocked) {
+			service.Warn(ID, u.tag, err.Error())
+		} else {
+			service.Error(ID, u.tag, err)
+		}
+		return err
+	}
+
+	hash, err := u.loginRepository.GetPassword(ctx, ID)
+	if err != nil {
+		if errors.Is(err, cerror.ErrNotFound) {
+			password.VerifyDummy(pwd)
+			u.refund(ctx, ID, ip)
+			return ErrUserNotFound
+		}
+		service.Error(ID, u.tag, err)
+		u.refund(ctx, ID, ip)
+		return err
+	}
+
+	ok, _, err := password.Verify(pwd, hash)
+	if err != nil {
+		service.Error(ID, u.tag, err)
+		u.refund(ctx, ID, ip)
+		return err
+	}
+	if !ok { // the failed attempt stays counted
+		service.Warn(ID, u.tag, cerror.ErrUnauthorized.Error())
+		return cerror.ErrUnauthorized
+	}
+
+	u.refund(ctx, ID, ip)
+	return nil
+}
+
+// refund removes the attempt that did not fail from the throttle.
+func (u *deleteUseCase) refund(ctx context.Context, ID, ip string) {
+	if err := u.throttle.Refund(ctx, ID, ip); err != nil {
+		service.Error(ID, u.tag, err)
+	}
+}
+
+// deletionPublisher revokes the tokens of the deleted user and publishes the EventUserDeleted.
+type deletionPublisher struct {
+	store             revocation.Store
+	revocationEncoder login.TokenRevocationEncoder
+	tokenProducer     kafka.Producer
+	encoder           account.EventEncoder
+	producer          kafka.Producer
+}
+
+// publish returns *ErrEventNotification if the events could not be published.
+func (p *deletionPublisher) publish(ctx context.Context, userID string) error {
+	revoked := login.NewTokenRevocation(userID, login.RevocationLogoutEverywhere)
+	ttl := time.Duration(config.ExpireToken()) * time.Hour
+	if err := p.store.RevokeUser(ctx, userID, revoked.RevokedAt, ttl); err != nil {
+		return err
+	}
+
+	if err := p.notify(ctx, revoked); err != nil {
+		return &ErrEventNotification{Msg: err.Error()}
+	}
+	return nil
+}
+
+func (p *deletionPublisher) notify(ctx context.Context, revoked *login.TokenRevocation) error {
+	rpb, err := p.revocationEncoder.Marshal(revoked)
+	if err != nil {
+		return err
+	}
+	if err = p.tokenProducer.Publish(ctx, []byte(revoked.UserID), rpb); err != nil {
+		return err
+	}
+
+	return account.Publish(ctx, p.encoder, p.producer,
+		account.NewEvent(revoked.UserID, account.EventUserDeleted))
+}
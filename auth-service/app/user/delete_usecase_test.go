@@ -0,0 +1,185 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/account"
+	"github.com/tsmweb/auth-service/app/login"
+	"github.com/tsmweb/auth-service/common"
+	"github.com/tsmweb/auth-service/common/password"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+func TestDeleteUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.WithValue(context.Background(), common.AuthContextKey, "+5518999999999")
+
+	revocationEncoder := login.TokenRevocationEncoderFunc(func(r *login.TokenRevocation) ([]byte, error) {
+		return []byte(r.Reason), nil
+	})
+	encoder := account.EventEncoderFunc(func(e *account.Event) ([]byte, error) {
+		return []byte(e.Event), nil
+	})
+
+	hash, _ := password.Hash("123456")
+	lr := new(mockLoginRepository)
+	lr.On("GetPassword", mock.Anything, "+5518999999999").
+		Return(hash, nil)
+	th := new(mockThrottle)
+	th.On("Attempt", mock.Anything, "+5518999999999", mock.Anything).
+		Return(nil)
+	th.On("Refund", mock.Anything, "+5518999999999", mock.Anything).
+		Return(nil)
+
+	t.Run("when use case fails with ErrOperationNotAllowed", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		uc := NewDeleteUseCase(r, lr, th, new(mockRevocationStore),
+			revocationEncoder, new(common.MockKafkaProducer), encoder, new(common.MockKafkaProducer))
+
+		err := uc.Execute(ctx, "+5518977777777", "123456")
+		assert.Equal(t, ErrOperationNotAllowed, err)
+		r.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with ErrValidateModel", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		uc := NewDeleteUseCase(r, lr, th, new(mockRevocationStore),
+			revocationEncoder, new(common.MockKafkaProducer), encoder, new(common.MockKafkaProducer))
+
+		err := uc.Execute(ctx, "+5518999999999", "")
+		assert.Equal(t, login.ErrPasswordValidateModel, err)
+		r.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with ErrUnauthorized", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		wth := new(mockThrottle)
+		wth.On("Attempt", mock.Anything, "+5518999999999", mock.Anything).
+			Return(nil).
+			Once()
+		uc := NewDeleteUseCase(r, lr, wth, new(mockRevocationStore),
+			revocationEncoder, new(common.MockKafkaProducer), encoder, new(common.MockKafkaProducer))
+
+		err := uc.Execute(ctx, "+5518999999999", "654321")
+		assert.Equal(t, cerror.ErrUnauthorized, err)
+		r.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+		// the failed attempt stays counted.
+		wth.AssertNotCalled(t, "Refund", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with ErrLocked", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		lth := new(mockThrottle)
+		lth.On("Attempt", mock.Anything, "+5518999999999", mock.Anything).
+			Return(&login.ErrLocked{RetryAfter: time.Minute}).
+			Once()
+		llr := new(mockLoginRepository)
+		uc := NewDeleteUseCase(r, llr, lth, new(mockRevocationStore),
+			revocationEncoder, new(common.MockKafkaProducer), encoder, new(common.MockKafkaProducer))
+
+		err := uc.Execute(ctx, "+5518999999999", "123456")
+		var errLocked *login.ErrLocked
+		assert.ErrorAs(t, err, &errLocked)
+		llr.AssertNotCalled(t, "GetPassword", mock.Anything, mock.Anything)
+		r.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with ErrUserNotFound", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Delete", mock.Anything, mock.Anything).
+			Return(false, nil).
+			Once()
+		s := new(mockRevocationStore)
+		uc := NewDeleteUseCase(r, lr, th, s,
+			revocationEncoder, new(common.MockKafkaProducer), encoder, new(common.MockKafkaProducer))
+
+		err := uc.Execute(ctx, "+5518999999999", "123456")
+		assert.Equal(t, ErrUserNotFound, err)
+		s.AssertNotCalled(t, "RevokeUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with ErrEventNotification", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Delete", mock.Anything, mock.Anything).
+			Return(true, nil).
+			Once()
+		s := new(mockRevocationStore)
+		s.On("RevokeUser", mock.Anything, "+5518999999999", mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		tp := new(common.MockKafkaProducer)
+		tp.On("Publish", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		p := new(common.MockKafkaProducer)
+		p.On("Publish", mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Once()
+
+		err := NewDeleteUseCase(r, lr, th, s, revocationEncoder, tp, encoder, p).
+			Execute(ctx, "+5518999999999", "123456")
+
+		var errEventNotification *ErrEventNotification
+		assert.ErrorAs(t, err, &errEventNotification)
+	})
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Delete", mock.Anything, mock.Anything).
+			Return(false, errors.New("error")).
+			Once()
+		uc := NewDeleteUseCase(r, lr, th, new(mockRevocationStore),
+			revocationEncoder, new(common.MockKafkaProducer), encoder, new(common.MockKafkaProducer))
+
+		err := uc.Execute(ctx, "+5518999999999", "123456")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when use case succeeds", func(t *testing.T) {
+		//t.Parallel()
+		var stored *Deletion
+
+		r := new(mockRepository)
+		r.On("Delete", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				stored = args.Get(1).(*Deletion)
+			}).
+			Return(true, nil).
+			Once()
+		s := new(mockRevocationStore)
+		s.On("RevokeUser", mock.Anything, "+5518999999999", mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		tp := new(common.MockKafkaProducer)
+		tp.On("Publish", mock.Anything, []byte("+5518999999999"),
+			[][]byte{[]byte("LogoutEverywhere")}).
+			Return(nil).
+			Once()
+		p := new(common.MockKafkaProducer)
+		p.On("Publish", mock.Anything, []byte("+5518999999999"),
+			[][]byte{[]byte("UserDeleted")}).
+			Return(nil).
+			Once()
+
+		err := NewDeleteUseCase(r, lr, th, s, revocationEncoder, tp, encoder, p).
+			Execute(ctx, "+5518999999999", "123456")
+		assert.Nil(t, err)
+		assert.Equal(t, "+5518999999999", stored.UserID)
+		assert.Equal(t, []string{"user-service", "broker-service", "file-service"}, stored.Pending())
+		s.AssertExpectations(t)
+		tp.AssertExpectations(t)
+		p.AssertExpectations(t)
+	})
+}
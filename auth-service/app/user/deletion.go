@@ -0,0 +1,105 @@
+package user
+
+import (
+	"context"
+	"time"
+)
+
+// ServiceName identifies auth-service in the steps of a Deletion.
+const ServiceName = "auth-service"
+
+// DeletionServices are the services that remove the data of a deleted user, each reporting its
+// step once completed.
+var DeletionServices = []string{ServiceName, "user-service", "broker-service", "file-service"}
+
+// DeletionStep is the removal of the data of a deleted user by a service, pending while
+// CompletedAt is zero.
+type DeletionStep struct {
+	Service     string
+	CompletedAt time.Time
+}
+
+// Deletion follows the removal of the data of a deleted user across the services. It completes
+// once every step is completed and the user is purged.
+type Deletion struct {
+	UserID      string
+	RequestedAt time.Time
+	CompletedAt time.Time
+	Steps       []*DeletionStep
+}
+
+// NewDeletion return an instance of Deletion with a pending step for each of DeletionServices.
+func NewDeletion(userID string) *Deletion {
+	d := &Deletion{
+		UserID:      userID,
+		RequestedAt: time.Now().UTC(),
+	}
+	for _, s := range DeletionServices {
+		d.Steps = append(d.Steps, &DeletionStep{Service: s})
+	}
+	return d
+}
+
+// CompleteStep completes the step of the service, returning false if it is not a step of
+// the deletion.
+func (d *Deletion) CompleteStep(service string, completedAt time.Time) bool {
+	for _, s := range d.Steps {
+		if s.Service == service {
+			s.CompletedAt = completedAt
+			return true
+		}
+	}
+	return false
+}
+
+// Pending returns the services whose steps are not completed yet.
+func (d *Deletion) Pending() []string {
+	var pending []string
+	for _, s := range d.Steps {
+		if s.CompletedAt.IsZero() {
+			pending = append(pending, s.Service)
+		}
+	}
+	return pending
+}
+
+// IsCompleted returns true when the user was purged after every step.
+func (d *Deletion) IsCompleted() bool {
+	return !d.CompletedAt.IsZero()
+}
+
+// DeletionReport is sent by a service when it completes its step of the Deletion.
+type DeletionReport struct {
+	UserID      string
+	Service     string
+	CompletedAt time.Time
+}
+
+// DeletionReportDecoder is a byte slice decoder for DeletionReport.
+type DeletionReportDecoder interface {
+	Unmarshal(in []byte, r *DeletionReport) error
+}
+
+// The DeletionReportDecoderFunc type is an adapter to allow the use of ordinary functions as
+// decoders of byte slice for DeletionReport.
+// If f is a function with the appropriate signature, DeletionReportDecoderFunc(f) is a
+// DeletionReportDecoder that calls f.
+type DeletionReportDecoderFunc func(in []byte, r *DeletionReport) error
+
+// Unmarshal calls f(in, r).
+func (f DeletionReportDecoderFunc) Unmarshal(in []byte, r *DeletionReport) error {
+	return f(in, r)
+}
+
+// DeletionRepository interface for Deletion data source.
+type DeletionRepository interface {
+	// Get returns the deletion of the user.
+	Get(ctx context.Context, userID string) (*Deletion, error)
+
+	// CompleteStep completes the step of the service, returning false if the deletion of the
+	// user or the step are not found.
+	CompleteStep(ctx context.Context, userID, service string, completedAt time.Time) (bool, error)
+
+	// Complete completes the deletion of the user.
+	Complete(ctx context.Context, userID string, completedAt time.Time) error
+}
@@ -0,0 +1,21 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeletion(t *testing.T) {
+	//t.Parallel()
+	d := NewDeletion("+5518999999999")
+	assert.Equal(t, DeletionServices, d.Pending())
+	assert.False(t, d.IsCompleted())
+
+	assert.False(t, d.CompleteStep("chat-service", time.Now()))
+	for _, s := range DeletionServices {
+		assert.True(t, d.CompleteStep(s, time.Now()))
+	}
+	assert.Empty(t, d.Pending())
+}
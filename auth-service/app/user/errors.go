@@ -8,4 +8,16 @@ var (
 	ErrUserNotFound        = errors.New("user not found")
 	ErrUserAlreadyExists   = errors.New("user already exists")
 	ErrOperationNotAllowed = errors.New("operation not allowed")
+	ErrDeletionNotFound    = errors.New("deletion not found")
+	ErrDeletionCompleted   = errors.New("deletion already completed")
 )
+
+// ErrEventNotification error thrown if publishing an event results in an error.
+type ErrEventNotification struct {
+	Msg string
+}
+
+// Error implements interface Error
+func (e *ErrEventNotification) Error() string {
+	return e.Msg
+}
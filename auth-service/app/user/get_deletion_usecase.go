@@ -0,0 +1,41 @@
+package user
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tsmweb/auth-service/common/service"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+// GetDeletionUseCase returns the Deletion of the user, reporting the steps completed by each
+// service, otherwise an error is returned.
+type GetDeletionUseCase interface {
+	Execute(ctx context.Context, userID string) (*Deletion, error)
+}
+
+type getDeletionUseCase struct {
+	tag                string
+	deletionRepository DeletionRepository
+}
+
+// NewGetDeletionUseCase create a new instance of GetDeletionUseCase.
+func NewGetDeletionUseCase(deletionRepository DeletionRepository) GetDeletionUseCase {
+	return &getDeletionUseCase{
+		tag:                "user::GetDeletionUseCase",
+		deletionRepository: deletionRepository,
+	}
+}
+
+// Execute executes the get deletion use case.
+func (u *getDeletionUseCase) Execute(ctx context.Context, userID string) (*Deletion, error) {
+	d, err := u.deletionRepository.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, cerror.ErrNotFound) {
+			return nil, ErrDeletionNotFound
+		}
+		service.Error(userID, u.tag, err)
+		return nil, err
+	}
+	return d, nil
+}
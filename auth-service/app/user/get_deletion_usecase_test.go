@@ -0,0 +1,51 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+func TestGetDeletionUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+
+	t.Run("when use case fails with ErrDeletionNotFound", func(t *testing.T) {
+		//t.Parallel()
+		d := new(mockDeletionRepository)
+		d.On("Get", mock.Anything, "+5518999999999").
+			Return(nil, cerror.ErrNotFound).
+			Once()
+
+		_, err := NewGetDeletionUseCase(d).Execute(ctx, "+5518999999999")
+		assert.Equal(t, ErrDeletionNotFound, err)
+	})
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		d := new(mockDeletionRepository)
+		d.On("Get", mock.Anything, "+5518999999999").
+			Return(nil, errors.New("error")).
+			Once()
+
+		_, err := NewGetDeletionUseCase(d).Execute(ctx, "+5518999999999")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when use case succeeds", func(t *testing.T) {
+		//t.Parallel()
+		deletion := NewDeletion("+5518999999999")
+		d := new(mockDeletionRepository)
+		d.On("Get", mock.Anything, "+5518999999999").
+			Return(deletion, nil).
+			Once()
+
+		result, err := NewGetDeletionUseCase(d).Execute(ctx, "+5518999999999")
+		assert.Nil(t, err)
+		assert.Equal(t, deletion, result)
+	})
+}
@@ -0,0 +1,55 @@
+package user
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/login"
+)
+
+// mockLoginRepository injects mock login.Repository dependency.
+type mockLoginRepository struct {
+	mock.Mock
+}
+
+// GetPassword represents the simulated method for the GetPassword feature in the
+// login.Repository.
+func (m *mockLoginRepository) GetPassword(ctx context.Context, ID string) (string, error) {
+	args := m.Called(ctx, ID)
+	return args.String(0), args.Error(1)
+}
+
+// Rehash represents the simulated method for the Rehash feature in the login.Repository.
+func (m *mockLoginRepository) Rehash(ctx context.Context, ID, oldHash, newHash string) (bool, error) {
+	args := m.Called(ctx, ID, oldHash, newHash)
+	return args.Bool(0), args.Error(1)
+}
+
+// Update represents the simulated method for the Update feature in the login.Repository.
+func (m *mockLoginRepository) Update(ctx context.Context, l *login.Login) (bool, error) {
+	args := m.Called(ctx, l)
+	return args.Bool(0), args.Error(1)
+}
+
+// mockThrottle injects mock login.Throttle dependency.
+type mockThrottle struct {
+	mock.Mock
+}
+
+// Attempt represents the simulated method for the Attempt feature in the login.Throttle.
+func (m *mockThrottle) Attempt(ctx context.Context, userID, ip string) error {
+	args := m.Called(ctx, userID, ip)
+	return args.Error(0)
+}
+
+// Refund represents the simulated method for the Refund feature in the login.Throttle.
+func (m *mockThrottle) Refund(ctx context.Context, userID, ip string) error {
+	args := m.Called(ctx, userID, ip)
+	return args.Error(0)
+}
+
+// Reset represents the simulated method for the Reset feature in the login.Throttle.
+func (m *mockThrottle) Reset(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,59 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/tsmweb/auth-service/common/service"
+	"github.com/tsmweb/go-helper-api/kafka"
+)
+
+// ReportConsumer consumes the DeletionReport sent by the services, executing the ReportUseCase
+// for each one.
+type ReportConsumer struct {
+	tag           string
+	consumer      kafka.Consumer
+	decoder       DeletionReportDecoder
+	reportUseCase ReportUseCase
+}
+
+// NewReportConsumer create a new instance of ReportConsumer.
+func NewReportConsumer(
+	consumer kafka.Consumer,
+	decoder DeletionReportDecoder,
+	reportUseCase ReportUseCase,
+) *ReportConsumer {
+	return &ReportConsumer{
+		tag:           "user::ReportConsumer",
+		consumer:      consumer,
+		decoder:       decoder,
+		reportUseCase: reportUseCase,
+	}
+}
+
+// Start consumes the reports until the ctx is done.
+func (c *ReportConsumer) Start(ctx context.Context) {
+	defer func() {
+		c.consumer.Close()
+		log.Printf("[STOP] %s\n", c.tag)
+	}()
+
+	callbackFn := func(event *kafka.Event, err error) {
+		if err != nil {
+			service.Error("", c.tag, fmt.Errorf("kafka::Consumer: %s", err.Error()))
+			return
+		}
+
+		var report DeletionReport
+		if err = c.decoder.Unmarshal(event.Value, &report); err != nil {
+			service.Error(string(event.Key), c.tag, err)
+			return
+		}
+
+		// the errors are reported by the use case.
+		c.reportUseCase.Execute(ctx, &report)
+	}
+
+	c.consumer.Subscribe(ctx, callbackFn)
+}
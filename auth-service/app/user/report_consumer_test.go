@@ -0,0 +1,41 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/go-helper-api/kafka"
+)
+
+func TestReportConsumer_Start(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+	decoder := DeletionReportDecoderFunc(func(in []byte, r *DeletionReport) error {
+		if string(in) == "" {
+			return errors.New("error")
+		}
+		r.UserID = "+5518999999999"
+		r.Service = string(in)
+		return nil
+	})
+
+	c := &mockKafkaConsumer{events: []*kafka.Event{
+		{Value: []byte("user-service")},
+		{Value: []byte("")},
+		{Value: []byte("file-service")},
+	}}
+	c.On("Close").Return().Once()
+	uc := new(mockReportUseCase)
+	uc.On("Execute", mock.Anything, &DeletionReport{UserID: "+5518999999999", Service: "user-service"}).
+		Return(nil).
+		Once()
+	uc.On("Execute", mock.Anything, &DeletionReport{UserID: "+5518999999999", Service: "file-service"}).
+		Return(ErrDeletionNotFound).
+		Once()
+
+	NewReportConsumer(c, decoder, uc).Start(ctx)
+	uc.AssertExpectations(t)
+	c.AssertExpectations(t)
+}
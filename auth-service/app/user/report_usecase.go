@@ -0,0 +1,73 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tsmweb/auth-service/common/service"
+)
+
+// ReportUseCase completes the step of the Deletion reported by a service and purges the user
+// once every step is completed, otherwise an error is returned.
+type ReportUseCase interface {
+	Execute(ctx context.Context, report *DeletionReport) error
+}
+
+type reportUseCase struct {
+	tag                string
+	repository         Repository
+	deletionRepository DeletionRepository
+}
+
+// NewReportUseCase create a new instance of ReportUseCase.
+func NewReportUseCase(repository Repository, deletionRepository DeletionRepository) ReportUseCase {
+	return &reportUseCase{
+		tag:                "user::ReportUseCase",
+		repository:         repository,
+		deletionRepository: deletionRepository,
+	}
+}
+
+// Execute executes the report use case.
+func (u *reportUseCase) Execute(ctx context.Context, report *DeletionReport) error {
+	ok, err := u.deletionRepository.CompleteStep(ctx, report.UserID, report.Service,
+		report.CompletedAt)
+	if err != nil {
+		service.Error(report.UserID, u.tag, err)
+		return err
+	}
+	if !ok {
+		service.Warn(report.UserID, u.tag,
+			fmt.Sprintf("%s: %s", ErrDeletionNotFound.Error(), report.Service))
+		return ErrDeletionNotFound
+	}
+
+	d, err := u.deletionRepository.Get(ctx, report.UserID)
+	if err != nil {
+		service.Error(report.UserID, u.tag, err)
+		return err
+	}
+	if d.IsCompleted() {
+		return nil
+	}
+	if pending := d.Pending(); len(pending) > 0 {
+		service.Info(report.UserID, u.tag, fmt.Sprintf("%s completed, waiting for %s",
+			report.Service, strings.Join(pending, ", ")))
+		return nil
+	}
+
+	if _, err = u.repository.Purge(ctx, report.UserID); err != nil {
+		service.Error(report.UserID, u.tag, err)
+		return err
+	}
+	if err = u.deletionRepository.Complete(ctx, report.UserID, time.Now().UTC()); err != nil {
+		service.Error(report.UserID, u.tag, err)
+		return err
+	}
+
+	service.Info(report.UserID, u.tag, fmt.Sprintf("account deletion completed: %s",
+		strings.Join(DeletionServices, ", ")))
+	return nil
+}
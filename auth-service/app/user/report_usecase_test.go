@@ -0,0 +1,111 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReportUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+	report := &DeletionReport{
+		UserID:      "+5518999999999",
+		Service:     "file-service",
+		CompletedAt: time.Now().UTC(),
+	}
+
+	t.Run("when use case fails with ErrDeletionNotFound", func(t *testing.T) {
+		//t.Parallel()
+		d := new(mockDeletionRepository)
+		d.On("CompleteStep", mock.Anything, "+5518999999999", "file-service", report.CompletedAt).
+			Return(false, nil).
+			Once()
+
+		err := NewReportUseCase(new(mockRepository), d).Execute(ctx, report)
+		assert.Equal(t, ErrDeletionNotFound, err)
+	})
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		d := new(mockDeletionRepository)
+		d.On("CompleteStep", mock.Anything, "+5518999999999", "file-service", report.CompletedAt).
+			Return(false, errors.New("error")).
+			Once()
+
+		err := NewReportUseCase(new(mockRepository), d).Execute(ctx, report)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when steps are pending", func(t *testing.T) {
+		//t.Parallel()
+		deletion := NewDeletion("+5518999999999")
+		deletion.CompleteStep("auth-service", time.Now())
+		deletion.CompleteStep("file-service", time.Now())
+
+		r := new(mockRepository)
+		d := new(mockDeletionRepository)
+		d.On("CompleteStep", mock.Anything, "+5518999999999", "file-service", report.CompletedAt).
+			Return(true, nil).
+			Once()
+		d.On("Get", mock.Anything, "+5518999999999").
+			Return(deletion, nil).
+			Once()
+
+		err := NewReportUseCase(r, d).Execute(ctx, report)
+		assert.Nil(t, err)
+		r.AssertNotCalled(t, "Purge", mock.Anything, mock.Anything)
+		d.AssertNotCalled(t, "Complete", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when every step is completed", func(t *testing.T) {
+		//t.Parallel()
+		deletion := NewDeletion("+5518999999999")
+		for _, s := range DeletionServices {
+			deletion.CompleteStep(s, time.Now())
+		}
+
+		r := new(mockRepository)
+		r.On("Purge", mock.Anything, "+5518999999999").
+			Return(true, nil).
+			Once()
+		d := new(mockDeletionRepository)
+		d.On("CompleteStep", mock.Anything, "+5518999999999", "file-service", report.CompletedAt).
+			Return(true, nil).
+			Once()
+		d.On("Get", mock.Anything, "+5518999999999").
+			Return(deletion, nil).
+			Once()
+		d.On("Complete", mock.Anything, "+5518999999999", mock.Anything).
+			Return(nil).
+			Once()
+
+		err := NewReportUseCase(r, d).Execute(ctx, report)
+		assert.Nil(t, err)
+		r.AssertExpectations(t)
+		d.AssertExpectations(t)
+	})
+
+	t.Run("when deletion is already completed", func(t *testing.T) {
+		//t.Parallel()
+		deletion := NewDeletion("+5518999999999")
+		deletion.CompletedAt = time.Now()
+
+		r := new(mockRepository)
+		d := new(mockDeletionRepository)
+		d.On("CompleteStep", mock.Anything, "+5518999999999", "file-service", report.CompletedAt).
+			Return(true, nil).
+			Once()
+		d.On("Get", mock.Anything, "+5518999999999").
+			Return(deletion, nil).
+			Once()
+
+		err := NewReportUseCase(r, d).Execute(ctx, report)
+		assert.Nil(t, err)
+		r.AssertNotCalled(t, "Purge", mock.Anything, mock.Anything)
+	})
+}
@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"time"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -36,3 +37,53 @@ func (m *mockRepository) Update(ctx context.Context, u *User) (bool, error) {
 	return args.Get(0).(bool), nil
 }
 
+// Delete represents the simulated method for the Delete feature in the Repository layer.
+func (m *mockRepository) Delete(ctx context.Context, d *Deletion) (bool, error) {
+	args := m.Called(ctx, d)
+	if args.Error(1) != nil {
+		return false, args.Error(1)
+	}
+	return args.Bool(0), nil
+}
+
+// Purge represents the simulated method for the Purge feature in the Repository layer.
+func (m *mockRepository) Purge(ctx context.Context, ID string) (bool, error) {
+	args := m.Called(ctx, ID)
+	if args.Error(1) != nil {
+		return false, args.Error(1)
+	}
+	return args.Bool(0), nil
+}
+
+// mockDeletionRepository injects mock DeletionRepository dependency into UserCase layer.
+type mockDeletionRepository struct {
+	mock.Mock
+}
+
+// Get represents the simulated method for the Get feature in the DeletionRepository layer.
+func (m *mockDeletionRepository) Get(ctx context.Context, userID string) (*Deletion, error) {
+	args := m.Called(ctx, userID)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Deletion), nil
+}
+
+// CompleteStep represents the simulated method for the CompleteStep feature in the
+// DeletionRepository layer.
+func (m *mockDeletionRepository) CompleteStep(ctx context.Context, userID, service string,
+	completedAt time.Time) (bool, error) {
+	args := m.Called(ctx, userID, service, completedAt)
+	if args.Error(1) != nil {
+		return false, args.Error(1)
+	}
+	return args.Bool(0), nil
+}
+
+// Complete represents the simulated method for the Complete feature in the DeletionRepository
+// layer.
+func (m *mockDeletionRepository) Complete(ctx context.Context, userID string,
+	completedAt time.Time) error {
+	args := m.Called(ctx, userID, completedAt)
+	return args.Error(0)
+}
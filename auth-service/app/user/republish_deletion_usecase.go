@@ -0,0 +1,73 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tsmweb/auth-service/app/account"
+	"github.com/tsmweb/auth-service/app/login"
+	"github.com/tsmweb/auth-service/common/service"
+	"github.com/tsmweb/chat-server/pkg/revocation"
+	"github.com/tsmweb/go-helper-api/cerror"
+	"github.com/tsmweb/go-helper-api/kafka"
+)
+
+// RepublishDeletionUseCase revokes the tokens of the user and publishes the EventUserDeleted
+// again while the Deletion is pending, such as when the publishing failed after the account
+// was deleted, otherwise an error is returned. The services remove the data of the user
+// again and report their steps.
+type RepublishDeletionUseCase interface {
+	Execute(ctx context.Context, userID string) error
+}
+
+type republishDeletionUseCase struct {
+	tag                string
+	deletionRepository DeletionRepository
+	publisher          *deletionPublisher
+}
+
+// NewRepublishDeletionUseCase create a new instance of RepublishDeletionUseCase.
+func NewRepublishDeletionUseCase(
+	deletionRepository DeletionRepository,
+	store revocation.Store,
+	revocationEncoder login.TokenRevocationEncoder,
+	tokenProducer kafka.Producer,
+	encoder account.EventEncoder,
+	producer kafka.Producer,
+) RepublishDeletionUseCase {
+	return &republishDeletionUseCase{
+		tag:                "user::RepublishDeletionUseCase",
+		deletionRepository: deletionRepository,
+		publisher: &deletionPublisher{
+			store:             store,
+			revocationEncoder: revocationEncoder,
+			tokenProducer:     tokenProducer,
+			encoder:           encoder,
+			producer:          producer,
+		},
+	}
+}
+
+// Execute executes the republish deletion use case.
+func (u *republishDeletionUseCase) Execute(ctx context.Context, userID string) error {
+	d, err := u.deletionRepository.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, cerror.ErrNotFound) {
+			return ErrDeletionNotFound
+		}
+		service.Error(userID, u.tag, err)
+		return err
+	}
+	if d.IsCompleted() {
+		return ErrDeletionCompleted
+	}
+
+	if err = u.publisher.publish(ctx, userID); err != nil {
+		service.Error(userID, u.tag, err)
+		return err
+	}
+
+	service.Warn(userID, u.tag, fmt.Sprintf("account deletion republished, pending %v", d.Pending()))
+	return nil
+}
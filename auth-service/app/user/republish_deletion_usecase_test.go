@@ -0,0 +1,122 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/account"
+	"github.com/tsmweb/auth-service/app/login"
+	"github.com/tsmweb/auth-service/common"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+func TestRepublishDeletionUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+
+	revocationEncoder := login.TokenRevocationEncoderFunc(func(r *login.TokenRevocation) ([]byte, error) {
+		return []byte(r.Reason), nil
+	})
+	encoder := account.EventEncoderFunc(func(e *account.Event) ([]byte, error) {
+		return []byte(e.Event), nil
+	})
+
+	t.Run("when use case fails with ErrDeletionNotFound", func(t *testing.T) {
+		//t.Parallel()
+		d := new(mockDeletionRepository)
+		d.On("Get", mock.Anything, "+5518999999999").
+			Return(nil, cerror.ErrNotFound).
+			Once()
+		s := new(mockRevocationStore)
+
+		err := NewRepublishDeletionUseCase(d, s, revocationEncoder, new(common.MockKafkaProducer),
+			encoder, new(common.MockKafkaProducer)).Execute(ctx, "+5518999999999")
+		assert.Equal(t, ErrDeletionNotFound, err)
+		s.AssertNotCalled(t, "RevokeUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with ErrDeletionCompleted", func(t *testing.T) {
+		//t.Parallel()
+		deletion := NewDeletion("+5518999999999")
+		deletion.CompletedAt = time.Now()
+		d := new(mockDeletionRepository)
+		d.On("Get", mock.Anything, "+5518999999999").
+			Return(deletion, nil).
+			Once()
+		s := new(mockRevocationStore)
+
+		err := NewRepublishDeletionUseCase(d, s, revocationEncoder, new(common.MockKafkaProducer),
+			encoder, new(common.MockKafkaProducer)).Execute(ctx, "+5518999999999")
+		assert.Equal(t, ErrDeletionCompleted, err)
+		s.AssertNotCalled(t, "RevokeUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with ErrEventNotification", func(t *testing.T) {
+		//t.Parallel()
+		d := new(mockDeletionRepository)
+		d.On("Get", mock.Anything, "+5518999999999").
+			Return(NewDeletion("+5518999999999"), nil).
+			Once()
+		s := new(mockRevocationStore)
+		s.On("RevokeUser", mock.Anything, "+5518999999999", mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		tp := new(common.MockKafkaProducer)
+		tp.On("Publish", mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Once()
+
+		err := NewRepublishDeletionUseCase(d, s, revocationEncoder, tp, encoder,
+			new(common.MockKafkaProducer)).Execute(ctx, "+5518999999999")
+		var errEventNotification *ErrEventNotification
+		assert.ErrorAs(t, err, &errEventNotification)
+	})
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		d := new(mockDeletionRepository)
+		d.On("Get", mock.Anything, "+5518999999999").
+			Return(nil, errors.New("error")).
+			Once()
+
+		err := NewRepublishDeletionUseCase(d, new(mockRevocationStore), revocationEncoder,
+			new(common.MockKafkaProducer), encoder, new(common.MockKafkaProducer)).
+			Execute(ctx, "+5518999999999")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when use case succeeds", func(t *testing.T) {
+		//t.Parallel()
+		deletion := NewDeletion("+5518999999999")
+		deletion.CompleteStep(ServiceName, deletion.RequestedAt)
+		d := new(mockDeletionRepository)
+		d.On("Get", mock.Anything, "+5518999999999").
+			Return(deletion, nil).
+			Once()
+		s := new(mockRevocationStore)
+		s.On("RevokeUser", mock.Anything, "+5518999999999", mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		tp := new(common.MockKafkaProducer)
+		tp.On("Publish", mock.Anything, []byte("+5518999999999"),
+			[][]byte{[]byte("LogoutEverywhere")}).
+			Return(nil).
+			Once()
+		p := new(common.MockKafkaProducer)
+		p.On("Publish", mock.Anything, []byte("+5518999999999"),
+			[][]byte{[]byte("UserDeleted")}).
+			Return(nil).
+			Once()
+
+		err := NewRepublishDeletionUseCase(d, s, revocationEncoder, tp, encoder, p).
+			Execute(ctx, "+5518999999999")
+		assert.Nil(t, err)
+		s.AssertExpectations(t)
+		tp.AssertExpectations(t)
+		p.AssertExpectations(t)
+	})
+}
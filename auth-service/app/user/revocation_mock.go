@@ -0,0 +1,46 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/chat-server/pkg/revocation"
+)
+
+// mockRevocationStore injects mock revocation.Store dependency.
+type mockRevocationStore struct {
+	mock.Mock
+}
+
+// IsRevoked represents the simulated method for the IsRevoked feature in the revocation.Store.
+func (m *mockRevocationStore) IsRevoked(ctx context.Context, t *revocation.Token) (bool, error) {
+	args := m.Called(ctx, t)
+	if args.Error(1) != nil {
+		return false, args.Error(1)
+	}
+	return args.Bool(0), nil
+}
+
+// RevokeToken represents the simulated method for the RevokeToken feature in the
+// revocation.Store.
+func (m *mockRevocationStore) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	args := m.Called(ctx, tokenID, expiresAt)
+	return args.Error(0)
+}
+
+// RevokeSession represents the simulated method for the RevokeSession feature in the
+// revocation.Store.
+func (m *mockRevocationStore) RevokeSession(ctx context.Context, sessionID string,
+	ttl time.Duration) error {
+	args := m.Called(ctx, sessionID, ttl)
+	return args.Error(0)
+}
+
+// RevokeUser represents the simulated method for the RevokeUser feature in the
+// revocation.Store.
+func (m *mockRevocationStore) RevokeUser(ctx context.Context, userID string, revokedAt time.Time,
+	ttl time.Duration) error {
+	args := m.Called(ctx, userID, revokedAt, ttl)
+	return args.Error(0)
+}
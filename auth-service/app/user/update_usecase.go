@@ -4,11 +4,13 @@ import (
 	"context"
 	"time"
 
+	"github.com/tsmweb/auth-service/app/account"
 	"github.com/tsmweb/auth-service/common"
 	"github.com/tsmweb/auth-service/common/service"
+	"github.com/tsmweb/go-helper-api/kafka"
 )
 
-// UpdateUseCase updates a User, otherwise an error is returned.
+// UpdateUseCase updates a User and publishes the EventUserUpdated, otherwise an error is returned.
 type UpdateUseCase interface {
 	Execute(ctx context.Context, profile *User) error
 }
@@ -16,13 +18,21 @@ type UpdateUseCase interface {
 type updateUseCase struct {
 	tag        string
 	repository Repository
+	encoder    account.EventEncoder
+	producer   kafka.Producer
 }
 
 // NewUpdateUseCase create a new instance of UpdateUseCase.
-func NewUpdateUseCase(repository Repository) UpdateUseCase {
+func NewUpdateUseCase(
+	repository Repository,
+	encoder account.EventEncoder,
+	producer kafka.Producer,
+) UpdateUseCase {
 	return &updateUseCase{
 		tag:        "user::UpdateUseCase",
 		repository: repository,
+		encoder:    encoder,
+		producer:   producer,
 	}
 }
 
@@ -49,6 +59,12 @@ func (u *updateUseCase) Execute(ctx context.Context, user *User) error {
 		return ErrUserNotFound
 	}
 
+	event := account.NewEvent(user.ID, account.EventUserUpdated)
+	if err = account.Publish(ctx, u.encoder, u.producer, event); err != nil {
+		service.Error(user.ID, u.tag, err)
+		return &ErrEventNotification{Msg: err.Error()}
+	}
+
 	return nil
 }
 
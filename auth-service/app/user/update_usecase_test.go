@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/account"
 	"github.com/tsmweb/auth-service/common"
 	"testing"
 )
@@ -13,6 +14,10 @@ func TestUpdateUseCase_Execute(t *testing.T) {
 	//t.Parallel()
 	ctx := context.WithValue(context.Background(), common.AuthContextKey, "+5518999999999")
 
+	encoder := account.EventEncoderFunc(func(e *account.Event) ([]byte, error) {
+		return []byte(e.Event), nil
+	})
+
 	t.Run("when use case fails with ErrValidateModel", func(t *testing.T) {
 		//t.Parallel()
 		user := &User{
@@ -22,7 +27,7 @@ func TestUpdateUseCase_Execute(t *testing.T) {
 		}
 
 		r := new(mockRepository)
-		uc := NewUpdateUseCase(r)
+		uc := NewUpdateUseCase(r, encoder, new(common.MockKafkaProducer))
 		err := uc.Execute(ctx, user)
 
 		assert.Equal(t, ErrNameValidateModel, err)
@@ -37,7 +42,7 @@ func TestUpdateUseCase_Execute(t *testing.T) {
 		}
 
 		r := new(mockRepository)
-		uc := NewUpdateUseCase(r)
+		uc := NewUpdateUseCase(r, encoder, new(common.MockKafkaProducer))
 		err := uc.Execute(ctx, profile)
 
 		assert.Equal(t, ErrOperationNotAllowed, err)
@@ -56,7 +61,7 @@ func TestUpdateUseCase_Execute(t *testing.T) {
 			LastName: "Jobs",
 		}
 
-		uc := NewUpdateUseCase(r)
+		uc := NewUpdateUseCase(r, encoder, new(common.MockKafkaProducer))
 		err := uc.Execute(ctx, profile)
 
 		assert.Equal(t, ErrUserNotFound, err)
@@ -75,7 +80,7 @@ func TestUpdateUseCase_Execute(t *testing.T) {
 			LastName: "Jobs",
 		}
 
-		uc := NewUpdateUseCase(r)
+		uc := NewUpdateUseCase(r, encoder, new(common.MockKafkaProducer))
 		err := uc.Execute(ctx, profile)
 
 		assert.NotNil(t, err)
@@ -94,9 +99,16 @@ func TestUpdateUseCase_Execute(t *testing.T) {
 			LastName: "Jobs",
 		}
 
-		uc := NewUpdateUseCase(r)
+		p := new(common.MockKafkaProducer)
+		p.On("Publish", mock.Anything, []byte("+5518999999999"),
+			[][]byte{[]byte(account.EventUserUpdated.String())}).
+			Return(nil).
+			Once()
+
+		uc := NewUpdateUseCase(r, encoder, p)
 		err := uc.Execute(ctx, user)
 
 		assert.Nil(t, err)
+		p.AssertExpectations(t)
 	})
 }
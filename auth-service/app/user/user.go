@@ -2,8 +2,8 @@ package user
 
 import (
 	"context"
+	"github.com/tsmweb/auth-service/common/password"
 	"github.com/tsmweb/go-helper-api/cerror"
-	"github.com/tsmweb/go-helper-api/util/hashutil"
 	"time"
 )
 
@@ -24,12 +24,12 @@ type User struct {
 }
 
 // NewUser create a new User
-func NewUser(ID, name, lastname, password string) (*User, error) {
+func NewUser(ID, name, lastname, pwd string) (*User, error) {
 	p := &User{
 		ID:        ID,
 		Name:      name,
 		LastName:  lastname,
-		Password:  password,
+		Password:  pwd,
 		CreatedAt: time.Now().UTC(),
 	}
 
@@ -38,11 +38,11 @@ func NewUser(ID, name, lastname, password string) (*User, error) {
 		return p, err
 	}
 
-	pwd, err := hashutil.HashSHA256(password)
+	hash, err := password.Hash(pwd)
 	if err != nil {
 		return p, err
 	}
-	p.Password = pwd
+	p.Password = hash
 
 	return p, nil
 }
@@ -67,4 +67,12 @@ type Repository interface {
 	Get(ctx context.Context, ID string) (*User, error)
 	Create(ctx context.Context, user *User) error
 	Update(ctx context.Context, user *User) (bool, error)
+
+	// Delete marks the user as deleted at the request of the deletion, removes its login,
+	// tokens and the other data kept by auth-service and stores the deletion in the same
+	// transaction, returning false if the user is not found.
+	Delete(ctx context.Context, d *Deletion) (bool, error)
+
+	// Purge removes the deleted user, once the other services have removed its data.
+	Purge(ctx context.Context, ID string) (bool, error)
 }
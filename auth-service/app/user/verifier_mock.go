@@ -0,0 +1,18 @@
+package user
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// mockVerifier injects mock verification.Verifier dependency.
+type mockVerifier struct {
+	mock.Mock
+}
+
+// Verify represents the simulated method for the Verify feature in the verification.Verifier.
+func (m *mockVerifier) Verify(ctx context.Context, phone, code string) error {
+	args := m.Called(ctx, phone, code)
+	return args.Error(0)
+}
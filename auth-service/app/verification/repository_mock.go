@@ -0,0 +1,69 @@
+package verification
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// mockRepository injects mock dependency into UseCase layer.
+type mockRepository struct {
+	mock.Mock
+}
+
+// Get represents the simulated method for the Get feature in the Repository layer.
+func (m *mockRepository) Get(ctx context.Context, ID string) (*Verification, error) {
+	args := m.Called(ctx, ID)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Verification), nil
+}
+
+// Save represents the simulated method for the Save feature in the Repository layer.
+func (m *mockRepository) Save(ctx context.Context, v *Verification) error {
+	args := m.Called(ctx, v)
+	return args.Error(0)
+}
+
+// Attempt represents the simulated method for the Attempt feature in the Repository layer.
+func (m *mockRepository) Attempt(ctx context.Context, ID string, maxAttempts int) (bool, error) {
+	args := m.Called(ctx, ID, maxAttempts)
+	if args.Error(1) != nil {
+		return false, args.Error(1)
+	}
+	return args.Bool(0), nil
+}
+
+// Delete represents the simulated method for the Delete feature in the Repository layer.
+func (m *mockRepository) Delete(ctx context.Context, ID, codeHash string) (bool, error) {
+	args := m.Called(ctx, ID, codeHash)
+	if args.Error(1) != nil {
+		return false, args.Error(1)
+	}
+	return args.Bool(0), nil
+}
+
+// mockSMSSender injects mock SMSSender dependency.
+type mockSMSSender struct {
+	mock.Mock
+}
+
+// Send represents the simulated method for the Send feature in the SMSSender.
+func (m *mockSMSSender) Send(ctx context.Context, phone, text string) error {
+	args := m.Called(ctx, phone, text)
+	return args.Error(0)
+}
+
+// mockRequestCounter injects mock RequestCounter dependency.
+type mockRequestCounter struct {
+	mock.Mock
+}
+
+// Fail represents the simulated method for the Fail feature in the RequestCounter.
+func (m *mockRequestCounter) Fail(ctx context.Context, key string, at time.Time,
+	ttl time.Duration) (int, error) {
+	args := m.Called(ctx, key, at, ttl)
+	return args.Int(0), args.Error(1)
+}
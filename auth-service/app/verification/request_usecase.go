@@ -0,0 +1,108 @@
+package verification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tsmweb/auth-service/common"
+	"github.com/tsmweb/auth-service/common/service"
+	"github.com/tsmweb/auth-service/config"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+// phoneWindow is the window of the codes sent to a phone number, which is limited to
+// config.OTPPhoneMaxRequests codes.
+const phoneWindow = 24 * time.Hour
+
+// RequestUseCase sends a verification code by SMS to the phone number, otherwise an error
+// is returned. A new code replaces the previous one, keeping its attempts, but can only be
+// requested after the resend interval. The codes sent to the phone number a day and the
+// codes requested by the client IP, informed in the context by common.ClientIPContextKey,
+// are limited as well, returning ErrTooManyRequests.
+type RequestUseCase interface {
+	Execute(ctx context.Context, phone string) error
+}
+
+type requestUseCase struct {
+	tag        string
+	repository Repository
+	counter    RequestCounter
+	sender     SMSSender
+}
+
+// NewRequestUseCase create a new instance of RequestUseCase.
+func NewRequestUseCase(repository Repository, counter RequestCounter,
+	sender SMSSender) RequestUseCase {
+	return &requestUseCase{
+		tag:        "verification::RequestUseCase",
+		repository: repository,
+		counter:    counter,
+		sender:     sender,
+	}
+}
+
+// Execute executes the request use case.
+func (u *requestUseCase) Execute(ctx context.Context, phone string) error {
+	if err := ValidatePhone(phone); err != nil {
+		return err
+	}
+
+	if ip, _ := ctx.Value(common.ClientIPContextKey).(string); ip != "" {
+		window := time.Duration(config.OTPIPWindow()) * time.Minute
+		requests, err := u.counter.Fail(ctx, "verification:ip:"+ip, time.Now(), window)
+		if err != nil {
+			service.Error(phone, u.tag, err)
+			return err
+		}
+		if requests > config.OTPIPMaxRequests() {
+			service.Warn(phone, u.tag, fmt.Sprintf("%s from client %s", ErrTooManyRequests, ip))
+			return ErrTooManyRequests
+		}
+	}
+
+	previous, err := u.repository.Get(ctx, phone)
+	if err != nil && !errors.Is(err, cerror.ErrNotFound) {
+		service.Error(phone, u.tag, err)
+		return err
+	}
+	if previous != nil {
+		interval := time.Duration(config.OTPResendInterval()) * time.Second
+		if time.Now().Before(previous.CreatedAt.Add(interval)) {
+			service.Warn(phone, u.tag, ErrResendTooSoon.Error())
+			return ErrResendTooSoon
+		}
+	}
+
+	requests, err := u.counter.Fail(ctx, "verification:phone:"+phone, time.Now(), phoneWindow)
+	if err != nil {
+		service.Error(phone, u.tag, err)
+		return err
+	}
+	if requests > config.OTPPhoneMaxRequests() {
+		service.Warn(phone, u.tag, ErrTooManyRequests.Error())
+		return ErrTooManyRequests
+	}
+
+	expire := time.Duration(config.OTPExpire()) * time.Minute
+	v, code, err := NewVerification(phone, expire)
+	if err != nil {
+		service.Error(phone, u.tag, err)
+		return err
+	}
+
+	if err = u.repository.Save(ctx, v); err != nil {
+		service.Error(phone, u.tag, err)
+		return err
+	}
+
+	text := fmt.Sprintf("Your verification code is %s. It expires in %d minutes.",
+		code, config.OTPExpire())
+	if err = u.sender.Send(ctx, phone, text); err != nil {
+		service.Error(phone, u.tag, err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package verification
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/common"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+func TestRequestUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+
+	t.Run("when use case fails with ErrValidateModel", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		s := new(mockSMSSender)
+
+		err := NewRequestUseCase(r, new(mockRequestCounter), s).Execute(ctx, "18999999999")
+		assert.Equal(t, ErrPhoneValidateModel, err)
+		s.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with ErrResendTooSoon", func(t *testing.T) {
+		//t.Parallel()
+		// the resend interval is not loaded in the tests, the code is sent in the future.
+		previous := &Verification{ID: "+5518999999999", CreatedAt: time.Now().Add(time.Minute)}
+
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(previous, nil).
+			Once()
+		s := new(mockSMSSender)
+
+		err := NewRequestUseCase(r, new(mockRequestCounter), s).Execute(ctx, "+5518999999999")
+		assert.Equal(t, ErrResendTooSoon, err)
+		r.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		s.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(nil, errors.New("error")).
+			Once()
+		s := new(mockSMSSender)
+		s.On("Send", mock.Anything, "+5518999999999", mock.Anything).
+			Return(errors.New("error")).
+			Once()
+		c := new(mockRequestCounter)
+		c.On("Fail", mock.Anything, "verification:phone:+5518999999999", mock.Anything, phoneWindow).
+			Return(0, nil)
+		uc := NewRequestUseCase(r, c, s)
+
+		err := uc.Execute(ctx, "+5518999999999")
+		assert.NotNil(t, err)
+
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(nil, cerror.ErrNotFound)
+		r.On("Save", mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Once()
+		err = uc.Execute(ctx, "+5518999999999")
+		assert.NotNil(t, err)
+
+		r.On("Save", mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		err = uc.Execute(ctx, "+5518999999999")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when use case fails with ErrTooManyRequests", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(nil, cerror.ErrNotFound).
+			Once()
+		c := new(mockRequestCounter)
+		c.On("Fail", mock.Anything, "verification:phone:+5518999999999", mock.Anything, phoneWindow).
+			Return(1, nil).
+			Once()
+		s := new(mockSMSSender)
+
+		// the maximum number of requests is not loaded in the tests.
+		err := NewRequestUseCase(r, c, s).Execute(ctx, "+5518999999999")
+		assert.Equal(t, ErrTooManyRequests, err)
+		r.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		s.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case succeeds", func(t *testing.T) {
+		//t.Parallel()
+		var stored *Verification
+		var text string
+
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(nil, cerror.ErrNotFound).
+			Once()
+		r.On("Save", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				stored = args.Get(1).(*Verification)
+			}).
+			Return(nil).
+			Once()
+		s := new(mockSMSSender)
+		s.On("Send", mock.Anything, "+5518999999999", mock.Anything).
+			Run(func(args mock.Arguments) {
+				text = args.String(2)
+			}).
+			Return(nil).
+			Once()
+		// the maximum number of requests is not loaded in the tests.
+		c := new(mockRequestCounter)
+		c.On("Fail", mock.Anything, "verification:phone:+5518999999999", mock.Anything, phoneWindow).
+			Return(0, nil).
+			Once()
+
+		err := NewRequestUseCase(r, c, s).Execute(ctx, "+5518999999999")
+		assert.Nil(t, err)
+		assert.Equal(t, "+5518999999999", stored.ID)
+
+		// the code sent matches the hash stored.
+		code := strings.Fields(text)[4]
+		assert.True(t, stored.Match(strings.TrimSuffix(code, ".")))
+	})
+}
+
+func TestRequestUseCase_ExecuteClientIP(t *testing.T) {
+	//t.Parallel()
+	ctx := context.WithValue(context.Background(), common.ClientIPContextKey, "10.0.0.1")
+
+	t.Run("when use case fails with ErrTooManyRequests", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		c := new(mockRequestCounter)
+		c.On("Fail", mock.Anything, "verification:ip:10.0.0.1", mock.Anything, mock.Anything).
+			Return(1, nil).
+			Once()
+		s := new(mockSMSSender)
+
+		// the maximum number of requests is not loaded in the tests.
+		err := NewRequestUseCase(r, c, s).Execute(ctx, "+5518999999999")
+		assert.Equal(t, ErrTooManyRequests, err)
+		r.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+		s.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		c := new(mockRequestCounter)
+		c.On("Fail", mock.Anything, "verification:ip:10.0.0.1", mock.Anything, mock.Anything).
+			Return(0, errors.New("error")).
+			Once()
+		s := new(mockSMSSender)
+
+		err := NewRequestUseCase(r, c, s).Execute(ctx, "+5518999999999")
+		assert.NotNil(t, err)
+		r.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+}
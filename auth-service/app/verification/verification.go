@@ -0,0 +1,142 @@
+package verification
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"regexp"
+	"time"
+
+	"github.com/tsmweb/auth-service/config"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+// CodeLength is the number of digits of the verification codes.
+const CodeLength = 6
+
+var (
+	ErrPhoneValidateModel = &cerror.ErrValidateModel{Msg: "invalid phone number, use the E.164 format"}
+	ErrInvalidCode        = errors.New("invalid verification code")
+	ErrTooManyAttempts    = errors.New("too many verification attempts")
+	ErrResendTooSoon      = errors.New("verification code recently sent")
+	ErrTooManyRequests    = errors.New("too many verification codes requested")
+)
+
+// phonePattern matches the phone numbers in the E.164 format, such as +5518999999999.
+var phonePattern = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+
+// Verification is a one-time code sent by SMS to prove the ownership of the phone number
+// used as the user ID. Only the HMAC-SHA256 of the code under the server key is stored, a
+// password hash is not needed since the code is short-lived and its attempts are limited.
+type Verification struct {
+	ID        string // phone number
+	CodeHash  string
+	Attempts  int
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// NewVerification creates a verification of the phone number valid for the given duration,
+// returning the data to be stored and the code to be sent to the user.
+func NewVerification(phone string, expire time.Duration) (*Verification, string, error) {
+	if err := ValidatePhone(phone); err != nil {
+		return nil, "", err
+	}
+
+	code, err := randomCode(CodeLength)
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now().UTC()
+	v := &Verification{
+		ID:        phone,
+		CodeHash:  hashCode(phone, code),
+		CreatedAt: now,
+		ExpiresAt: now.Add(expire),
+	}
+	return v, code, nil
+}
+
+// ValidatePhone checks if the phone number is in the E.164 format.
+func ValidatePhone(phone string) error {
+	if !phonePattern.MatchString(phone) {
+		return ErrPhoneValidateModel
+	}
+	return nil
+}
+
+// IsExpired reports whether the code expired at the given time.
+func (v *Verification) IsExpired(now time.Time) bool {
+	return !now.Before(v.ExpiresAt)
+}
+
+// Match reports whether the code is the one sent to the user.
+func (v *Verification) Match(code string) bool {
+	return hmac.Equal([]byte(hashCode(v.ID, code)), []byte(v.CodeHash))
+}
+
+// hashCode returns the hex encoded HMAC-SHA256 of the code sent to the phone number under
+// the server key, so that the codes stored are of no use without the key.
+func hashCode(phone, code string) string {
+	mac := hmac.New(sha256.New, []byte(config.OTPSecret()))
+	mac.Write([]byte(phone))
+	mac.Write([]byte(":"))
+	mac.Write([]byte(code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func randomCode(n int) (string, error) {
+	digits := make([]byte, n)
+	for i := range digits {
+		d, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		digits[i] = byte('0' + d.Int64())
+	}
+	return string(digits), nil
+}
+
+// SMSSender delivers text messages to phone numbers.
+type SMSSender interface {
+	Send(ctx context.Context, phone, text string) error
+}
+
+// The SMSSenderFunc type is an adapter to allow the use of ordinary functions as SMSSender.
+// If f is a function with the appropriate signature, SMSSenderFunc(f) is a SMSSender that
+// calls f.
+type SMSSenderFunc func(ctx context.Context, phone, text string) error
+
+// Send calls f(ctx, phone, text).
+func (f SMSSenderFunc) Send(ctx context.Context, phone, text string) error {
+	return f(ctx, phone, text)
+}
+
+// RequestCounter counts the verification codes requested by each client IP and sent to each
+// phone number, shared by the replicas of auth-service. It is implemented by the login.AttemptRepository.
+type RequestCounter interface {
+	// Fail records a request of the key at the given time, returning the requests of the
+	// key. The requests are forgotten after ttl without requests.
+	Fail(ctx context.Context, key string, at time.Time, ttl time.Duration) (int, error)
+}
+
+// Repository interface for verification data source.
+type Repository interface {
+	// Get returns the verification of the phone number, or cerror.ErrNotFound.
+	Get(ctx context.Context, ID string) (*Verification, error)
+	// Save stores the verification, replacing the previous one of the phone number. The
+	// attempts of the previous one are kept unless it expired, so that a resend does not
+	// allow more attempts.
+	Save(ctx context.Context, v *Verification) error
+	// Attempt counts an attempt to verify the code, returning false if the limit of
+	// attempts was already reached.
+	Attempt(ctx context.Context, ID string, maxAttempts int) (bool, error)
+	// Delete removes the verification of the phone number if its code is still the one of
+	// the hash, returning false if it was replaced or already removed.
+	Delete(ctx context.Context, ID, codeHash string) (bool, error)
+}
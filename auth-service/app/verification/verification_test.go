@@ -0,0 +1,47 @@
+package verification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePhone(t *testing.T) {
+	//t.Parallel()
+	assert.Nil(t, ValidatePhone("+5518999999999"))
+	assert.Nil(t, ValidatePhone("+14155552671"))
+
+	assert.Equal(t, ErrPhoneValidateModel, ValidatePhone(""))
+	assert.Equal(t, ErrPhoneValidateModel, ValidatePhone("5518999999999"))
+	assert.Equal(t, ErrPhoneValidateModel, ValidatePhone("+0518999999999"))
+	assert.Equal(t, ErrPhoneValidateModel, ValidatePhone("+55 18 99999-9999"))
+	assert.Equal(t, ErrPhoneValidateModel, ValidatePhone("+5518999999999999"))
+}
+
+func TestNewVerification(t *testing.T) {
+	//t.Parallel()
+
+	t.Run("when phone is invalid", func(t *testing.T) {
+		//t.Parallel()
+		_, _, err := NewVerification("18999999999", time.Minute)
+		assert.Equal(t, ErrPhoneValidateModel, err)
+	})
+
+	t.Run("when verification is created", func(t *testing.T) {
+		//t.Parallel()
+		v, code, err := NewVerification("+5518999999999", time.Minute)
+		assert.Nil(t, err)
+		assert.Len(t, code, CodeLength)
+		assert.NotContains(t, v.CodeHash, code)
+		assert.Equal(t, "+5518999999999", v.ID)
+		assert.False(t, v.IsExpired(time.Now()))
+		assert.True(t, v.IsExpired(time.Now().Add(time.Minute)))
+
+		assert.True(t, v.Match(code))
+		assert.False(t, v.Match("abcdef"))
+
+		v.ID = "+5518988888888" // the code is bound to the phone number
+		assert.False(t, v.Match(code))
+	})
+}
@@ -0,0 +1,72 @@
+package verification
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/tsmweb/auth-service/config"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+// Verifier checks the verification codes sent to the phone numbers.
+type Verifier interface {
+	// Verify returns nil if the code is the one sent to the phone number, consuming it.
+	Verify(ctx context.Context, phone, code string) error
+}
+
+type verifier struct {
+	repository Repository
+}
+
+// NewVerifier create a new instance of Verifier.
+func NewVerifier(repository Repository) Verifier {
+	return &verifier{
+		repository: repository,
+	}
+}
+
+// Verify counts the attempt before comparing the code, so that the codes cannot be guessed
+// beyond the limit of attempts even by concurrent requests.
+func (v *verifier) Verify(ctx context.Context, phone, code string) error {
+	code = strings.TrimSpace(code)
+	if len(code) != CodeLength {
+		return ErrInvalidCode
+	}
+
+	vrf, err := v.repository.Get(ctx, phone)
+	if err != nil {
+		if errors.Is(err, cerror.ErrNotFound) {
+			return ErrInvalidCode
+		}
+		return err
+	}
+	if vrf.IsExpired(time.Now()) {
+		return ErrInvalidCode
+	}
+
+	ok, err := v.repository.Attempt(ctx, phone, config.OTPMaxAttempts())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrTooManyAttempts
+	}
+
+	if !vrf.Match(code) {
+		return ErrInvalidCode
+	}
+
+	// the code is consumed only if it was not replaced by a resend or consumed by a
+	// concurrent request in the meantime.
+	deleted, err := v.repository.Delete(ctx, phone, vrf.CodeHash)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrInvalidCode
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package verification
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+func TestVerifier_Verify(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+
+	v, code, err := NewVerification("+5518999999999", time.Hour)
+	assert.Nil(t, err)
+
+	wrongCode := "000000"
+	if code == wrongCode {
+		wrongCode = "111111"
+	}
+
+	t.Run("when code is invalid", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "+5518977777777").
+			Return(nil, cerror.ErrNotFound).
+			Once()
+		vf := NewVerifier(r)
+
+		assert.Equal(t, ErrInvalidCode, vf.Verify(ctx, "+5518999999999", ""))
+		assert.Equal(t, ErrInvalidCode, vf.Verify(ctx, "+5518999999999", "12345"))
+		assert.Equal(t, ErrInvalidCode, vf.Verify(ctx, "+5518977777777", code))
+
+		expired := *v
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(&expired, nil).
+			Once()
+		assert.Equal(t, ErrInvalidCode, vf.Verify(ctx, "+5518999999999", code))
+
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(v, nil).
+			Once()
+		r.On("Attempt", mock.Anything, "+5518999999999", mock.Anything).
+			Return(true, nil).
+			Once()
+		assert.Equal(t, ErrInvalidCode, vf.Verify(ctx, "+5518999999999", wrongCode))
+		r.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when attempts are exhausted", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(v, nil).
+			Once()
+		r.On("Attempt", mock.Anything, "+5518999999999", mock.Anything).
+			Return(false, nil).
+			Once()
+
+		err := NewVerifier(r).Verify(ctx, "+5518999999999", code)
+		assert.Equal(t, ErrTooManyAttempts, err)
+		r.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when repository fails", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(nil, errors.New("error")).
+			Once()
+		vf := NewVerifier(r)
+
+		assert.NotNil(t, vf.Verify(ctx, "+5518999999999", code))
+
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(v, nil).
+			Once()
+		r.On("Attempt", mock.Anything, "+5518999999999", mock.Anything).
+			Return(false, errors.New("error")).
+			Once()
+		assert.NotNil(t, vf.Verify(ctx, "+5518999999999", code))
+	})
+
+	t.Run("when code is valid", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(v, nil).
+			Once()
+		r.On("Attempt", mock.Anything, "+5518999999999", mock.Anything).
+			Return(true, nil).
+			Once()
+		r.On("Delete", mock.Anything, "+5518999999999", v.CodeHash).
+			Return(true, nil).
+			Once()
+
+		assert.Nil(t, NewVerifier(r).Verify(ctx, "+5518999999999", code))
+		r.AssertExpectations(t)
+	})
+
+	t.Run("when code was replaced", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "+5518999999999").
+			Return(v, nil).
+			Once()
+		r.On("Attempt", mock.Anything, "+5518999999999", mock.Anything).
+			Return(true, nil).
+			Once()
+		r.On("Delete", mock.Anything, "+5518999999999", v.CodeHash).
+			Return(false, nil).
+			Once()
+
+		err := NewVerifier(r).Verify(ctx, "+5518999999999", code)
+		assert.Equal(t, ErrInvalidCode, err)
+	})
+}
@@ -2,25 +2,43 @@ package main
 
 import (
 	"context"
+	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/tsmweb/auth-service/adapter"
+	"github.com/tsmweb/auth-service/app/account"
+	"github.com/tsmweb/auth-service/app/client"
 	"github.com/tsmweb/auth-service/app/login"
+	"github.com/tsmweb/auth-service/app/reset"
+	"github.com/tsmweb/auth-service/app/session"
+	"github.com/tsmweb/auth-service/app/token"
+	"github.com/tsmweb/auth-service/app/twofactor"
 	"github.com/tsmweb/auth-service/app/user"
+	"github.com/tsmweb/auth-service/app/verification"
 	"github.com/tsmweb/auth-service/config"
 	"github.com/tsmweb/auth-service/infra/db"
 	"github.com/tsmweb/auth-service/infra/repository"
+	"github.com/tsmweb/auth-service/infra/sms"
+	"github.com/tsmweb/auth-service/pkg/jwks"
 	"github.com/tsmweb/auth-service/web/api/handler"
+	"github.com/tsmweb/chat-server/pkg/revocation"
 	"github.com/tsmweb/go-helper-api/auth"
 	"github.com/tsmweb/go-helper-api/kafka"
 	"github.com/tsmweb/go-helper-api/middleware"
 )
 
 type Provider struct {
-	ctx      context.Context
-	jwt      auth.JWT
-	mAuth    middleware.Auth
-	dataBase db.Database
-	kafka    kafka.Kafka
+	ctx                 context.Context
+	jwt                 auth.JWT
+	keyring             *jwks.Keyring
+	issuer              token.Issuer
+	twoFactorRepository twofactor.Repository
+	attemptRepository   login.AttemptRepository
+	throttle            login.Throttle
+	mAuth               middleware.Auth
+	revocationStore     revocation.Store
+	dataBase            db.Database
+	kafka               kafka.Kafka
 }
 
 func CreateProvider(ctx context.Context) *Provider {
@@ -30,10 +48,25 @@ func CreateProvider(ctx context.Context) *Provider {
 }
 
 func (p *Provider) UserRouter(mr *mux.Router) {
+	verificationRepository := repository.NewVerificationRepositoryPostgres(p.DatabaseProvider())
+	loginRepository := repository.NewLoginRepositoryPostgres(p.DatabaseProvider())
 	repository := repository.NewUserRepositoryPostgres(p.DatabaseProvider())
+	accountEncoder := account.EventEncoderFunc(adapter.AccountEventMarshal)
+	accountProducer := p.NewKafkaProducer(config.KafkaAccountEventTopic())
+
 	getUseCase := user.NewGetUseCase(repository)
-	createUseCase := user.NewCreateUseCase(repository)
-	updateUseCase := user.NewUpdateUseCase(repository)
+	createUseCase := user.NewCreateUseCase(repository, verification.NewVerifier(verificationRepository),
+		accountEncoder, accountProducer)
+	updateUseCase := user.NewUpdateUseCase(repository, accountEncoder, accountProducer)
+	deleteUseCase := user.NewDeleteUseCase(
+		repository,
+		loginRepository,
+		p.ThrottleProvider(),
+		p.RevocationProvider(),
+		login.TokenRevocationEncoderFunc(adapter.TokenRevocationMarshal),
+		p.NewKafkaProducer(config.KafkaTokensTopic()),
+		accountEncoder,
+		accountProducer)
 
 	handler.MakeUserHandlers(
 		mr,
@@ -41,40 +74,267 @@ func (p *Provider) UserRouter(mr *mux.Router) {
 		p.AuthProvider(),
 		getUseCase,
 		createUseCase,
-		updateUseCase)
+		updateUseCase,
+		deleteUseCase)
 }
 
 func (p *Provider) LoginRouter(mr *mux.Router) {
+	tokenRepository := repository.NewRefreshTokenRepositoryPostgres(p.DatabaseProvider())
 	repository := repository.NewLoginRepositoryPostgres(p.DatabaseProvider())
-	loginUseCase := login.NewLoginUseCase(repository, p.JwtProvider())
-	updateUseCase := login.NewUpdateUseCase(repository)
+	revocationEncoder := login.TokenRevocationEncoderFunc(adapter.TokenRevocationMarshal)
+	tokenProducer := p.NewKafkaProducer(config.KafkaTokensTopic())
+	revocationStore := p.RevocationProvider()
+
+	loginUseCase := login.NewLoginUseCase(repository, p.IssuerProvider(),
+		twofactor.NewChallenger(p.TwoFactorRepositoryProvider()), p.ThrottleProvider())
+	updateUseCase := login.NewUpdateUseCase(repository, revocationStore, tokenRepository,
+		revocationEncoder, tokenProducer, account.EventEncoderFunc(adapter.AccountEventMarshal),
+		p.NewKafkaProducer(config.KafkaAccountEventTopic()))
+	logoutUseCase := login.NewLogoutUseCase(tokenRepository, revocationStore, revocationEncoder,
+		tokenProducer)
 
 	handler.MakeLoginHandlers(
 		mr,
 		p.JwtProvider(),
 		p.AuthProvider(),
 		loginUseCase,
-		updateUseCase)
+		updateUseCase,
+		logoutUseCase)
+}
+
+func (p *Provider) ClientRouter(mr *mux.Router) {
+	repository := repository.NewClientRepositoryPostgres(p.DatabaseProvider())
+	createUseCase := client.NewCreateUseCase(repository)
+	getAllUseCase := client.NewGetAllUseCase(repository)
+	revokeUseCase := client.NewRevokeUseCase(repository, p.RevocationProvider(),
+		login.TokenRevocationEncoderFunc(adapter.TokenRevocationMarshal),
+		p.NewKafkaProducer(config.KafkaTokensTopic()))
+	tokenUseCase := client.NewTokenUseCase(repository, p.JwtProvider())
+	setWebhookUseCase := client.NewSetWebhookUseCase(repository)
+	deleteWebhookUseCase := client.NewDeleteWebhookUseCase(repository)
+
+	handler.MakeClientHandlers(
+		mr,
+		p.JwtProvider(),
+		p.AuthProvider(),
+		createUseCase,
+		getAllUseCase,
+		revokeUseCase,
+		tokenUseCase,
+		setWebhookUseCase,
+		deleteWebhookUseCase)
+}
+
+func (p *Provider) SessionRouter(mr *mux.Router) {
+	repository := repository.NewSessionRepositoryPostgres(p.DatabaseProvider())
+	listUseCase := session.NewListUseCase(repository)
+	revokeSessionUseCase := login.NewRevokeSessionUseCase(repository, p.RevocationProvider(),
+		login.TokenRevocationEncoderFunc(adapter.TokenRevocationMarshal),
+		p.NewKafkaProducer(config.KafkaTokensTopic()))
+
+	handler.MakeSessionHandlers(
+		mr,
+		p.JwtProvider(),
+		p.AuthProvider(),
+		listUseCase,
+		revokeSessionUseCase)
+}
+
+func (p *Provider) TokenRouter(mr *mux.Router) {
+	sessionRepository := repository.NewSessionRepositoryPostgres(p.DatabaseProvider())
+	repository := repository.NewRefreshTokenRepositoryPostgres(p.DatabaseProvider())
+	revokeSessionUseCase := login.NewRevokeSessionUseCase(sessionRepository,
+		p.RevocationProvider(), login.TokenRevocationEncoderFunc(adapter.TokenRevocationMarshal),
+		p.NewKafkaProducer(config.KafkaTokensTopic()))
+	refreshUseCase := token.NewRefreshUseCase(repository, p.IssuerProvider(),
+		revokeSessionUseCase)
+
+	handler.MakeTokenHandlers(
+		mr,
+		refreshUseCase)
+}
+
+func (p *Provider) TwoFactorRouter(mr *mux.Router) {
+	repository := p.TwoFactorRepositoryProvider()
+	enrolUseCase := twofactor.NewEnrolUseCase(repository)
+	confirmUseCase := twofactor.NewConfirmUseCase(repository)
+	disableUseCase := twofactor.NewDisableUseCase(repository)
+	verifyUseCase := twofactor.NewVerifyUseCase(repository, p.IssuerProvider(),
+		p.AttemptRepositoryProvider(),
+		twofactor.Lockout{
+			MaxAttempts: config.TOTPUserMaxAttempts(),
+			Duration:    time.Duration(config.TOTPUserLockout()) * time.Minute,
+		})
+
+	handler.MakeTwoFactorHandlers(
+		mr,
+		p.JwtProvider(),
+		p.AuthProvider(),
+		enrolUseCase,
+		confirmUseCase,
+		disableUseCase,
+		verifyUseCase)
+}
+
+func (p *Provider) VerificationRouter(mr *mux.Router) {
+	repository := repository.NewVerificationRepositoryPostgres(p.DatabaseProvider())
+	requestUseCase := verification.NewRequestUseCase(repository, p.AttemptRepositoryProvider(),
+		p.SMSSenderProvider())
+
+	handler.MakeVerificationHandlers(
+		mr,
+		requestUseCase)
+}
+
+func (p *Provider) ResetRouter(mr *mux.Router) {
+	loginRepository := repository.NewLoginRepositoryPostgres(p.DatabaseProvider())
+	tokenRepository := repository.NewRefreshTokenRepositoryPostgres(p.DatabaseProvider())
+	repository := repository.NewResetRepositoryPostgres(p.DatabaseProvider())
+	revocationEncoder := login.TokenRevocationEncoderFunc(adapter.TokenRevocationMarshal)
+	notifier := reset.NotifierFunc(p.SMSSenderProvider().Send)
+
+	requestUseCase := reset.NewRequestUseCase(repository, loginRepository, notifier)
+	confirmUseCase := reset.NewConfirmUseCase(repository, loginRepository, tokenRepository,
+		p.RevocationProvider(), revocationEncoder, p.NewKafkaProducer(config.KafkaTokensTopic()),
+		account.EventEncoderFunc(adapter.AccountEventMarshal),
+		p.NewKafkaProducer(config.KafkaAccountEventTopic()))
+
+	handler.MakeResetHandlers(
+		mr,
+		requestUseCase,
+		confirmUseCase)
+}
+
+func (p *Provider) AdminRouter(mr *mux.Router) {
+	unlockUseCase := login.NewUnlockUseCase(p.ThrottleProvider())
+	deletionRepository := repository.NewDeletionRepositoryPostgres(p.DatabaseProvider())
+	getDeletionUseCase := user.NewGetDeletionUseCase(deletionRepository)
+	republishDeletionUseCase := user.NewRepublishDeletionUseCase(
+		deletionRepository,
+		p.RevocationProvider(),
+		login.TokenRevocationEncoderFunc(adapter.TokenRevocationMarshal),
+		p.NewKafkaProducer(config.KafkaTokensTopic()),
+		account.EventEncoderFunc(adapter.AccountEventMarshal),
+		p.NewKafkaProducer(config.KafkaAccountEventTopic()))
+
+	handler.MakeAdminHandlers(
+		mr,
+		p.JwtProvider(),
+		p.AuthProvider(),
+		unlockUseCase,
+		getDeletionUseCase,
+		republishDeletionUseCase)
+}
+
+func (p *Provider) ReportConsumerProvider() *user.ReportConsumer {
+	reportUseCase := user.NewReportUseCase(
+		repository.NewUserRepositoryPostgres(p.DatabaseProvider()),
+		repository.NewDeletionRepositoryPostgres(p.DatabaseProvider()))
+
+	return user.NewReportConsumer(
+		p.KafkaProvider().NewConsumer(config.KafkaGroupID(), config.KafkaDeletionReportTopic()),
+		user.DeletionReportDecoderFunc(adapter.DeletionReportUnmarshal),
+		reportUseCase)
+}
+
+func (p *Provider) SMSSenderProvider() verification.SMSSender {
+	if config.SMSSender() == "file" {
+		return sms.NewFileSender(config.SMSFile())
+	}
+	return sms.NewLogSender()
+}
+
+func (p *Provider) TwoFactorRepositoryProvider() twofactor.Repository {
+	if p.twoFactorRepository == nil {
+		p.twoFactorRepository = repository.NewTwoFactorRepositoryPostgres(p.DatabaseProvider())
+	}
+	return p.twoFactorRepository
+}
+
+func (p *Provider) AttemptRepositoryProvider() login.AttemptRepository {
+	if p.attemptRepository == nil {
+		p.attemptRepository = repository.NewLoginAttemptRepositoryRedis(config.RedisHost(),
+			config.RedisPassword())
+	}
+	return p.attemptRepository
+}
+
+func (p *Provider) ThrottleProvider() login.Throttle {
+	if p.throttle == nil {
+		lockout := time.Duration(config.LoginLockout()) * time.Minute
+		delay := time.Duration(config.LoginDelay()) * time.Second
+
+		p.throttle = login.NewThrottle(p.AttemptRepositoryProvider(),
+			login.Policy{
+				FreeAttempts: config.LoginFreeAttempts(),
+				Delay:        delay,
+				MaxAttempts:  config.LoginMaxAttempts(),
+				Lockout:      lockout,
+			},
+			login.Policy{
+				FreeAttempts: config.LoginIPFreeAttempts(),
+				Delay:        delay,
+				MaxAttempts:  config.LoginIPMaxAttempts(),
+				Lockout:      lockout,
+			})
+	}
+	return p.throttle
+}
+
+func (p *Provider) IssuerProvider() token.Issuer {
+	if p.issuer == nil {
+		sessionRepository := repository.NewSessionRepositoryPostgres(p.DatabaseProvider())
+		repository := repository.NewRefreshTokenRepositoryPostgres(p.DatabaseProvider())
+		p.issuer = token.NewIssuer(repository, sessionRepository, p.JwtProvider())
+	}
+	return p.issuer
 }
 
 func (p *Provider) NewKafkaProducer(topic string) kafka.Producer {
 	return p.KafkaProvider().NewProducer(topic)
 }
 
+func (p *Provider) JWKSRouter(mr *mux.Router) {
+	handler.MakeJWKSHandlers(
+		mr,
+		p.keyring,
+		time.Duration(config.JWKSMaxAge())*time.Second)
+}
+
+// LoadKeyring loads the keys that sign the access tokens, from SIGNING_KEYS_DIR when informed
+// or else the single key file of the service.
+func (p *Provider) LoadKeyring() error {
+	var err error
+	if config.SigningKeysDir() != "" {
+		p.keyring, err = jwks.LoadKeyring(config.SigningKeysDir(), config.SigningKeyID())
+	} else {
+		p.keyring, err = jwks.LoadKeyFile(config.KeySecureFile())
+	}
+	return err
+}
+
 func (p *Provider) JwtProvider() auth.JWT {
 	if p.jwt == nil {
-		p.jwt = auth.NewJWT(config.KeySecureFile(), config.PubSecureFile())
+		p.jwt = jwks.NewJWT(p.keyring)
 	}
 	return p.jwt
 }
 
 func (p *Provider) AuthProvider() middleware.Auth {
 	if p.mAuth == nil {
-		p.mAuth = middleware.NewAuth(p.JwtProvider())
+		mAuth := middleware.NewAuth(p.JwtProvider())
+		p.mAuth = revocation.NewAuth(mAuth, p.JwtProvider(), p.RevocationProvider())
 	}
 	return p.mAuth
 }
 
+func (p *Provider) RevocationProvider() revocation.Store {
+	if p.revocationStore == nil {
+		p.revocationStore = revocation.NewRedisStore(config.RedisHost(), config.RedisPassword())
+	}
+	return p.revocationStore
+}
+
 func (p *Provider) DatabaseProvider() db.Database {
 	if p.dataBase == nil {
 		p.dataBase = db.NewPostgresDatabase()
@@ -7,6 +7,7 @@ import (
 	"os"
 
 	"github.com/gorilla/mux"
+	"github.com/tsmweb/auth-service/common/password"
 	"github.com/tsmweb/auth-service/config"
 	"github.com/tsmweb/go-helper-api/middleware"
 	"github.com/tsmweb/go-helper-api/observability/event"
@@ -22,8 +23,20 @@ func main() {
 		panic(err)
 	}
 
+	// Cost of the password hashes.
+	params := password.DefaultParams
+	params.Memory = uint32(config.PasswordMemory())
+	params.Iterations = uint32(config.PasswordIterations())
+	params.Threads = uint8(config.PasswordThreads())
+	password.SetParams(params)
+
 	provider := CreateProvider(context.Background())
 
+	// Loads the keys that sign the access tokens.
+	if err := provider.LoadKeyring(); err != nil {
+		log.Fatalf("[ERROR] Could not load the signing keys. Error: %s\n", err.Error())
+	}
+
 	// Initializes the service's event producer.
 	producerEvents := provider.NewKafkaProducer(config.KafkaEventsTopic())
 	if err := event.Init(producerEvents); err != nil {
@@ -31,10 +44,21 @@ func main() {
 	}
 	defer event.Close()
 
+	// Follows the deletion of the accounts reported by the services.
+	go provider.ReportConsumerProvider().Start(provider.ctx)
+
 	// Configure the routes.
 	router := mux.NewRouter()
 	provider.UserRouter(router)
 	provider.LoginRouter(router)
+	provider.SessionRouter(router)
+	provider.TokenRouter(router)
+	provider.TwoFactorRouter(router)
+	provider.VerificationRouter(router)
+	provider.ResetRouter(router)
+	provider.AdminRouter(router)
+	provider.ClientRouter(router)
+	provider.JWKSRouter(router)
 
 	handler := middleware.GZIP(router)
 	handler = middleware.CORS(handler)
@@ -3,5 +3,7 @@ package common
 type ContextKey string
 
 const (
-	AuthContextKey = ContextKey("ID")
+	AuthContextKey     = ContextKey("ID")
+	ClientIPContextKey = ContextKey("ClientIP")
+	DeviceContextKey   = ContextKey("Device")
 )
@@ -32,7 +32,7 @@ func (m *MockJWT) ExtractToken(r *http.Request) (string, error) {
 func (m *MockJWT) GetDataToken(r *http.Request, key string) (interface{}, error) {
 	args := m.Called(r, key)
 	if args.Get(0) == nil {
-		return "", args.Error(1)
+		return nil, args.Error(1)
 	}
 	return args.Get(0).(string), nil
 }
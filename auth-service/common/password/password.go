@@ -0,0 +1,182 @@
+// Package password hashes and verifies the users' passwords.
+//
+// Passwords are hashed with argon2id and encoded with their parameters in the format
+// "$argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>", so that the cost can be raised without
+// invalidating the hashes already stored. Hashes in the legacy format, an unsalted SHA-256
+// in hexadecimal, are still verified and reported as needing a rehash.
+package password
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+)
+
+var (
+	ErrInvalidHash         = errors.New("invalid password hash")
+	ErrIncompatibleVersion = errors.New("incompatible argon2 version")
+)
+
+// Params are the cost parameters of argon2id.
+type Params struct {
+	Memory     uint32 // KiB
+	Iterations uint32
+	Threads    uint8
+	SaltLength uint32
+	KeyLength  uint32
+}
+
+// DefaultParams follow the recommendations of RFC 9106 for memory constrained environments.
+var DefaultParams = Params{
+	Memory:     64 * 1024,
+	Iterations: 3,
+	Threads:    2,
+	SaltLength: 16,
+	KeyLength:  32,
+}
+
+var (
+	mu     sync.RWMutex
+	params = DefaultParams
+
+	// dummyHash is verified when the user does not exist, so that the response time
+	// does not reveal whether the user exists.
+	dummyHash string
+
+	// slots limits the hashes computed at the same time, each one allocates Params.Memory,
+	// to the number of CPUs that can run them, the next ones wait for a free slot.
+	slots = make(chan struct{}, runtime.GOMAXPROCS(0))
+)
+
+// SetParams sets the cost parameters of the new hashes.
+func SetParams(p Params) {
+	mu.Lock()
+	params = p
+	dummyHash = ""
+	mu.Unlock()
+}
+
+func currentParams() Params {
+	mu.RLock()
+	defer mu.RUnlock()
+	return params
+}
+
+// Hash returns the encoded argon2id hash of the password with a random salt.
+func Hash(password string) (string, error) {
+	p := currentParams()
+
+	salt := make([]byte, p.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := idKey(password, salt, p)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, p.Memory, p.Iterations, p.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify reports whether the password matches the encoded hash, comparing them in
+// constant time. Rehash reports whether the hash should be replaced by a new one, as it
+// uses the legacy format or parameters different from the current ones.
+func Verify(password, encoded string) (ok bool, rehash bool, err error) {
+	if isLegacy(encoded) {
+		sum := sha256.Sum256([]byte(password))
+		hash := hex.EncodeToString(sum[:])
+		ok = subtle.ConstantTimeCompare([]byte(hash), []byte(strings.ToLower(encoded))) == 1
+		return ok, true, nil
+	}
+
+	p, salt, key, err := decode(encoded)
+	if err != nil {
+		return false, false, err
+	}
+
+	other := idKey(password, salt, p)
+	ok = subtle.ConstantTimeCompare(key, other) == 1
+
+	current := currentParams()
+	rehash = p.Memory != current.Memory || p.Iterations != current.Iterations ||
+		p.Threads != current.Threads || p.KeyLength != current.KeyLength ||
+		p.SaltLength != current.SaltLength
+
+	return ok, rehash, nil
+}
+
+// VerifyDummy spends the same time as Verify with the current parameters, to be called
+// when there is no hash to compare the password with.
+func VerifyDummy(password string) {
+	mu.RLock()
+	hash := dummyHash
+	mu.RUnlock()
+
+	if hash == "" {
+		hash, _ = Hash("dummy-password")
+		mu.Lock()
+		dummyHash = hash
+		mu.Unlock()
+	}
+
+	Verify(password, hash)
+}
+
+// idKey derives the argon2id key of the password once a slot is free.
+func idKey(password string, salt []byte, p Params) []byte {
+	slots <- struct{}{}
+	defer func() { <-slots }()
+
+	return argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Threads, p.KeyLength)
+}
+
+// isLegacy reports whether the hash is an unsalted SHA-256 in hexadecimal.
+func isLegacy(encoded string) bool {
+	if len(encoded) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(encoded)
+	return err == nil
+}
+
+func decode(encoded string) (p Params, salt, key []byte, err error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return p, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err = fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return p, nil, nil, ErrInvalidHash
+	}
+	if version != argon2.Version {
+		return p, nil, nil, ErrIncompatibleVersion
+	}
+
+	if _, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d",
+		&p.Memory, &p.Iterations, &p.Threads); err != nil {
+		return p, nil, nil, ErrInvalidHash
+	}
+
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return p, nil, nil, ErrInvalidHash
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return p, nil, nil, ErrInvalidHash
+	}
+	p.SaltLength = uint32(len(salt))
+	p.KeyLength = uint32(len(key))
+
+	return p, salt, key, nil
+}
@@ -0,0 +1,113 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsmweb/go-helper-api/util/hashutil"
+)
+
+func TestHash(t *testing.T) {
+	//t.Parallel()
+	SetParams(Params{Memory: 1024, Iterations: 1, Threads: 1, SaltLength: 16, KeyLength: 32})
+	defer SetParams(DefaultParams)
+
+	hash, err := Hash("123456")
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+	other, err := Hash("123456")
+	assert.Nil(t, err)
+	assert.NotEqual(t, hash, other, "hashes must be salted")
+}
+
+func TestVerify(t *testing.T) {
+	//t.Parallel()
+	SetParams(Params{Memory: 1024, Iterations: 1, Threads: 1, SaltLength: 16, KeyLength: 32})
+	defer SetParams(DefaultParams)
+
+	t.Run("when hash is argon2id", func(t *testing.T) {
+		//t.Parallel()
+		hash, _ := Hash("123456")
+
+		ok, rehash, err := Verify("123456", hash)
+		assert.Nil(t, err)
+		assert.True(t, ok)
+		assert.False(t, rehash)
+
+		ok, _, err = Verify("654321", hash)
+		assert.Nil(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("when hash has old parameters", func(t *testing.T) {
+		//t.Parallel()
+		hash, _ := Hash("123456")
+		SetParams(Params{Memory: 2048, Iterations: 1, Threads: 1, SaltLength: 16, KeyLength: 32})
+		defer SetParams(Params{Memory: 1024, Iterations: 1, Threads: 1, SaltLength: 16, KeyLength: 32})
+
+		ok, rehash, err := Verify("123456", hash)
+		assert.Nil(t, err)
+		assert.True(t, ok)
+		assert.True(t, rehash)
+	})
+
+	t.Run("when hash is legacy SHA-256", func(t *testing.T) {
+		//t.Parallel()
+		hash, _ := hashutil.HashSHA256("123456")
+
+		ok, rehash, err := Verify("123456", hash)
+		assert.Nil(t, err)
+		assert.True(t, ok)
+		assert.True(t, rehash)
+
+		ok, _, err = Verify("654321", hash)
+		assert.Nil(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("when hash is invalid", func(t *testing.T) {
+		//t.Parallel()
+		tests := []struct {
+			hash string
+			want error
+		}{
+			{"", ErrInvalidHash},
+			{"123456", ErrInvalidHash},
+			{"$argon2i$v=19$m=1024,t=1,p=1$c2FsdA$a2V5", ErrInvalidHash},
+			{"$argon2id$v=16$m=1024,t=1,p=1$c2FsdA$a2V5", ErrIncompatibleVersion},
+			{"$argon2id$v=19$m=x,t=1,p=1$c2FsdA$a2V5", ErrInvalidHash},
+			{"$argon2id$v=19$m=1024,t=1,p=1$!!$a2V5", ErrInvalidHash},
+		}
+
+		for _, tc := range tests {
+			ok, _, err := Verify("123456", tc.hash)
+			assert.False(t, ok)
+			assert.Equal(t, tc.want, err, tc.hash)
+		}
+	})
+}
+
+func TestVerify_Concurrent(t *testing.T) {
+	//t.Parallel()
+	SetParams(Params{Memory: 1024, Iterations: 1, Threads: 1, SaltLength: 16, KeyLength: 32})
+	defer SetParams(DefaultParams)
+
+	hash, err := Hash("123456")
+	assert.Nil(t, err)
+
+	// more calls than slots wait for a free one instead of failing.
+	n := 4 * cap(slots)
+	results := make(chan bool, n)
+	for i := 0; i < n; i++ {
+		go func() {
+			ok, _, _ := Verify("123456", hash)
+			results <- ok
+		}()
+	}
+	for i := 0; i < n; i++ {
+		assert.True(t, <-results)
+	}
+	assert.Equal(t, 0, len(slots))
+}
@@ -0,0 +1,99 @@
+// Package totp generates and validates the time-based one-time passwords of RFC 6238,
+// with the parameters supported by the authenticator apps: HMAC-SHA1, 6 digits and a
+// period of 30 seconds.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	Digits = 6
+	Period = 30 // seconds
+
+	secretSize = 20 // bytes, the size of the HMAC-SHA1 output recommended by RFC 4226
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a random secret encoded in base32.
+func GenerateSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(b), nil
+}
+
+// URI returns the otpauth URI of the secret, shown as a QR code to be scanned by the
+// authenticator apps.
+func URI(issuer, account, secret string) string {
+	label := url.PathEscape(issuer + ":" + account)
+	v := url.Values{}
+	v.Set("secret", secret)
+	v.Set("issuer", issuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprint(Digits))
+	v.Set("period", fmt.Sprint(Period))
+	return "otpauth://totp/" + label + "?" + v.Encode()
+}
+
+// Step returns the time step of t.
+func Step(t time.Time) int64 {
+	return t.Unix() / Period
+}
+
+// Code returns the code of the secret at the time step.
+func Code(secret string, step int64) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// dynamic truncation of RFC 4226.
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", Digits, value%mod), nil
+}
+
+// Validate checks the code against the secret at the time t, accepting the codes of skew
+// steps before and after to tolerate clock drift. It returns the time step matched, which
+// must be recorded so that the code is not accepted again.
+func Validate(secret, code string, t time.Time, skew int) (int64, bool, error) {
+	if len(code) != Digits {
+		return 0, false, nil
+	}
+
+	now := Step(t)
+	for i := -skew; i <= skew; i++ {
+		expected, err := Code(secret, now+int64(i))
+		if err != nil {
+			return 0, false, err
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return now + int64(i), true, nil
+		}
+	}
+	return 0, false, nil
+}
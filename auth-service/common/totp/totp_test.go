@@ -0,0 +1,81 @@
+package totp
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// secret of the test vectors of RFC 6238, "12345678901234567890" in base32.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	//t.Parallel()
+	vectors := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+
+	for unix, expected := range vectors {
+		code, err := Code(rfcSecret, Step(time.Unix(unix, 0)))
+		assert.Nil(t, err)
+		assert.Equal(t, expected, code)
+	}
+
+	_, err := Code("not base32!", 1)
+	assert.NotNil(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	//t.Parallel()
+	now := time.Unix(1111111109, 0)
+
+	step, ok, err := Validate(rfcSecret, "081804", now, 1)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, Step(now), step)
+
+	// the code of the previous step is accepted within the skew.
+	step, ok, _ = Validate(rfcSecret, "081804", now.Add(Period*time.Second), 1)
+	assert.True(t, ok)
+	assert.Equal(t, Step(now), step)
+
+	_, ok, _ = Validate(rfcSecret, "081804", now.Add(2*Period*time.Second), 1)
+	assert.False(t, ok)
+
+	_, ok, _ = Validate(rfcSecret, "000000", now, 1)
+	assert.False(t, ok)
+
+	_, ok, _ = Validate(rfcSecret, "81804", now, 1)
+	assert.False(t, ok)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	//t.Parallel()
+	secret, err := GenerateSecret()
+	assert.Nil(t, err)
+	assert.Len(t, secret, 32)
+
+	other, _ := GenerateSecret()
+	assert.NotEqual(t, secret, other)
+
+	_, err = Code(secret, 1)
+	assert.Nil(t, err)
+}
+
+func TestURI(t *testing.T) {
+	//t.Parallel()
+	uri := URI("Chat", "+5518999999999", rfcSecret)
+
+	u, err := url.Parse(uri)
+	assert.Nil(t, err)
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/Chat:+5518999999999", u.Path)
+	assert.Equal(t, rfcSecret, u.Query().Get("secret"))
+	assert.Equal(t, "Chat", u.Query().Get("issuer"))
+}
@@ -1,30 +1,68 @@
 package config
 
 import (
+	"errors"
 	"log"
 	"os"
 	"path"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
 var (
-	hostID                string
-	serverPort            int
-	dbHost                string
-	dbPort                int
-	dbUser                string
-	dbPassword            string
-	dbName                string
-	dbSchema              string
-	keySecureFile         string
-	pubSecureFile         string
-	certSecureFile        string
-	expireToken           int
-	kafkaBootstrapServers string
-	kafkaClientID         string
-	kafkaEventsTopic      string
+	hostID                   string
+	serverPort               int
+	dbHost                   string
+	dbPort                   int
+	dbUser                   string
+	dbPassword               string
+	dbName                   string
+	dbSchema                 string
+	keySecureFile            string
+	certSecureFile           string
+	signingKeysDir           string
+	signingKeyID             string
+	jwksMaxAge               int
+	expireToken              int
+	refreshTokenExpire       int
+	passwordMemory           int
+	passwordIterations       int
+	passwordThreads          int
+	otpExpire                int
+	otpMaxAttempts           int
+	otpResendInterval        int
+	otpSecret                string
+	otpIPMaxRequests         int
+	otpIPWindow              int
+	otpPhoneMaxRequests      int
+	smsSender                string
+	smsFile                  string
+	totpIssuer               string
+	totpChallengeExpire      int
+	totpMaxAttempts          int
+	totpUserMaxAttempts      int
+	totpUserLockout          int
+	loginFreeAttempts        int
+	loginDelay               int
+	loginMaxAttempts         int
+	loginIPFreeAttempts      int
+	loginIPMaxAttempts       int
+	loginLockout             int
+	resetExpire              int
+	resetResendInterval      int
+	adminUsers               []string
+	webhookAllowPrivate      bool
+	redisHost                string
+	redisPassword            string
+	kafkaBootstrapServers    string
+	kafkaClientID            string
+	kafkaGroupID             string
+	kafkaEventsTopic         string
+	kafkaTokensTopic         string
+	kafkaAccountEventTopic   string
+	kafkaDeletionReportTopic string
 )
 
 func Load(workDir string) error {
@@ -51,17 +89,150 @@ func Load(workDir string) error {
 	dbSchema = os.Getenv("DB_SCHEMA")
 
 	keySecureFile = workDir + "/config/cert/server.pem"
-	pubSecureFile = workDir + "/config/cert/server.pub"
 	certSecureFile = workDir + "/config/cert/server.crt"
 
+	signingKeysDir = os.Getenv("SIGNING_KEYS_DIR")
+	signingKeyID = os.Getenv("SIGNING_KEY_ID")
+	jwksMaxAge, err = strconv.Atoi(os.Getenv("JWKS_MAX_AGE")) // second
+	if err != nil {
+		jwksMaxAge = 300
+	}
+
 	expireToken, err = strconv.Atoi(os.Getenv("EXPIRE_TOKEN")) // hour
 	if err != nil {
 		return err
 	}
 
+	refreshTokenExpire, err = strconv.Atoi(os.Getenv("REFRESH_TOKEN_EXPIRE")) // hour
+	if err != nil {
+		refreshTokenExpire = 30 * 24
+	}
+
+	passwordMemory, err = strconv.Atoi(os.Getenv("PASSWORD_MEMORY")) // KiB
+	if err != nil {
+		passwordMemory = 64 * 1024
+	}
+	passwordIterations, err = strconv.Atoi(os.Getenv("PASSWORD_ITERATIONS"))
+	if err != nil {
+		passwordIterations = 3
+	}
+	passwordThreads, err = strconv.Atoi(os.Getenv("PASSWORD_THREADS"))
+	if err != nil {
+		passwordThreads = 2
+	}
+
+	otpExpire, err = strconv.Atoi(os.Getenv("OTP_EXPIRE")) // minute
+	if err != nil {
+		otpExpire = 10
+	}
+	otpMaxAttempts, err = strconv.Atoi(os.Getenv("OTP_MAX_ATTEMPTS"))
+	if err != nil {
+		otpMaxAttempts = 5
+	}
+	otpResendInterval, err = strconv.Atoi(os.Getenv("OTP_RESEND_INTERVAL")) // second
+	if err != nil {
+		otpResendInterval = 60
+	}
+	otpSecret = os.Getenv("OTP_SECRET")
+	if otpSecret == "" {
+		return errors.New("OTP_SECRET is required")
+	}
+	otpIPMaxRequests, err = strconv.Atoi(os.Getenv("OTP_IP_MAX_REQUESTS"))
+	if err != nil {
+		otpIPMaxRequests = 10
+	}
+	otpIPWindow, err = strconv.Atoi(os.Getenv("OTP_IP_WINDOW")) // minute
+	if err != nil {
+		otpIPWindow = 60
+	}
+	otpPhoneMaxRequests, err = strconv.Atoi(os.Getenv("OTP_PHONE_MAX_REQUESTS"))
+	if err != nil {
+		otpPhoneMaxRequests = 10
+	}
+
+	smsSender = os.Getenv("SMS_SENDER")
+	if smsSender == "" {
+		smsSender = "log"
+	}
+	smsFile = os.Getenv("SMS_FILE")
+	if smsFile == "" {
+		smsFile = workDir + "/sms.log"
+	}
+
+	totpIssuer = os.Getenv("TOTP_ISSUER")
+	if totpIssuer == "" {
+		totpIssuer = "Chat"
+	}
+	totpChallengeExpire, err = strconv.Atoi(os.Getenv("TOTP_CHALLENGE_EXPIRE")) // minute
+	if err != nil {
+		totpChallengeExpire = 5
+	}
+	totpMaxAttempts, err = strconv.Atoi(os.Getenv("TOTP_MAX_ATTEMPTS"))
+	if err != nil {
+		totpMaxAttempts = 5
+	}
+	totpUserMaxAttempts, err = strconv.Atoi(os.Getenv("TOTP_USER_MAX_ATTEMPTS"))
+	if err != nil {
+		totpUserMaxAttempts = 10
+	}
+	totpUserLockout, err = strconv.Atoi(os.Getenv("TOTP_USER_LOCKOUT")) // minute
+	if err != nil {
+		totpUserLockout = 15
+	}
+
+	loginFreeAttempts, err = strconv.Atoi(os.Getenv("LOGIN_FREE_ATTEMPTS"))
+	if err != nil {
+		loginFreeAttempts = 3
+	}
+	loginDelay, err = strconv.Atoi(os.Getenv("LOGIN_DELAY")) // second
+	if err != nil {
+		loginDelay = 1
+	}
+	loginMaxAttempts, err = strconv.Atoi(os.Getenv("LOGIN_MAX_ATTEMPTS"))
+	if err != nil {
+		loginMaxAttempts = 10
+	}
+	loginIPFreeAttempts, err = strconv.Atoi(os.Getenv("LOGIN_IP_FREE_ATTEMPTS"))
+	if err != nil {
+		loginIPFreeAttempts = 20
+	}
+	loginIPMaxAttempts, err = strconv.Atoi(os.Getenv("LOGIN_IP_MAX_ATTEMPTS"))
+	if err != nil {
+		loginIPMaxAttempts = 100
+	}
+	loginLockout, err = strconv.Atoi(os.Getenv("LOGIN_LOCKOUT")) // minute
+	if err != nil {
+		loginLockout = 15
+	}
+
+	resetExpire, err = strconv.Atoi(os.Getenv("RESET_EXPIRE")) // minute
+	if err != nil {
+		resetExpire = 30
+	}
+	resetResendInterval, err = strconv.Atoi(os.Getenv("RESET_RESEND_INTERVAL")) // second
+	if err != nil {
+		resetResendInterval = 60
+	}
+
+	adminUsers = nil
+	for _, id := range strings.Split(os.Getenv("ADMIN_USERS"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			adminUsers = append(adminUsers, id)
+		}
+	}
+
+	webhookAllowPrivate, _ = strconv.ParseBool(os.Getenv("WEBHOOK_ALLOW_PRIVATE"))
+
+	redisHost = os.Getenv("REDIS_HOST")
+	redisPassword = os.Getenv("REDIS_PASSWORD")
+
 	kafkaBootstrapServers = os.Getenv("KAFKA_BOOTSTRAP_SERVERS")
 	kafkaClientID = os.Getenv("KAFKA_CLIENT_ID")
+	kafkaGroupID = os.Getenv("KAFKA_GROUP_ID")
 	kafkaEventsTopic = os.Getenv("KAFKA_EVENTS_TOPIC")
+	kafkaTokensTopic = os.Getenv("KAFKA_TOKENS_TOPIC")
+	kafkaAccountEventTopic = os.Getenv("KAFKA_ACCOUNT_EVENT_TOPIC")
+	kafkaDeletionReportTopic = os.Getenv("KAFKA_DELETION_REPORT_TOPIC")
 
 	return nil
 }
@@ -102,18 +273,150 @@ func KeySecureFile() string {
 	return keySecureFile
 }
 
-func PubSecureFile() string {
-	return pubSecureFile
-}
-
 func CertSecureFile() string {
 	return certSecureFile
 }
 
+func SigningKeysDir() string {
+	return signingKeysDir
+}
+
+func SigningKeyID() string {
+	return signingKeyID
+}
+
+func JWKSMaxAge() int {
+	return jwksMaxAge
+}
+
 func ExpireToken() int {
 	return expireToken
 }
 
+func RefreshTokenExpire() int {
+	return refreshTokenExpire
+}
+
+func PasswordMemory() int {
+	return passwordMemory
+}
+
+func PasswordIterations() int {
+	return passwordIterations
+}
+
+func PasswordThreads() int {
+	return passwordThreads
+}
+
+func OTPExpire() int {
+	return otpExpire
+}
+
+func OTPMaxAttempts() int {
+	return otpMaxAttempts
+}
+
+func OTPResendInterval() int {
+	return otpResendInterval
+}
+
+// OTPSecret is the server key of the HMAC of the verification codes stored.
+func OTPSecret() string {
+	return otpSecret
+}
+
+// OTPIPMaxRequests is the limit of verification codes requested by a client IP in the window
+// of OTPIPWindow minutes, which stops SMS pumping.
+func OTPIPMaxRequests() int {
+	return otpIPMaxRequests
+}
+
+func OTPIPWindow() int {
+	return otpIPWindow
+}
+
+// OTPPhoneMaxRequests is the limit of verification codes sent to a phone number a day.
+func OTPPhoneMaxRequests() int {
+	return otpPhoneMaxRequests
+}
+
+func SMSSender() string {
+	return smsSender
+}
+
+func SMSFile() string {
+	return smsFile
+}
+
+func TOTPIssuer() string {
+	return totpIssuer
+}
+
+func TOTPChallengeExpire() int {
+	return totpChallengeExpire
+}
+
+func TOTPMaxAttempts() int {
+	return totpMaxAttempts
+}
+
+func TOTPUserMaxAttempts() int {
+	return totpUserMaxAttempts
+}
+
+func TOTPUserLockout() int {
+	return totpUserLockout
+}
+
+func LoginFreeAttempts() int {
+	return loginFreeAttempts
+}
+
+func LoginDelay() int {
+	return loginDelay
+}
+
+func LoginMaxAttempts() int {
+	return loginMaxAttempts
+}
+
+func LoginIPFreeAttempts() int {
+	return loginIPFreeAttempts
+}
+
+func LoginIPMaxAttempts() int {
+	return loginIPMaxAttempts
+}
+
+func LoginLockout() int {
+	return loginLockout
+}
+
+func ResetExpire() int {
+	return resetExpire
+}
+
+func ResetResendInterval() int {
+	return resetResendInterval
+}
+
+func AdminUsers() []string {
+	return adminUsers
+}
+
+func WebhookAllowPrivate() bool {
+	return webhookAllowPrivate
+}
+
+func RedisHost() string {
+	return redisHost
+}
+
+func RedisPassword() string {
+	return redisPassword
+}
+
 func KafkaBootstrapServers() string {
 	return kafkaBootstrapServers
 }
@@ -122,6 +425,22 @@ func KafkaClientID() string {
 	return kafkaClientID
 }
 
+func KafkaGroupID() string {
+	return kafkaGroupID
+}
+
 func KafkaEventsTopic() string {
 	return kafkaEventsTopic
 }
+
+func KafkaTokensTopic() string {
+	return kafkaTokensTopic
+}
+
+func KafkaAccountEventTopic() string {
+	return kafkaAccountEventTopic
+}
+
+func KafkaDeletionReportTopic() string {
+	return kafkaDeletionReportTopic
+}
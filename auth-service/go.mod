@@ -3,18 +3,24 @@ module github.com/tsmweb/auth-service
 go 1.19
 
 require (
+	github.com/go-redis/redis/v8 v8.11.5
+	github.com/golang-jwt/jwt/v4 v4.4.2
 	github.com/gorilla/mux v1.8.0
 	github.com/joho/godotenv v1.4.0
 	github.com/lib/pq v1.10.7
 	github.com/stretchr/testify v1.8.0
+	github.com/tsmweb/chat-server/pkg v0.0.0
 	github.com/tsmweb/go-helper-api v1.4.2
 	github.com/urfave/negroni v1.0.0
+	golang.org/x/crypto v0.1.0
+	google.golang.org/protobuf v1.28.1
 )
 
 require (
+	github.com/cespare/xxhash/v2 v2.1.2 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
 	github.com/felixge/httpsnoop v1.0.3 // indirect
-	github.com/golang-jwt/jwt/v4 v4.4.2 // indirect
 	github.com/gorilla/handlers v1.5.1 // indirect
 	github.com/klauspost/compress v1.15.9 // indirect
 	github.com/pierrec/lz4/v4 v4.1.15 // indirect
@@ -22,5 +28,8 @@ require (
 	github.com/segmentio/kafka-go v0.4.34 // indirect
 	github.com/stretchr/objx v0.4.0 // indirect
 	github.com/xi2/httpgzip v0.0.0-20190509075255-932ab5e254ae // indirect
+	golang.org/x/sys v0.1.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace github.com/tsmweb/chat-server/pkg => ../pkg
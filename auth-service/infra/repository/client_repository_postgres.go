@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/tsmweb/auth-service/app/client"
+	"github.com/tsmweb/auth-service/infra/db"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+// clientRepositoryPostgres implementation for client.Repository interface.
+type clientRepositoryPostgres struct {
+	dataBase db.Database
+}
+
+// NewClientRepositoryPostgres creates a new instance of client.Repository.
+func NewClientRepositoryPostgres(db db.Database) client.Repository {
+	return &clientRepositoryPostgres{dataBase: db}
+}
+
+// Create stores the service account and its user, without login, in the data base.
+func (r *clientRepositoryPostgres) Create(ctx context.Context, c *client.Client) error {
+	txn, err := r.dataBase.DB().Begin()
+	if err != nil {
+		return err
+	}
+
+	_, err = txn.ExecContext(ctx, `
+		INSERT INTO "user"(id, name, created_at)
+		VALUES($1, $2, $3)`,
+		c.ID, c.Name, c.CreatedAt)
+	if err != nil {
+		txn.Rollback()
+		return err
+	}
+
+	_, err = txn.ExecContext(ctx, `
+		INSERT INTO oauth_client(id, name, secret_hash, scope, created_by, created_at)
+		VALUES($1, $2, $3, $4, $5, $6)`,
+		c.ID, c.Name, c.SecretHash, strings.Join(c.Scopes, " "), c.CreatedBy, c.CreatedAt)
+	if err != nil {
+		txn.Rollback()
+		return err
+	}
+
+	if err = txn.Commit(); err != nil {
+		txn.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+// Get returns the service account by ID.
+func (r *clientRepositoryPostgres) Get(ctx context.Context, ID string) (*client.Client, error) {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		SELECT id, name, secret_hash, scope, created_by, created_at, revoked_at
+		FROM oauth_client
+		WHERE id = $1`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	c, err := scanClient(stmt.QueryRowContext(ctx, ID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, cerror.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// GetAll returns the service accounts not revoked.
+func (r *clientRepositoryPostgres) GetAll(ctx context.Context) ([]*client.Client, error) {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		SELECT id, name, secret_hash, scope, created_by, created_at, revoked_at
+		FROM oauth_client
+		WHERE revoked_at IS NULL
+		ORDER BY created_at`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var clients []*client.Client
+
+	for rows.Next() {
+		c, err := scanClient(rows)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, c)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return clients, nil
+}
+
+// Revoke revokes the service account if it was not revoked yet.
+func (r *clientRepositoryPostgres) Revoke(ctx context.Context, ID string,
+	revokedAt time.Time) (bool, error) {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		UPDATE oauth_client
+		SET revoked_at = $1
+		WHERE id = $2
+		AND revoked_at IS NULL`)
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, revokedAt, ID)
+	if err != nil {
+		return false, err
+	}
+
+	ra, _ := result.RowsAffected()
+	return ra == 1, nil
+}
+
+// SetWebhook stores the webhook of the service account, replacing the previous one.
+func (r *clientRepositoryPostgres) SetWebhook(ctx context.Context, w *client.Webhook) error {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		INSERT INTO webhook(client_id, url, secret, created_at)
+		VALUES($1, $2, $3, $4)
+		ON CONFLICT(client_id)
+		DO UPDATE SET
+			url = $2,
+			secret = $3,
+			created_at = $4`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, w.ClientID, w.URL, w.Secret, w.CreatedAt)
+	return err
+}
+
+// DeleteWebhook removes the webhook of the service account.
+func (r *clientRepositoryPostgres) DeleteWebhook(ctx context.Context, clientID string) (bool, error) {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		DELETE FROM webhook
+		WHERE client_id = $1`)
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, clientID)
+	if err != nil {
+		return false, err
+	}
+
+	ra, _ := result.RowsAffected()
+	return ra == 1, nil
+}
+
+func scanClient(row rowScanner) (*client.Client, error) {
+	var c client.Client
+	var scopes string
+	var revokedAt sql.NullTime
+
+	err := row.Scan(&c.ID,
+		&c.Name,
+		&c.SecretHash,
+		&scopes,
+		&c.CreatedBy,
+		&c.CreatedAt,
+		&revokedAt)
+	if err != nil {
+		return nil, err
+	}
+	c.Scopes = strings.Fields(scopes)
+	c.RevokedAt = revokedAt.Time
+
+	return &c, nil
+}
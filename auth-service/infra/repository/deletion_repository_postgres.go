@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/tsmweb/auth-service/app/user"
+	"github.com/tsmweb/auth-service/infra/db"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+// deletionRepositoryPostgres implementation for user.DeletionRepository interface.
+type deletionRepositoryPostgres struct {
+	dataBase db.Database
+}
+
+// NewDeletionRepositoryPostgres creates a new instance of user.DeletionRepository.
+func NewDeletionRepositoryPostgres(db db.Database) user.DeletionRepository {
+	return &deletionRepositoryPostgres{dataBase: db}
+}
+
+// Get returns the deletion of the user with its steps.
+func (r *deletionRepositoryPostgres) Get(ctx context.Context, userID string) (*user.Deletion, error) {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		SELECT user_id, requested_at, completed_at
+		FROM user_deletion
+		WHERE user_id = $1`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var d user.Deletion
+	var completedAt sql.NullTime
+	err = stmt.QueryRowContext(ctx, userID).
+		Scan(&d.UserID,
+			&d.RequestedAt,
+			&completedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, cerror.ErrNotFound
+		}
+		return nil, err
+	}
+	d.CompletedAt = completedAt.Time
+
+	rows, err := r.dataBase.DB().QueryContext(ctx, `
+		SELECT service, completed_at
+		FROM user_deletion_step
+		WHERE user_id = $1
+		ORDER BY service`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var step user.DeletionStep
+		var stepCompletedAt sql.NullTime
+		if err = rows.Scan(&step.Service, &stepCompletedAt); err != nil {
+			return nil, err
+		}
+		step.CompletedAt = stepCompletedAt.Time
+		d.Steps = append(d.Steps, &step)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// createDeletion stores the deletion and its steps in the transaction, replacing a previous
+// one of the user.
+func createDeletion(ctx context.Context, txn *sql.Tx, d *user.Deletion) error {
+	// the steps of the previous deletion are deleted in cascade.
+	_, err := txn.ExecContext(ctx, `DELETE FROM user_deletion WHERE user_id = $1`, d.UserID)
+	if err != nil {
+		return err
+	}
+
+	_, err = txn.ExecContext(ctx, `
+		INSERT INTO user_deletion(user_id, requested_at)
+		VALUES($1, $2)`, d.UserID, d.RequestedAt)
+	if err != nil {
+		return err
+	}
+
+	stmt, err := txn.PrepareContext(ctx, `
+		INSERT INTO user_deletion_step(user_id, service, completed_at)
+		VALUES($1, $2, $3)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, step := range d.Steps {
+		completedAt := sql.NullTime{Time: step.CompletedAt, Valid: !step.CompletedAt.IsZero()}
+		if _, err = stmt.ExecContext(ctx, d.UserID, step.Service, completedAt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CompleteStep completes the step of the service, keeping the time of the first report.
+func (r *deletionRepositoryPostgres) CompleteStep(ctx context.Context, userID, service string,
+	completedAt time.Time) (bool, error) {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		UPDATE user_deletion_step
+		SET completed_at = COALESCE(completed_at, $1)
+		WHERE user_id = $2
+		AND service = $3`)
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, completedAt, userID, service)
+	if err != nil {
+		return false, err
+	}
+
+	ra, _ := result.RowsAffected()
+	return ra == 1, nil
+}
+
+// Complete completes the deletion of the user.
+func (r *deletionRepositoryPostgres) Complete(ctx context.Context, userID string,
+	completedAt time.Time) error {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		UPDATE user_deletion
+		SET completed_at = $1
+		WHERE user_id = $2`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, completedAt, userID)
+	return err
+}
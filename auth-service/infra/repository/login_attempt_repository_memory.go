@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tsmweb/auth-service/app/login"
+)
+
+type loginAttempts struct {
+	failures  int
+	last      time.Time
+	expiresAt time.Time
+}
+
+// loginAttemptRepositoryMemory implementation for login.AttemptRepository interface.
+type loginAttemptRepositoryMemory struct {
+	mu       sync.Mutex
+	attempts map[string]*loginAttempts
+}
+
+// NewLoginAttemptRepositoryMemory creates a new instance of login.AttemptRepository that
+// keeps the attempts in memory, for a single process.
+func NewLoginAttemptRepositoryMemory() login.AttemptRepository {
+	return &loginAttemptRepositoryMemory{
+		attempts: make(map[string]*loginAttempts),
+	}
+}
+
+// Get returns the failed attempts of the key and the time of the last one.
+func (r *loginAttemptRepositoryMemory) Get(_ context.Context, key string) (int, time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	a, ok := r.attempts[key]
+	if !ok || !time.Now().Before(a.expiresAt) {
+		return 0, time.Time{}, nil
+	}
+	return a.failures, a.last, nil
+}
+
+// Fail increments the failed attempts of the key and renews their expiration.
+func (r *loginAttemptRepositoryMemory) Fail(_ context.Context, key string, at time.Time,
+	ttl time.Duration) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for k, a := range r.attempts {
+		if !now.Before(a.expiresAt) {
+			delete(r.attempts, k)
+		}
+	}
+
+	a, ok := r.attempts[key]
+	if !ok {
+		a = &loginAttempts{}
+		r.attempts[key] = a
+	}
+	a.failures++
+	a.last = at
+	a.expiresAt = now.Add(ttl)
+	return a.failures, nil
+}
+
+// Refund decrements the attempts of the key, if they were not forgotten.
+func (r *loginAttemptRepositoryMemory) Refund(_ context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if a, ok := r.attempts[key]; ok && a.failures > 0 {
+		a.failures--
+	}
+	return nil
+}
+
+// Reset deletes the failed attempts of the key.
+func (r *loginAttemptRepositoryMemory) Reset(_ context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.attempts, key)
+	return nil
+}
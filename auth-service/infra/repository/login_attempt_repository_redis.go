@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/tsmweb/auth-service/app/login"
+)
+
+const loginAttemptKeyPrefix = "login:attempts:"
+
+// loginAttemptRefundScript decrements the attempts only while the key exists, so that a
+// refund after the expiration or the reset does not leave a key without expiration.
+var loginAttemptRefundScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 1 then
+	return redis.call("HINCRBY", KEYS[1], "failures", -1)
+end
+return 0
+`)
+
+// loginAttemptRepositoryRedis implementation for login.AttemptRepository interface.
+type loginAttemptRepositoryRedis struct {
+	db *redis.Client
+}
+
+// NewLoginAttemptRepositoryRedis creates a new instance of login.AttemptRepository that
+// keeps the attempts in Redis, shared by the replicas of auth-service.
+func NewLoginAttemptRepositoryRedis(addr, password string) login.AttemptRepository {
+	db := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: password,
+		DB:       0, // use default DB
+	})
+
+	return &loginAttemptRepositoryRedis{db: db}
+}
+
+// Get returns the failed attempts of the key and the time of the last one.
+func (r *loginAttemptRepositoryRedis) Get(ctx context.Context, key string) (int, time.Time, error) {
+	values, err := r.db.HGetAll(ctx, loginAttemptKeyPrefix+key).Result()
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	if len(values) == 0 {
+		return 0, time.Time{}, nil
+	}
+
+	failures, err := strconv.Atoi(values["failures"])
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	last, err := strconv.ParseInt(values["last"], 10, 64)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	return failures, time.Unix(0, last), nil
+}
+
+// Fail increments the failed attempts of the key and renews their expiration.
+func (r *loginAttemptRepositoryRedis) Fail(ctx context.Context, key string, at time.Time,
+	ttl time.Duration) (int, error) {
+	key = loginAttemptKeyPrefix + key
+
+	var failures *redis.IntCmd
+	_, err := r.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		failures = pipe.HIncrBy(ctx, key, "failures", 1)
+		pipe.HSet(ctx, key, "last", at.UnixNano())
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(failures.Val()), nil
+}
+
+// Refund decrements the attempts of the key, if it still exists.
+func (r *loginAttemptRepositoryRedis) Refund(ctx context.Context, key string) error {
+	return loginAttemptRefundScript.Run(ctx, r.db, []string{loginAttemptKeyPrefix + key}).Err()
+}
+
+// Reset deletes the failed attempts of the key.
+func (r *loginAttemptRepositoryRedis) Reset(ctx context.Context, key string) error {
+	return r.db.Del(ctx, loginAttemptKeyPrefix+key).Err()
+}
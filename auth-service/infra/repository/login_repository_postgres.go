@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"github.com/tsmweb/auth-service/app/login"
 	"github.com/tsmweb/auth-service/infra/db"
+	"github.com/tsmweb/go-helper-api/cerror"
 )
 
 // loginRepositoryPostgres implementation for login.Repository interface.
@@ -17,25 +18,48 @@ func NewLoginRepositoryPostgres(db db.Database) login.Repository {
 	return &loginRepositoryPostgres{dataBase: db}
 }
 
-// Login returns if ID and password are valid.
-func (r *loginRepositoryPostgres) Login(ctx context.Context, login *login.Login) (bool, error) {
-	ok := false
+// GetPassword returns the password hash of the user.
+func (r *loginRepositoryPostgres) GetPassword(ctx context.Context, ID string) (string, error) {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		SELECT password FROM login 
+		WHERE user_id = $1`)
+	if err != nil {
+		return "", err
+	}
+	defer stmt.Close()
+
+	var hash string
+	err = stmt.QueryRowContext(ctx, ID).Scan(&hash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", cerror.ErrNotFound
+		}
+		return "", err
+	}
 
+	return hash, nil
+}
+
+// Rehash replaces the password hash of the user, unless the password was changed
+// after oldHash was read.
+func (r *loginRepositoryPostgres) Rehash(ctx context.Context, ID, oldHash, newHash string) (bool, error) {
 	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
-		SELECT true FROM login 
-		WHERE user_id = $1 
-		AND password = $2`)
+		UPDATE login 
+		SET password = $1
+		WHERE user_id = $2
+		AND password = $3`)
 	if err != nil {
-		return ok, err
+		return false, err
 	}
 	defer stmt.Close()
 
-	err = stmt.QueryRowContext(ctx, login.ID, login.Password).Scan(&ok)
-	if err != nil && err != sql.ErrNoRows {
+	result, err := stmt.ExecContext(ctx, newHash, ID, oldHash)
+	if err != nil {
 		return false, err
 	}
 
-	return ok, nil
+	ra, _ := result.RowsAffected()
+	return ra == 1, nil
 }
 
 // Update login data in the data base.
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/tsmweb/auth-service/app/token"
+	"github.com/tsmweb/auth-service/infra/db"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+// refreshTokenRepositoryPostgres implementation for token.Repository interface.
+type refreshTokenRepositoryPostgres struct {
+	dataBase db.Database
+}
+
+// NewRefreshTokenRepositoryPostgres creates a new instance of token.Repository.
+func NewRefreshTokenRepositoryPostgres(db db.Database) token.Repository {
+	return &refreshTokenRepositoryPostgres{dataBase: db}
+}
+
+// Create stores the refresh token in the data base.
+func (r *refreshTokenRepositoryPostgres) Create(ctx context.Context, t *token.RefreshToken) error {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		INSERT INTO refresh_token(id, family_id, user_id, created_at, expires_at)
+		VALUES($1, $2, $3, $4, $5)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, t.ID, t.FamilyID, t.UserID, t.CreatedAt, t.ExpiresAt)
+	return err
+}
+
+// Get returns the refresh token by its hash.
+func (r *refreshTokenRepositoryPostgres) Get(ctx context.Context, ID string) (*token.RefreshToken, error) {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		SELECT id, family_id, user_id, created_at, expires_at, used_at, revoked_at
+		FROM refresh_token
+		WHERE id = $1`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var t token.RefreshToken
+	var usedAt, revokedAt sql.NullTime
+
+	err = stmt.QueryRowContext(ctx, ID).
+		Scan(&t.ID,
+			&t.FamilyID,
+			&t.UserID,
+			&t.CreatedAt,
+			&t.ExpiresAt,
+			&usedAt,
+			&revokedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, cerror.ErrNotFound
+		}
+		return nil, err
+	}
+	t.UsedAt = usedAt.Time
+	t.RevokedAt = revokedAt.Time
+
+	return &t, nil
+}
+
+// Use marks the refresh token as used if it was not used or revoked yet.
+func (r *refreshTokenRepositoryPostgres) Use(ctx context.Context, ID string, usedAt time.Time) (bool, error) {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		UPDATE refresh_token
+		SET used_at = $1
+		WHERE id = $2
+		AND used_at IS NULL
+		AND revoked_at IS NULL`)
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, usedAt, ID)
+	if err != nil {
+		return false, err
+	}
+
+	ra, _ := result.RowsAffected()
+	return ra == 1, nil
+}
+
+// RevokeFamily revokes all refresh tokens of the family.
+func (r *refreshTokenRepositoryPostgres) RevokeFamily(ctx context.Context, familyID string,
+	revokedAt time.Time) error {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		UPDATE refresh_token
+		SET revoked_at = $1
+		WHERE family_id = $2
+		AND revoked_at IS NULL`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, revokedAt, familyID)
+	return err
+}
+
+// RevokeUser revokes all refresh tokens of the user.
+func (r *refreshTokenRepositoryPostgres) RevokeUser(ctx context.Context, userID string,
+	revokedAt time.Time) error {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		UPDATE refresh_token
+		SET revoked_at = $1
+		WHERE user_id = $2
+		AND revoked_at IS NULL`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, revokedAt, userID)
+	return err
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/tsmweb/auth-service/app/reset"
+	"github.com/tsmweb/auth-service/infra/db"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+// resetRepositoryPostgres implementation for reset.Repository interface.
+type resetRepositoryPostgres struct {
+	dataBase db.Database
+}
+
+// NewResetRepositoryPostgres creates a new instance of reset.Repository.
+func NewResetRepositoryPostgres(db db.Database) reset.Repository {
+	return &resetRepositoryPostgres{dataBase: db}
+}
+
+// GetByUser returns the reset requested by the user.
+func (r *resetRepositoryPostgres) GetByUser(ctx context.Context, userID string) (*reset.Reset, error) {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		SELECT id, user_id, created_at, expires_at
+		FROM password_reset
+		WHERE user_id = $1`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var rs reset.Reset
+	err = stmt.QueryRowContext(ctx, userID).
+		Scan(&rs.ID,
+			&rs.UserID,
+			&rs.CreatedAt,
+			&rs.ExpiresAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, cerror.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &rs, nil
+}
+
+// Save stores the reset, replacing the token of the previous one.
+func (r *resetRepositoryPostgres) Save(ctx context.Context, rs *reset.Reset) error {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		INSERT INTO password_reset(id, user_id, created_at, expires_at)
+		VALUES($1, $2, $3, $4)
+		ON CONFLICT(user_id)
+		DO UPDATE SET id = $1, created_at = $3, expires_at = $4`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, rs.ID, rs.UserID, rs.CreatedAt, rs.ExpiresAt)
+	return err
+}
+
+// Use deletes the reset with the token hash, returning it.
+func (r *resetRepositoryPostgres) Use(ctx context.Context, ID string) (*reset.Reset, error) {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		DELETE FROM password_reset
+		WHERE id = $1
+		RETURNING id, user_id, created_at, expires_at`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var rs reset.Reset
+	err = stmt.QueryRowContext(ctx, ID).
+		Scan(&rs.ID,
+			&rs.UserID,
+			&rs.CreatedAt,
+			&rs.ExpiresAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, cerror.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &rs, nil
+}
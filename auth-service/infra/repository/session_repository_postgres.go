@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/tsmweb/auth-service/app/session"
+	"github.com/tsmweb/auth-service/infra/db"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+// sessionRepositoryPostgres implementation for session.Repository interface.
+type sessionRepositoryPostgres struct {
+	dataBase db.Database
+}
+
+// NewSessionRepositoryPostgres creates a new instance of session.Repository.
+func NewSessionRepositoryPostgres(db db.Database) session.Repository {
+	return &sessionRepositoryPostgres{dataBase: db}
+}
+
+// Create stores the session in the data base.
+func (r *sessionRepositoryPostgres) Create(ctx context.Context, s *session.Session) error {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		INSERT INTO user_session(id, user_id, device_name, user_agent, ip, created_at, last_seen_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, s.ID, s.UserID, s.DeviceName, s.UserAgent, s.IP,
+		s.CreatedAt, s.LastSeenAt)
+	return err
+}
+
+// Get returns the session by ID.
+func (r *sessionRepositoryPostgres) Get(ctx context.Context, ID string) (*session.Session, error) {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		SELECT id, user_id, device_name, user_agent, ip, created_at, last_seen_at, revoked_at
+		FROM user_session
+		WHERE id = $1`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	s, err := scanSession(stmt.QueryRowContext(ctx, ID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, cerror.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// GetAll returns the sessions of the user not revoked that still have a valid refresh token,
+// as the tokens may also be revoked on logout, on password change or when reused.
+func (r *sessionRepositoryPostgres) GetAll(ctx context.Context, userID string) ([]*session.Session, error) {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		SELECT s.id, s.user_id, s.device_name, s.user_agent, s.ip, s.created_at, s.last_seen_at,
+			s.revoked_at
+		FROM user_session s
+		WHERE s.user_id = $1
+		AND s.revoked_at IS NULL
+		AND EXISTS (
+			SELECT 1
+			FROM refresh_token t
+			WHERE t.family_id = s.id
+			AND t.used_at IS NULL
+			AND t.revoked_at IS NULL
+			AND t.expires_at > $2)
+		ORDER BY s.last_seen_at DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, userID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*session.Session
+
+	for rows.Next() {
+		s, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// Touch updates the last time the session was seen.
+func (r *sessionRepositoryPostgres) Touch(ctx context.Context, ID string, lastSeenAt time.Time) error {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		UPDATE user_session
+		SET last_seen_at = $1
+		WHERE id = $2`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, lastSeenAt, ID)
+	return err
+}
+
+// Revoke revokes the session and its refresh tokens in a single transaction.
+func (r *sessionRepositoryPostgres) Revoke(ctx context.Context, ID string,
+	revokedAt time.Time) (bool, error) {
+	txn, err := r.dataBase.DB().Begin()
+	if err != nil {
+		return false, err
+	}
+
+	result, err := txn.ExecContext(ctx, `
+		UPDATE user_session
+		SET revoked_at = $1
+		WHERE id = $2
+		AND revoked_at IS NULL`,
+		revokedAt, ID)
+	if err != nil {
+		txn.Rollback()
+		return false, err
+	}
+
+	ra, _ := result.RowsAffected()
+	if ra != 1 {
+		txn.Rollback()
+		return false, nil
+	}
+
+	_, err = txn.ExecContext(ctx, `
+		UPDATE refresh_token
+		SET revoked_at = $1
+		WHERE family_id = $2
+		AND revoked_at IS NULL`,
+		revokedAt, ID)
+	if err != nil {
+		txn.Rollback()
+		return false, err
+	}
+
+	if err = txn.Commit(); err != nil {
+		txn.Rollback()
+		return false, err
+	}
+
+	return true, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSession(row rowScanner) (*session.Session, error) {
+	var s session.Session
+	var deviceName, userAgent, ip sql.NullString
+	var revokedAt sql.NullTime
+
+	err := row.Scan(&s.ID,
+		&s.UserID,
+		&deviceName,
+		&userAgent,
+		&ip,
+		&s.CreatedAt,
+		&s.LastSeenAt,
+		&revokedAt)
+	if err != nil {
+		return nil, err
+	}
+	s.DeviceName = deviceName.String
+	s.UserAgent = userAgent.String
+	s.IP = ip.String
+	s.RevokedAt = revokedAt.Time
+
+	return &s, nil
+}
@@ -0,0 +1,286 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/tsmweb/auth-service/app/twofactor"
+	"github.com/tsmweb/auth-service/infra/db"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+// twoFactorRepositoryPostgres implementation for twofactor.Repository interface.
+type twoFactorRepositoryPostgres struct {
+	dataBase db.Database
+}
+
+// NewTwoFactorRepositoryPostgres creates a new instance of twofactor.Repository.
+func NewTwoFactorRepositoryPostgres(db db.Database) twofactor.Repository {
+	return &twoFactorRepositoryPostgres{dataBase: db}
+}
+
+// Get returns the two-factor authentication of the user.
+func (r *twoFactorRepositoryPostgres) Get(ctx context.Context, ID string) (*twofactor.TwoFactor, error) {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		SELECT id, secret, enabled, last_step, created_at, updated_at
+		FROM two_factor
+		WHERE id = $1`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var t twofactor.TwoFactor
+	var updatedAt sql.NullTime
+
+	err = stmt.QueryRowContext(ctx, ID).
+		Scan(&t.ID,
+			&t.Secret,
+			&t.Enabled,
+			&t.LastStep,
+			&t.CreatedAt,
+			&updatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, cerror.ErrNotFound
+		}
+		return nil, err
+	}
+	t.UpdatedAt = updatedAt.Time
+
+	return &t, nil
+}
+
+// Save stores the pending enrolment, replacing the previous one if it is not enabled.
+func (r *twoFactorRepositoryPostgres) Save(ctx context.Context, t *twofactor.TwoFactor) (bool, error) {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		INSERT INTO two_factor(id, secret, enabled, last_step, created_at)
+		VALUES($1, $2, false, 0, $3)
+		ON CONFLICT(id)
+		DO UPDATE SET secret = $2, last_step = 0, created_at = $3, updated_at = NULL
+		WHERE two_factor.enabled = false`)
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, t.ID, t.Secret, t.CreatedAt)
+	if err != nil {
+		return false, err
+	}
+
+	ra, _ := result.RowsAffected()
+	return ra == 1, nil
+}
+
+// Enable enables the pending enrolment and replaces the recovery codes of the user.
+func (r *twoFactorRepositoryPostgres) Enable(ctx context.Context, ID string, step int64,
+	recoveryCodes []string) (bool, error) {
+	txn, err := r.dataBase.DB().Begin()
+	if err != nil {
+		return false, err
+	}
+
+	stmt, err := txn.PrepareContext(ctx, `
+		UPDATE two_factor
+		SET enabled = true, last_step = $1, updated_at = $2
+		WHERE id = $3
+		AND enabled = false`)
+	if err != nil {
+		txn.Rollback()
+		return false, err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, step, time.Now().UTC(), ID)
+	if err != nil {
+		txn.Rollback()
+		return false, err
+	}
+
+	ra, _ := result.RowsAffected()
+	if ra != 1 {
+		txn.Rollback()
+		return false, nil
+	}
+
+	if err = r.replaceRecoveryCodes(ctx, txn, ID, recoveryCodes); err != nil {
+		txn.Rollback()
+		return false, err
+	}
+
+	if err = txn.Commit(); err != nil {
+		txn.Rollback()
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *twoFactorRepositoryPostgres) replaceRecoveryCodes(ctx context.Context, txn *sql.Tx,
+	ID string, recoveryCodes []string) error {
+	_, err := txn.ExecContext(ctx, `DELETE FROM recovery_code WHERE user_id = $1`, ID)
+	if err != nil {
+		return err
+	}
+
+	stmt, err := txn.PrepareContext(ctx, `
+		INSERT INTO recovery_code(user_id, code_hash)
+		VALUES($1, $2)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, codeHash := range recoveryCodes {
+		if _, err = stmt.ExecContext(ctx, ID, codeHash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UseStep records the time step of the code accepted if it is later than the last one.
+func (r *twoFactorRepositoryPostgres) UseStep(ctx context.Context, ID string, step int64) (bool, error) {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		UPDATE two_factor
+		SET last_step = $1
+		WHERE id = $2
+		AND last_step < $1`)
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, step, ID)
+	if err != nil {
+		return false, err
+	}
+
+	ra, _ := result.RowsAffected()
+	return ra == 1, nil
+}
+
+// UseRecoveryCode deletes the recovery code of the user.
+func (r *twoFactorRepositoryPostgres) UseRecoveryCode(ctx context.Context, ID, codeHash string) (bool, error) {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		DELETE FROM recovery_code
+		WHERE user_id = $1
+		AND code_hash = $2`)
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, ID, codeHash)
+	if err != nil {
+		return false, err
+	}
+
+	ra, _ := result.RowsAffected()
+	return ra == 1, nil
+}
+
+// Delete deletes the two-factor authentication and the recovery codes of the user.
+func (r *twoFactorRepositoryPostgres) Delete(ctx context.Context, ID string) error {
+	txn, err := r.dataBase.DB().Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err = txn.ExecContext(ctx, `DELETE FROM recovery_code WHERE user_id = $1`, ID); err != nil {
+		txn.Rollback()
+		return err
+	}
+	if _, err = txn.ExecContext(ctx, `DELETE FROM two_factor WHERE id = $1`, ID); err != nil {
+		txn.Rollback()
+		return err
+	}
+
+	if err = txn.Commit(); err != nil {
+		txn.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+// CreateChallenge stores the challenge in the data base.
+func (r *twoFactorRepositoryPostgres) CreateChallenge(ctx context.Context, c *twofactor.Challenge) error {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		INSERT INTO two_factor_challenge(id, user_id, attempts, created_at, expires_at)
+		VALUES($1, $2, 0, $3, $4)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, c.ID, c.UserID, c.CreatedAt, c.ExpiresAt)
+	return err
+}
+
+// GetChallenge returns the challenge by its hash.
+func (r *twoFactorRepositoryPostgres) GetChallenge(ctx context.Context, ID string) (*twofactor.Challenge, error) {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		SELECT id, user_id, attempts, created_at, expires_at
+		FROM two_factor_challenge
+		WHERE id = $1`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var c twofactor.Challenge
+	err = stmt.QueryRowContext(ctx, ID).
+		Scan(&c.ID,
+			&c.UserID,
+			&c.Attempts,
+			&c.CreatedAt,
+			&c.ExpiresAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, cerror.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// AttemptChallenge increments the attempts of the challenge if the limit was not reached.
+func (r *twoFactorRepositoryPostgres) AttemptChallenge(ctx context.Context, ID string,
+	maxAttempts int) (bool, error) {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		UPDATE two_factor_challenge
+		SET attempts = attempts + 1
+		WHERE id = $1
+		AND attempts < $2`)
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, ID, maxAttempts)
+	if err != nil {
+		return false, err
+	}
+
+	ra, _ := result.RowsAffected()
+	return ra == 1, nil
+}
+
+// DeleteChallenge deletes the challenge, along with the expired ones.
+func (r *twoFactorRepositoryPostgres) DeleteChallenge(ctx context.Context, ID string) error {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		DELETE FROM two_factor_challenge
+		WHERE id = $1
+		OR expires_at < $2`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, ID, time.Now().UTC())
+	return err
+}
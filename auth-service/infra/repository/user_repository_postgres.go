@@ -28,7 +28,7 @@ func (r *userRepositoryPostgres) Get(ctx context.Context, ID string) (*user.User
 			u.lastname, 
 			u.created_at,
 			COALESCE(u.updated_at, u.created_at, u.updated_at) AS updated_at
-		FROM "user" u WHERE u.id = $1`)
+		FROM "user" u WHERE u.id = $1 AND u.deleted_at IS NULL`)
 	if err != nil {
 		return nil, err
 	}
@@ -103,7 +103,7 @@ func (r *userRepositoryPostgres) Update(ctx context.Context, user *user.User) (b
 	stmt, err := txn.PrepareContext(ctx, `
 		UPDATE "user" 
 		SET name = $1, lastname = $2, updated_at = $3
-		WHERE id = $4`)
+		WHERE id = $4 AND deleted_at IS NULL`)
 	if err != nil {
 		return false, err
 	}
@@ -130,6 +130,87 @@ func (r *userRepositoryPostgres) Update(ctx context.Context, user *user.User) (b
 	return true, nil
 }
 
+// Delete marks the user as deleted, removes its login, tokens and the other data kept by
+// auth-service and stores the deletion, in a single transaction.
+func (r *userRepositoryPostgres) Delete(ctx context.Context, d *user.Deletion) (bool, error) {
+	txn, err := r.dataBase.DB().Begin()
+	if err != nil {
+		return false, err
+	}
+
+	stmt, err := txn.PrepareContext(ctx, `
+		UPDATE "user"
+		SET deleted_at = $1
+		WHERE id = $2 AND deleted_at IS NULL`)
+	if err != nil {
+		txn.Rollback()
+		return false, err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, d.RequestedAt, d.UserID)
+	if err != nil {
+		txn.Rollback()
+		return false, err
+	}
+
+	ra, _ := result.RowsAffected()
+	if ra != 1 {
+		txn.Rollback()
+		return false, nil
+	}
+
+	deletes := []string{
+		`DELETE FROM login WHERE user_id = $1`,
+		`DELETE FROM refresh_token WHERE user_id = $1`,
+		`DELETE FROM user_session WHERE user_id = $1`,
+		`DELETE FROM webhook WHERE client_id = $1`,
+		`DELETE FROM oauth_client WHERE id = $1`,
+		`DELETE FROM recovery_code WHERE user_id = $1`,
+		`DELETE FROM two_factor_challenge WHERE user_id = $1`,
+		`DELETE FROM two_factor WHERE id = $1`,
+		`DELETE FROM password_reset WHERE user_id = $1`,
+		`DELETE FROM verification WHERE id = $1`,
+	}
+	for _, query := range deletes {
+		if _, err = txn.ExecContext(ctx, query, d.UserID); err != nil {
+			txn.Rollback()
+			return false, err
+		}
+	}
+
+	if err = createDeletion(ctx, txn, d); err != nil {
+		txn.Rollback()
+		return false, err
+	}
+
+	if err = txn.Commit(); err != nil {
+		txn.Rollback()
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Purge removes the deleted user from the data base.
+func (r *userRepositoryPostgres) Purge(ctx context.Context, ID string) (bool, error) {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		DELETE FROM "user"
+		WHERE id = $1 AND deleted_at IS NOT NULL`)
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, ID)
+	if err != nil {
+		return false, err
+	}
+
+	ra, _ := result.RowsAffected()
+	return ra == 1, nil
+}
+
 func (r *userRepositoryPostgres) addLogin(ctx context.Context,
 	txn *sql.Tx, userID string, password string, createdAt time.Time) error {
 	stmt, err := txn.PrepareContext(ctx, `
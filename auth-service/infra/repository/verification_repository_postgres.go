@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/tsmweb/auth-service/app/verification"
+	"github.com/tsmweb/auth-service/infra/db"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+// verificationRepositoryPostgres implementation for verification.Repository interface.
+type verificationRepositoryPostgres struct {
+	dataBase db.Database
+}
+
+// NewVerificationRepositoryPostgres creates a new instance of verification.Repository.
+func NewVerificationRepositoryPostgres(db db.Database) verification.Repository {
+	return &verificationRepositoryPostgres{dataBase: db}
+}
+
+// Get returns the verification by phone number.
+func (r *verificationRepositoryPostgres) Get(ctx context.Context, ID string) (*verification.Verification, error) {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		SELECT id, code_hash, attempts, created_at, expires_at
+		FROM verification
+		WHERE id = $1`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var v verification.Verification
+	err = stmt.QueryRowContext(ctx, ID).
+		Scan(&v.ID,
+			&v.CodeHash,
+			&v.Attempts,
+			&v.CreatedAt,
+			&v.ExpiresAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, cerror.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// Save stores the verification, keeping the attempts of the previous one unless it expired.
+func (r *verificationRepositoryPostgres) Save(ctx context.Context, v *verification.Verification) error {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		INSERT INTO verification(id, code_hash, attempts, created_at, expires_at)
+		VALUES($1, $2, 0, $3, $4)
+		ON CONFLICT(id)
+		DO UPDATE SET code_hash = $2,
+			attempts = CASE WHEN verification.expires_at > $3 THEN verification.attempts ELSE 0 END,
+			created_at = $3, expires_at = $4`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, v.ID, v.CodeHash, v.CreatedAt, v.ExpiresAt)
+	return err
+}
+
+// Attempt increments the attempts of the verification if the limit was not reached.
+func (r *verificationRepositoryPostgres) Attempt(ctx context.Context, ID string, maxAttempts int) (bool, error) {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		UPDATE verification
+		SET attempts = attempts + 1
+		WHERE id = $1
+		AND attempts < $2`)
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, ID, maxAttempts)
+	if err != nil {
+		return false, err
+	}
+
+	ra, _ := result.RowsAffected()
+	return ra == 1, nil
+}
+
+// Delete removes the verification of the phone number if its code hash still matches.
+func (r *verificationRepositoryPostgres) Delete(ctx context.Context, ID, codeHash string) (bool, error) {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		DELETE FROM verification
+		WHERE id = $1
+		AND code_hash = $2`)
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, ID, codeHash)
+	if err != nil {
+		return false, err
+	}
+
+	ra, _ := result.RowsAffected()
+	return ra == 1, nil
+}
@@ -0,0 +1,53 @@
+// Package sms implements the delivery of the text messages sent to the users, such as the
+// verification codes. The senders of this package are stand-ins for local use, the messages
+// are not delivered to the phones.
+package sms
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/tsmweb/auth-service/app/verification"
+)
+
+// NewLogSender returns a verification.SMSSender that writes the messages to the log.
+func NewLogSender() verification.SMSSender {
+	return verification.SMSSenderFunc(func(_ context.Context, phone, text string) error {
+		log.Printf("[INFO] SMS to %s: %s\n", phone, text)
+		return nil
+	})
+}
+
+// fileSender implements the verification.SMSSender interface.
+type fileSender struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileSender returns a verification.SMSSender that appends the messages to the file,
+// one per line in the format "<RFC 3339 time> <phone> <text>".
+func NewFileSender(path string) verification.SMSSender {
+	return &fileSender{
+		path: path,
+	}
+}
+
+func (s *fileSender) Send(_ context.Context, phone, text string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(f, "%s %s %s\n", time.Now().UTC().Format(time.RFC3339), phone, text)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
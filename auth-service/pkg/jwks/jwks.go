@@ -0,0 +1,44 @@
+// Package jwks signs the access tokens with a ring of RSA keys identified by the "kid"
+// header and publishes their public keys as a JSON Web Key Set (RFC 7517), so that the
+// other services verify the tokens without a copy of the key files and the signing key
+// can be rotated.
+package jwks
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+
+	sharedjwks "github.com/tsmweb/chat-server/pkg/jwks"
+)
+
+// NewJWK returns the JSON Web Key of the public key used to verify RS256 signatures, of the
+// type decoded by the other services.
+func NewJWK(kid string, pub *rsa.PublicKey) sharedjwks.JWK {
+	n, e := encodePublicKey(pub)
+	return sharedjwks.JWK{
+		Kty: "RSA",
+		Use: "sig",
+		Alg: "RS256",
+		Kid: kid,
+		N:   n,
+		E:   e,
+	}
+}
+
+// Thumbprint returns the JWK thumbprint of the public key (RFC 7638), used as the kid of
+// the keys loaded without one.
+func Thumbprint(pub *rsa.PublicKey) string {
+	n, e := encodePublicKey(pub)
+	// the required members in lexicographic order, without whitespace.
+	sum := sha256.Sum256([]byte(fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`, e, n)))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func encodePublicKey(pub *rsa.PublicKey) (string, string) {
+	n := base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+	e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	return n, e
+}
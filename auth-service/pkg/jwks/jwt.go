@@ -0,0 +1,80 @@
+package jwks
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/golang-jwt/jwt/v4/request"
+	"github.com/tsmweb/go-helper-api/auth"
+)
+
+// ErrUnknownKey is returned when the token is signed by a key not in the keyring.
+var ErrUnknownKey = errors.New("jwks: unknown signing key")
+
+type jwtAuth struct {
+	keyring *Keyring
+}
+
+// NewJWT returns an auth.JWT that signs the tokens with the signing key of the keyring,
+// informing its kid in the header, and verifies them with any key of the keyring.
+func NewJWT(keyring *Keyring) auth.JWT {
+	return &jwtAuth{keyring: keyring}
+}
+
+// GenerateToken signs the payload, valid for exp hours.
+func (j *jwtAuth) GenerateToken(payload map[string]interface{}, exp int) (string, error) {
+	claims := jwt.MapClaims{}
+	for k, v := range payload {
+		claims[k] = v
+	}
+	claims["exp"] = time.Now().Add(time.Duration(exp) * time.Hour).Unix()
+
+	kid, key := j.keyring.SigningKey()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	return token.SignedString(key)
+}
+
+// ExtractToken returns the token of the request if it is valid.
+func (j *jwtAuth) ExtractToken(r *http.Request) (string, error) {
+	token, err := j.parse(r)
+	if err != nil {
+		return "", err
+	}
+	return token.Raw, nil
+}
+
+// GetDataToken returns the claim of the valid token of the request.
+func (j *jwtAuth) GetDataToken(r *http.Request, key string) (interface{}, error) {
+	token, err := j.parse(r)
+	if err != nil {
+		return nil, err
+	}
+	return token.Claims.(jwt.MapClaims)[key], nil
+}
+
+func (j *jwtAuth) parse(r *http.Request) (*jwt.Token, error) {
+	return request.ParseFromRequest(r, request.OAuth2Extractor, j.key,
+		request.WithClaims(jwt.MapClaims{}))
+}
+
+// key returns the public key of the kid, the tokens issued before the key rotation
+// support carry no kid and are verified with the signing key.
+func (j *jwtAuth) key(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+		return nil, fmt.Errorf("jwks: unexpected signing method %v", token.Header["alg"])
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		kid, _ = j.keyring.SigningKey()
+	}
+	key, ok := j.keyring.PublicKey(kid)
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return key, nil
+}
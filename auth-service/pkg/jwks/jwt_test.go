@@ -0,0 +1,62 @@
+package jwks
+
+import (
+	"crypto/rsa"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newRequest(token string) *http.Request {
+	r, _ := http.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+	return r
+}
+
+func TestJWT(t *testing.T) {
+	//t.Parallel()
+	oldKey, newKey := newKey(t), newKey(t)
+	keys := map[string]*rsa.PrivateKey{"old": oldKey, "new": newKey}
+
+	oldRing, err := NewKeyring("old", keys)
+	assert.Nil(t, err)
+	newRing, err := NewKeyring("new", keys)
+	assert.Nil(t, err)
+	otherRing, err := NewKeyring("new", map[string]*rsa.PrivateKey{"new": oldKey})
+	assert.Nil(t, err)
+
+	payload := map[string]interface{}{"id": "+5518999999999"}
+
+	t.Run("when the token is signed by a key of the keyring", func(t *testing.T) {
+		//t.Parallel()
+		token, err := NewJWT(oldRing).GenerateToken(payload, 1)
+		assert.Nil(t, err)
+
+		// rotated: the old key still verifies the tokens it signed.
+		j := NewJWT(newRing)
+		raw, err := j.ExtractToken(newRequest(token))
+		assert.Nil(t, err)
+		assert.Equal(t, token, raw)
+
+		id, err := j.GetDataToken(newRequest(token), "id")
+		assert.Nil(t, err)
+		assert.Equal(t, "+5518999999999", id)
+	})
+
+	t.Run("when the token is invalid", func(t *testing.T) {
+		//t.Parallel()
+		token, err := NewJWT(otherRing).GenerateToken(payload, 1)
+		assert.Nil(t, err)
+		_, err = NewJWT(newRing).ExtractToken(newRequest(token))
+		assert.NotNil(t, err)
+
+		expired, err := NewJWT(newRing).GenerateToken(payload, -1)
+		assert.Nil(t, err)
+		_, err = NewJWT(newRing).ExtractToken(newRequest(expired))
+		assert.NotNil(t, err)
+
+		_, err = NewJWT(newRing).ExtractToken(newRequest(""))
+		assert.NotNil(t, err)
+	})
+}
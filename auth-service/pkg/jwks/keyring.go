@@ -0,0 +1,133 @@
+package jwks
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	sharedjwks "github.com/tsmweb/chat-server/pkg/jwks"
+)
+
+var (
+	ErrNoKeys            = errors.New("jwks: no signing keys")
+	ErrSigningKeyMissing = errors.New("jwks: signing key not found")
+	ErrInvalidKey        = errors.New("jwks: invalid RSA private key")
+)
+
+// Keyring holds the private keys of auth-service. The signing key signs the new tokens,
+// the others are still published to verify the tokens they signed until they expire.
+type Keyring struct {
+	signingKeyID string
+	keys         map[string]*rsa.PrivateKey
+}
+
+// NewKeyring returns a Keyring that signs with the key signingKeyID.
+func NewKeyring(signingKeyID string, keys map[string]*rsa.PrivateKey) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+	if _, ok := keys[signingKeyID]; !ok {
+		return nil, fmt.Errorf("%w: %q", ErrSigningKeyMissing, signingKeyID)
+	}
+
+	return &Keyring{
+		signingKeyID: signingKeyID,
+		keys:         keys,
+	}, nil
+}
+
+// LoadKeyring loads the PEM private keys of the files "<kid>.pem" in dir. When
+// signingKeyID is empty, dir must hold a single key, which signs the tokens.
+func LoadKeyring(dir, signingKeyID string) (*Keyring, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*rsa.PrivateKey, len(files))
+	for _, file := range files {
+		key, err := readPrivateKey(file)
+		if err != nil {
+			return nil, err
+		}
+		kid := strings.TrimSuffix(filepath.Base(file), ".pem")
+		keys[kid] = key
+
+		if signingKeyID == "" && len(files) == 1 {
+			signingKeyID = kid
+		}
+	}
+
+	return NewKeyring(signingKeyID, keys)
+}
+
+// LoadKeyFile loads the single PEM private key of the file, identified by its thumbprint.
+func LoadKeyFile(file string) (*Keyring, error) {
+	key, err := readPrivateKey(file)
+	if err != nil {
+		return nil, err
+	}
+
+	kid := Thumbprint(&key.PublicKey)
+	return NewKeyring(kid, map[string]*rsa.PrivateKey{kid: key})
+}
+
+// SigningKey returns the kid and the key that signs the new tokens.
+func (k *Keyring) SigningKey() (string, *rsa.PrivateKey) {
+	return k.signingKeyID, k.keys[k.signingKeyID]
+}
+
+// PublicKey returns the public key of the kid.
+func (k *Keyring) PublicKey(kid string) (*rsa.PublicKey, bool) {
+	key, ok := k.keys[kid]
+	if !ok {
+		return nil, false
+	}
+	return &key.PublicKey, true
+}
+
+// Set returns the public keys of the keyring, sorted by kid.
+func (k *Keyring) Set() *sharedjwks.Set {
+	kids := make([]string, 0, len(k.keys))
+	for kid := range k.keys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	set := &sharedjwks.Set{Keys: make([]sharedjwks.JWK, 0, len(kids))}
+	for _, kid := range kids {
+		set.Keys = append(set.Keys, NewJWK(kid, &k.keys[kid].PublicKey))
+	}
+	return set
+}
+
+func readPrivateKey(file string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidKey, file)
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidKey, file)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidKey, file)
+	}
+	return rsaKey, nil
+}
@@ -0,0 +1,93 @@
+package jwks
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func writeKey(t *testing.T, file string, key *rsa.PrivateKey) {
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	if err := os.WriteFile(file, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadKeyring(t *testing.T) {
+	//t.Parallel()
+	dir := t.TempDir()
+	oldKey, newKey := newKey(t), newKey(t)
+	writeKey(t, filepath.Join(dir, "2022-01.pem"), oldKey)
+
+	t.Run("when the dir holds a single key", func(t *testing.T) {
+		//t.Parallel()
+		k, err := LoadKeyring(dir, "")
+		assert.Nil(t, err)
+		kid, _ := k.SigningKey()
+		assert.Equal(t, "2022-01", kid)
+	})
+
+	writeKey(t, filepath.Join(dir, "2022-07.pem"), newKey)
+
+	t.Run("when the signing key is not informed", func(t *testing.T) {
+		//t.Parallel()
+		_, err := LoadKeyring(dir, "")
+		assert.ErrorIs(t, err, ErrSigningKeyMissing)
+
+		_, err = LoadKeyring(dir, "2023-01")
+		assert.ErrorIs(t, err, ErrSigningKeyMissing)
+
+		_, err = LoadKeyring(t.TempDir(), "")
+		assert.ErrorIs(t, err, ErrNoKeys)
+	})
+
+	t.Run("when the keys are loaded", func(t *testing.T) {
+		//t.Parallel()
+		k, err := LoadKeyring(dir, "2022-07")
+		assert.Nil(t, err)
+
+		kid, key := k.SigningKey()
+		assert.Equal(t, "2022-07", kid)
+		assert.True(t, newKey.Equal(key))
+
+		set := k.Set()
+		assert.Len(t, set.Keys, 2)
+		assert.Equal(t, NewJWK("2022-01", &oldKey.PublicKey), set.Keys[0])
+		assert.Equal(t, "2022-07", set.Keys[1].Kid)
+		assert.Equal(t, "AQAB", set.Keys[1].E)
+
+		// the keys are decoded by the other services.
+		pub, err := set.Keys[1].PublicKey()
+		assert.Nil(t, err)
+		assert.True(t, newKey.PublicKey.Equal(pub))
+	})
+}
+
+func TestLoadKeyFile(t *testing.T) {
+	//t.Parallel()
+	key := newKey(t)
+	file := filepath.Join(t.TempDir(), "server.pem")
+	writeKey(t, file, key)
+
+	k, err := LoadKeyFile(file)
+	assert.Nil(t, err)
+	kid, _ := k.SigningKey()
+	assert.Equal(t, Thumbprint(&key.PublicKey), kid)
+
+	_, err = LoadKeyFile(filepath.Join(t.TempDir(), "none.pem"))
+	assert.NotNil(t, err)
+}
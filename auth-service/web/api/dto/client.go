@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/tsmweb/auth-service/app/client"
+	"github.com/tsmweb/auth-service/app/token"
+	"github.com/tsmweb/chat-server/pkg/scope"
+)
+
+// Client data, a service account. The secret is only returned on creation.
+type Client struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Scopes    []string  `json:"scopes"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FromEntity mapper client.Client to Client
+func (c *Client) FromEntity(entity *client.Client) {
+	c.ID = entity.ID
+	c.Name = entity.Name
+	c.Scopes = entity.Scopes
+	c.CreatedBy = entity.CreatedBy
+	c.CreatedAt = entity.CreatedAt
+}
+
+// Webhook data, the URL where a service account receives its messages. The secret is only
+// returned when the webhook is set.
+type Webhook struct {
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FromEntity mapper client.Webhook to Webhook
+func (w *Webhook) FromEntity(entity *client.Webhook) {
+	w.URL = entity.URL
+	w.Secret = entity.Secret
+	w.CreatedAt = entity.CreatedAt
+}
+
+// OAuthToken data, the access token issued with the OAuth2 client credentials grant.
+type OAuthToken struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
+
+// FromEntity mapper token.Token to OAuthToken
+func (t *OAuthToken) FromEntity(entity *token.Token, scopes scope.Set) {
+	t.AccessToken = entity.AccessToken
+	t.TokenType = "Bearer"
+	t.ExpiresIn = entity.ExpiresIn
+	t.Scope = scopes.String()
+}
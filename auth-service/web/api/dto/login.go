@@ -2,10 +2,11 @@ package dto
 
 import "github.com/tsmweb/auth-service/app/login"
 
-// Login data.
+// Login data, the device name is optional and identifies the session of the login.
 type Login struct {
-	ID       string `json:"id"`
-	Password string `json:"password"`
+	ID         string `json:"id"`
+	Password   string `json:"password"`
+	DeviceName string `json:"device_name,omitempty"`
 }
 
 // ToEntity mapper Login to login.Login
@@ -15,3 +16,8 @@ func (p *Login) ToEntity() *login.Login {
 		Password: p.Password,
 	}
 }
+
+// Unlock data, the user whose failed login attempts are forgotten.
+type Unlock struct {
+	ID string `json:"id"`
+}
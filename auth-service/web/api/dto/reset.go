@@ -0,0 +1,12 @@
+package dto
+
+// ResetRequest data, requests a password reset token for the user.
+type ResetRequest struct {
+	ID string `json:"id"`
+}
+
+// ResetConfirm data, sets the new password with the reset token.
+type ResetConfirm struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
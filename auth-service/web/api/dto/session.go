@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/tsmweb/auth-service/app/session"
+)
+
+// Session data, Current is set on the session of the token of the request.
+type Session struct {
+	ID         string    `json:"id"`
+	DeviceName string    `json:"device_name,omitempty"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	IP         string    `json:"ip,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	Current    bool      `json:"current"`
+}
+
+// FromEntity mapper session.Session to Session
+func (s *Session) FromEntity(entity *session.Session, currentID string) {
+	s.ID = entity.ID
+	s.DeviceName = entity.DeviceName
+	s.UserAgent = entity.UserAgent
+	s.IP = entity.IP
+	s.CreatedAt = entity.CreatedAt
+	s.LastSeenAt = entity.LastSeenAt
+	s.Current = entity.ID == currentID
+}
@@ -1,6 +1,28 @@
 package dto
 
+import "github.com/tsmweb/auth-service/app/token"
+
 // TokenAuth JWT token.
 type TokenAuth struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int    `json:"expires_in,omitempty"`
+}
+
+// FromEntity mapper token.Token to TokenAuth
+func (t *TokenAuth) FromEntity(entity *token.Token) {
+	t.Token = entity.AccessToken
+	t.RefreshToken = entity.RefreshToken
+	t.ExpiresIn = entity.ExpiresIn
+}
+
+// RefreshToken data.
+type RefreshToken struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Logout data, the refresh token is revoked along with the access token.
+type Logout struct {
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Everywhere   bool   `json:"everywhere,omitempty"`
 }
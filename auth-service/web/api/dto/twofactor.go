@@ -0,0 +1,44 @@
+package dto
+
+import "github.com/tsmweb/auth-service/app/twofactor"
+
+// TwoFactorEnrolment data, the TOTP secret to be added to the authenticator app.
+type TwoFactorEnrolment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// FromEntity mapper twofactor.TwoFactor to TwoFactorEnrolment
+func (e *TwoFactorEnrolment) FromEntity(entity *twofactor.TwoFactor) {
+	e.Secret = entity.Secret
+	e.URI = entity.URI()
+}
+
+// TwoFactorCode data, a TOTP or recovery code.
+type TwoFactorCode struct {
+	Code string `json:"code"`
+}
+
+// RecoveryCodes data.
+type RecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TwoFactorChallenge data, returned by the login when two-factor authentication is enabled.
+type TwoFactorChallenge struct {
+	ChallengeToken string `json:"challenge_token"`
+	ExpiresIn      int    `json:"expires_in"`
+}
+
+// FromEntity mapper twofactor.ChallengeToken to TwoFactorChallenge
+func (c *TwoFactorChallenge) FromEntity(entity *twofactor.ChallengeToken) {
+	c.ChallengeToken = entity.Token
+	c.ExpiresIn = entity.ExpiresIn
+}
+
+// TwoFactorLogin data, the second step of the login.
+type TwoFactorLogin struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+	DeviceName     string `json:"device_name,omitempty"`
+}
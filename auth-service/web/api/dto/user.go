@@ -11,6 +11,7 @@ type User struct {
 	Name      string    `json:"name"`
 	LastName  string    `json:"lastname"`
 	Password  string    `json:"password,omitempty"`
+	Code      string    `json:"code,omitempty"` // verification code sent to the phone on sign-up
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -33,3 +34,43 @@ func (u *User) FromEntity(entity *user.User) {
 	u.CreatedAt = entity.CreatedAt
 	u.UpdatedAt = entity.UpdatedAt
 }
+
+// DeleteUser data, the current password confirms the deletion of the account.
+type DeleteUser struct {
+	Password string `json:"password"`
+}
+
+// DeletionStep data, the step of a service pending while CompletedAt is nil.
+type DeletionStep struct {
+	Service     string     `json:"service"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// Deletion data, the report of the removal of the data of a deleted user.
+type Deletion struct {
+	UserID      string          `json:"user_id"`
+	RequestedAt time.Time       `json:"requested_at"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+	Steps       []*DeletionStep `json:"steps"`
+}
+
+// FromEntity mapper user.Deletion to Deletion
+func (p *Deletion) FromEntity(d *user.Deletion) {
+	p.UserID = d.UserID
+	p.RequestedAt = d.RequestedAt
+	p.CompletedAt = timeOrNil(d.CompletedAt)
+	p.Steps = make([]*DeletionStep, 0, len(d.Steps))
+	for _, s := range d.Steps {
+		p.Steps = append(p.Steps, &DeletionStep{
+			Service:     s.Service,
+			CompletedAt: timeOrNil(s.CompletedAt),
+		})
+	}
+}
+
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,6 @@
+package dto
+
+// Verification data, requests a verification code for the phone number.
+type Verification struct {
+	ID string `json:"id"`
+}
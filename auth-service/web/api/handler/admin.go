@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tsmweb/auth-service/app/login"
+	"github.com/tsmweb/auth-service/app/user"
+	"github.com/tsmweb/auth-service/config"
+	"github.com/tsmweb/auth-service/web/api/dto"
+	"github.com/tsmweb/go-helper-api/auth"
+	"github.com/tsmweb/go-helper-api/cerror"
+	"github.com/tsmweb/go-helper-api/httputil"
+	"github.com/tsmweb/go-helper-api/middleware"
+	"github.com/urfave/negroni"
+)
+
+// RequireAdmin only allows the request to proceed if the token user is set in config.AdminUsers.
+func RequireAdmin(jwt auth.JWT) negroni.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+		data, err := jwt.GetDataToken(r, "id")
+		if err != nil || data == nil {
+			log.Println("[ERROR] RequireAdmin: could not get token user")
+			httputil.RespondWithError(w, http.StatusInternalServerError,
+				http.StatusText(http.StatusInternalServerError))
+			return
+		}
+		userID, _ := data.(string)
+
+		for _, adminID := range config.AdminUsers() {
+			if adminID == userID {
+				next(w, r)
+				return
+			}
+		}
+
+		httputil.RespondWithError(w, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+	}
+}
+
+// UnlockLogin forgets the failed login attempts of the user, lifting the delays and the lockout.
+func UnlockLogin(unlockUseCase login.UnlockUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !httputil.HasContentType(r, httputil.MimeApplicationJSON) {
+			httputil.RespondWithError(w, http.StatusUnsupportedMediaType, http.StatusText(http.StatusUnsupportedMediaType))
+			return
+		}
+
+		input := dto.Unlock{}
+		decoder := json.NewDecoder(r.Body)
+
+		if err := decoder.Decode(&input); err != nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusUnprocessableEntity, "Malformed JSON")
+			return
+		}
+
+		if err := unlockUseCase.Execute(r.Context(), input.ID); err != nil {
+			log.Println(err.Error())
+			var errValidateModel *cerror.ErrValidateModel
+			if errors.As(err, &errValidateModel) {
+				httputil.RespondWithError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// GetUserDeletion reports the steps of the deletion of the user completed by each service.
+func GetUserDeletion(getDeletionUseCase user.GetDeletionUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		userID := vars["id"]
+
+		d, err := getDeletionUseCase.Execute(r.Context(), userID)
+		if err != nil {
+			log.Println(err.Error())
+
+			if errors.Is(err, user.ErrDeletionNotFound) {
+				httputil.RespondWithError(w, http.StatusNotFound, err.Error())
+				return
+			}
+
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		deletionDto := &dto.Deletion{}
+		deletionDto.FromEntity(d)
+
+		httputil.RespondWithJSON(w, http.StatusOK, deletionDto)
+	})
+}
+
+// RepublishUserDeletion publishes again the deletion of the user while some service has not
+// reported its step.
+func RepublishUserDeletion(republishDeletionUseCase user.RepublishDeletionUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		userID := vars["id"]
+
+		if err := republishDeletionUseCase.Execute(r.Context(), userID); err != nil {
+			log.Println(err.Error())
+
+			if errors.Is(err, user.ErrDeletionNotFound) {
+				httputil.RespondWithError(w, http.StatusNotFound, err.Error())
+				return
+			}
+
+			if errors.Is(err, user.ErrDeletionCompleted) {
+				httputil.RespondWithError(w, http.StatusConflict, err.Error())
+				return
+			}
+
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+const adminApiVersion string = "v1"
+
+var adminResource string
+
+func init() {
+	adminResource = fmt.Sprintf("/%s/admin", adminApiVersion)
+}
+
+// MakeAdminHandlers creates the handlers of the administration of the accounts.
+func MakeAdminHandlers(
+	r *mux.Router,
+	jwt auth.JWT,
+	auth middleware.Auth,
+	unlockUseCase login.UnlockUseCase,
+	getDeletionUseCase user.GetDeletionUseCase,
+	republishDeletionUseCase user.RepublishDeletionUseCase) {
+
+	// admin/login/unlock [POST]
+	r.Handle(fmt.Sprintf("%s/login/unlock", adminResource), negroni.New(
+		negroni.HandlerFunc(auth.RequireTokenAuth),
+		RequireAdmin(jwt),
+		negroni.Wrap(UnlockLogin(unlockUseCase))),
+	).Methods(http.MethodPost)
+
+	// admin/user/{id}/deletion [GET]
+	r.Handle(fmt.Sprintf("%s/user/{id}/deletion", adminResource), negroni.New(
+		negroni.HandlerFunc(auth.RequireTokenAuth),
+		RequireAdmin(jwt),
+		negroni.Wrap(GetUserDeletion(getDeletionUseCase))),
+	).Methods(http.MethodGet)
+
+	// admin/user/{id}/deletion/republish [POST]
+	r.Handle(fmt.Sprintf("%s/user/{id}/deletion/republish", adminResource), negroni.New(
+		negroni.HandlerFunc(auth.RequireTokenAuth),
+		RequireAdmin(jwt),
+		negroni.Wrap(RepublishUserDeletion(republishDeletionUseCase))),
+	).Methods(http.MethodPost)
+}
@@ -0,0 +1,248 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/login"
+	"github.com/tsmweb/auth-service/app/user"
+	"github.com/tsmweb/auth-service/common"
+	"github.com/tsmweb/auth-service/config"
+	"github.com/tsmweb/auth-service/web/api/dto"
+	"github.com/tsmweb/go-helper-api/middleware"
+)
+
+const adminID = "+5518977777777"
+
+func newAdminRouter(
+	t *testing.T,
+	userID string,
+	unlockUseCase login.UnlockUseCase,
+	getDeletionUseCase user.GetDeletionUseCase,
+	republishDeletionUseCase user.RepublishDeletionUseCase,
+) *mux.Router {
+	t.Setenv("ADMIN_USERS", adminID)
+	if err := config.Load("../../../"); err != nil {
+		t.Fatal(err)
+	}
+
+	mJWT := new(common.MockJWT)
+	mJWT.On("ExtractToken", mock.Anything).Return("token", nil)
+	mJWT.On("GetDataToken", mock.Anything, "id").Return(userID, nil)
+
+	router := mux.NewRouter()
+	MakeAdminHandlers(router, mJWT, middleware.NewAuth(mJWT), unlockUseCase,
+		getDeletionUseCase, republishDeletionUseCase)
+	return router
+}
+
+func TestHandler_UnlockLogin(t *testing.T) {
+	//t.Parallel()
+	resource := fmt.Sprintf("%s/login/unlock", adminResource)
+	body, _ := json.Marshal(&dto.Unlock{ID: "+5518999999999"})
+
+	serve := func(userID, contentType string, body []byte, uc login.UnlockUseCase) int {
+		req := httptest.NewRequest(http.MethodPost, resource, bytes.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		rec := httptest.NewRecorder()
+
+		newAdminRouter(t, userID, uc, new(mockGetDeletionUseCase),
+			new(mockRepublishDeletionUseCase)).ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("when user is not admin", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockUnlockUseCase)
+
+		code := serve("+5518966666666", "application/json", body, uc)
+		assert.Equal(t, http.StatusForbidden, code)
+		uc.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when handler.UnlockLogin return StatusUnsupportedMediaType", func(t *testing.T) {
+		//t.Parallel()
+		code := serve(adminID, "text/plain", body, new(mockUnlockUseCase))
+		assert.Equal(t, http.StatusUnsupportedMediaType, code)
+	})
+
+	t.Run("when handler.UnlockLogin return StatusUnprocessableEntity", func(t *testing.T) {
+		//t.Parallel()
+		code := serve(adminID, "application/json", []byte("{[}"), new(mockUnlockUseCase))
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+	})
+
+	t.Run("when handler.UnlockLogin return StatusBadRequest", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockUnlockUseCase)
+		uc.On("Execute", mock.Anything, "+5518999999999").
+			Return(login.ErrIDValidateModel).
+			Once()
+
+		code := serve(adminID, "application/json", body, uc)
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("when handler.UnlockLogin return StatusInternalServerError", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockUnlockUseCase)
+		uc.On("Execute", mock.Anything, "+5518999999999").
+			Return(errors.New("error")).
+			Once()
+
+		code := serve(adminID, "application/json", body, uc)
+		assert.Equal(t, http.StatusInternalServerError, code)
+	})
+
+	t.Run("when handler.UnlockLogin return StatusOK", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockUnlockUseCase)
+		uc.On("Execute", mock.Anything, "+5518999999999").
+			Return(nil).
+			Once()
+
+		code := serve(adminID, "application/json", body, uc)
+		assert.Equal(t, http.StatusOK, code)
+		uc.AssertExpectations(t)
+	})
+}
+
+func TestHandler_GetUserDeletion(t *testing.T) {
+	//t.Parallel()
+	userID := "+5518999999999"
+	resource := fmt.Sprintf("%s/user/%s/deletion", adminResource, userID)
+
+	serve := func(adminUserID string, uc user.GetDeletionUseCase) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, resource, nil)
+		rec := httptest.NewRecorder()
+
+		newAdminRouter(t, adminUserID, new(mockUnlockUseCase), uc,
+			new(mockRepublishDeletionUseCase)).ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("when user is not admin", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockGetDeletionUseCase)
+
+		rec := serve("+5518966666666", uc)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		uc.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when handler.GetUserDeletion return StatusNotFound", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockGetDeletionUseCase)
+		uc.On("Execute", mock.Anything, userID).
+			Return(nil, user.ErrDeletionNotFound).
+			Once()
+
+		rec := serve(adminID, uc)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("when handler.GetUserDeletion return StatusInternalServerError", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockGetDeletionUseCase)
+		uc.On("Execute", mock.Anything, userID).
+			Return(nil, errors.New("error")).
+			Once()
+
+		rec := serve(adminID, uc)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("when handler.GetUserDeletion return StatusOK", func(t *testing.T) {
+		//t.Parallel()
+		d := user.NewDeletion(userID)
+		d.CompleteStep(user.ServiceName, d.RequestedAt)
+
+		uc := new(mockGetDeletionUseCase)
+		uc.On("Execute", mock.Anything, userID).
+			Return(d, nil).
+			Once()
+
+		rec := serve(adminID, uc)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		deletionDto := &dto.Deletion{}
+		err := json.NewDecoder(rec.Body).Decode(deletionDto)
+		assert.Nil(t, err)
+		assert.Equal(t, userID, deletionDto.UserID)
+		assert.Len(t, deletionDto.Steps, len(user.DeletionServices))
+		assert.Nil(t, deletionDto.CompletedAt)
+		uc.AssertExpectations(t)
+	})
+}
+
+func TestHandler_RepublishUserDeletion(t *testing.T) {
+	//t.Parallel()
+	userID := "+5518999999999"
+	resource := fmt.Sprintf("%s/user/%s/deletion/republish", adminResource, userID)
+
+	serve := func(adminUserID string, uc user.RepublishDeletionUseCase) int {
+		req := httptest.NewRequest(http.MethodPost, resource, nil)
+		rec := httptest.NewRecorder()
+
+		newAdminRouter(t, adminUserID, new(mockUnlockUseCase), new(mockGetDeletionUseCase), uc).
+			ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("when user is not admin", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockRepublishDeletionUseCase)
+
+		assert.Equal(t, http.StatusForbidden, serve("+5518966666666", uc))
+		uc.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when handler.RepublishUserDeletion return StatusNotFound", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockRepublishDeletionUseCase)
+		uc.On("Execute", mock.Anything, userID).
+			Return(user.ErrDeletionNotFound).
+			Once()
+
+		assert.Equal(t, http.StatusNotFound, serve(adminID, uc))
+	})
+
+	t.Run("when handler.RepublishUserDeletion return StatusConflict", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockRepublishDeletionUseCase)
+		uc.On("Execute", mock.Anything, userID).
+			Return(user.ErrDeletionCompleted).
+			Once()
+
+		assert.Equal(t, http.StatusConflict, serve(adminID, uc))
+	})
+
+	t.Run("when handler.RepublishUserDeletion return StatusInternalServerError", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockRepublishDeletionUseCase)
+		uc.On("Execute", mock.Anything, userID).
+			Return(&user.ErrEventNotification{Msg: "error"}).
+			Once()
+
+		assert.Equal(t, http.StatusInternalServerError, serve(adminID, uc))
+	})
+
+	t.Run("when handler.RepublishUserDeletion return StatusAccepted", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockRepublishDeletionUseCase)
+		uc.On("Execute", mock.Anything, userID).
+			Return(nil).
+			Once()
+
+		assert.Equal(t, http.StatusAccepted, serve(adminID, uc))
+		uc.AssertExpectations(t)
+	})
+}
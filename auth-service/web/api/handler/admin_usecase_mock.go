@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/user"
+)
+
+// mockUnlockUseCase injects mock dependency into Handler layer.
+type mockUnlockUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockUnlockUseCase) Execute(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+// mockGetDeletionUseCase injects mock dependency into Handler layer.
+type mockGetDeletionUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockGetDeletionUseCase) Execute(ctx context.Context, userID string) (*user.Deletion, error) {
+	args := m.Called(ctx, userID)
+	d, _ := args.Get(0).(*user.Deletion)
+	return d, args.Error(1)
+}
+
+// mockRepublishDeletionUseCase injects mock dependency into Handler layer.
+type mockRepublishDeletionUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockRepublishDeletionUseCase) Execute(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,328 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/tsmweb/auth-service/app/client"
+	"github.com/tsmweb/auth-service/web/api/dto"
+	"github.com/tsmweb/chat-server/pkg/scope"
+	"github.com/tsmweb/go-helper-api/auth"
+	"github.com/tsmweb/go-helper-api/cerror"
+	"github.com/tsmweb/go-helper-api/httputil"
+	"github.com/tsmweb/go-helper-api/middleware"
+	"github.com/urfave/negroni"
+)
+
+// CreateClient creates a service account, returning its secret only once.
+func CreateClient(jwt auth.JWT, createUseCase client.CreateUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !httputil.HasContentType(r, httputil.MimeApplicationJSON) {
+			httputil.RespondWithError(w, http.StatusUnsupportedMediaType, http.StatusText(http.StatusUnsupportedMediaType))
+			return
+		}
+
+		data, err := jwt.GetDataToken(r, "id")
+		if err != nil || data == nil {
+			log.Println("[ERROR] CreateClient: could not get token user")
+			httputil.RespondWithError(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+		userID := data.(string)
+
+		input := dto.Client{}
+		decoder := json.NewDecoder(r.Body)
+
+		if err = decoder.Decode(&input); err != nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusUnprocessableEntity, "Malformed JSON")
+			return
+		}
+
+		c, secret, err := createUseCase.Execute(r.Context(), input.Name, input.Scopes, userID)
+		if err != nil {
+			log.Println(err.Error())
+			var errValidateModel *cerror.ErrValidateModel
+			if errors.As(err, &errValidateModel) {
+				httputil.RespondWithError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		clientDto := &dto.Client{}
+		clientDto.FromEntity(c)
+		clientDto.Secret = secret
+
+		httputil.RespondWithJSON(w, http.StatusCreated, clientDto)
+	})
+}
+
+// GetAllClients returns the service accounts not revoked.
+func GetAllClients(getAllUseCase client.GetAllUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clients, err := getAllUseCase.Execute(r.Context())
+		if err != nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		clientsDto := make([]*dto.Client, 0, len(clients))
+		for _, c := range clients {
+			clientDto := &dto.Client{}
+			clientDto.FromEntity(c)
+			clientsDto = append(clientsDto, clientDto)
+		}
+
+		httputil.RespondWithJSON(w, http.StatusOK, clientsDto)
+	})
+}
+
+// RevokeClient revokes a service account along with its access tokens.
+func RevokeClient(revokeUseCase client.RevokeUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		ID := vars["id"]
+
+		if err := revokeUseCase.Execute(r.Context(), ID); err != nil {
+			log.Println(err.Error())
+			if errors.Is(err, client.ErrClientNotFound) {
+				httputil.RespondWithError(w, http.StatusNotFound, err.Error())
+				return
+			}
+
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// RequireClient only allows the request to proceed if the token belongs to a service account.
+func RequireClient(jwt auth.JWT) negroni.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+		data, err := jwt.GetDataToken(r, "id")
+		if err != nil || data == nil {
+			log.Println("[ERROR] RequireClient: could not get token user")
+			httputil.RespondWithError(w, http.StatusInternalServerError,
+				http.StatusText(http.StatusInternalServerError))
+			return
+		}
+		userID, _ := data.(string)
+
+		if !strings.HasPrefix(userID, client.IDPrefix) {
+			httputil.RespondWithError(w, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// SetWebhook registers the URL where the service account of the token receives its messages,
+// returning the secret that signs the deliveries.
+func SetWebhook(jwt auth.JWT, setWebhookUseCase client.SetWebhookUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !httputil.HasContentType(r, httputil.MimeApplicationJSON) {
+			httputil.RespondWithError(w, http.StatusUnsupportedMediaType, http.StatusText(http.StatusUnsupportedMediaType))
+			return
+		}
+
+		data, err := jwt.GetDataToken(r, "id")
+		if err != nil || data == nil {
+			log.Println("[ERROR] SetWebhook: could not get token user")
+			httputil.RespondWithError(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+		clientID := data.(string)
+
+		input := dto.Webhook{}
+		decoder := json.NewDecoder(r.Body)
+
+		if err = decoder.Decode(&input); err != nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusUnprocessableEntity, "Malformed JSON")
+			return
+		}
+
+		webhook, err := setWebhookUseCase.Execute(r.Context(), clientID, input.URL)
+		if err != nil {
+			log.Println(err.Error())
+			var errValidateModel *cerror.ErrValidateModel
+			if errors.As(err, &errValidateModel) {
+				httputil.RespondWithError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			if errors.Is(err, client.ErrClientNotFound) {
+				httputil.RespondWithError(w, http.StatusNotFound, err.Error())
+				return
+			}
+
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		webhookDto := &dto.Webhook{}
+		webhookDto.FromEntity(webhook)
+
+		httputil.RespondWithJSON(w, http.StatusOK, webhookDto)
+	})
+}
+
+// DeleteWebhook removes the webhook of the service account of the token.
+func DeleteWebhook(jwt auth.JWT, deleteWebhookUseCase client.DeleteWebhookUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := jwt.GetDataToken(r, "id")
+		if err != nil || data == nil {
+			log.Println("[ERROR] DeleteWebhook: could not get token user")
+			httputil.RespondWithError(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+		clientID := data.(string)
+
+		if err = deleteWebhookUseCase.Execute(r.Context(), clientID); err != nil {
+			log.Println(err.Error())
+			if errors.Is(err, client.ErrWebhookNotFound) {
+				httputil.RespondWithError(w, http.StatusNotFound, err.Error())
+				return
+			}
+
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// OAuthToken issues an access token to a service account with the OAuth2 client credentials
+// grant. The credentials are informed with HTTP Basic authentication or in the client_id and
+// client_secret parameters of the form.
+func OAuthToken(tokenUseCase client.TokenUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Content-Type"), mimeApplicationForm) {
+			httputil.RespondWithError(w, http.StatusUnsupportedMediaType, http.StatusText(http.StatusUnsupportedMediaType))
+			return
+		}
+
+		if err := r.ParseForm(); err != nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusBadRequest, "Malformed form")
+			return
+		}
+		if r.PostForm.Get("grant_type") != "client_credentials" {
+			httputil.RespondWithError(w, http.StatusBadRequest, "unsupported grant type")
+			return
+		}
+
+		ID, secret, ok := r.BasicAuth()
+		if !ok {
+			ID = r.PostForm.Get("client_id")
+			secret = r.PostForm.Get("client_secret")
+		}
+
+		tk, scopes, err := tokenUseCase.Execute(r.Context(), ID, secret, r.PostForm.Get("scope"))
+		if err != nil {
+			log.Println(err.Error())
+			if errors.Is(err, client.ErrInvalidClient) {
+				httputil.RespondWithError(w, http.StatusUnauthorized, err.Error())
+				return
+			}
+
+			if errors.Is(err, client.ErrInvalidScope) {
+				httputil.RespondWithError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		tokenDto := &dto.OAuthToken{}
+		tokenDto.FromEntity(tk, scopes)
+
+		w.Header().Set("Cache-Control", "no-store")
+		httputil.RespondWithJSON(w, http.StatusOK, tokenDto)
+	})
+}
+
+const clientApiVersion string = "v1"
+
+// mimeApplicationForm is the content type of the token requests, as required by OAuth2.
+const mimeApplicationForm = "application/x-www-form-urlencoded"
+
+var clientResource string
+var oauthTokenResource string
+var webhookResource string
+
+func init() {
+	clientResource = fmt.Sprintf("/%s/admin/client", clientApiVersion)
+	oauthTokenResource = fmt.Sprintf("/%s/oauth/token", clientApiVersion)
+	webhookResource = fmt.Sprintf("/%s/client/webhook", clientApiVersion)
+}
+
+// MakeClientHandlers creates the handlers of the service accounts, administered by the users
+// set in config.AdminUsers, of the token endpoint where they obtain the access tokens and of
+// their webhooks.
+func MakeClientHandlers(
+	r *mux.Router,
+	jwt auth.JWT,
+	auth middleware.Auth,
+	createUseCase client.CreateUseCase,
+	getAllUseCase client.GetAllUseCase,
+	revokeUseCase client.RevokeUseCase,
+	tokenUseCase client.TokenUseCase,
+	setWebhookUseCase client.SetWebhookUseCase,
+	deleteWebhookUseCase client.DeleteWebhookUseCase) {
+
+	// admin/client [POST]
+	r.Handle(clientResource, negroni.New(
+		negroni.HandlerFunc(auth.RequireTokenAuth),
+		RequireAdmin(jwt),
+		negroni.Wrap(CreateClient(jwt, createUseCase))),
+	).Methods(http.MethodPost)
+
+	// admin/client [GET]
+	r.Handle(clientResource, negroni.New(
+		negroni.HandlerFunc(auth.RequireTokenAuth),
+		RequireAdmin(jwt),
+		negroni.Wrap(GetAllClients(getAllUseCase))),
+	).Methods(http.MethodGet)
+
+	// admin/client/{id} [DELETE]
+	r.Handle(fmt.Sprintf("%s/{id}", clientResource), negroni.New(
+		negroni.HandlerFunc(auth.RequireTokenAuth),
+		RequireAdmin(jwt),
+		negroni.Wrap(RevokeClient(revokeUseCase))),
+	).Methods(http.MethodDelete)
+
+	// oauth/token [POST]
+	r.Handle(oauthTokenResource, OAuthToken(tokenUseCase)).
+		Methods(http.MethodPost)
+
+	// client/webhook [PUT]
+	r.Handle(webhookResource, negroni.New(
+		negroni.HandlerFunc(auth.RequireTokenAuth),
+		RequireClient(jwt),
+		negroni.HandlerFunc(scope.Require(jwt, scope.WebhookWrite)),
+		negroni.Wrap(SetWebhook(jwt, setWebhookUseCase))),
+	).Methods(http.MethodPut)
+
+	// client/webhook [DELETE]
+	r.Handle(webhookResource, negroni.New(
+		negroni.HandlerFunc(auth.RequireTokenAuth),
+		RequireClient(jwt),
+		negroni.HandlerFunc(scope.Require(jwt, scope.WebhookWrite)),
+		negroni.Wrap(DeleteWebhook(jwt, deleteWebhookUseCase))),
+	).Methods(http.MethodDelete)
+}
@@ -0,0 +1,377 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/client"
+	"github.com/tsmweb/auth-service/app/token"
+	"github.com/tsmweb/auth-service/common"
+	"github.com/tsmweb/auth-service/config"
+	"github.com/tsmweb/auth-service/web/api/dto"
+	"github.com/tsmweb/chat-server/pkg/scope"
+	"github.com/tsmweb/go-helper-api/middleware"
+)
+
+func newClientRouter(
+	t *testing.T,
+	userID string,
+	createUseCase client.CreateUseCase,
+	getAllUseCase client.GetAllUseCase,
+	revokeUseCase client.RevokeUseCase,
+	tokenUseCase client.TokenUseCase,
+) *mux.Router {
+	t.Setenv("ADMIN_USERS", adminID)
+	if err := config.Load("../../../"); err != nil {
+		t.Fatal(err)
+	}
+
+	mJWT := new(common.MockJWT)
+	mJWT.On("ExtractToken", mock.Anything).Return("token", nil)
+	mJWT.On("GetDataToken", mock.Anything, "id").Return(userID, nil)
+
+	router := mux.NewRouter()
+	MakeClientHandlers(router, mJWT, middleware.NewAuth(mJWT), createUseCase, getAllUseCase,
+		revokeUseCase, tokenUseCase, new(mockSetWebhookUseCase), new(mockDeleteWebhookUseCase))
+	return router
+}
+
+func newWebhookRouter(
+	userID, scopes string,
+	setWebhookUseCase client.SetWebhookUseCase,
+	deleteWebhookUseCase client.DeleteWebhookUseCase,
+) *mux.Router {
+	mJWT := new(common.MockJWT)
+	mJWT.On("ExtractToken", mock.Anything).Return("token", nil)
+	mJWT.On("GetDataToken", mock.Anything, "id").Return(userID, nil)
+	mJWT.On("GetDataToken", mock.Anything, "scope").Return(scopes, nil)
+
+	router := mux.NewRouter()
+	MakeClientHandlers(router, mJWT, middleware.NewAuth(mJWT), new(mockCreateClientUseCase),
+		new(mockGetAllClientsUseCase), new(mockRevokeClientUseCase), new(mockClientTokenUseCase),
+		setWebhookUseCase, deleteWebhookUseCase)
+	return router
+}
+
+func TestHandler_CreateClient(t *testing.T) {
+	//t.Parallel()
+	scopes := []string{scope.Group("G1")}
+	body, _ := json.Marshal(&dto.Client{Name: "Bot", Scopes: scopes})
+
+	serve := func(userID string, uc client.CreateUseCase) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, clientResource, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		newClientRouter(t, userID, uc, new(mockGetAllClientsUseCase), new(mockRevokeClientUseCase),
+			new(mockClientTokenUseCase)).ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("when user is not admin", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockCreateClientUseCase)
+
+		rec := serve("+5518966666666", uc)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		uc.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when handler.CreateClient return StatusBadRequest", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockCreateClientUseCase)
+		uc.On("Execute", mock.Anything, "Bot", scopes, adminID).
+			Return(nil, "", client.ErrScopeValidateModel).
+			Once()
+
+		rec := serve(adminID, uc)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("when handler.CreateClient return StatusCreated", func(t *testing.T) {
+		//t.Parallel()
+		c := &client.Client{ID: client.IDPrefix + "A1", Name: "Bot", Scopes: scopes,
+			CreatedBy: adminID}
+
+		uc := new(mockCreateClientUseCase)
+		uc.On("Execute", mock.Anything, "Bot", scopes, adminID).
+			Return(c, "S3CR3T", nil).
+			Once()
+
+		rec := serve(adminID, uc)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+
+		clientDto := &dto.Client{}
+		err := json.NewDecoder(rec.Body).Decode(clientDto)
+		assert.Nil(t, err)
+		assert.Equal(t, c.ID, clientDto.ID)
+		assert.Equal(t, "S3CR3T", clientDto.Secret)
+		uc.AssertExpectations(t)
+	})
+}
+
+func TestHandler_GetAllClients(t *testing.T) {
+	//t.Parallel()
+	serve := func(uc client.GetAllUseCase) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, clientResource, nil)
+		rec := httptest.NewRecorder()
+
+		newClientRouter(t, adminID, new(mockCreateClientUseCase), uc, new(mockRevokeClientUseCase),
+			new(mockClientTokenUseCase)).ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("when handler.GetAllClients return StatusInternalServerError", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockGetAllClientsUseCase)
+		uc.On("Execute", mock.Anything).
+			Return(nil, errors.New("error")).
+			Once()
+
+		assert.Equal(t, http.StatusInternalServerError, serve(uc).Code)
+	})
+
+	t.Run("when handler.GetAllClients return StatusOK", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockGetAllClientsUseCase)
+		uc.On("Execute", mock.Anything).
+			Return([]*client.Client{{ID: client.IDPrefix + "A1", Name: "Bot",
+				SecretHash: "hash"}}, nil).
+			Once()
+
+		rec := serve(uc)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "hash")
+	})
+}
+
+func TestHandler_RevokeClient(t *testing.T) {
+	//t.Parallel()
+	ID := client.IDPrefix + "A1"
+
+	serve := func(uc client.RevokeUseCase) int {
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%s", clientResource, ID), nil)
+		rec := httptest.NewRecorder()
+
+		newClientRouter(t, adminID, new(mockCreateClientUseCase), new(mockGetAllClientsUseCase), uc,
+			new(mockClientTokenUseCase)).ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("when handler.RevokeClient return StatusNotFound", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockRevokeClientUseCase)
+		uc.On("Execute", mock.Anything, ID).
+			Return(client.ErrClientNotFound).
+			Once()
+
+		assert.Equal(t, http.StatusNotFound, serve(uc))
+	})
+
+	t.Run("when handler.RevokeClient return StatusOK", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockRevokeClientUseCase)
+		uc.On("Execute", mock.Anything, ID).
+			Return(nil).
+			Once()
+
+		assert.Equal(t, http.StatusOK, serve(uc))
+		uc.AssertExpectations(t)
+	})
+}
+
+func TestHandler_OAuthToken(t *testing.T) {
+	//t.Parallel()
+	ID := client.IDPrefix + "A1"
+
+	serve := func(form url.Values, basicAuth bool, uc client.TokenUseCase) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, oauthTokenResource,
+			strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if basicAuth {
+			req.SetBasicAuth(ID, "S3CR3T")
+		}
+		rec := httptest.NewRecorder()
+
+		OAuthToken(uc).ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("when handler.OAuthToken return StatusUnsupportedMediaType", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodPost, oauthTokenResource, strings.NewReader("{}"))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		OAuthToken(new(mockClientTokenUseCase)).ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	})
+
+	t.Run("when handler.OAuthToken return StatusBadRequest", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockClientTokenUseCase)
+
+		rec := serve(url.Values{"grant_type": {"password"}}, true, uc)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		uc.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when handler.OAuthToken return StatusUnauthorized", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockClientTokenUseCase)
+		uc.On("Execute", mock.Anything, ID, "wrong", "").
+			Return(nil, nil, client.ErrInvalidClient).
+			Once()
+
+		form := url.Values{
+			"grant_type":    {"client_credentials"},
+			"client_id":     {ID},
+			"client_secret": {"wrong"},
+		}
+		rec := serve(form, false, uc)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("when handler.OAuthToken return StatusOK", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockClientTokenUseCase)
+		uc.On("Execute", mock.Anything, ID, "S3CR3T", scope.ContactsRead).
+			Return(&token.Token{AccessToken: "A1B2C3D4E5F6", ExpiresIn: 3600},
+				scope.Parse(scope.ContactsRead), nil).
+			Once()
+
+		form := url.Values{
+			"grant_type": {"client_credentials"},
+			"scope":      {scope.ContactsRead},
+		}
+		rec := serve(form, true, uc)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		tokenDto := &dto.OAuthToken{}
+		err := json.NewDecoder(rec.Body).Decode(tokenDto)
+		assert.Nil(t, err)
+		assert.Equal(t, "A1B2C3D4E5F6", tokenDto.AccessToken)
+		assert.Equal(t, "Bearer", tokenDto.TokenType)
+		assert.Equal(t, scope.ContactsRead, tokenDto.Scope)
+		uc.AssertExpectations(t)
+	})
+}
+
+func TestHandler_SetWebhook(t *testing.T) {
+	//t.Parallel()
+	ID := client.IDPrefix + "A1"
+	url := "https://bot.example.com/webhook"
+	body, _ := json.Marshal(&dto.Webhook{URL: url})
+
+	serve := func(userID string, uc client.SetWebhookUseCase) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, webhookResource, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		newWebhookRouter(userID, scope.WebhookWrite, uc, new(mockDeleteWebhookUseCase)).ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("when token is not of a service account", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockSetWebhookUseCase)
+
+		rec := serve("+5518977777777", uc)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		uc.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when token is not granted the webhook scope", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodPut, webhookResource, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		uc := new(mockSetWebhookUseCase)
+
+		newWebhookRouter(ID, scope.MessagesSend, uc, new(mockDeleteWebhookUseCase)).
+			ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		uc.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when handler.SetWebhook return StatusBadRequest", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockSetWebhookUseCase)
+		uc.On("Execute", mock.Anything, ID, url).
+			Return(nil, client.ErrURLValidateModel).
+			Once()
+
+		assert.Equal(t, http.StatusBadRequest, serve(ID, uc).Code)
+	})
+
+	t.Run("when handler.SetWebhook return StatusOK", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockSetWebhookUseCase)
+		uc.On("Execute", mock.Anything, ID, url).
+			Return(&client.Webhook{ClientID: ID, URL: url, Secret: "whsec_S3CR3T"}, nil).
+			Once()
+
+		rec := serve(ID, uc)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		webhookDto := &dto.Webhook{}
+		err := json.NewDecoder(rec.Body).Decode(webhookDto)
+		assert.Nil(t, err)
+		assert.Equal(t, url, webhookDto.URL)
+		assert.Equal(t, "whsec_S3CR3T", webhookDto.Secret)
+	})
+}
+
+func TestHandler_DeleteWebhook(t *testing.T) {
+	//t.Parallel()
+	ID := client.IDPrefix + "A1"
+
+	serve := func(uc client.DeleteWebhookUseCase) int {
+		req := httptest.NewRequest(http.MethodDelete, webhookResource, nil)
+		rec := httptest.NewRecorder()
+
+		newWebhookRouter(ID, scope.WebhookWrite, new(mockSetWebhookUseCase), uc).ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("when token is not granted the webhook scope", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodDelete, webhookResource, nil)
+		rec := httptest.NewRecorder()
+		uc := new(mockDeleteWebhookUseCase)
+
+		newWebhookRouter(ID, scope.MessagesSend, new(mockSetWebhookUseCase), uc).ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		uc.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when handler.DeleteWebhook return StatusNotFound", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockDeleteWebhookUseCase)
+		uc.On("Execute", mock.Anything, ID).
+			Return(client.ErrWebhookNotFound).
+			Once()
+
+		assert.Equal(t, http.StatusNotFound, serve(uc))
+	})
+
+	t.Run("when handler.DeleteWebhook return StatusOK", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockDeleteWebhookUseCase)
+		uc.On("Execute", mock.Anything, ID).
+			Return(nil).
+			Once()
+
+		assert.Equal(t, http.StatusOK, serve(uc))
+	})
+}
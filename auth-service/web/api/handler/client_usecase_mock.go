@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/client"
+	"github.com/tsmweb/auth-service/app/token"
+	"github.com/tsmweb/chat-server/pkg/scope"
+)
+
+// mockCreateClientUseCase injects mock dependency into Handler layer.
+type mockCreateClientUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockCreateClientUseCase) Execute(ctx context.Context, name string, scopes []string,
+	createdBy string) (*client.Client, string, error) {
+	args := m.Called(ctx, name, scopes, createdBy)
+	c, _ := args.Get(0).(*client.Client)
+	return c, args.String(1), args.Error(2)
+}
+
+// mockGetAllClientsUseCase injects mock dependency into Handler layer.
+type mockGetAllClientsUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockGetAllClientsUseCase) Execute(ctx context.Context) ([]*client.Client, error) {
+	args := m.Called(ctx)
+	clients, _ := args.Get(0).([]*client.Client)
+	return clients, args.Error(1)
+}
+
+// mockRevokeClientUseCase injects mock dependency into Handler layer.
+type mockRevokeClientUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockRevokeClientUseCase) Execute(ctx context.Context, ID string) error {
+	args := m.Called(ctx, ID)
+	return args.Error(0)
+}
+
+// mockClientTokenUseCase injects mock dependency into Handler layer.
+type mockClientTokenUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockClientTokenUseCase) Execute(ctx context.Context, ID, secret,
+	requestedScope string) (*token.Token, scope.Set, error) {
+	args := m.Called(ctx, ID, secret, requestedScope)
+	tk, _ := args.Get(0).(*token.Token)
+	scopes, _ := args.Get(1).(scope.Set)
+	return tk, scopes, args.Error(2)
+}
+
+// mockSetWebhookUseCase injects mock dependency into Handler layer.
+type mockSetWebhookUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockSetWebhookUseCase) Execute(ctx context.Context, clientID, url string) (*client.Webhook, error) {
+	args := m.Called(ctx, clientID, url)
+	w, _ := args.Get(0).(*client.Webhook)
+	return w, args.Error(1)
+}
+
+// mockDeleteWebhookUseCase injects mock dependency into Handler layer.
+type mockDeleteWebhookUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockDeleteWebhookUseCase) Execute(ctx context.Context, clientID string) error {
+	args := m.Called(ctx, clientID)
+	return args.Error(0)
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/tsmweb/auth-service/pkg/jwks"
+	"github.com/tsmweb/go-helper-api/httputil"
+)
+
+// GetJWKS publishes the public keys that verify the access tokens, which the other services
+// may cache for maxAge.
+func GetJWKS(keyring *jwks.Keyring, maxAge time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
+		httputil.RespondWithJSON(w, http.StatusOK, keyring.Set())
+	})
+}
+
+const jwksResource string = "/.well-known/jwks.json"
+
+func MakeJWKSHandlers(
+	r *mux.Router,
+	keyring *jwks.Keyring,
+	maxAge time.Duration) {
+
+	// .well-known/jwks.json [GET]
+	r.Handle(jwksResource, GetJWKS(keyring, maxAge)).
+		Methods(http.MethodGet)
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsmweb/auth-service/pkg/jwks"
+	sharedjwks "github.com/tsmweb/chat-server/pkg/jwks"
+)
+
+func TestHandler_GetJWKS(t *testing.T) {
+	//t.Parallel()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	keyring, err := jwks.NewKeyring("2022-07", map[string]*rsa.PrivateKey{"2022-07": key})
+	assert.Nil(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, jwksResource, nil)
+	rec := httptest.NewRecorder()
+
+	GetJWKS(keyring, 5*time.Minute).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "public, max-age=300", rec.Header().Get("Cache-Control"))
+
+	var set sharedjwks.Set
+	assert.Nil(t, json.NewDecoder(rec.Body).Decode(&set))
+	assert.Equal(t, keyring.Set(), &set)
+}
@@ -7,18 +7,26 @@ import (
 	"fmt"
 	"github.com/gorilla/mux"
 	"github.com/tsmweb/auth-service/app/login"
+	"github.com/tsmweb/auth-service/app/session"
 	"github.com/tsmweb/auth-service/common"
 	"github.com/tsmweb/auth-service/web/api/dto"
+	"github.com/tsmweb/chat-server/pkg/revocation"
+	"github.com/tsmweb/chat-server/pkg/scope"
 	"github.com/tsmweb/go-helper-api/auth"
 	"github.com/tsmweb/go-helper-api/cerror"
 	"github.com/tsmweb/go-helper-api/httputil"
 	"github.com/tsmweb/go-helper-api/middleware"
 	"github.com/urfave/negroni"
 	"log"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
-// Login returns a token if ID and password are valid.
+// Login returns a token if ID and password are valid, or a challenge token if the
+// two-factor authentication of the user is enabled.
 func Login(loginUseCase login.LoginUseCase) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !httputil.HasContentType(r, httputil.MimeApplicationJSON) {
@@ -35,7 +43,10 @@ func Login(loginUseCase login.LoginUseCase) http.Handler {
 			return
 		}
 
-		token, err := loginUseCase.Execute(r.Context(), input.ID, input.Password)
+		ctx := context.WithValue(r.Context(), common.ClientIPContextKey, clientIP(r))
+		ctx = withDevice(ctx, r, input.DeviceName)
+
+		tk, challenge, err := loginUseCase.Execute(ctx, input.ID, input.Password)
 		if err != nil {
 			log.Println(err.Error())
 			var errValidateModel *cerror.ErrValidateModel
@@ -44,6 +55,14 @@ func Login(loginUseCase login.LoginUseCase) http.Handler {
 				return
 			}
 
+			var errLocked *login.ErrLocked
+			if errors.As(err, &errLocked) {
+				retryAfter := int(math.Ceil(errLocked.RetryAfter.Seconds()))
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				httputil.RespondWithError(w, http.StatusTooManyRequests, err.Error())
+				return
+			}
+
 			if errors.Is(err, cerror.ErrUnauthorized) {
 				httputil.RespondWithError(w, http.StatusUnauthorized, err.Error())
 				return
@@ -53,7 +72,40 @@ func Login(loginUseCase login.LoginUseCase) http.Handler {
 			return
 		}
 
-		httputil.RespondWithJSON(w, http.StatusOK, &dto.TokenAuth{Token: token})
+		if challenge != nil {
+			challengeDto := &dto.TwoFactorChallenge{}
+			challengeDto.FromEntity(challenge)
+
+			httputil.RespondWithJSON(w, http.StatusOK, challengeDto)
+			return
+		}
+
+		tokenDto := &dto.TokenAuth{}
+		tokenDto.FromEntity(tk)
+
+		httputil.RespondWithJSON(w, http.StatusOK, tokenDto)
+	})
+}
+
+// clientIP returns the IP of the client, informed by nginx in the X-Real-IP header.
+func clientIP(r *http.Request) string {
+	if ip := strings.TrimSpace(r.Header.Get("X-Real-IP")); ip != "" {
+		return ip
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// withDevice returns a copy of ctx with the device of the request, which identifies the
+// session started by the login.
+func withDevice(ctx context.Context, r *http.Request, name string) context.Context {
+	return context.WithValue(ctx, common.DeviceContextKey, &session.Device{
+		Name:      strings.TrimSpace(name),
+		UserAgent: r.UserAgent(),
+		IP:        clientIP(r),
 	})
 }
 
@@ -110,12 +162,51 @@ func UpdatePassword(jwt auth.JWT, updateUseCase login.UpdateUseCase) http.Handle
 	})
 }
 
+// Logout revokes the access token of the request, or all the tokens of the user.
+func Logout(jwt auth.JWT, logoutUseCase login.LogoutUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := dto.Logout{}
+
+		// the body is optional.
+		if r.ContentLength != 0 {
+			if !httputil.HasContentType(r, httputil.MimeApplicationJSON) {
+				httputil.RespondWithError(w, http.StatusUnsupportedMediaType, http.StatusText(http.StatusUnsupportedMediaType))
+				return
+			}
+
+			decoder := json.NewDecoder(r.Body)
+			if err := decoder.Decode(&input); err != nil {
+				log.Println(err.Error())
+				httputil.RespondWithError(w, http.StatusUnprocessableEntity, "Malformed JSON")
+				return
+			}
+		}
+
+		t, err := revocation.FromRequest(jwt, r)
+		if err != nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+
+		if err = logoutUseCase.Execute(r.Context(), t, input.RefreshToken, input.Everywhere); err != nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
 const loginApiVersion string = "v1"
 
 var loginResource string
+var logoutResource string
 
 func init() {
 	loginResource = fmt.Sprintf("/%s/login", loginApiVersion)
+	logoutResource = fmt.Sprintf("/%s/logout", loginApiVersion)
 }
 
 func MakeLoginHandlers(
@@ -123,7 +214,8 @@ func MakeLoginHandlers(
 	jwt auth.JWT,
 	auth middleware.Auth,
 	loginUseCase login.LoginUseCase,
-	updateUseCase login.UpdateUseCase) {
+	updateUseCase login.UpdateUseCase,
+	logoutUseCase login.LogoutUseCase) {
 
 	// login [POST]
 	r.Handle(loginResource, Login(loginUseCase)).
@@ -132,6 +224,14 @@ func MakeLoginHandlers(
 	// login [PUT]
 	r.Handle(loginResource, negroni.New(
 		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.RequireUnrestricted(jwt)),
 		negroni.Wrap(UpdatePassword(jwt, updateUseCase)),
 	)).Methods(http.MethodPut)
+
+	// logout [POST]
+	r.Handle(logoutResource, negroni.New(
+		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.RequireUnrestricted(jwt)),
+		negroni.Wrap(Logout(jwt, logoutUseCase)),
+	)).Methods(http.MethodPost)
 }
@@ -2,17 +2,26 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/tsmweb/auth-service/app/login"
+	"github.com/tsmweb/auth-service/app/session"
+	tokenpkg "github.com/tsmweb/auth-service/app/token"
+	"github.com/tsmweb/auth-service/app/twofactor"
 	"github.com/tsmweb/auth-service/common"
 	"github.com/tsmweb/auth-service/web/api/dto"
+	"github.com/tsmweb/chat-server/pkg/revocation"
+	"github.com/tsmweb/chat-server/pkg/scope"
 	"github.com/tsmweb/go-helper-api/cerror"
+	"github.com/tsmweb/go-helper-api/middleware"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestHandler_Login(t *testing.T) {
@@ -58,7 +67,7 @@ func TestHandler_Login(t *testing.T) {
 
 		mLoginUseCase := new(mockLoginUseCase)
 		mLoginUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything).
-			Return("", login.ErrPasswordValidateModel).
+			Return(nil, nil, login.ErrPasswordValidateModel).
 			Once()
 
 		Login(mLoginUseCase).ServeHTTP(rec, req)
@@ -82,7 +91,7 @@ func TestHandler_Login(t *testing.T) {
 
 		mLoginUseCase := new(mockLoginUseCase)
 		mLoginUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything).
-			Return("", cerror.ErrUnauthorized).
+			Return(nil, nil, cerror.ErrUnauthorized).
 			Once()
 
 		Login(mLoginUseCase).ServeHTTP(rec, req)
@@ -90,6 +99,34 @@ func TestHandler_Login(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, rec.Code)
 	})
 
+	t.Run("when handler.Login return StatusTooManyRequests", func(t *testing.T) {
+		//t.Parallel()
+		loginDto := &dto.Login{
+			ID:       "+5518999999999",
+			Password: "123456",
+		}
+
+		jLoginDto, err := json.Marshal(loginDto)
+		assert.Nil(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, loginResource, bytes.NewReader(jLoginDto))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Real-IP", "10.0.0.1")
+		rec := httptest.NewRecorder()
+
+		mLoginUseCase := new(mockLoginUseCase)
+		mLoginUseCase.On("Execute", mock.MatchedBy(func(ctx context.Context) bool {
+			return ctx.Value(common.ClientIPContextKey) == "10.0.0.1"
+		}), mock.Anything, mock.Anything).
+			Return(nil, nil, &login.ErrLocked{RetryAfter: 1500 * time.Millisecond}).
+			Once()
+
+		Login(mLoginUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+	})
+
 	t.Run("when handler.Login return StatusInternalServerError", func(t *testing.T) {
 		//t.Parallel()
 		loginDto := &dto.Login{
@@ -106,7 +143,7 @@ func TestHandler_Login(t *testing.T) {
 
 		mLoginUseCase := new(mockLoginUseCase)
 		mLoginUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything).
-			Return("", errors.New("error")).
+			Return(nil, nil, errors.New("error")).
 			Once()
 
 		Login(mLoginUseCase).ServeHTTP(rec, req)
@@ -117,15 +154,18 @@ func TestHandler_Login(t *testing.T) {
 	t.Run("when handler.Login return StatusOK", func(t *testing.T) {
 		//t.Parallel()
 		loginDto := &dto.Login{
-			ID:       "+5518999999999",
-			Password: "123456",
+			ID:         "+5518999999999",
+			Password:   "123456",
+			DeviceName: "Phone",
 		}
 
 		jLoginDto, err := json.Marshal(loginDto)
 		assert.Nil(t, err)
 
 		token := dto.TokenAuth{
-			Token: "A1B2C3D4E5F6",
+			Token:        "A1B2C3D4E5F6",
+			RefreshToken: "F6E5D4C3B2A1",
+			ExpiresIn:    3600,
 		}
 
 		jToken, err := json.Marshal(token)
@@ -133,11 +173,19 @@ func TestHandler_Login(t *testing.T) {
 
 		req := httptest.NewRequest(http.MethodPost, loginResource, bytes.NewReader(jLoginDto))
 		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", "Test/1.0")
 		rec := httptest.NewRecorder()
 
 		mLoginUseCase := new(mockLoginUseCase)
-		mLoginUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything).
-			Return("A1B2C3D4E5F6", nil).
+		mLoginUseCase.On("Execute", mock.MatchedBy(func(ctx context.Context) bool {
+			device, _ := ctx.Value(common.DeviceContextKey).(*session.Device)
+			return device != nil && device.Name == "Phone" && device.UserAgent == "Test/1.0"
+		}), mock.Anything, mock.Anything).
+			Return(&tokenpkg.Token{
+				AccessToken:  "A1B2C3D4E5F6",
+				RefreshToken: "F6E5D4C3B2A1",
+				ExpiresIn:    3600,
+			}, nil, nil).
 			Once()
 
 		Login(mLoginUseCase).ServeHTTP(rec, req)
@@ -146,6 +194,39 @@ func TestHandler_Login(t *testing.T) {
 		assert.Equal(t, string(jToken), rec.Body.String())
 		//t.Log(rec.Body.String())
 	})
+
+	t.Run("when handler.Login return StatusOK with challenge", func(t *testing.T) {
+		//t.Parallel()
+		loginDto := &dto.Login{
+			ID:       "+5518999999999",
+			Password: "123456",
+		}
+
+		jLoginDto, err := json.Marshal(loginDto)
+		assert.Nil(t, err)
+
+		challenge := dto.TwoFactorChallenge{
+			ChallengeToken: "C1H2A3L4",
+			ExpiresIn:      300,
+		}
+
+		jChallenge, err := json.Marshal(challenge)
+		assert.Nil(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, loginResource, bytes.NewReader(jLoginDto))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		mLoginUseCase := new(mockLoginUseCase)
+		mLoginUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, &twofactor.ChallengeToken{Token: "C1H2A3L4", ExpiresIn: 300}, nil).
+			Once()
+
+		Login(mLoginUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, string(jChallenge), rec.Body.String())
+	})
 }
 
 func TestHandler_UpdatePassword(t *testing.T) {
@@ -336,3 +417,119 @@ func TestHandler_UpdatePassword(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 	})
 }
+
+func TestHandler_Logout(t *testing.T) {
+	//t.Parallel()
+
+	newJWT := func() *common.MockJWT {
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, "id").
+			Return("+5518999999999", nil)
+		mJWT.On("GetDataToken", mock.Anything, "jti").
+			Return("A1B2C3", nil)
+		mJWT.On("GetDataToken", mock.Anything, "sid").
+			Return("S1S2S3", nil)
+		mJWT.On("GetDataToken", mock.Anything, "iat").
+			Return("1600000000", nil)
+		return mJWT
+	}
+
+	t.Run("when handler.Logout return StatusUnsupportedMediaType", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodPost, logoutResource, bytes.NewReader([]byte("{}")))
+		req.Header.Set("Content-Type", "text/plain")
+		rec := httptest.NewRecorder()
+
+		mLogoutUseCase := new(mockLogoutUseCase)
+		Logout(newJWT(), mLogoutUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	})
+
+	t.Run("when handler.Logout return StatusUnprocessableEntity", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodPost, logoutResource, bytes.NewReader([]byte("{[}")))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		mLogoutUseCase := new(mockLogoutUseCase)
+		Logout(newJWT(), mLogoutUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("when JWT fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodPost, logoutResource, nil)
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return(nil, errors.New("jwt error")).
+			Once()
+		mLogoutUseCase := new(mockLogoutUseCase)
+		Logout(mJWT, mLogoutUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("when handler.Logout return StatusInternalServerError", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodPost, logoutResource, nil)
+		rec := httptest.NewRecorder()
+
+		mLogoutUseCase := new(mockLogoutUseCase)
+		mLogoutUseCase.On("Execute", mock.Anything, mock.Anything, "", false).
+			Return(errors.New("error")).
+			Once()
+		Logout(newJWT(), mLogoutUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("when handler.Logout return StatusOK", func(t *testing.T) {
+		//t.Parallel()
+		logoutDto := &dto.Logout{
+			RefreshToken: "F6E5D4C3B2A1",
+			Everywhere:   true,
+		}
+
+		jLogoutDto, err := json.Marshal(logoutDto)
+		assert.Nil(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, logoutResource, bytes.NewReader(jLogoutDto))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		mLogoutUseCase := new(mockLogoutUseCase)
+		mLogoutUseCase.On("Execute", mock.Anything, mock.MatchedBy(func(t *revocation.Token) bool {
+			return t.ID == "A1B2C3" && t.UserID == "+5518999999999"
+		}), "F6E5D4C3B2A1", true).
+			Return(nil).
+			Once()
+		Logout(newJWT(), mLogoutUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mLogoutUseCase.AssertExpectations(t)
+	})
+}
+
+func TestMakeLoginHandlers_RestrictedToken(t *testing.T) {
+	//t.Parallel()
+	mJWT := newRestrictedJWT(scope.MessagesSend)
+	router := mux.NewRouter()
+	MakeLoginHandlers(router, mJWT, middleware.NewAuth(mJWT), new(mockLoginUseCase),
+		new(mockLoginUpdateUseCase), new(mockLogoutUseCase))
+
+	for method, resource := range map[string]string{
+		http.MethodPut:  loginResource,
+		http.MethodPost: logoutResource,
+	} {
+		req := httptest.NewRequest(method, resource, bytes.NewReader([]byte("{}")))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusForbidden, rec.Code, resource)
+	}
+}
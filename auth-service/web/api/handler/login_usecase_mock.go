@@ -4,6 +4,9 @@ import (
 	"context"
 	"github.com/stretchr/testify/mock"
 	"github.com/tsmweb/auth-service/app/login"
+	"github.com/tsmweb/auth-service/app/token"
+	"github.com/tsmweb/auth-service/app/twofactor"
+	"github.com/tsmweb/chat-server/pkg/revocation"
 )
 
 // mockLoginUseCase injects mock dependency into Handler layer.
@@ -12,13 +15,17 @@ type mockLoginUseCase struct {
 }
 
 // Execute represents the simulated method for the Execute feature in the UseCase layer.
-func (m *mockLoginUseCase) Execute(ctx context.Context, ID, password string) (string, error) {
+func (m *mockLoginUseCase) Execute(ctx context.Context, ID, password string) (*token.Token,
+	*twofactor.ChallengeToken, error) {
 	args := m.Called(ctx, ID, password)
+	if args.Get(2) != nil {
+		return nil, nil, args.Error(2)
+	}
 	if args.Get(1) != nil {
-		return "", args.Error(1)
+		return nil, args.Get(1).(*twofactor.ChallengeToken), nil
 	}
 
-	return args.Get(0).(string), nil
+	return args.Get(0).(*token.Token), nil, nil
 }
 
 // mockLoginUpdateUseCase injects mock dependency into Handler layer.
@@ -31,3 +38,15 @@ func (m *mockLoginUpdateUseCase) Execute(ctx context.Context, l *login.Login) er
 	args := m.Called(ctx, l)
 	return args.Error(0)
 }
+
+// mockLogoutUseCase injects mock dependency into Handler layer.
+type mockLogoutUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockLogoutUseCase) Execute(ctx context.Context, t *revocation.Token, refreshToken string,
+	everywhere bool) error {
+	args := m.Called(ctx, t, refreshToken, everywhere)
+	return args.Error(0)
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tsmweb/auth-service/app/login"
+	"github.com/tsmweb/auth-service/app/reset"
+	"github.com/tsmweb/auth-service/web/api/dto"
+	"github.com/tsmweb/go-helper-api/cerror"
+	"github.com/tsmweb/go-helper-api/httputil"
+)
+
+// RequestReset sends a password reset token to the user. The response is the same whether
+// the user exists or not.
+func RequestReset(requestUseCase reset.RequestUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !httputil.HasContentType(r, httputil.MimeApplicationJSON) {
+			httputil.RespondWithError(w, http.StatusUnsupportedMediaType, http.StatusText(http.StatusUnsupportedMediaType))
+			return
+		}
+
+		input := dto.ResetRequest{}
+		decoder := json.NewDecoder(r.Body)
+
+		if err := decoder.Decode(&input); err != nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusUnprocessableEntity, "Malformed JSON")
+			return
+		}
+
+		if err := requestUseCase.Execute(r.Context(), input.ID); err != nil {
+			log.Println(err.Error())
+			var errValidateModel *cerror.ErrValidateModel
+			if errors.As(err, &errValidateModel) {
+				httputil.RespondWithError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+// ConfirmReset sets the new password of the user with the reset token, logging the user
+// out everywhere.
+func ConfirmReset(confirmUseCase reset.ConfirmUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !httputil.HasContentType(r, httputil.MimeApplicationJSON) {
+			httputil.RespondWithError(w, http.StatusUnsupportedMediaType, http.StatusText(http.StatusUnsupportedMediaType))
+			return
+		}
+
+		input := dto.ResetConfirm{}
+		decoder := json.NewDecoder(r.Body)
+
+		if err := decoder.Decode(&input); err != nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusUnprocessableEntity, "Malformed JSON")
+			return
+		}
+
+		if err := confirmUseCase.Execute(r.Context(), input.Token, input.Password); err != nil {
+			log.Println(err.Error())
+			var errValidateModel *cerror.ErrValidateModel
+			if errors.As(err, &errValidateModel) {
+				httputil.RespondWithError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			if errors.Is(err, reset.ErrInvalidToken) {
+				httputil.RespondWithError(w, http.StatusUnauthorized, err.Error())
+				return
+			}
+
+			if errors.Is(err, login.ErrUserNotFound) {
+				httputil.RespondWithError(w, http.StatusNotFound, err.Error())
+				return
+			}
+
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+const resetApiVersion string = "v1"
+
+var resetResource string
+
+func init() {
+	resetResource = fmt.Sprintf("/%s/password/reset", resetApiVersion)
+}
+
+func MakeResetHandlers(
+	r *mux.Router,
+	requestUseCase reset.RequestUseCase,
+	confirmUseCase reset.ConfirmUseCase) {
+
+	// password/reset [POST]
+	r.Handle(resetResource, RequestReset(requestUseCase)).
+		Methods(http.MethodPost)
+
+	// password/reset/confirm [POST]
+	r.Handle(fmt.Sprintf("%s/confirm", resetResource), ConfirmReset(confirmUseCase)).
+		Methods(http.MethodPost)
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/login"
+	"github.com/tsmweb/auth-service/app/reset"
+	"github.com/tsmweb/auth-service/web/api/dto"
+)
+
+func TestHandler_RequestReset(t *testing.T) {
+	//t.Parallel()
+	body, _ := json.Marshal(&dto.ResetRequest{ID: "+5518999999999"})
+
+	serve := func(contentType string, body []byte, uc reset.RequestUseCase) int {
+		req := httptest.NewRequest(http.MethodPost, resetResource, bytes.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		rec := httptest.NewRecorder()
+
+		RequestReset(uc).ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("when handler.RequestReset return StatusUnsupportedMediaType", func(t *testing.T) {
+		//t.Parallel()
+		code := serve("text/plain", body, new(mockResetRequestUseCase))
+		assert.Equal(t, http.StatusUnsupportedMediaType, code)
+	})
+
+	t.Run("when handler.RequestReset return StatusUnprocessableEntity", func(t *testing.T) {
+		//t.Parallel()
+		code := serve("application/json", []byte("{[}"), new(mockResetRequestUseCase))
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+	})
+
+	t.Run("when handler.RequestReset return StatusBadRequest", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockResetRequestUseCase)
+		uc.On("Execute", mock.Anything, "+5518999999999").
+			Return(reset.ErrIDValidateModel).
+			Once()
+
+		code := serve("application/json", body, uc)
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("when handler.RequestReset return StatusInternalServerError", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockResetRequestUseCase)
+		uc.On("Execute", mock.Anything, "+5518999999999").
+			Return(errors.New("error")).
+			Once()
+
+		code := serve("application/json", body, uc)
+		assert.Equal(t, http.StatusInternalServerError, code)
+	})
+
+	t.Run("when handler.RequestReset return StatusAccepted", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockResetRequestUseCase)
+		uc.On("Execute", mock.Anything, "+5518999999999").
+			Return(nil).
+			Once()
+
+		code := serve("application/json", body, uc)
+		assert.Equal(t, http.StatusAccepted, code)
+	})
+}
+
+func TestHandler_ConfirmReset(t *testing.T) {
+	//t.Parallel()
+	resource := fmt.Sprintf("%s/confirm", resetResource)
+	body, _ := json.Marshal(&dto.ResetConfirm{Token: "token", Password: "123456"})
+
+	serve := func(contentType string, body []byte, uc reset.ConfirmUseCase) int {
+		req := httptest.NewRequest(http.MethodPost, resource, bytes.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		rec := httptest.NewRecorder()
+
+		ConfirmReset(uc).ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("when handler.ConfirmReset return StatusUnsupportedMediaType", func(t *testing.T) {
+		//t.Parallel()
+		code := serve("text/plain", body, new(mockResetConfirmUseCase))
+		assert.Equal(t, http.StatusUnsupportedMediaType, code)
+	})
+
+	t.Run("when handler.ConfirmReset return StatusUnprocessableEntity", func(t *testing.T) {
+		//t.Parallel()
+		code := serve("application/json", []byte("{[}"), new(mockResetConfirmUseCase))
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+	})
+
+	t.Run("when handler.ConfirmReset return StatusBadRequest", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockResetConfirmUseCase)
+		uc.On("Execute", mock.Anything, "token", "123456").
+			Return(login.ErrPasswordValidateModel).
+			Once()
+
+		code := serve("application/json", body, uc)
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("when handler.ConfirmReset return StatusUnauthorized", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockResetConfirmUseCase)
+		uc.On("Execute", mock.Anything, "token", "123456").
+			Return(reset.ErrInvalidToken).
+			Once()
+
+		code := serve("application/json", body, uc)
+		assert.Equal(t, http.StatusUnauthorized, code)
+	})
+
+	t.Run("when handler.ConfirmReset return StatusNotFound", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockResetConfirmUseCase)
+		uc.On("Execute", mock.Anything, "token", "123456").
+			Return(login.ErrUserNotFound).
+			Once()
+
+		code := serve("application/json", body, uc)
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("when handler.ConfirmReset return StatusInternalServerError", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockResetConfirmUseCase)
+		uc.On("Execute", mock.Anything, "token", "123456").
+			Return(errors.New("error")).
+			Once()
+
+		code := serve("application/json", body, uc)
+		assert.Equal(t, http.StatusInternalServerError, code)
+	})
+
+	t.Run("when handler.ConfirmReset return StatusOK", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockResetConfirmUseCase)
+		uc.On("Execute", mock.Anything, "token", "123456").
+			Return(nil).
+			Once()
+
+		code := serve("application/json", body, uc)
+		assert.Equal(t, http.StatusOK, code)
+	})
+}
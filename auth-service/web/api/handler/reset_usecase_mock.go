@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// mockResetRequestUseCase injects mock dependency into Handler layer.
+type mockResetRequestUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockResetRequestUseCase) Execute(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+// mockResetConfirmUseCase injects mock dependency into Handler layer.
+type mockResetConfirmUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockResetConfirmUseCase) Execute(ctx context.Context, resetToken, password string) error {
+	args := m.Called(ctx, resetToken, password)
+	return args.Error(0)
+}
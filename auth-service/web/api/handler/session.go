@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tsmweb/auth-service/app/login"
+	"github.com/tsmweb/auth-service/app/session"
+	"github.com/tsmweb/auth-service/web/api/dto"
+	"github.com/tsmweb/chat-server/pkg/scope"
+	"github.com/tsmweb/go-helper-api/auth"
+	"github.com/tsmweb/go-helper-api/httputil"
+	"github.com/tsmweb/go-helper-api/middleware"
+	"github.com/urfave/negroni"
+)
+
+// GetSessions returns the active sessions of the user, marking the session of the request.
+func GetSessions(jwt auth.JWT, listUseCase session.ListUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := jwt.GetDataToken(r, "id")
+		if err != nil || data == nil {
+			log.Println("[ERROR] GetSessions: could not get token user")
+			httputil.RespondWithError(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+		userID := data.(string)
+
+		// Tokens issued before session support do not carry a session ID.
+		data, _ = jwt.GetDataToken(r, "sid")
+		sessionID, _ := data.(string)
+
+		sessions, err := listUseCase.Execute(r.Context(), userID)
+		if err != nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		sessionsDto := make([]*dto.Session, 0, len(sessions))
+		for _, s := range sessions {
+			sessionDto := &dto.Session{}
+			sessionDto.FromEntity(s, sessionID)
+			sessionsDto = append(sessionsDto, sessionDto)
+		}
+
+		httputil.RespondWithJSON(w, http.StatusOK, sessionsDto)
+	})
+}
+
+// RevokeSession revokes a session of the user, disconnecting the device.
+func RevokeSession(jwt auth.JWT, revokeSessionUseCase login.RevokeSessionUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := jwt.GetDataToken(r, "id")
+		if err != nil || data == nil {
+			log.Println("[ERROR] RevokeSession: could not get token user")
+			httputil.RespondWithError(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+		userID := data.(string)
+
+		vars := mux.Vars(r)
+		sessionID := vars["id"]
+
+		if err = revokeSessionUseCase.Execute(r.Context(), userID, sessionID); err != nil {
+			log.Println(err.Error())
+			if errors.Is(err, session.ErrSessionNotFound) {
+				httputil.RespondWithError(w, http.StatusNotFound, err.Error())
+				return
+			}
+
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+const sessionApiVersion string = "v1"
+
+var sessionResource string
+
+func init() {
+	sessionResource = fmt.Sprintf("/%s/session", sessionApiVersion)
+}
+
+// MakeSessionHandlers creates the handlers of the sessions of the user.
+func MakeSessionHandlers(
+	r *mux.Router,
+	jwt auth.JWT,
+	auth middleware.Auth,
+	listUseCase session.ListUseCase,
+	revokeSessionUseCase login.RevokeSessionUseCase) {
+
+	// session [GET]
+	r.Handle(sessionResource, negroni.New(
+		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.RequireUnrestricted(jwt)),
+		negroni.Wrap(GetSessions(jwt, listUseCase)),
+	)).Methods(http.MethodGet)
+
+	// session/{id} [DELETE]
+	r.Handle(fmt.Sprintf("%s/{id}", sessionResource), negroni.New(
+		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.RequireUnrestricted(jwt)),
+		negroni.Wrap(RevokeSession(jwt, revokeSessionUseCase)),
+	)).Methods(http.MethodDelete)
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/login"
+	"github.com/tsmweb/auth-service/app/session"
+	"github.com/tsmweb/auth-service/common"
+	"github.com/tsmweb/auth-service/web/api/dto"
+	"github.com/tsmweb/chat-server/pkg/scope"
+	"github.com/tsmweb/go-helper-api/middleware"
+)
+
+func newSessionRouter(
+	userID, sessionID string,
+	listUseCase session.ListUseCase,
+	revokeSessionUseCase login.RevokeSessionUseCase,
+) *mux.Router {
+	mJWT := new(common.MockJWT)
+	mJWT.On("ExtractToken", mock.Anything).Return("token", nil)
+	mJWT.On("GetDataToken", mock.Anything, "id").Return(userID, nil)
+	mJWT.On("GetDataToken", mock.Anything, "sid").Return(sessionID, nil)
+	mJWT.On("GetDataToken", mock.Anything, "scope").Return(nil, nil)
+
+	router := mux.NewRouter()
+	MakeSessionHandlers(router, mJWT, middleware.NewAuth(mJWT), listUseCase, revokeSessionUseCase)
+	return router
+}
+
+func TestHandler_GetSessions(t *testing.T) {
+	//t.Parallel()
+	userID := "+5518999999999"
+
+	serve := func(uc session.ListUseCase) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, sessionResource, nil)
+		rec := httptest.NewRecorder()
+
+		newSessionRouter(userID, "S1", uc, new(mockRevokeSessionUseCase)).ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("when handler.GetSessions return StatusInternalServerError", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockListSessionUseCase)
+		uc.On("Execute", mock.Anything, userID).
+			Return(nil, errors.New("error")).
+			Once()
+
+		rec := serve(uc)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("when handler.GetSessions return StatusOK", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockListSessionUseCase)
+		uc.On("Execute", mock.Anything, userID).
+			Return([]*session.Session{
+				session.NewSession("S1", userID, &session.Device{Name: "Phone"}),
+				session.NewSession("S2", userID, nil),
+			}, nil).
+			Once()
+
+		rec := serve(uc)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var sessionsDto []*dto.Session
+		err := json.NewDecoder(rec.Body).Decode(&sessionsDto)
+		assert.Nil(t, err)
+		assert.Len(t, sessionsDto, 2)
+		assert.Equal(t, "Phone", sessionsDto[0].DeviceName)
+		assert.True(t, sessionsDto[0].Current)
+		assert.False(t, sessionsDto[1].Current)
+		uc.AssertExpectations(t)
+	})
+}
+
+func TestHandler_RevokeSession(t *testing.T) {
+	//t.Parallel()
+	userID := "+5518999999999"
+	resource := fmt.Sprintf("%s/%s", sessionResource, "S2")
+
+	serve := func(uc login.RevokeSessionUseCase) int {
+		req := httptest.NewRequest(http.MethodDelete, resource, nil)
+		rec := httptest.NewRecorder()
+
+		newSessionRouter(userID, "S1", new(mockListSessionUseCase), uc).ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("when handler.RevokeSession return StatusNotFound", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockRevokeSessionUseCase)
+		uc.On("Execute", mock.Anything, userID, "S2").
+			Return(session.ErrSessionNotFound).
+			Once()
+
+		assert.Equal(t, http.StatusNotFound, serve(uc))
+	})
+
+	t.Run("when handler.RevokeSession return StatusInternalServerError", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockRevokeSessionUseCase)
+		uc.On("Execute", mock.Anything, userID, "S2").
+			Return(errors.New("error")).
+			Once()
+
+		assert.Equal(t, http.StatusInternalServerError, serve(uc))
+	})
+
+	t.Run("when handler.RevokeSession return StatusOK", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockRevokeSessionUseCase)
+		uc.On("Execute", mock.Anything, userID, "S2").
+			Return(nil).
+			Once()
+
+		assert.Equal(t, http.StatusOK, serve(uc))
+		uc.AssertExpectations(t)
+	})
+}
+
+func TestMakeSessionHandlers_RestrictedToken(t *testing.T) {
+	//t.Parallel()
+	mJWT := newRestrictedJWT(scope.MessagesSend)
+	router := mux.NewRouter()
+	MakeSessionHandlers(router, mJWT, middleware.NewAuth(mJWT), new(mockListSessionUseCase),
+		new(mockRevokeSessionUseCase))
+
+	for method, resource := range map[string]string{
+		http.MethodGet:    sessionResource,
+		http.MethodDelete: fmt.Sprintf("%s/S1", sessionResource),
+	} {
+		req := httptest.NewRequest(method, resource, nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusForbidden, rec.Code, method)
+	}
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/session"
+)
+
+// mockListSessionUseCase injects mock dependency into Handler layer.
+type mockListSessionUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockListSessionUseCase) Execute(ctx context.Context, userID string) ([]*session.Session, error) {
+	args := m.Called(ctx, userID)
+	sessions, _ := args.Get(0).([]*session.Session)
+	return sessions, args.Error(1)
+}
+
+// mockRevokeSessionUseCase injects mock dependency into Handler layer.
+type mockRevokeSessionUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockRevokeSessionUseCase) Execute(ctx context.Context, userID, sessionID string) error {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Error(0)
+}
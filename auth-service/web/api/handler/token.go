@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tsmweb/auth-service/app/token"
+	"github.com/tsmweb/auth-service/web/api/dto"
+	"github.com/tsmweb/go-helper-api/httputil"
+)
+
+// RefreshToken exchanges the refresh token for a new access token and refresh token.
+func RefreshToken(refreshUseCase token.RefreshUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !httputil.HasContentType(r, httputil.MimeApplicationJSON) {
+			httputil.RespondWithError(w, http.StatusUnsupportedMediaType, http.StatusText(http.StatusUnsupportedMediaType))
+			return
+		}
+
+		input := dto.RefreshToken{}
+		decoder := json.NewDecoder(r.Body)
+
+		if err := decoder.Decode(&input); err != nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusUnprocessableEntity, "Malformed JSON")
+			return
+		}
+
+		tk, err := refreshUseCase.Execute(r.Context(), input.RefreshToken)
+		if err != nil {
+			log.Println(err.Error())
+			if errors.Is(err, token.ErrInvalidRefreshToken) ||
+				errors.Is(err, token.ErrRefreshTokenReused) {
+				httputil.RespondWithError(w, http.StatusUnauthorized, err.Error())
+				return
+			}
+
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		tokenDto := &dto.TokenAuth{}
+		tokenDto.FromEntity(tk)
+
+		httputil.RespondWithJSON(w, http.StatusOK, tokenDto)
+	})
+}
+
+const tokenApiVersion string = "v1"
+
+var tokenResource string
+
+func init() {
+	tokenResource = fmt.Sprintf("/%s/token", tokenApiVersion)
+}
+
+func MakeTokenHandlers(
+	r *mux.Router,
+	refreshUseCase token.RefreshUseCase) {
+
+	// token/refresh [POST]
+	r.Handle(fmt.Sprintf("%s/refresh", tokenResource), RefreshToken(refreshUseCase)).
+		Methods(http.MethodPost)
+}
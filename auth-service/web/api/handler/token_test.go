@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/token"
+	"github.com/tsmweb/auth-service/web/api/dto"
+)
+
+func TestHandler_RefreshToken(t *testing.T) {
+	//t.Parallel()
+	resource := tokenResource + "/refresh"
+	body, _ := json.Marshal(&dto.RefreshToken{RefreshToken: "F6E5D4C3B2A1"})
+
+	t.Run("when handler.RefreshToken return StatusUnsupportedMediaType", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodPost, resource, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "text/plain")
+		rec := httptest.NewRecorder()
+
+		RefreshToken(new(mockRefreshUseCase)).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	})
+
+	t.Run("when handler.RefreshToken return StatusUnprocessableEntity", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodPost, resource, bytes.NewReader([]byte("{[}")))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		RefreshToken(new(mockRefreshUseCase)).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("when handler.RefreshToken return StatusUnauthorized", func(t *testing.T) {
+		//t.Parallel()
+		for _, err := range []error{token.ErrInvalidRefreshToken, token.ErrRefreshTokenReused} {
+			req := httptest.NewRequest(http.MethodPost, resource, bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			mRefreshUseCase := new(mockRefreshUseCase)
+			mRefreshUseCase.On("Execute", mock.Anything, "F6E5D4C3B2A1").
+				Return(nil, err).
+				Once()
+
+			RefreshToken(mRefreshUseCase).ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		}
+	})
+
+	t.Run("when handler.RefreshToken return StatusInternalServerError", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodPost, resource, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		mRefreshUseCase := new(mockRefreshUseCase)
+		mRefreshUseCase.On("Execute", mock.Anything, "F6E5D4C3B2A1").
+			Return(nil, errors.New("error")).
+			Once()
+
+		RefreshToken(mRefreshUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("when handler.RefreshToken return StatusOK", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodPost, resource, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		mRefreshUseCase := new(mockRefreshUseCase)
+		mRefreshUseCase.On("Execute", mock.Anything, "F6E5D4C3B2A1").
+			Return(&token.Token{
+				AccessToken:  "A1B2C3D4E5F6",
+				RefreshToken: "A2B3C4D5E6F7",
+				ExpiresIn:    3600,
+			}, nil).
+			Once()
+
+		RefreshToken(mRefreshUseCase).ServeHTTP(rec, req)
+
+		jToken, _ := json.Marshal(&dto.TokenAuth{
+			Token:        "A1B2C3D4E5F6",
+			RefreshToken: "A2B3C4D5E6F7",
+			ExpiresIn:    3600,
+		})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, string(jToken), rec.Body.String())
+	})
+}
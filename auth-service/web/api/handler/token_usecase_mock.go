@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/token"
+)
+
+// mockRefreshUseCase injects mock dependency into Handler layer.
+type mockRefreshUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockRefreshUseCase) Execute(ctx context.Context, refreshToken string) (*token.Token, error) {
+	args := m.Called(ctx, refreshToken)
+	if args.Get(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*token.Token), nil
+}
@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tsmweb/auth-service/app/twofactor"
+	"github.com/tsmweb/auth-service/web/api/dto"
+	"github.com/tsmweb/chat-server/pkg/scope"
+	"github.com/tsmweb/go-helper-api/auth"
+	"github.com/tsmweb/go-helper-api/httputil"
+	"github.com/tsmweb/go-helper-api/middleware"
+	"github.com/urfave/negroni"
+)
+
+// EnrolTwoFactor returns a new TOTP secret of the user, to be confirmed with the first code.
+func EnrolTwoFactor(jwt auth.JWT, enrolUseCase twofactor.EnrolUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := jwt.GetDataToken(r, "id")
+		if err != nil || data == nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+		userID := data.(string)
+
+		tf, err := enrolUseCase.Execute(r.Context(), userID)
+		if err != nil {
+			log.Println(err.Error())
+			if errors.Is(err, twofactor.ErrAlreadyEnabled) {
+				httputil.RespondWithError(w, http.StatusConflict, err.Error())
+				return
+			}
+
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		enrolmentDto := &dto.TwoFactorEnrolment{}
+		enrolmentDto.FromEntity(tf)
+
+		httputil.RespondWithJSON(w, http.StatusOK, enrolmentDto)
+	})
+}
+
+// ConfirmTwoFactor enables the two-factor authentication of the user, returning the
+// recovery codes.
+func ConfirmTwoFactor(jwt auth.JWT, confirmUseCase twofactor.ConfirmUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !httputil.HasContentType(r, httputil.MimeApplicationJSON) {
+			httputil.RespondWithError(w, http.StatusUnsupportedMediaType, http.StatusText(http.StatusUnsupportedMediaType))
+			return
+		}
+
+		data, err := jwt.GetDataToken(r, "id")
+		if err != nil || data == nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+		userID := data.(string)
+
+		input := dto.TwoFactorCode{}
+		decoder := json.NewDecoder(r.Body)
+
+		if err = decoder.Decode(&input); err != nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusUnprocessableEntity, "Malformed JSON")
+			return
+		}
+
+		codes, err := confirmUseCase.Execute(r.Context(), userID, input.Code)
+		if err != nil {
+			log.Println(err.Error())
+			if errors.Is(err, twofactor.ErrInvalidCode) {
+				httputil.RespondWithError(w, http.StatusUnauthorized, err.Error())
+				return
+			}
+
+			if errors.Is(err, twofactor.ErrNotEnrolled) {
+				httputil.RespondWithError(w, http.StatusNotFound, err.Error())
+				return
+			}
+
+			if errors.Is(err, twofactor.ErrAlreadyEnabled) {
+				httputil.RespondWithError(w, http.StatusConflict, err.Error())
+				return
+			}
+
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		httputil.RespondWithJSON(w, http.StatusOK, &dto.RecoveryCodes{RecoveryCodes: codes})
+	})
+}
+
+// DisableTwoFactor disables the two-factor authentication of the user with a valid TOTP
+// or recovery code.
+func DisableTwoFactor(jwt auth.JWT, disableUseCase twofactor.DisableUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !httputil.HasContentType(r, httputil.MimeApplicationJSON) {
+			httputil.RespondWithError(w, http.StatusUnsupportedMediaType, http.StatusText(http.StatusUnsupportedMediaType))
+			return
+		}
+
+		data, err := jwt.GetDataToken(r, "id")
+		if err != nil || data == nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+		userID := data.(string)
+
+		input := dto.TwoFactorCode{}
+		decoder := json.NewDecoder(r.Body)
+
+		if err = decoder.Decode(&input); err != nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusUnprocessableEntity, "Malformed JSON")
+			return
+		}
+
+		if err = disableUseCase.Execute(r.Context(), userID, input.Code); err != nil {
+			log.Println(err.Error())
+			if errors.Is(err, twofactor.ErrInvalidCode) {
+				httputil.RespondWithError(w, http.StatusUnauthorized, err.Error())
+				return
+			}
+
+			if errors.Is(err, twofactor.ErrNotEnabled) {
+				httputil.RespondWithError(w, http.StatusNotFound, err.Error())
+				return
+			}
+
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// VerifyTwoFactor returns a token if the challenge token of the login and the TOTP or
+// recovery code are valid.
+func VerifyTwoFactor(verifyUseCase twofactor.VerifyUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !httputil.HasContentType(r, httputil.MimeApplicationJSON) {
+			httputil.RespondWithError(w, http.StatusUnsupportedMediaType, http.StatusText(http.StatusUnsupportedMediaType))
+			return
+		}
+
+		input := dto.TwoFactorLogin{}
+		decoder := json.NewDecoder(r.Body)
+
+		if err := decoder.Decode(&input); err != nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusUnprocessableEntity, "Malformed JSON")
+			return
+		}
+
+		ctx := withDevice(r.Context(), r, input.DeviceName)
+
+		tk, err := verifyUseCase.Execute(ctx, input.ChallengeToken, input.Code)
+		if err != nil {
+			log.Println(err.Error())
+			if errors.Is(err, twofactor.ErrInvalidChallenge) || errors.Is(err, twofactor.ErrInvalidCode) {
+				httputil.RespondWithError(w, http.StatusUnauthorized, err.Error())
+				return
+			}
+
+			if errors.Is(err, twofactor.ErrTooManyAttempts) {
+				httputil.RespondWithError(w, http.StatusTooManyRequests, err.Error())
+				return
+			}
+
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		tokenDto := &dto.TokenAuth{}
+		tokenDto.FromEntity(tk)
+
+		httputil.RespondWithJSON(w, http.StatusOK, tokenDto)
+	})
+}
+
+const twoFactorApiVersion string = "v1"
+
+var twoFactorEnrolResource string
+var twoFactorConfirmResource string
+var twoFactorDisableResource string
+var twoFactorLoginResource string
+
+func init() {
+	twoFactorEnrolResource = fmt.Sprintf("/%s/2fa/enrol", twoFactorApiVersion)
+	twoFactorConfirmResource = fmt.Sprintf("/%s/2fa/confirm", twoFactorApiVersion)
+	twoFactorDisableResource = fmt.Sprintf("/%s/2fa/disable", twoFactorApiVersion)
+	twoFactorLoginResource = fmt.Sprintf("/%s/login/2fa", twoFactorApiVersion)
+}
+
+func MakeTwoFactorHandlers(
+	r *mux.Router,
+	jwt auth.JWT,
+	auth middleware.Auth,
+	enrolUseCase twofactor.EnrolUseCase,
+	confirmUseCase twofactor.ConfirmUseCase,
+	disableUseCase twofactor.DisableUseCase,
+	verifyUseCase twofactor.VerifyUseCase) {
+
+	// 2fa/enrol [POST]
+	r.Handle(twoFactorEnrolResource, negroni.New(
+		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.RequireUnrestricted(jwt)),
+		negroni.Wrap(EnrolTwoFactor(jwt, enrolUseCase)),
+	)).Methods(http.MethodPost)
+
+	// 2fa/confirm [POST]
+	r.Handle(twoFactorConfirmResource, negroni.New(
+		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.RequireUnrestricted(jwt)),
+		negroni.Wrap(ConfirmTwoFactor(jwt, confirmUseCase)),
+	)).Methods(http.MethodPost)
+
+	// 2fa/disable [POST]
+	r.Handle(twoFactorDisableResource, negroni.New(
+		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.RequireUnrestricted(jwt)),
+		negroni.Wrap(DisableTwoFactor(jwt, disableUseCase)),
+	)).Methods(http.MethodPost)
+
+	// login/2fa [POST]
+	r.Handle(twoFactorLoginResource, VerifyTwoFactor(verifyUseCase)).
+		Methods(http.MethodPost)
+}
@@ -0,0 +1,273 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	tokenpkg "github.com/tsmweb/auth-service/app/token"
+	"github.com/tsmweb/auth-service/app/twofactor"
+	"github.com/tsmweb/auth-service/common"
+	"github.com/tsmweb/auth-service/web/api/dto"
+	"github.com/tsmweb/chat-server/pkg/scope"
+	"github.com/tsmweb/go-helper-api/middleware"
+)
+
+func newMockJWT() *common.MockJWT {
+	mJWT := new(common.MockJWT)
+	mJWT.On("GetDataToken", mock.Anything, "id").
+		Return("+5518999999999", nil)
+	return mJWT
+}
+
+func TestHandler_EnrolTwoFactor(t *testing.T) {
+	//t.Parallel()
+	serve := func(uc twofactor.EnrolUseCase) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, twoFactorEnrolResource, nil)
+		rec := httptest.NewRecorder()
+
+		EnrolTwoFactor(newMockJWT(), uc).ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("when handler.EnrolTwoFactor return StatusConflict", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockTwoFactorEnrolUseCase)
+		uc.On("Execute", mock.Anything, "+5518999999999").
+			Return(nil, twofactor.ErrAlreadyEnabled).
+			Once()
+
+		assert.Equal(t, http.StatusConflict, serve(uc).Code)
+	})
+
+	t.Run("when handler.EnrolTwoFactor return StatusInternalServerError", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockTwoFactorEnrolUseCase)
+		uc.On("Execute", mock.Anything, "+5518999999999").
+			Return(nil, errors.New("error")).
+			Once()
+
+		assert.Equal(t, http.StatusInternalServerError, serve(uc).Code)
+	})
+
+	t.Run("when handler.EnrolTwoFactor return StatusOK", func(t *testing.T) {
+		//t.Parallel()
+		tf := &twofactor.TwoFactor{ID: "+5518999999999", Secret: "GEZDGNBVGY3TQOJQ"}
+		uc := new(mockTwoFactorEnrolUseCase)
+		uc.On("Execute", mock.Anything, "+5518999999999").
+			Return(tf, nil).
+			Once()
+
+		rec := serve(uc)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var enrolment dto.TwoFactorEnrolment
+		assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &enrolment))
+		assert.Equal(t, tf.Secret, enrolment.Secret)
+		assert.Equal(t, tf.URI(), enrolment.URI)
+	})
+}
+
+func TestHandler_ConfirmTwoFactor(t *testing.T) {
+	//t.Parallel()
+	body, _ := json.Marshal(&dto.TwoFactorCode{Code: "123456"})
+
+	serve := func(contentType string, body []byte, uc twofactor.ConfirmUseCase) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, twoFactorConfirmResource, bytes.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		rec := httptest.NewRecorder()
+
+		ConfirmTwoFactor(newMockJWT(), uc).ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("when handler.ConfirmTwoFactor return StatusUnsupportedMediaType", func(t *testing.T) {
+		//t.Parallel()
+		rec := serve("text/plain", body, new(mockTwoFactorConfirmUseCase))
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	})
+
+	t.Run("when handler.ConfirmTwoFactor return StatusUnprocessableEntity", func(t *testing.T) {
+		//t.Parallel()
+		rec := serve("application/json", []byte("{[}"), new(mockTwoFactorConfirmUseCase))
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("when handler.ConfirmTwoFactor return error status", func(t *testing.T) {
+		//t.Parallel()
+		errs := map[error]int{
+			twofactor.ErrInvalidCode:    http.StatusUnauthorized,
+			twofactor.ErrNotEnrolled:    http.StatusNotFound,
+			twofactor.ErrAlreadyEnabled: http.StatusConflict,
+			errors.New("error"):         http.StatusInternalServerError,
+		}
+
+		for err, status := range errs {
+			uc := new(mockTwoFactorConfirmUseCase)
+			uc.On("Execute", mock.Anything, "+5518999999999", "123456").
+				Return(nil, err).
+				Once()
+
+			assert.Equal(t, status, serve("application/json", body, uc).Code, err.Error())
+		}
+	})
+
+	t.Run("when handler.ConfirmTwoFactor return StatusOK", func(t *testing.T) {
+		//t.Parallel()
+		codes := []string{"abcd-efgh", "ijkl-mnop"}
+		uc := new(mockTwoFactorConfirmUseCase)
+		uc.On("Execute", mock.Anything, "+5518999999999", "123456").
+			Return(codes, nil).
+			Once()
+
+		jCodes, _ := json.Marshal(&dto.RecoveryCodes{RecoveryCodes: codes})
+
+		rec := serve("application/json", body, uc)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, string(jCodes), rec.Body.String())
+	})
+}
+
+func TestHandler_DisableTwoFactor(t *testing.T) {
+	//t.Parallel()
+	body, _ := json.Marshal(&dto.TwoFactorCode{Code: "123456"})
+
+	serve := func(contentType string, body []byte, uc twofactor.DisableUseCase) int {
+		req := httptest.NewRequest(http.MethodPost, twoFactorDisableResource, bytes.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		rec := httptest.NewRecorder()
+
+		DisableTwoFactor(newMockJWT(), uc).ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("when handler.DisableTwoFactor return StatusUnsupportedMediaType", func(t *testing.T) {
+		//t.Parallel()
+		code := serve("text/plain", body, new(mockTwoFactorDisableUseCase))
+		assert.Equal(t, http.StatusUnsupportedMediaType, code)
+	})
+
+	t.Run("when handler.DisableTwoFactor return StatusUnprocessableEntity", func(t *testing.T) {
+		//t.Parallel()
+		code := serve("application/json", []byte("{[}"), new(mockTwoFactorDisableUseCase))
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+	})
+
+	t.Run("when handler.DisableTwoFactor return error status", func(t *testing.T) {
+		//t.Parallel()
+		errs := map[error]int{
+			twofactor.ErrInvalidCode: http.StatusUnauthorized,
+			twofactor.ErrNotEnabled:  http.StatusNotFound,
+			errors.New("error"):      http.StatusInternalServerError,
+		}
+
+		for err, status := range errs {
+			uc := new(mockTwoFactorDisableUseCase)
+			uc.On("Execute", mock.Anything, "+5518999999999", "123456").
+				Return(err).
+				Once()
+
+			assert.Equal(t, status, serve("application/json", body, uc), err.Error())
+		}
+	})
+
+	t.Run("when handler.DisableTwoFactor return StatusOK", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockTwoFactorDisableUseCase)
+		uc.On("Execute", mock.Anything, "+5518999999999", "123456").
+			Return(nil).
+			Once()
+
+		assert.Equal(t, http.StatusOK, serve("application/json", body, uc))
+	})
+}
+
+func TestHandler_VerifyTwoFactor(t *testing.T) {
+	//t.Parallel()
+	body, _ := json.Marshal(&dto.TwoFactorLogin{ChallengeToken: "C1H2A3L4", Code: "123456"})
+
+	serve := func(contentType string, body []byte, uc twofactor.VerifyUseCase) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, twoFactorLoginResource, bytes.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		rec := httptest.NewRecorder()
+
+		VerifyTwoFactor(uc).ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("when handler.VerifyTwoFactor return StatusUnsupportedMediaType", func(t *testing.T) {
+		//t.Parallel()
+		rec := serve("text/plain", body, new(mockTwoFactorVerifyUseCase))
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	})
+
+	t.Run("when handler.VerifyTwoFactor return StatusUnprocessableEntity", func(t *testing.T) {
+		//t.Parallel()
+		rec := serve("application/json", []byte("{[}"), new(mockTwoFactorVerifyUseCase))
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("when handler.VerifyTwoFactor return error status", func(t *testing.T) {
+		//t.Parallel()
+		errs := map[error]int{
+			twofactor.ErrInvalidChallenge: http.StatusUnauthorized,
+			twofactor.ErrInvalidCode:      http.StatusUnauthorized,
+			twofactor.ErrTooManyAttempts:  http.StatusTooManyRequests,
+			errors.New("error"):           http.StatusInternalServerError,
+		}
+
+		for err, status := range errs {
+			uc := new(mockTwoFactorVerifyUseCase)
+			uc.On("Execute", mock.Anything, "C1H2A3L4", "123456").
+				Return(nil, err).
+				Once()
+
+			assert.Equal(t, status, serve("application/json", body, uc).Code, err.Error())
+		}
+	})
+
+	t.Run("when handler.VerifyTwoFactor return StatusOK", func(t *testing.T) {
+		//t.Parallel()
+		tk := &tokenpkg.Token{AccessToken: "A1B2C3D4E5F6", RefreshToken: "F6E5D4C3B2A1", ExpiresIn: 3600}
+		uc := new(mockTwoFactorVerifyUseCase)
+		uc.On("Execute", mock.Anything, "C1H2A3L4", "123456").
+			Return(tk, nil).
+			Once()
+
+		tokenDto := &dto.TokenAuth{}
+		tokenDto.FromEntity(tk)
+		jToken, _ := json.Marshal(tokenDto)
+
+		rec := serve("application/json", body, uc)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, string(jToken), rec.Body.String())
+	})
+}
+
+func TestMakeTwoFactorHandlers_RestrictedToken(t *testing.T) {
+	//t.Parallel()
+	mJWT := newRestrictedJWT(scope.MessagesSend)
+	router := mux.NewRouter()
+	MakeTwoFactorHandlers(router, mJWT, middleware.NewAuth(mJWT), new(mockTwoFactorEnrolUseCase),
+		new(mockTwoFactorConfirmUseCase), new(mockTwoFactorDisableUseCase),
+		new(mockTwoFactorVerifyUseCase))
+
+	for _, resource := range []string{
+		twoFactorEnrolResource,
+		twoFactorConfirmResource,
+		twoFactorDisableResource,
+	} {
+		req := httptest.NewRequest(http.MethodPost, resource, bytes.NewReader([]byte("{}")))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusForbidden, rec.Code, resource)
+	}
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/token"
+	"github.com/tsmweb/auth-service/app/twofactor"
+)
+
+// mockTwoFactorEnrolUseCase injects mock dependency into Handler layer.
+type mockTwoFactorEnrolUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockTwoFactorEnrolUseCase) Execute(ctx context.Context, userID string) (*twofactor.TwoFactor, error) {
+	args := m.Called(ctx, userID)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*twofactor.TwoFactor), nil
+}
+
+// mockTwoFactorConfirmUseCase injects mock dependency into Handler layer.
+type mockTwoFactorConfirmUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockTwoFactorConfirmUseCase) Execute(ctx context.Context, userID, code string) ([]string, error) {
+	args := m.Called(ctx, userID, code)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), nil
+}
+
+// mockTwoFactorDisableUseCase injects mock dependency into Handler layer.
+type mockTwoFactorDisableUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockTwoFactorDisableUseCase) Execute(ctx context.Context, userID, code string) error {
+	args := m.Called(ctx, userID, code)
+	return args.Error(0)
+}
+
+// mockTwoFactorVerifyUseCase injects mock dependency into Handler layer.
+type mockTwoFactorVerifyUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockTwoFactorVerifyUseCase) Execute(ctx context.Context, challengeToken, code string) (*token.Token, error) {
+	args := m.Called(ctx, challengeToken, code)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*token.Token), nil
+}
@@ -6,16 +6,21 @@ import (
 	"errors"
 	"fmt"
 	"github.com/gorilla/mux"
+	"github.com/tsmweb/auth-service/app/login"
 	"github.com/tsmweb/auth-service/app/user"
+	"github.com/tsmweb/auth-service/app/verification"
 	"github.com/tsmweb/auth-service/common"
 	"github.com/tsmweb/auth-service/web/api/dto"
+	"github.com/tsmweb/chat-server/pkg/scope"
 	"github.com/tsmweb/go-helper-api/auth"
 	"github.com/tsmweb/go-helper-api/cerror"
 	"github.com/tsmweb/go-helper-api/httputil"
 	"github.com/tsmweb/go-helper-api/middleware"
 	"github.com/urfave/negroni"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
 )
 
 // GetUser a user by ID.
@@ -65,7 +70,8 @@ func CreateUser(createUseCase user.CreateUseCase) http.Handler {
 			return
 		}
 
-		err = createUseCase.Execute(r.Context(), userDto.ID, userDto.Name, userDto.LastName, userDto.Password)
+		err = createUseCase.Execute(r.Context(), userDto.ID, userDto.Name, userDto.LastName, userDto.Password,
+			userDto.Code)
 		if err != nil {
 			log.Println(err.Error())
 
@@ -75,6 +81,16 @@ func CreateUser(createUseCase user.CreateUseCase) http.Handler {
 				return
 			}
 
+			if errors.Is(err, verification.ErrInvalidCode) {
+				httputil.RespondWithError(w, http.StatusUnauthorized, err.Error())
+				return
+			}
+
+			if errors.Is(err, verification.ErrTooManyAttempts) {
+				httputil.RespondWithError(w, http.StatusTooManyRequests, err.Error())
+				return
+			}
+
 			if errors.Is(err, user.ErrUserAlreadyExists) {
 				httputil.RespondWithError(w, http.StatusConflict, err.Error())
 				return
@@ -142,6 +158,70 @@ func UpdateUser(jwt auth.JWT, updateUseCase user.UpdateUseCase) http.Handler {
 	})
 }
 
+// DeleteUser deletes the account of the user, confirmed by its current password. The data of
+// the user is removed by the services after the response, see GetUserDeletion.
+func DeleteUser(jwt auth.JWT, deleteUseCase user.DeleteUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !httputil.HasContentType(r, httputil.MimeApplicationJSON) {
+			httputil.RespondWithError(w, http.StatusUnsupportedMediaType, http.StatusText(http.StatusUnsupportedMediaType))
+			return
+		}
+
+		data, err := jwt.GetDataToken(r, "id")
+		if err != nil || data == nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+		userID := data.(string)
+
+		input := dto.DeleteUser{}
+		decoder := json.NewDecoder(r.Body)
+
+		if err = decoder.Decode(&input); err != nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusUnprocessableEntity, "Malformed JSON")
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), common.AuthContextKey, userID)
+		ctx = context.WithValue(ctx, common.ClientIPContextKey, clientIP(r))
+
+		err = deleteUseCase.Execute(ctx, userID, input.Password)
+		if err != nil {
+			log.Println(err.Error())
+			var errValidateModel *cerror.ErrValidateModel
+			if errors.As(err, &errValidateModel) {
+				httputil.RespondWithError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			var errLocked *login.ErrLocked
+			if errors.As(err, &errLocked) {
+				retryAfter := int(math.Ceil(errLocked.RetryAfter.Seconds()))
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				httputil.RespondWithError(w, http.StatusTooManyRequests, err.Error())
+				return
+			}
+
+			if errors.Is(err, user.ErrOperationNotAllowed) || errors.Is(err, cerror.ErrUnauthorized) {
+				httputil.RespondWithError(w, http.StatusUnauthorized, err.Error())
+				return
+			}
+
+			if errors.Is(err, user.ErrUserNotFound) {
+				httputil.RespondWithError(w, http.StatusNotFound, err.Error())
+				return
+			}
+
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
 const userApiVersion string = "v1"
 
 var userResource string
@@ -156,11 +236,13 @@ func MakeUserHandlers(
 	auth middleware.Auth,
 	getUseCase user.GetUseCase,
 	createUseCase user.CreateUseCase,
-	updateUseCase user.UpdateUseCase) {
+	updateUseCase user.UpdateUseCase,
+	deleteUseCase user.DeleteUseCase) {
 
 	// user [GET]
 	r.Handle(userResource, negroni.New(
 		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.RequireUnrestricted(jwt)),
 		negroni.Wrap(GetUser(jwt, getUseCase))),
 	).Methods(http.MethodGet)
 
@@ -171,6 +253,14 @@ func MakeUserHandlers(
 	// user [PUT]
 	r.Handle(userResource, negroni.New(
 		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.RequireUnrestricted(jwt)),
 		negroni.Wrap(UpdateUser(jwt, updateUseCase))),
 	).Methods(http.MethodPut)
+
+	// user [DELETE]
+	r.Handle(userResource, negroni.New(
+		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.RequireUnrestricted(jwt)),
+		negroni.Wrap(DeleteUser(jwt, deleteUseCase))),
+	).Methods(http.MethodDelete)
 }
@@ -4,14 +4,21 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/login"
 	"github.com/tsmweb/auth-service/app/user"
+	"github.com/tsmweb/auth-service/app/verification"
 	"github.com/tsmweb/auth-service/common"
 	"github.com/tsmweb/auth-service/web/api/dto"
+	"github.com/tsmweb/chat-server/pkg/scope"
+	"github.com/tsmweb/go-helper-api/cerror"
+	"github.com/tsmweb/go-helper-api/middleware"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestHandler_GetUser(t *testing.T) {
@@ -151,7 +158,7 @@ func TestHandler_CreateUser(t *testing.T) {
 		rec := httptest.NewRecorder()
 
 		mCreateUseCase := new(mockUserCreateUseCase)
-		mCreateUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		mCreateUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(user.ErrNameValidateModel).
 			Once()
 
@@ -160,6 +167,60 @@ func TestHandler_CreateUser(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
 	})
 
+	t.Run("when handler.CreateUser return StatusUnauthorized", func(t *testing.T) {
+		//t.Parallel()
+		userDto := &dto.User{
+			ID:       "+5518999999999",
+			Name:     "Steve",
+			LastName: "Jobs",
+			Password: "123456",
+			Code:     "654321",
+		}
+
+		jUserDto, err := json.Marshal(userDto)
+		assert.Nil(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, userResource, bytes.NewReader(jUserDto))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		mCreateUseCase := new(mockUserCreateUseCase)
+		mCreateUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, "654321").
+			Return(verification.ErrInvalidCode).
+			Once()
+
+		CreateUser(mCreateUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("when handler.CreateUser return StatusTooManyRequests", func(t *testing.T) {
+		//t.Parallel()
+		userDto := &dto.User{
+			ID:       "+5518999999999",
+			Name:     "Steve",
+			LastName: "Jobs",
+			Password: "123456",
+			Code:     "654321",
+		}
+
+		jUserDto, err := json.Marshal(userDto)
+		assert.Nil(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, userResource, bytes.NewReader(jUserDto))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		mCreateUseCase := new(mockUserCreateUseCase)
+		mCreateUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, "654321").
+			Return(verification.ErrTooManyAttempts).
+			Once()
+
+		CreateUser(mCreateUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	})
+
 	t.Run("when handler.CreateUser return StatusConflict", func(t *testing.T) {
 		//t.Parallel()
 		userDto := &dto.User{
@@ -177,7 +238,7 @@ func TestHandler_CreateUser(t *testing.T) {
 		rec := httptest.NewRecorder()
 
 		mCreateUseCase := new(mockUserCreateUseCase)
-		mCreateUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		mCreateUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(user.ErrUserAlreadyExists).
 			Once()
 
@@ -203,7 +264,7 @@ func TestHandler_CreateUser(t *testing.T) {
 		rec := httptest.NewRecorder()
 
 		mCreateUseCase := new(mockUserCreateUseCase)
-		mCreateUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		mCreateUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(errors.New("error")).
 			Once()
 
@@ -229,7 +290,7 @@ func TestHandler_CreateUser(t *testing.T) {
 		rec := httptest.NewRecorder()
 
 		mCreateUseCase := new(mockUserCreateUseCase)
-		mCreateUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		mCreateUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(nil).
 			Once()
 
@@ -404,3 +465,192 @@ func TestHandler_UpdateUser(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 	})
 }
+
+func TestHandler_DeleteUser(t *testing.T) {
+	//t.Parallel()
+
+	t.Run("when JWT fails with ErrInternalServer", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodDelete, userResource,
+			bytes.NewReader([]byte(`{"password":"123456"}`)))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return(nil, errors.New("jwt error")).
+			Once()
+		mDeleteUseCase := new(mockUserDeleteUseCase)
+
+		DeleteUser(mJWT, mDeleteUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		mDeleteUseCase.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when handler.DeleteUser return StatusUnsupportedMediaType", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodDelete, userResource, nil)
+		rec := httptest.NewRecorder()
+
+		mDeleteUseCase := new(mockUserDeleteUseCase)
+
+		DeleteUser(new(common.MockJWT), mDeleteUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+		mDeleteUseCase.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when handler.DeleteUser return StatusBadRequest", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodDelete, userResource, bytes.NewReader([]byte(`{}`)))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return("+5518999999999", nil).
+			Once()
+		mDeleteUseCase := new(mockUserDeleteUseCase)
+		mDeleteUseCase.On("Execute", mock.Anything, "+5518999999999", "").
+			Return(login.ErrPasswordValidateModel).
+			Once()
+
+		DeleteUser(mJWT, mDeleteUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("when handler.DeleteUser return StatusUnauthorized", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodDelete, userResource,
+			bytes.NewReader([]byte(`{"password":"123456"}`)))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return("+5518999999999", nil).
+			Once()
+		mDeleteUseCase := new(mockUserDeleteUseCase)
+		mDeleteUseCase.On("Execute", mock.Anything, "+5518999999999", "123456").
+			Return(cerror.ErrUnauthorized).
+			Once()
+
+		DeleteUser(mJWT, mDeleteUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("when handler.DeleteUser return StatusTooManyRequests", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodDelete, userResource,
+			bytes.NewReader([]byte(`{"password":"123456"}`)))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return("+5518999999999", nil).
+			Once()
+		mDeleteUseCase := new(mockUserDeleteUseCase)
+		mDeleteUseCase.On("Execute", mock.Anything, "+5518999999999", "123456").
+			Return(&login.ErrLocked{RetryAfter: 90 * time.Second}).
+			Once()
+
+		DeleteUser(mJWT, mDeleteUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "90", rec.Header().Get("Retry-After"))
+	})
+
+	t.Run("when handler.DeleteUser return StatusNotFound", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodDelete, userResource,
+			bytes.NewReader([]byte(`{"password":"123456"}`)))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return("+5518999999999", nil).
+			Once()
+		mDeleteUseCase := new(mockUserDeleteUseCase)
+		mDeleteUseCase.On("Execute", mock.Anything, "+5518999999999", "123456").
+			Return(user.ErrUserNotFound).
+			Once()
+
+		DeleteUser(mJWT, mDeleteUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("when handler.DeleteUser return StatusInternalServerError", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodDelete, userResource,
+			bytes.NewReader([]byte(`{"password":"123456"}`)))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return("+5518999999999", nil).
+			Once()
+		mDeleteUseCase := new(mockUserDeleteUseCase)
+		mDeleteUseCase.On("Execute", mock.Anything, "+5518999999999", "123456").
+			Return(errors.New("error")).
+			Once()
+
+		DeleteUser(mJWT, mDeleteUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("when handler.DeleteUser return StatusAccepted", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodDelete, userResource,
+			bytes.NewReader([]byte(`{"password":"123456"}`)))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return("+5518999999999", nil).
+			Once()
+		mDeleteUseCase := new(mockUserDeleteUseCase)
+		mDeleteUseCase.On("Execute", mock.Anything, "+5518999999999", "123456").
+			Return(nil).
+			Once()
+
+		DeleteUser(mJWT, mDeleteUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		mDeleteUseCase.AssertExpectations(t)
+	})
+}
+
+// newRestrictedJWT returns a JWT of a service account restricted to the scopes.
+func newRestrictedJWT(scopes string) *common.MockJWT {
+	mJWT := new(common.MockJWT)
+	mJWT.On("ExtractToken", mock.Anything).Return("token", nil)
+	mJWT.On("GetDataToken", mock.Anything, "id").Return("svc_A1B2C3", nil)
+	mJWT.On("GetDataToken", mock.Anything, "scope").Return(scopes, nil)
+	return mJWT
+}
+
+func TestMakeUserHandlers_RestrictedToken(t *testing.T) {
+	//t.Parallel()
+	mJWT := newRestrictedJWT(scope.MessagesSend + " " + scope.ContactsWrite)
+	router := mux.NewRouter()
+	MakeUserHandlers(router, mJWT, middleware.NewAuth(mJWT), new(mockUserGetUseCase),
+		new(mockUserCreateUseCase), new(mockUserUpdateUseCase), new(mockUserDeleteUseCase))
+
+	for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete} {
+		req := httptest.NewRequest(method, userResource, bytes.NewReader([]byte("{}")))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusForbidden, rec.Code, method)
+	}
+}
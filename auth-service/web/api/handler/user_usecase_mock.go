@@ -26,8 +26,8 @@ type mockUserCreateUseCase struct {
 }
 
 // Execute represents the simulated method for the Execute feature in the UseCase layer.
-func (m *mockUserCreateUseCase) Execute(ctx context.Context, ID, name, lastname, password string) error {
-	args := m.Called(ctx, ID, name, lastname, password)
+func (m *mockUserCreateUseCase) Execute(ctx context.Context, ID, name, lastname, password, code string) error {
+	args := m.Called(ctx, ID, name, lastname, password, code)
 	return args.Error(0)
 }
 
@@ -41,3 +41,14 @@ func (m *mockUserUpdateUseCase) Execute(ctx context.Context, u *user.User) error
 	args := m.Called(ctx, u)
 	return args.Error(0)
 }
+
+// mockUserDeleteUseCase injects mock dependency into Handler layer.
+type mockUserDeleteUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockUserDeleteUseCase) Execute(ctx context.Context, ID, password string) error {
+	args := m.Called(ctx, ID, password)
+	return args.Error(0)
+}
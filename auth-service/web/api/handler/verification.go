@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tsmweb/auth-service/app/verification"
+	"github.com/tsmweb/auth-service/common"
+	"github.com/tsmweb/auth-service/web/api/dto"
+	"github.com/tsmweb/go-helper-api/cerror"
+	"github.com/tsmweb/go-helper-api/httputil"
+)
+
+// RequestVerification sends a verification code to the phone number, required to create the
+// user with it.
+func RequestVerification(requestUseCase verification.RequestUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !httputil.HasContentType(r, httputil.MimeApplicationJSON) {
+			httputil.RespondWithError(w, http.StatusUnsupportedMediaType, http.StatusText(http.StatusUnsupportedMediaType))
+			return
+		}
+
+		input := dto.Verification{}
+		decoder := json.NewDecoder(r.Body)
+
+		if err := decoder.Decode(&input); err != nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusUnprocessableEntity, "Malformed JSON")
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), common.ClientIPContextKey, clientIP(r))
+
+		if err := requestUseCase.Execute(ctx, input.ID); err != nil {
+			log.Println(err.Error())
+			var errValidateModel *cerror.ErrValidateModel
+			if errors.As(err, &errValidateModel) {
+				httputil.RespondWithError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			if errors.Is(err, verification.ErrResendTooSoon) ||
+				errors.Is(err, verification.ErrTooManyRequests) {
+				httputil.RespondWithError(w, http.StatusTooManyRequests, err.Error())
+				return
+			}
+
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+const verificationApiVersion string = "v1"
+
+var verificationResource string
+
+func init() {
+	verificationResource = fmt.Sprintf("/%s/verification", verificationApiVersion)
+}
+
+func MakeVerificationHandlers(
+	r *mux.Router,
+	requestUseCase verification.RequestUseCase) {
+
+	// verification [POST]
+	r.Handle(verificationResource, RequestVerification(requestUseCase)).
+		Methods(http.MethodPost)
+}
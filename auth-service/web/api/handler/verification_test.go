@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/auth-service/app/verification"
+	"github.com/tsmweb/auth-service/web/api/dto"
+)
+
+func TestHandler_RequestVerification(t *testing.T) {
+	//t.Parallel()
+	body, _ := json.Marshal(&dto.Verification{ID: "+5518999999999"})
+
+	serve := func(contentType string, body []byte, uc verification.RequestUseCase) int {
+		req := httptest.NewRequest(http.MethodPost, verificationResource, bytes.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		rec := httptest.NewRecorder()
+
+		RequestVerification(uc).ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("when handler.RequestVerification return StatusUnsupportedMediaType", func(t *testing.T) {
+		//t.Parallel()
+		code := serve("text/plain", body, new(mockVerificationRequestUseCase))
+		assert.Equal(t, http.StatusUnsupportedMediaType, code)
+	})
+
+	t.Run("when handler.RequestVerification return StatusUnprocessableEntity", func(t *testing.T) {
+		//t.Parallel()
+		code := serve("application/json", []byte("{[}"), new(mockVerificationRequestUseCase))
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+	})
+
+	t.Run("when handler.RequestVerification return StatusBadRequest", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockVerificationRequestUseCase)
+		uc.On("Execute", mock.Anything, "+5518999999999").
+			Return(verification.ErrPhoneValidateModel).
+			Once()
+
+		code := serve("application/json", body, uc)
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("when handler.RequestVerification return StatusTooManyRequests", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockVerificationRequestUseCase)
+		uc.On("Execute", mock.Anything, "+5518999999999").
+			Return(verification.ErrResendTooSoon).
+			Once()
+
+		code := serve("application/json", body, uc)
+		assert.Equal(t, http.StatusTooManyRequests, code)
+	})
+
+	t.Run("when handler.RequestVerification return StatusInternalServerError", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockVerificationRequestUseCase)
+		uc.On("Execute", mock.Anything, "+5518999999999").
+			Return(errors.New("error")).
+			Once()
+
+		code := serve("application/json", body, uc)
+		assert.Equal(t, http.StatusInternalServerError, code)
+	})
+
+	t.Run("when handler.RequestVerification return StatusAccepted", func(t *testing.T) {
+		//t.Parallel()
+		uc := new(mockVerificationRequestUseCase)
+		uc.On("Execute", mock.Anything, "+5518999999999").
+			Return(nil).
+			Once()
+
+		code := serve("application/json", body, uc)
+		assert.Equal(t, http.StatusAccepted, code)
+	})
+}
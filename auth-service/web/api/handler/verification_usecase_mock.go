@@ -0,0 +1,18 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// mockVerificationRequestUseCase injects mock dependency into Handler layer.
+type mockVerificationRequestUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockVerificationRequestUseCase) Execute(ctx context.Context, phone string) error {
+	args := m.Called(ctx, phone)
+	return args.Error(0)
+}
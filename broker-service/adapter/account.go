@@ -0,0 +1,31 @@
+package adapter
+
+import (
+	"time"
+
+	"github.com/tsmweb/broker-service/broker/account"
+	"github.com/tsmweb/broker-service/infra/protobuf"
+	"google.golang.org/protobuf/proto"
+)
+
+// AccountEventUnmarshal is a protobuf.AccountEvent decoder for account.Event.
+func AccountEventUnmarshal(in []byte, e *account.Event) error {
+	epb := new(protobuf.AccountEvent)
+	if err := proto.Unmarshal(in, epb); err != nil {
+		return err
+	}
+	e.UserID = epb.GetUserId()
+	e.Event = epb.GetEvent().String()
+	e.EventDate = time.Unix(epb.GetEventDate(), 0)
+	return nil
+}
+
+// DeletionReportMarshal is a account.DeletionReport encoder for protobuf.DeletionReport.
+func DeletionReportMarshal(r *account.DeletionReport) ([]byte, error) {
+	rpb := &protobuf.DeletionReport{
+		UserId:      r.UserID,
+		Service:     r.Service,
+		CompletedAt: r.CompletedAt.Unix(),
+	}
+	return proto.Marshal(rpb)
+}
@@ -0,0 +1,97 @@
+package account
+
+import "time"
+
+// ServiceName identifies broker-service in the DeletionReport.
+const ServiceName = "broker-service"
+
+// EventType represents the event type of the user account ("user deleted", "user created",
+// "user updated", "password changed").
+type EventType int
+
+const (
+	EventUserDeleted     EventType = 0x1
+	EventUserCreated     EventType = 0x2
+	EventUserUpdated     EventType = 0x4
+	EventPasswordChanged EventType = 0x8
+)
+
+// String return the name of the EventType.
+func (et EventType) String() (str string) {
+	name := func(eventType EventType, name string) bool {
+		if et&eventType == 0 {
+			return false
+		}
+		str = name
+		return true
+	}
+
+	if name(EventUserDeleted, "UserDeleted") {
+		return
+	}
+	if name(EventUserCreated, "UserCreated") {
+		return
+	}
+	if name(EventUserUpdated, "UserUpdated") {
+		return
+	}
+	if name(EventPasswordChanged, "PasswordChanged") {
+		return
+	}
+
+	return
+}
+
+// Event represents the events of the user account published by auth-service.
+type Event struct {
+	UserID    string
+	Event     string
+	EventDate time.Time
+}
+
+// EventDecoder is a byte slice decoder for account.Event.
+type EventDecoder interface {
+	Unmarshal(in []byte, evt *Event) error
+}
+
+// The EventDecoderFunc type is an adapter to allow the use of ordinary functions as decoders of
+// byte slice for account.Event.
+// If f is a function with the appropriate signature, EventDecoderFunc(f) is a Decoder that calls f.
+type EventDecoderFunc func(in []byte, evt *Event) error
+
+// Unmarshal calls f(in, m).
+func (f EventDecoderFunc) Unmarshal(in []byte, evt *Event) error {
+	return f(in, evt)
+}
+
+// DeletionReport reports to auth-service that the data of a deleted user was removed.
+type DeletionReport struct {
+	UserID      string
+	Service     string
+	CompletedAt time.Time
+}
+
+// NewDeletionReport return an instance of DeletionReport for broker-service.
+func NewDeletionReport(userID string) *DeletionReport {
+	return &DeletionReport{
+		UserID:      userID,
+		Service:     ServiceName,
+		CompletedAt: time.Now().UTC(),
+	}
+}
+
+// ReportEncoder is a DeletionReport encoder for byte slice.
+type ReportEncoder interface {
+	Marshal(r *DeletionReport) ([]byte, error)
+}
+
+// The ReportEncoderFunc type is an adapter to allow the use of ordinary functions as encoders of
+// DeletionReport for byte slice.
+// If f is a function with the appropriate signature, ReportEncoderFunc(f) is a ReportEncoder
+// that calls f.
+type ReportEncoderFunc func(r *DeletionReport) ([]byte, error)
+
+// Marshal calls f(r).
+func (f ReportEncoderFunc) Marshal(r *DeletionReport) ([]byte, error) {
+	return f(r)
+}
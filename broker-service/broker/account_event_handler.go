@@ -0,0 +1,71 @@
+package broker
+
+import (
+	"context"
+
+	"github.com/tsmweb/broker-service/broker/account"
+	"github.com/tsmweb/broker-service/broker/message"
+	"github.com/tsmweb/broker-service/broker/user"
+	"github.com/tsmweb/go-helper-api/kafka"
+)
+
+// AccountEventHandler handles the events of the user account.
+type AccountEventHandler interface {
+	// Execute performs account event handling.
+	Execute(ctx context.Context, evt account.Event) error
+}
+
+type accountEventHandler struct {
+	userRepository user.Repository
+	msgRepository  message.Repository
+	encoder        account.ReportEncoder
+	producer       kafka.Producer
+}
+
+// NewAccountEventHandler implements the AccountEventHandler interface.
+func NewAccountEventHandler(
+	userRepository user.Repository,
+	msgRepository message.Repository,
+	encoder account.ReportEncoder,
+	producer kafka.Producer,
+) AccountEventHandler {
+	return &accountEventHandler{
+		userRepository: userRepository,
+		msgRepository:  msgRepository,
+		encoder:        encoder,
+		producer:       producer,
+	}
+}
+
+// Execute performs account event handling. When the user is deleted, its presence and offline
+// messages are removed and the deletion is reported to auth-service. When the user is created
+// or changed, it is cached as valid.
+func (h *accountEventHandler) Execute(ctx context.Context, evt account.Event) error {
+	switch evt.Event {
+	case account.EventUserDeleted.String():
+		return h.deleteUser(ctx, evt.UserID)
+	case account.EventUserCreated.String(),
+		account.EventUserUpdated.String(),
+		account.EventPasswordChanged.String():
+		return h.userRepository.UpdateValidUserCache(ctx, evt.UserID, true)
+	}
+	return nil
+}
+
+func (h *accountEventHandler) deleteUser(ctx context.Context, userID string) error {
+	if err := h.userRepository.RemoveUserPresence(ctx, userID); err != nil {
+		return err
+	}
+	if err := h.userRepository.InvalidateUserCache(ctx, userID); err != nil {
+		return err
+	}
+	if err := h.msgRepository.PurgeMessages(ctx, userID); err != nil {
+		return err
+	}
+
+	rpb, err := h.encoder.Marshal(account.NewDeletionReport(userID))
+	if err != nil {
+		return err
+	}
+	return h.producer.Publish(ctx, []byte(userID), rpb)
+}
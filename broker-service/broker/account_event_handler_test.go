@@ -0,0 +1,100 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/broker-service/broker/account"
+	"testing"
+)
+
+func TestAccountEventHandler_Execute(t *testing.T) {
+	ctx := context.Background()
+	userID := "+5518977777777"
+	evt := account.Event{UserID: userID, Event: account.EventUserDeleted.String()}
+
+	encoder := account.ReportEncoderFunc(func(r *account.DeletionReport) ([]byte, error) {
+		return []byte(r.Service), nil
+	})
+
+	t.Run("when the event is not handled", func(t *testing.T) {
+		userRepo := new(mockUserRepository)
+		msgRepo := new(mockMessageRepository)
+		producer := new(mockProducer)
+
+		handler := NewAccountEventHandler(userRepo, msgRepo, encoder, producer)
+		err := handler.Execute(ctx, account.Event{UserID: userID, Event: "UserLocked"})
+		assert.Nil(t, err)
+		userRepo.AssertNotCalled(t, "RemoveUserPresence", mock.Anything, mock.Anything)
+		userRepo.AssertNotCalled(t, "UpdateValidUserCache", mock.Anything, mock.Anything,
+			mock.Anything)
+	})
+
+	t.Run("when the user is created or changed", func(t *testing.T) {
+		userRepo := new(mockUserRepository)
+		userRepo.On("UpdateValidUserCache", mock.Anything, userID, true).
+			Return(nil).
+			Times(3)
+		msgRepo := new(mockMessageRepository)
+		producer := new(mockProducer)
+
+		handler := NewAccountEventHandler(userRepo, msgRepo, encoder, producer)
+		for _, et := range []account.EventType{
+			account.EventUserCreated,
+			account.EventUserUpdated,
+			account.EventPasswordChanged,
+		} {
+			err := handler.Execute(ctx, account.Event{UserID: userID, Event: et.String()})
+			assert.Nil(t, err)
+		}
+		userRepo.AssertExpectations(t)
+		producer.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when purging the messages fails", func(t *testing.T) {
+		userRepo := new(mockUserRepository)
+		userRepo.On("RemoveUserPresence", mock.Anything, userID).
+			Return(nil).
+			Once()
+		userRepo.On("InvalidateUserCache", mock.Anything, userID).
+			Return(nil).
+			Once()
+		msgRepo := new(mockMessageRepository)
+		msgRepo.On("PurgeMessages", mock.Anything, userID).
+			Return(errors.New("error")).
+			Once()
+		producer := new(mockProducer)
+
+		handler := NewAccountEventHandler(userRepo, msgRepo, encoder, producer)
+		err := handler.Execute(ctx, evt)
+		assert.NotNil(t, err)
+		producer.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when the user is deleted", func(t *testing.T) {
+		userRepo := new(mockUserRepository)
+		userRepo.On("RemoveUserPresence", mock.Anything, userID).
+			Return(nil).
+			Once()
+		userRepo.On("InvalidateUserCache", mock.Anything, userID).
+			Return(nil).
+			Once()
+		msgRepo := new(mockMessageRepository)
+		msgRepo.On("PurgeMessages", mock.Anything, userID).
+			Return(nil).
+			Once()
+		producer := new(mockProducer)
+		producer.On("Publish", mock.Anything, []byte(userID),
+			[][]byte{[]byte(account.ServiceName)}).
+			Return(nil).
+			Once()
+
+		handler := NewAccountEventHandler(userRepo, msgRepo, encoder, producer)
+		err := handler.Execute(ctx, evt)
+		assert.Nil(t, err)
+		userRepo.AssertExpectations(t)
+		msgRepo.AssertExpectations(t)
+		producer.AssertExpectations(t)
+	})
+}
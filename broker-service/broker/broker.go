@@ -6,6 +6,7 @@ import (
 	"log"
 	"sync"
 
+	"github.com/tsmweb/broker-service/broker/account"
 	"github.com/tsmweb/broker-service/broker/group"
 	"github.com/tsmweb/broker-service/broker/message"
 	"github.com/tsmweb/broker-service/broker/user"
@@ -24,22 +25,26 @@ type Broker struct {
 	chUserMessage          chan message.Message
 	chGroupEvent           chan group.Event
 	chUserEvent            chan user.Event
+	chAccountEvent         chan account.Event
 	userDecoder            user.Decoder
 	msgDecoder             message.Decoder
 	groupEventDecoder      group.EventDecoder
 	userEventDecoder       user.EventDecoder
+	accountEventDecoder    account.EventDecoder
 	userConsumer           kafka.Consumer
 	userPresenceConsumer   kafka.Consumer
 	messageConsumer        kafka.Consumer
 	offlineMessageConsumer kafka.Consumer
 	groupEventConsumer     kafka.Consumer
 	userEventConsumer      kafka.Consumer
+	accountEventConsumer   kafka.Consumer
 	userHandler            UserHandler
 	userPresenceHandler    UserPresenceHandler
 	messageHandler         MessageHandler
 	offlineMessageHandler  OfflineMessageHandler
 	groupEventHandler      GroupEventHandler
 	userEventHandler       UserEventHandler
+	accountEventHandler    AccountEventHandler
 }
 
 // NewBroker creates an instance of Broker.
@@ -49,18 +54,21 @@ func NewBroker(
 	msgDecoder message.Decoder,
 	groupEventDecoder group.EventDecoder,
 	userEventDecoder user.EventDecoder,
+	accountEventDecoder account.EventDecoder,
 	userConsumer kafka.Consumer,
 	userPresenceConsumer kafka.Consumer,
 	messageConsumer kafka.Consumer,
 	offlineMessageConsumer kafka.Consumer,
 	groupEventConsumer kafka.Consumer,
 	userEventConsumer kafka.Consumer,
+	accountEventConsumer kafka.Consumer,
 	userHandler UserHandler,
 	userPresenceHandler UserPresenceHandler,
 	messageHandler MessageHandler,
 	offlineMessageHandler OfflineMessageHandler,
 	groupEventHandler GroupEventHandler,
 	userEventHandler UserEventHandler,
+	accountEventHandler AccountEventHandler,
 ) *Broker {
 	broker := &Broker{
 		ctx:                    ctx,
@@ -71,22 +79,26 @@ func NewBroker(
 		chUserMessage:          make(chan message.Message),
 		chGroupEvent:           make(chan group.Event),
 		chUserEvent:            make(chan user.Event),
+		chAccountEvent:         make(chan account.Event),
 		userDecoder:            userDecoder,
 		msgDecoder:             msgDecoder,
 		groupEventDecoder:      groupEventDecoder,
 		userEventDecoder:       userEventDecoder,
+		accountEventDecoder:    accountEventDecoder,
 		userConsumer:           userConsumer,
 		userPresenceConsumer:   userPresenceConsumer,
 		messageConsumer:        messageConsumer,
 		offlineMessageConsumer: offlineMessageConsumer,
 		groupEventConsumer:     groupEventConsumer,
 		userEventConsumer:      userEventConsumer,
+		accountEventConsumer:   accountEventConsumer,
 		userHandler:            userHandler,
 		userPresenceHandler:    userPresenceHandler,
 		messageHandler:         messageHandler,
 		offlineMessageHandler:  offlineMessageHandler,
 		groupEventHandler:      groupEventHandler,
 		userEventHandler:       userEventHandler,
+		accountEventHandler:    accountEventHandler,
 	}
 
 	return broker
@@ -99,6 +111,7 @@ func (b *Broker) Start() {
 	go b.offlineMessagesConsumer()
 	go b.groupEventsConsumer()
 	go b.userEventsConsumer()
+	go b.accountEventsConsumer()
 
 	b.messageProcessor()
 }
@@ -229,6 +242,21 @@ func (b *Broker) messageProcessor() {
 		}
 	}()
 
+	// Account Events
+	wg.Add(1)
+	go func() {
+		defer func() {
+			wg.Done()
+			log.Println("[STOP] broker::Broker::chAccountEvent")
+		}()
+
+		for e := range b.chAccountEvent {
+			if err := poolEvents.Schedule(b.accountEventTask(e)); err != nil {
+				log.Printf("[ERROR] broker::Broker::poolEvents: %v\n", err)
+			}
+		}
+	}()
+
 	wg.Wait()
 }
 
@@ -382,6 +410,31 @@ func (b *Broker) userEventsConsumer() {
 	b.userEventConsumer.Subscribe(b.ctx, callbackFn)
 }
 
+func (b *Broker) accountEventsConsumer() {
+	defer func() {
+		b.accountEventConsumer.Close()
+		close(b.chAccountEvent)
+		log.Println("[STOP] broker::Broker::accountEventsConsumer")
+	}()
+
+	callbackFn := func(event *kafka.Event, err error) {
+		if err != nil {
+			service.Error("", "broker::Broker::accountEventsConsumer", err)
+			return
+		}
+
+		var accountEvent account.Event
+		if err = b.accountEventDecoder.Unmarshal(event.Value, &accountEvent); err != nil {
+			service.Error(string(event.Key), "broker::Broker::accountEventsConsumer", err)
+			return
+		}
+
+		b.chAccountEvent <- accountEvent
+	}
+
+	b.accountEventConsumer.Subscribe(b.ctx, callbackFn)
+}
+
 func (b *Broker) userTask(usr user.User, wg *sync.WaitGroup) func(ctx context.Context) {
 	return func(ctx context.Context) {
 		defer wg.Done()
@@ -437,3 +490,12 @@ func (b *Broker) userEventTask(evt user.Event) func(ctx context.Context) {
 		}
 	}
 }
+
+func (b *Broker) accountEventTask(evt account.Event) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		if err := b.accountEventHandler.Execute(ctx, evt); err != nil {
+			service.Error(evt.UserID, "broker::Broker::accountEventTask",
+				fmt.Errorf("broker::AccountEventHandler: %s", err.Error()))
+		}
+	}
+}
@@ -78,6 +78,9 @@ type Repository interface {
 
 	// DeleteAllMessages deletes all messages by userID.
 	DeleteAllMessages(ctx context.Context, userID string) error
+
+	// PurgeMessages deletes all messages addressed to userID, delivered or not.
+	PurgeMessages(ctx context.Context, userID string) error
 }
 
 var (
@@ -101,6 +104,10 @@ type Message struct {
 	Date        time.Time `json:"date"`
 	ContentType string    `json:"content_type"`
 	Content     string    `json:"content"`
+
+	// Callback is the URL informed by the sender to be notified of the delivery errors,
+	// instead of receiving them as messages. It is not exposed to the users.
+	Callback string `json:"-"`
 }
 
 // New creates and returns a new Message instance.
@@ -7,6 +7,7 @@ import (
 
 	"github.com/tsmweb/broker-service/broker/message"
 	"github.com/tsmweb/broker-service/broker/user"
+	"github.com/tsmweb/broker-service/broker/webhook"
 	"github.com/tsmweb/broker-service/config"
 	"github.com/tsmweb/go-helper-api/kafka"
 )
@@ -18,24 +19,30 @@ type MessageHandler interface {
 }
 
 type messageHandler struct {
-	userRepository user.Repository
-	msgRepository  message.Repository
-	queue          kafka.Kafka
-	encoder        message.Encoder
+	userRepository    user.Repository
+	msgRepository     message.Repository
+	webhookRepository webhook.Repository
+	queue             kafka.Kafka
+	encoder           message.Encoder
+	dispatcher        webhook.Dispatcher
 }
 
 // NewMessageHandler implements the MessageHandler interface.
 func NewMessageHandler(
 	userRepository user.Repository,
 	msgRepository message.Repository,
+	webhookRepository webhook.Repository,
 	queue kafka.Kafka,
 	encoder message.Encoder,
+	dispatcher webhook.Dispatcher,
 ) MessageHandler {
 	return &messageHandler{
-		userRepository: userRepository,
-		msgRepository:  msgRepository,
-		queue:          queue,
-		encoder:        encoder,
+		userRepository:    userRepository,
+		msgRepository:     msgRepository,
+		webhookRepository: webhookRepository,
+		queue:             queue,
+		encoder:           encoder,
+		dispatcher:        dispatcher,
 	}
 }
 
@@ -73,14 +80,7 @@ func (h *messageHandler) processGroupMessage(ctx context.Context, msg *message.M
 		return err
 	}
 	if len(members) < 1 {
-		msgResponse := message.NewResponse(
-			msg.ID,
-			msg.From,
-			msg.Group,
-			message.ContentTypeError,
-			message.ErrGroupIsInvalid.Error(),
-		)
-		return h.sendMessage(ctx, msgResponse)
+		return h.sendError(ctx, msg, message.ErrGroupIsInvalid)
 	}
 	if len(members) == 1 {
 		return nil
@@ -112,14 +112,7 @@ func (h *messageHandler) isValidUser(ctx context.Context, msg *message.Message)
 		return false, err
 	}
 	if !ok {
-		msgResponse := message.NewResponse(
-			msg.ID,
-			msg.From,
-			"",
-			message.ContentTypeError,
-			message.ErrMessageAddresseeIsInvalid.Error(),
-		)
-		return false, h.sendMessage(ctx, msgResponse)
+		return false, h.sendError(ctx, msg, message.ErrMessageAddresseeIsInvalid)
 	}
 
 	return true, nil
@@ -132,20 +125,53 @@ func (h *messageHandler) isBlockedUser(ctx context.Context, msg *message.Message
 		return false, err
 	}
 	if ok {
-		msgResponse := message.NewResponse(
-			msg.ID,
-			msg.From,
-			"",
-			message.ContentTypeError,
-			message.ErrMessageSendingBlocked.Error(),
-		)
-		return true, h.sendMessage(ctx, msgResponse)
+		return true, h.sendError(ctx, msg, message.ErrMessageSendingBlocked)
 	}
 
 	return false, nil
 }
 
+// sendError responds to the sender that the message could not be delivered. The response is
+// POSTed to the callback of the message, if informed, signed with the secret of the sender's
+// webhook when it has one. The dispatcher refuses the callbacks to internal addresses.
+func (h *messageHandler) sendError(ctx context.Context, msg *message.Message, err error) error {
+	msgResponse := message.NewResponse(
+		msg.ID,
+		msg.From,
+		msg.Group,
+		message.ContentTypeError,
+		err.Error(),
+	)
+
+	if msg.Callback == "" {
+		return h.sendMessage(ctx, msgResponse)
+	}
+
+	callback := &webhook.Webhook{UserID: msg.From, URL: msg.Callback}
+	hook, err := h.webhookRepository.GetWebhook(ctx, msg.From)
+	if err != nil {
+		return err
+	}
+	if hook != nil {
+		callback.Secret = hook.Secret
+	}
+	h.dispatcher.Dispatch(callback, msgResponse)
+	return nil
+}
+
 func (h *messageHandler) sendMessage(ctx context.Context, msg *message.Message) error {
+	// Bots with a webhook receive their messages by HTTP instead of a connection.
+	hook, err := h.webhookRepository.GetWebhook(ctx, msg.To)
+	if err != nil {
+		return err
+	}
+	if hook != nil {
+		if msg.ContentType != message.ContentTypeStatus.String() {
+			h.dispatcher.Dispatch(hook, msg)
+		}
+		return nil
+	}
+
 	serverID, err := h.userRepository.GetUserServer(ctx, msg.To)
 	if err != nil {
 		return err
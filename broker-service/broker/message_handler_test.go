@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/tsmweb/broker-service/broker/message"
+	"github.com/tsmweb/broker-service/broker/webhook"
 	"testing"
 )
 
@@ -30,7 +31,7 @@ func TestMessageHandler_Execute(t *testing.T) {
 		queue := new(mockKafka)
 		queue.On("NewProducer", mock.Anything).
 			Return(producer)
-		handler := NewMessageHandler(userRepo, msgRepo, queue, encoder)
+		handler := NewMessageHandler(userRepo, msgRepo, noWebhooks(), queue, encoder, new(mockDispatcher))
 
 		msgRepo.On("GetAllGroupMembers", mock.Anything, mock.Anything).
 			Return(nil, errors.New("error")).
@@ -73,7 +74,7 @@ func TestMessageHandler_Execute(t *testing.T) {
 		queue.On("NewProducer", mock.Anything).
 			Return(producer)
 
-		handler := NewMessageHandler(userRepo, msgRepo, queue, encoder)
+		handler := NewMessageHandler(userRepo, msgRepo, noWebhooks(), queue, encoder, new(mockDispatcher))
 		err := handler.Execute(ctx, *msgGroup)
 		assert.Nil(t, err)
 	})
@@ -85,7 +86,7 @@ func TestMessageHandler_Execute(t *testing.T) {
 		queue := new(mockKafka)
 		queue.On("NewProducer", mock.Anything).
 			Return(producer)
-		handler := NewMessageHandler(userRepo, msgRepo, queue, encoder)
+		handler := NewMessageHandler(userRepo, msgRepo, noWebhooks(), queue, encoder, new(mockDispatcher))
 
 		userRepo.On("IsValidUser", mock.Anything, mock.Anything).
 			Return(false, errors.New("error")).
@@ -133,7 +134,7 @@ func TestMessageHandler_Execute(t *testing.T) {
 		queue := new(mockKafka)
 		queue.On("NewProducer", mock.Anything).
 			Return(producer)
-		handler := NewMessageHandler(userRepo, msgRepo, queue, encoder)
+		handler := NewMessageHandler(userRepo, msgRepo, noWebhooks(), queue, encoder, new(mockDispatcher))
 
 		userRepo.On("IsValidUser", mock.Anything, mock.Anything).
 			Return(true, nil)
@@ -154,3 +155,173 @@ func TestMessageHandler_Execute(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestMessageHandler_ExecuteWebhook(t *testing.T) {
+	ctx := context.Background()
+	hook := &webhook.Webhook{UserID: "svc_A1B2C3", URL: "http://localhost/webhook",
+		Secret: "whsec_S3CR3T"}
+
+	msg, _ := message.New("+5518911111111", hook.UserID, "", message.ContentTypeText,
+		"message test")
+	status, _ := message.New("+5518911111111", hook.UserID, "", message.ContentTypeStatus,
+		"typing")
+	msgGroup, _ := message.New("+5518911111111", "", "123456", message.ContentTypeText,
+		"message group test")
+
+	newHandler := func(dispatcher *mockDispatcher) (MessageHandler, *mockKafka) {
+		userRepo := new(mockUserRepository)
+		userRepo.On("IsValidUser", mock.Anything, mock.Anything).
+			Return(true, nil)
+		userRepo.On("IsBlockedUser", mock.Anything, mock.Anything, mock.Anything).
+			Return(false, nil)
+		userRepo.On("GetUserServer", mock.Anything, mock.Anything).
+			Return("", nil)
+
+		msgRepo := new(mockMessageRepository)
+		msgRepo.On("GetAllGroupMembers", mock.Anything, mock.Anything).
+			Return([]string{"+5518911111111", hook.UserID}, nil)
+
+		webhookRepo := new(mockWebhookRepository)
+		webhookRepo.On("GetWebhook", mock.Anything, hook.UserID).
+			Return(hook, nil)
+
+		producer := new(mockProducer)
+		producer.On("Publish", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+		queue := new(mockKafka)
+		queue.On("NewProducer", mock.Anything).
+			Return(producer)
+
+		encoder := new(mockMessageEncoder)
+		encoder.On("Marshal", mock.Anything).
+			Return([]byte{}, nil)
+
+		return NewMessageHandler(userRepo, msgRepo, webhookRepo, queue, encoder, dispatcher), queue
+	}
+
+	t.Run("when addressee has a webhook", func(t *testing.T) {
+		dispatcher := new(mockDispatcher)
+		dispatcher.On("Dispatch", hook, mock.MatchedBy(func(m *message.Message) bool {
+			return m.ID == msg.ID
+		})).Once()
+		handler, queue := newHandler(dispatcher)
+
+		err := handler.Execute(ctx, *msg)
+		assert.Nil(t, err)
+		dispatcher.AssertExpectations(t)
+		queue.AssertNotCalled(t, "NewProducer", mock.Anything)
+	})
+
+	t.Run("when group member has a webhook", func(t *testing.T) {
+		dispatcher := new(mockDispatcher)
+		dispatcher.On("Dispatch", hook, mock.MatchedBy(func(m *message.Message) bool {
+			return m.To == hook.UserID && m.Group == msgGroup.Group
+		})).Once()
+		handler, _ := newHandler(dispatcher)
+
+		err := handler.Execute(ctx, *msgGroup)
+		assert.Nil(t, err)
+		dispatcher.AssertExpectations(t)
+	})
+
+	t.Run("when status is addressed to a webhook", func(t *testing.T) {
+		dispatcher := new(mockDispatcher)
+		handler, _ := newHandler(dispatcher)
+
+		err := handler.Execute(ctx, *status)
+		assert.Nil(t, err)
+		dispatcher.AssertNotCalled(t, "Dispatch", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when getting the webhook fails", func(t *testing.T) {
+		webhookRepo := new(mockWebhookRepository)
+		webhookRepo.On("GetWebhook", mock.Anything, mock.Anything).
+			Return(nil, errors.New("error"))
+		userRepo := new(mockUserRepository)
+		userRepo.On("IsValidUser", mock.Anything, mock.Anything).
+			Return(true, nil)
+		userRepo.On("IsBlockedUser", mock.Anything, mock.Anything, mock.Anything).
+			Return(false, nil)
+
+		handler := NewMessageHandler(userRepo, new(mockMessageRepository), webhookRepo,
+			new(mockKafka), new(mockMessageEncoder), new(mockDispatcher))
+
+		err := handler.Execute(ctx, *msg)
+		assert.NotNil(t, err)
+	})
+}
+
+// noWebhooks returns a webhook.Repository where no user has a webhook.
+func noWebhooks() *mockWebhookRepository {
+	webhookRepo := new(mockWebhookRepository)
+	webhookRepo.On("GetWebhook", mock.Anything, mock.Anything).
+		Return(nil, nil)
+	return webhookRepo
+}
+
+func TestMessageHandler_ExecuteCallback(t *testing.T) {
+	ctx := context.Background()
+	callback := "https://alerts.example.com/errors"
+
+	msg, _ := message.New("svc_A1B2C3", "+5518911111111", "", message.ContentTypeText,
+		"message test")
+	msg.Callback = callback
+
+	newHandler := func(webhookRepo *mockWebhookRepository,
+		dispatcher *mockDispatcher) (MessageHandler, *mockKafka) {
+		userRepo := new(mockUserRepository)
+		userRepo.On("IsValidUser", mock.Anything, mock.Anything).
+			Return(false, nil)
+
+		queue := new(mockKafka)
+		return NewMessageHandler(userRepo, new(mockMessageRepository), webhookRepo, queue,
+			new(mockMessageEncoder), dispatcher), queue
+	}
+
+	isErrorResponse := mock.MatchedBy(func(m *message.Message) bool {
+		return m.ID == msg.ID && m.To == msg.From &&
+			m.ContentType == message.ContentTypeError.String() &&
+			m.Content == message.ErrMessageAddresseeIsInvalid.Error()
+	})
+
+	t.Run("when sender has no webhook", func(t *testing.T) {
+		dispatcher := new(mockDispatcher)
+		dispatcher.On("Dispatch", &webhook.Webhook{UserID: msg.From, URL: callback},
+			isErrorResponse).Once()
+		handler, queue := newHandler(noWebhooks(), dispatcher)
+
+		err := handler.Execute(ctx, *msg)
+		assert.Nil(t, err)
+		dispatcher.AssertExpectations(t)
+		queue.AssertNotCalled(t, "NewProducer", mock.Anything)
+	})
+
+	t.Run("when sender has a webhook", func(t *testing.T) {
+		webhookRepo := new(mockWebhookRepository)
+		webhookRepo.On("GetWebhook", mock.Anything, msg.From).
+			Return(&webhook.Webhook{UserID: msg.From, URL: "http://localhost/webhook",
+				Secret: "whsec_S3CR3T"}, nil)
+
+		dispatcher := new(mockDispatcher)
+		dispatcher.On("Dispatch", &webhook.Webhook{UserID: msg.From, URL: callback,
+			Secret: "whsec_S3CR3T"}, isErrorResponse).Once()
+		handler, _ := newHandler(webhookRepo, dispatcher)
+
+		err := handler.Execute(ctx, *msg)
+		assert.Nil(t, err)
+		dispatcher.AssertExpectations(t)
+	})
+
+	t.Run("when getting the webhook fails", func(t *testing.T) {
+		webhookRepo := new(mockWebhookRepository)
+		webhookRepo.On("GetWebhook", mock.Anything, mock.Anything).
+			Return(nil, errors.New("error"))
+
+		dispatcher := new(mockDispatcher)
+		handler, _ := newHandler(webhookRepo, dispatcher)
+
+		err := handler.Execute(ctx, *msg)
+		assert.NotNil(t, err)
+		dispatcher.AssertNotCalled(t, "Dispatch", mock.Anything, mock.Anything)
+	})
+}
@@ -74,6 +74,21 @@ func (m *mockUserRepository) UpdateBlockedUserCache(ctx context.Context, userID
 	return args.Error(0)
 }
 
+// InvalidateUserCache represents the simulated method for the InvalidateUserCache feature in
+// the user.Repository layer.
+func (m *mockUserRepository) InvalidateUserCache(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+// UpdateValidUserCache represents the simulated method for the UpdateValidUserCache feature in
+// the user.Repository layer.
+func (m *mockUserRepository) UpdateValidUserCache(ctx context.Context, userID string,
+	valid bool) error {
+	args := m.Called(ctx, userID, valid)
+	return args.Error(0)
+}
+
 // GetAllContactsOnline represents the simulated method for the GetAllContactsOnline
 // feature in the user.Repository layer.
 func (m *mockUserRepository) GetAllContactsOnline(ctx context.Context,
@@ -159,3 +174,10 @@ func (m *mockMessageRepository) DeleteAllMessages(ctx context.Context, userID st
 	args := m.Called(ctx, userID)
 	return args.Error(0)
 }
+
+// PurgeMessages represents the simulated method for the PurgeMessages
+// feature in the message.Repository layer.
+func (m *mockMessageRepository) PurgeMessages(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -57,6 +57,12 @@ type Repository interface {
 	UpdateBlockedUserCache(ctx context.Context, userID string, blockedUserID string,
 		blocked bool) error
 
+	// InvalidateUserCache removes the user presence from cache and caches the user as invalid.
+	InvalidateUserCache(ctx context.Context, userID string) error
+
+	// UpdateValidUserCache refresh valid users cache.
+	UpdateValidUserCache(ctx context.Context, userID string, valid bool) error
+
 	// GetAllContactsOnline returns all online contacts by userID.
 	GetAllContactsOnline(ctx context.Context, userID string) ([]string, error)
 
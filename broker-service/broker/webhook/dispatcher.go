@@ -0,0 +1,194 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/tsmweb/broker-service/broker/message"
+	"github.com/tsmweb/broker-service/common/service"
+	"github.com/tsmweb/chat-server/pkg/netguard"
+)
+
+// Dispatcher delivers messages to webhooks.
+type Dispatcher interface {
+	// Dispatch delivers the message to the webhook in background, retrying the failed
+	// attempts.
+	Dispatch(hook *Webhook, msg *message.Message)
+}
+
+type dispatcher struct {
+	tag           string
+	ctx           context.Context
+	client        *http.Client
+	maxAttempts   int
+	retryInterval time.Duration
+	queue         chan *delivery
+}
+
+type delivery struct {
+	hook    *Webhook
+	msgID   string
+	body    []byte
+	attempt int
+	wait    time.Duration
+}
+
+// NewDispatcher creates a Dispatcher that makes up to maxAttempts attempts to deliver each
+// message, waiting retryInterval after the first failure and doubling the wait after each
+// of the next ones. The deliveries are made by the given number of workers, up to queueSize
+// messages wait for a free worker and the next ones are dropped. The retries are queued
+// again after their wait, so the workers are not held, and are dropped as well if the queue
+// is full. The workers stop and the pending retries are abandoned when ctx is done. Unless allowPrivate, the connections to
+// loopback, private or link-local addresses are refused.
+func NewDispatcher(
+	ctx context.Context,
+	timeout time.Duration,
+	maxAttempts int,
+	retryInterval time.Duration,
+	workers int,
+	queueSize int,
+	allowPrivate bool,
+) Dispatcher {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+	d := &dispatcher{
+		tag:           "webhook::Dispatcher",
+		ctx:           ctx,
+		client:        newClient(timeout, allowPrivate),
+		maxAttempts:   maxAttempts,
+		retryInterval: retryInterval,
+		queue:         make(chan *delivery, queueSize),
+	}
+	for i := 0; i < workers; i++ {
+		go d.work()
+	}
+	return d
+}
+
+// newClient creates the HTTP client of the deliveries. The addresses are checked by the
+// dialer, after the host names are resolved, and the proxies are not used, as the dialer
+// would check the address of the proxy instead.
+func newClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	if allowPrivate {
+		return &http.Client{Timeout: timeout}
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   netguard.Control,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+// Dispatch delivers the message to the webhook in background, the message is dropped if the
+// queue is full.
+func (d *dispatcher) Dispatch(hook *Webhook, msg *message.Message) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		service.Error(hook.UserID, d.tag, err)
+		return
+	}
+
+	d.enqueue(&delivery{hook: hook, msgID: msg.ID, body: body, attempt: 1, wait: d.retryInterval})
+}
+
+// enqueue puts the delivery in the queue, the delivery is dropped if the queue is full.
+func (d *dispatcher) enqueue(dl *delivery) {
+	select {
+	case d.queue <- dl:
+	default:
+		service.Error(dl.hook.UserID, d.tag,
+			fmt.Errorf("webhook queue is full, delivery of message %s dropped", dl.msgID))
+	}
+}
+
+func (d *dispatcher) work() {
+	for {
+		select {
+		case dl := <-d.queue:
+			d.deliver(dl)
+		case <-d.ctx.Done():
+			return
+		}
+	}
+}
+
+// deliver makes an attempt of delivery, scheduling the retry of a failed attempt instead of
+// waiting for it.
+func (d *dispatcher) deliver(dl *delivery) {
+	retry, err := d.post(dl.hook, dl.msgID, dl.body)
+	if err == nil {
+		return
+	}
+	if !retry || dl.attempt == d.maxAttempts || d.ctx.Err() != nil {
+		service.Error(dl.hook.UserID, d.tag,
+			fmt.Errorf("delivery of message %s failed: %w", dl.msgID, err))
+		return
+	}
+
+	next := &delivery{
+		hook:    dl.hook,
+		msgID:   dl.msgID,
+		body:    dl.body,
+		attempt: dl.attempt + 1,
+		wait:    dl.wait * 2,
+	}
+	time.AfterFunc(dl.wait, func() {
+		if d.ctx.Err() != nil {
+			return
+		}
+		d.enqueue(next)
+	})
+}
+
+// post makes an attempt of delivery, reporting whether a failed attempt must be retried.
+// Client errors and forbidden addresses are not retried, except for timeouts and rate limiting.
+func (d *dispatcher) post(hook *Webhook, msgID string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, hook.URL,
+		bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderTimestamp, fmt.Sprint(timestamp))
+	if hook.Secret != "" {
+		req.Header.Set(HeaderSignature, Sign(hook.Secret, timestamp, body))
+	}
+	req.Header.Set(HeaderDelivery, msgID)
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return !errors.Is(err, netguard.ErrForbiddenAddress), err
+	}
+	res.Body.Close()
+
+	switch {
+	case res.StatusCode >= 200 && res.StatusCode < 300:
+		return false, nil
+	case res.StatusCode == http.StatusRequestTimeout ||
+		res.StatusCode == http.StatusTooManyRequests ||
+		res.StatusCode >= 500:
+		return true, fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	default:
+		return false, fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+}
@@ -0,0 +1,145 @@
+package webhook_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsmweb/broker-service/broker/message"
+	"github.com/tsmweb/broker-service/broker/webhook"
+	"github.com/tsmweb/broker-service/broker/webhook/webhooktest"
+)
+
+func TestDispatcher_Dispatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	msg, _ := message.New("+5518977777777", "svc_A1B2C3", "", message.ContentTypeText, "hello")
+	dispatcher := webhook.NewDispatcher(ctx, time.Second, 3, 10*time.Millisecond, 2, 16, true)
+
+	t.Run("when delivery succeeds", func(t *testing.T) {
+		r := webhooktest.NewReceiver()
+		defer r.Close()
+		r.SetSecret("whsec_S3CR3T")
+
+		dispatcher.Dispatch(&webhook.Webhook{UserID: msg.To, URL: r.URL, Secret: "whsec_S3CR3T"}, msg)
+
+		d, err := r.Expect(time.Second, func(d *webhooktest.Delivery) bool { return d.ID == msg.ID })
+		assert.Nil(t, err)
+		assert.Equal(t, msg.Content, d.Message.Content)
+		assert.Equal(t, msg.From, d.Message.From)
+		assert.Equal(t, 1, r.Attempts())
+	})
+
+	t.Run("when delivery is retried", func(t *testing.T) {
+		r := webhooktest.NewReceiver()
+		defer r.Close()
+		r.SetSecret("whsec_S3CR3T")
+		r.FailNext(2)
+
+		dispatcher.Dispatch(&webhook.Webhook{UserID: msg.To, URL: r.URL, Secret: "whsec_S3CR3T"}, msg)
+
+		_, err := r.Expect(time.Second, func(d *webhooktest.Delivery) bool { return d.ID == msg.ID })
+		assert.Nil(t, err)
+		assert.Equal(t, 3, r.Attempts())
+	})
+
+	t.Run("when delivery gives up after max attempts", func(t *testing.T) {
+		r := webhooktest.NewReceiver()
+		defer r.Close()
+		r.SetSecret("whsec_S3CR3T")
+		r.FailNext(5)
+
+		dispatcher.Dispatch(&webhook.Webhook{UserID: msg.To, URL: r.URL, Secret: "whsec_S3CR3T"}, msg)
+
+		_, err := r.Expect(200*time.Millisecond, func(d *webhooktest.Delivery) bool { return true })
+		assert.Equal(t, webhooktest.ErrTimeout, err)
+		assert.Equal(t, 3, r.Attempts())
+	})
+
+	t.Run("when signature is rejected", func(t *testing.T) {
+		r := webhooktest.NewReceiver()
+		defer r.Close()
+		r.SetSecret("whsec_S3CR3T")
+
+		dispatcher.Dispatch(&webhook.Webhook{UserID: msg.To, URL: r.URL, Secret: "whsec_OTHER"}, msg)
+
+		_, err := r.Expect(200*time.Millisecond, func(d *webhooktest.Delivery) bool { return true })
+		assert.Equal(t, webhooktest.ErrTimeout, err)
+		assert.Equal(t, 1, r.Attempts())
+		assert.Equal(t, 1, r.Rejected())
+	})
+
+	t.Run("when webhook has no secret", func(t *testing.T) {
+		r := webhooktest.NewReceiver()
+		defer r.Close()
+
+		dispatcher.Dispatch(&webhook.Webhook{UserID: msg.From, URL: r.URL}, msg)
+
+		d, err := r.Expect(time.Second, func(d *webhooktest.Delivery) bool { return d.ID == msg.ID })
+		assert.Nil(t, err)
+		assert.Equal(t, msg.Content, d.Message.Content)
+		assert.Equal(t, 0, r.Rejected())
+	})
+
+	t.Run("when queue is full", func(t *testing.T) {
+		r := webhooktest.NewReceiver()
+		defer r.Close()
+		r.SetSecret("whsec_S3CR3T")
+		release := r.Hold()
+
+		// One delivery is held by the single worker, one waits in the queue and the last
+		// one is dropped.
+		single := webhook.NewDispatcher(ctx, time.Second, 1, 10*time.Millisecond, 1, 1, true)
+		hook := &webhook.Webhook{UserID: msg.To, URL: r.URL, Secret: "whsec_S3CR3T"}
+		for i := 0; i < 3; i++ {
+			m, _ := message.New(msg.From, msg.To, "", message.ContentTypeText, "hello")
+			single.Dispatch(hook, m)
+			if i == 0 {
+				// waits for the worker to take the first delivery.
+				time.Sleep(50 * time.Millisecond)
+			}
+		}
+		release()
+
+		_, err := r.Expect(500*time.Millisecond, func(d *webhooktest.Delivery) bool { return false })
+		assert.Equal(t, webhooktest.ErrTimeout, err)
+		assert.Equal(t, 2, r.Attempts())
+	})
+
+	t.Run("when retry is pending", func(t *testing.T) {
+		failing := webhooktest.NewReceiver()
+		defer failing.Close()
+		failing.FailNext(1)
+		r := webhooktest.NewReceiver()
+		defer r.Close()
+
+		// The single worker delivers the second message while the first one waits to be
+		// retried.
+		single := webhook.NewDispatcher(ctx, time.Second, 2, 500*time.Millisecond, 1, 2, true)
+		single.Dispatch(&webhook.Webhook{UserID: msg.From, URL: failing.URL}, msg)
+		m, _ := message.New(msg.From, msg.To, "", message.ContentTypeText, "hello")
+		single.Dispatch(&webhook.Webhook{UserID: msg.From, URL: r.URL}, m)
+
+		_, err := r.Expect(250*time.Millisecond, func(d *webhooktest.Delivery) bool { return d.ID == m.ID })
+		assert.Nil(t, err)
+		assert.Equal(t, 1, failing.Attempts())
+
+		_, err = failing.Expect(time.Second, func(d *webhooktest.Delivery) bool { return d.ID == msg.ID })
+		assert.Nil(t, err)
+		assert.Equal(t, 2, failing.Attempts())
+	})
+
+	t.Run("when address is internal", func(t *testing.T) {
+		r := webhooktest.NewReceiver()
+		defer r.Close()
+
+		guarded := webhook.NewDispatcher(ctx, time.Second, 3, 10*time.Millisecond, 1, 1, false)
+		guarded.Dispatch(&webhook.Webhook{UserID: msg.From, URL: r.URL}, msg)
+
+		_, err := r.Expect(200*time.Millisecond, func(d *webhooktest.Delivery) bool { return true })
+		assert.Equal(t, webhooktest.ErrTimeout, err)
+		assert.Equal(t, 0, r.Attempts())
+	})
+}
@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// Headers of the deliveries.
+const (
+	// HeaderSignature carries the signature of the delivery, see Sign.
+	HeaderSignature = "X-Chat-Signature"
+
+	// HeaderTimestamp carries the Unix time when the delivery was signed.
+	HeaderTimestamp = "X-Chat-Timestamp"
+
+	// HeaderDelivery carries the ID of the message delivered, the same on every attempt.
+	HeaderDelivery = "X-Chat-Delivery"
+)
+
+const signaturePrefix = "sha256="
+
+// Webhook is the URL where a bot account receives its messages, registered in auth-service.
+// The deliveries to a Webhook without Secret, as the callbacks of delivery errors of the
+// senders that have no webhook, are not signed.
+type Webhook struct {
+	UserID string
+	URL    string
+	Secret string
+}
+
+// Repository represents an abstraction of the data persistence layer.
+type Repository interface {
+	// GetWebhook returns the webhook of the user, or nil if the user has no webhook.
+	GetWebhook(ctx context.Context, userID string) (*Webhook, error)
+}
+
+// Sign returns the signature of the body of a delivery made at timestamp, the hex encoded
+// HMAC-SHA256 of "{timestamp}.{body}" with the secret of the webhook, prefixed by "sha256=".
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the signature of the body of a delivery made at
+// timestamp, as sent in the HeaderSignature and HeaderTimestamp headers.
+func Verify(secret, timestamp string, body []byte, signature string) bool {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, ts, body)), []byte(signature))
+}
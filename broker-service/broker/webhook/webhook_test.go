@@ -0,0 +1,31 @@
+package webhook
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"id":"A1"}`)
+
+	signature := Sign("whsec_S3CR3T", 1600000000, body)
+	assert.Equal(t, signature, Sign("whsec_S3CR3T", 1600000000, body))
+	assert.NotEqual(t, signature, Sign("whsec_OTHER", 1600000000, body))
+	assert.NotEqual(t, signature, Sign("whsec_S3CR3T", 1600000001, body))
+	assert.Equal(t, "sha256=", signature[:len(signaturePrefix)])
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"id":"A1"}`)
+	timestamp := int64(1600000000)
+	signature := Sign("whsec_S3CR3T", timestamp, body)
+
+	assert.True(t, Verify("whsec_S3CR3T", strconv.FormatInt(timestamp, 10), body, signature))
+	assert.False(t, Verify("whsec_OTHER", strconv.FormatInt(timestamp, 10), body, signature))
+	assert.False(t, Verify("whsec_S3CR3T", "1600000001", body, signature))
+	assert.False(t, Verify("whsec_S3CR3T", "", body, signature))
+	assert.False(t, Verify("whsec_S3CR3T", strconv.FormatInt(timestamp, 10),
+		[]byte(`{"id":"B2"}`), signature))
+}
@@ -0,0 +1,173 @@
+// Package webhooktest provides a local HTTP receiver of webhook deliveries for tests.
+package webhooktest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/tsmweb/broker-service/broker/message"
+	"github.com/tsmweb/broker-service/broker/webhook"
+)
+
+// ErrTimeout is returned when the expected deliveries are not received in time.
+var ErrTimeout = errors.New("webhooktest: timeout waiting for deliveries")
+
+// Delivery is a message received by the Receiver.
+type Delivery struct {
+	ID         string
+	Message    message.Message
+	ReceivedAt time.Time
+}
+
+// Receiver is an HTTP server listening on a loopback address that accepts the deliveries
+// signed with its secret, recording them, and rejects the others. Without a secret it accepts
+// the unsigned deliveries, as the callbacks of delivery errors.
+type Receiver struct {
+	URL string
+
+	srv        *httptest.Server
+	mu         sync.Mutex
+	secret     string
+	failures   int
+	attempts   int
+	rejected   int
+	deliveries []*Delivery
+	notify     chan struct{}
+	hold       chan struct{}
+}
+
+// NewReceiver starts a Receiver. The secret is informed with SetSecret once the webhook
+// is registered.
+func NewReceiver() *Receiver {
+	r := &Receiver{notify: make(chan struct{}, 1)}
+	r.srv = httptest.NewServer(http.HandlerFunc(r.serveHTTP))
+	r.URL = r.srv.URL
+	return r
+}
+
+// SetSecret sets the secret that signs the deliveries.
+func (r *Receiver) SetSecret(secret string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.secret = secret
+}
+
+// FailNext makes the next n deliveries fail with 503 Service Unavailable, to be retried.
+func (r *Receiver) FailNext(n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures = n
+}
+
+// Hold makes the deliveries wait until the returned function is called.
+func (r *Receiver) Hold() (release func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	hold := make(chan struct{})
+	r.hold = hold
+	var once sync.Once
+	return func() { once.Do(func() { close(hold) }) }
+}
+
+// Attempts returns the number of delivery attempts received, accepted or not.
+func (r *Receiver) Attempts() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.attempts
+}
+
+// Rejected returns the number of deliveries rejected for an invalid signature.
+func (r *Receiver) Rejected() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rejected
+}
+
+// Deliveries returns the deliveries accepted so far.
+func (r *Receiver) Deliveries() []*Delivery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deliveries := make([]*Delivery, len(r.deliveries))
+	copy(deliveries, r.deliveries)
+	return deliveries
+}
+
+// Expect waits until a delivery matching the function is accepted, returning it.
+func (r *Receiver) Expect(timeout time.Duration, match func(d *Delivery) bool) (*Delivery, error) {
+	deadline := time.After(timeout)
+	for {
+		for _, d := range r.Deliveries() {
+			if match(d) {
+				return d, nil
+			}
+		}
+
+		select {
+		case <-r.notify:
+		case <-deadline:
+			return nil, ErrTimeout
+		}
+	}
+}
+
+// Close stops the Receiver.
+func (r *Receiver) Close() {
+	r.srv.Close()
+}
+
+func (r *Receiver) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	r.mu.Lock()
+	hold := r.hold
+	r.mu.Unlock()
+	if hold != nil {
+		<-hold
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.attempts++
+
+	if r.secret != "" && !webhook.Verify(r.secret, req.Header.Get(webhook.HeaderTimestamp),
+		body, req.Header.Get(webhook.HeaderSignature)) {
+		r.rejected++
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if r.failures > 0 {
+		r.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	d := &Delivery{
+		ID:         req.Header.Get(webhook.HeaderDelivery),
+		ReceivedAt: time.Now(),
+	}
+	if err = json.Unmarshal(body, &d.Message); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	r.deliveries = append(r.deliveries, d)
+
+	select {
+	case r.notify <- struct{}{}:
+	default:
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
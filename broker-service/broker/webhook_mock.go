@@ -0,0 +1,34 @@
+package broker
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/broker-service/broker/message"
+	"github.com/tsmweb/broker-service/broker/webhook"
+)
+
+// mockWebhookRepository injects mock webhook.Repository dependency.
+type mockWebhookRepository struct {
+	mock.Mock
+}
+
+// GetWebhook represents the simulated method for the GetWebhook feature in the
+// webhook.Repository layer.
+func (m *mockWebhookRepository) GetWebhook(ctx context.Context,
+	userID string) (*webhook.Webhook, error) {
+	args := m.Called(ctx, userID)
+	hook, _ := args.Get(0).(*webhook.Webhook)
+	return hook, args.Error(1)
+}
+
+// mockDispatcher injects mock webhook.Dispatcher dependency.
+type mockDispatcher struct {
+	mock.Mock
+}
+
+// Dispatch represents the simulated method for the Dispatch feature in the
+// webhook.Dispatcher layer.
+func (m *mockDispatcher) Dispatch(hook *webhook.Webhook, msg *message.Message) {
+	m.Called(hook, msg)
+}
@@ -7,6 +7,7 @@ import (
 	"os/signal"
 
 	"github.com/tsmweb/broker-service/config"
+	"github.com/tsmweb/broker-service/di"
 	"github.com/tsmweb/go-helper-api/observability/event"
 )
 
@@ -26,7 +27,7 @@ func main() {
 		cancelFunc()
 	}()
 
-	provider := CreateProvider(ctx)
+	provider := di.CreateProvider(ctx)
 
 	// Initializes the service's event producer.
 	producerEvents := provider.NewKafkaProducer(config.KafkaEventsTopic())
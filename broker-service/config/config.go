@@ -12,27 +12,34 @@ import (
 )
 
 var (
-	hostID                  string
-	goPoolSize              int
-	dbHost                  string
-	dbPort                  int
-	dbUser                  string
-	dbPassword              string
-	dbName                  string
-	dbSchema                string
-	redisHost               string
-	redisPassword           string
-	kafkaBootstrapServers   string
-	kafkaClientID           string
-	kafkaGroupID            string
-	kafkaUsersTopic         string
-	kafkaUsersPresenceTopic string
-	kafkaNewMessagesTopic   string
-	kafkaOffMessagesTopic   string
-	kafkaGroupEventTopic    string
-	kafkaContactEventTopic  string
-	kafkaHostTopic          string
-	kafkaEventsTopic        string
+	hostID                   string
+	goPoolSize               int
+	dbHost                   string
+	dbPort                   int
+	dbUser                   string
+	dbPassword               string
+	dbName                   string
+	dbSchema                 string
+	redisHost                string
+	redisPassword            string
+	kafkaBootstrapServers    string
+	kafkaClientID            string
+	kafkaGroupID             string
+	kafkaUsersTopic          string
+	kafkaUsersPresenceTopic  string
+	kafkaNewMessagesTopic    string
+	kafkaOffMessagesTopic    string
+	kafkaGroupEventTopic     string
+	kafkaContactEventTopic   string
+	kafkaAccountEventTopic   string
+	kafkaDeletionReportTopic string
+	kafkaHostTopic           string
+	kafkaEventsTopic         string
+	webhookTimeout           int
+	webhookMaxAttempts       int
+	webhookRetryInterval     int
+	webhookQueueSize         int
+	webhookAllowPrivate      bool
 )
 
 func Load(workDir string) error {
@@ -69,9 +76,29 @@ func Load(workDir string) error {
 	kafkaOffMessagesTopic = os.Getenv("KAFKA_OFF_MESSAGES_TOPIC")
 	kafkaGroupEventTopic = os.Getenv("KAFKA_GROUP_EVENT_TOPIC")
 	kafkaContactEventTopic = os.Getenv("KAFKA_CONTACT_EVENT_TOPIC")
+	kafkaAccountEventTopic = os.Getenv("KAFKA_ACCOUNT_EVENT_TOPIC")
+	kafkaDeletionReportTopic = os.Getenv("KAFKA_DELETION_REPORT_TOPIC")
 	kafkaHostTopic = os.Getenv("KAFKA_HOST_TOPIC")
 	kafkaEventsTopic = os.Getenv("KAFKA_EVENTS_TOPIC")
 
+	webhookTimeout, err = strconv.Atoi(os.Getenv("WEBHOOK_TIMEOUT"))
+	if err != nil {
+		webhookTimeout = 10
+	}
+	webhookMaxAttempts, err = strconv.Atoi(os.Getenv("WEBHOOK_MAX_ATTEMPTS"))
+	if err != nil {
+		webhookMaxAttempts = 5
+	}
+	webhookRetryInterval, err = strconv.Atoi(os.Getenv("WEBHOOK_RETRY_INTERVAL"))
+	if err != nil {
+		webhookRetryInterval = 1
+	}
+	webhookQueueSize, err = strconv.Atoi(os.Getenv("WEBHOOK_QUEUE_SIZE"))
+	if err != nil {
+		webhookQueueSize = 1024
+	}
+	webhookAllowPrivate, _ = strconv.ParseBool(os.Getenv("WEBHOOK_ALLOW_PRIVATE"))
+
 	return nil
 }
 
@@ -151,6 +178,14 @@ func KafkaContactEventTopic() string {
 	return kafkaContactEventTopic
 }
 
+func KafkaAccountEventTopic() string {
+	return kafkaAccountEventTopic
+}
+
+func KafkaDeletionReportTopic() string {
+	return kafkaDeletionReportTopic
+}
+
 func KafkaHostTopic(serverID string) string {
 	return fmt.Sprintf("%s_%s", serverID, kafkaHostTopic)
 }
@@ -158,3 +193,23 @@ func KafkaHostTopic(serverID string) string {
 func KafkaEventsTopic() string {
 	return kafkaEventsTopic
 }
+
+func WebhookTimeout() int {
+	return webhookTimeout
+}
+
+func WebhookMaxAttempts() int {
+	return webhookMaxAttempts
+}
+
+func WebhookRetryInterval() int {
+	return webhookRetryInterval
+}
+
+func WebhookQueueSize() int {
+	return webhookQueueSize
+}
+
+func WebhookAllowPrivate() bool {
+	return webhookAllowPrivate
+}
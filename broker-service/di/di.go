@@ -1,19 +1,25 @@
-package main
+// Package di wires the dependencies of broker-service. It is used by cmd/broker and by the
+// all-in-one mode, which replaces Apache Kafka and Redis with in-memory implementations.
+package di
 
 import (
 	"context"
+	"time"
 
 	"github.com/tsmweb/broker-service/adapter"
 	"github.com/tsmweb/broker-service/broker"
+	"github.com/tsmweb/broker-service/broker/account"
 	"github.com/tsmweb/broker-service/broker/group"
 	"github.com/tsmweb/broker-service/broker/message"
 	"github.com/tsmweb/broker-service/broker/user"
+	"github.com/tsmweb/broker-service/broker/webhook"
 	"github.com/tsmweb/broker-service/config"
 	"github.com/tsmweb/broker-service/infra/db"
 	"github.com/tsmweb/broker-service/infra/repository"
 	"github.com/tsmweb/go-helper-api/kafka"
 )
 
+// Provider creates the dependencies of broker-service on demand.
 type Provider struct {
 	ctx      context.Context
 	database db.Database
@@ -22,12 +28,23 @@ type Provider struct {
 	broker   *broker.Broker
 }
 
+// CreateProvider creates a provider that connects to Apache Kafka and Redis.
 func CreateProvider(ctx context.Context) *Provider {
 	return &Provider{
 		ctx: ctx,
 	}
 }
 
+// CreateProviderWith creates a provider that uses k and cache instead of Apache Kafka
+// and Redis. A nil value falls back to the default dependency.
+func CreateProviderWith(ctx context.Context, k kafka.Kafka, cache db.CacheDB) *Provider {
+	return &Provider{
+		ctx:   ctx,
+		kafka: k,
+		cache: cache,
+	}
+}
+
 func (p *Provider) BrokerProvider() *broker.Broker {
 	if p.broker == nil {
 		userDecoder := user.DecoderFunc(adapter.UserUnmarshal)
@@ -35,6 +52,7 @@ func (p *Provider) BrokerProvider() *broker.Broker {
 		messageDecoder := message.DecoderFunc(adapter.MessageUnmarshal)
 		groupEventDecoder := group.EventDecoderFunc(adapter.GroupEventUnmarshal)
 		userEventDecoder := user.EventDecoderFunc(adapter.UserEventUnmarshal)
+		accountEventDecoder := account.EventDecoderFunc(adapter.AccountEventUnmarshal)
 
 		userConsumer := p.KafkaProvider().NewConsumer(config.KafkaGroupID(),
 			config.KafkaUsersTopic())
@@ -48,18 +66,32 @@ func (p *Provider) BrokerProvider() *broker.Broker {
 			config.KafkaGroupEventTopic())
 		userEventConsumer := p.KafkaProvider().NewConsumer(config.KafkaClientID(),
 			config.KafkaContactEventTopic())
+		accountEventConsumer := p.KafkaProvider().NewConsumer(config.KafkaGroupID(),
+			config.KafkaAccountEventTopic())
 
 		userRepository := repository.NewUserRepository(p.DatabaseProvider(), p.CacheDBProvider())
 		messageRepository := repository.NewMessageRepository(p.DatabaseProvider(),
 			p.CacheDBProvider())
+		webhookRepository := repository.NewWebhookRepository(p.DatabaseProvider(),
+			p.CacheDBProvider())
+		webhookDispatcher := webhook.NewDispatcher(p.ctx,
+			time.Duration(config.WebhookTimeout())*time.Second,
+			config.WebhookMaxAttempts(),
+			time.Duration(config.WebhookRetryInterval())*time.Second,
+			config.GoPoolSize(),
+			config.WebhookQueueSize(),
+			config.WebhookAllowPrivate())
 
 		userHandler := broker.NewUserHandler(userRepository, messageRepository)
 		userPresenceHandler := broker.NewUserPresenceHandler(userRepository)
 		messageHandler := broker.NewMessageHandler(userRepository, messageRepository,
-			p.KafkaProvider(), messageEncoder)
+			webhookRepository, p.KafkaProvider(), messageEncoder, webhookDispatcher)
 		offMessageHandler := broker.NewOfflineMessageHandler(messageRepository)
 		groupEventHandler := broker.NewGroupEventHandler(messageRepository)
 		userEventHandler := broker.NewUserEventHandler(userRepository)
+		accountEventHandler := broker.NewAccountEventHandler(userRepository, messageRepository,
+			account.ReportEncoderFunc(adapter.DeletionReportMarshal),
+			p.KafkaProvider().NewProducer(config.KafkaDeletionReportTopic()))
 
 		p.broker = broker.NewBroker(
 			p.ctx,
@@ -67,18 +99,21 @@ func (p *Provider) BrokerProvider() *broker.Broker {
 			messageDecoder,
 			groupEventDecoder,
 			userEventDecoder,
+			accountEventDecoder,
 			userConsumer,
 			userPresenceConsumer,
 			messageConsumer,
 			offMessageConsumer,
 			groupEventConsumer,
 			userEventConsumer,
+			accountEventConsumer,
 			userHandler,
 			userPresenceHandler,
 			messageHandler,
 			offMessageHandler,
 			groupEventHandler,
 			userEventHandler,
+			accountEventHandler,
 		)
 	}
 	return p.broker
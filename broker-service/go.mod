@@ -7,6 +7,7 @@ require (
 	github.com/joho/godotenv v1.4.0
 	github.com/lib/pq v1.10.7
 	github.com/stretchr/testify v1.8.0
+	github.com/tsmweb/chat-server/pkg v0.0.0
 	github.com/tsmweb/go-helper-api v1.4.2
 	google.golang.org/protobuf v1.28.1
 )
@@ -22,3 +23,5 @@ require (
 	github.com/stretchr/objx v0.4.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace github.com/tsmweb/chat-server/pkg => ../pkg
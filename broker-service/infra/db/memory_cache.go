@@ -0,0 +1,299 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrWrongType is returned when a command is executed against a key holding the wrong kind of value.
+var ErrWrongType = errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")
+
+type memoryEntry struct {
+	value     interface{} // string, map[string]string (hash) or map[string]struct{} (set)
+	expiresAt time.Time
+}
+
+func (e *memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// MemoryCacheDB implements the CacheDB interface keeping the data in memory,
+// with the same semantics as the Redis commands used by RedisCacheDB.
+// It is intended for single node deployments, development and tests.
+type MemoryCacheDB struct {
+	mu      sync.Mutex
+	entries map[string]*memoryEntry
+}
+
+// NewMemoryCacheDB returns an instance of CacheDB.
+func NewMemoryCacheDB() CacheDB {
+	return &MemoryCacheDB{
+		entries: make(map[string]*memoryEntry),
+	}
+}
+
+func (c *MemoryCacheDB) Key(ctx context.Context, key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !strings.ContainsAny(key, "*?[") {
+		return c.entry(key) != nil
+	}
+
+	for k := range c.entries {
+		if ok, _ := path.Match(key, k); ok && c.entry(k) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *MemoryCacheDB) Set(ctx context.Context, key string, value interface{},
+	expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &memoryEntry{value: toString(value)}
+	if expiration > 0 {
+		entry.expiresAt = time.Now().Add(expiration)
+	}
+	c.entries[key] = entry
+	return nil
+}
+
+func (c *MemoryCacheDB) Get(ctx context.Context, key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := c.entry(key)
+	if entry == nil {
+		return "", nil
+	}
+
+	val, ok := entry.value.(string)
+	if !ok {
+		return "", ErrWrongType
+	}
+	return val, nil
+}
+
+func (c *MemoryCacheDB) Del(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+	return nil
+}
+
+func (c *MemoryCacheDB) HSet(ctx context.Context, key string, values ...interface{}) error {
+	args := flatten(values)
+	if len(args)%2 != 0 {
+		return errors.New("ERR wrong number of arguments for 'hset' command")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	hash, err := c.hash(key, true)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(args); i += 2 {
+		hash[args[i]] = args[i+1]
+	}
+	return nil
+}
+
+func (c *MemoryCacheDB) HGet(ctx context.Context, key, field string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	hash, err := c.hash(key, false)
+	if err != nil || hash == nil {
+		return "", err
+	}
+	return hash[field], nil
+}
+
+func (c *MemoryCacheDB) HDel(ctx context.Context, key string, fields ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	hash, err := c.hash(key, false)
+	if err != nil || hash == nil {
+		return err
+	}
+	for _, field := range fields {
+		delete(hash, field)
+	}
+	if len(hash) == 0 {
+		delete(c.entries, key)
+	}
+	return nil
+}
+
+func (c *MemoryCacheDB) SAdd(ctx context.Context, key string, members ...interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	set, err := c.set(key, true)
+	if err != nil {
+		return err
+	}
+	for _, member := range flatten(members) {
+		set[member] = struct{}{}
+	}
+	return nil
+}
+
+func (c *MemoryCacheDB) SRem(ctx context.Context, key string, members ...interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	set, err := c.set(key, false)
+	if err != nil || set == nil {
+		return err
+	}
+	for _, member := range flatten(members) {
+		delete(set, member)
+	}
+	if len(set) == 0 {
+		delete(c.entries, key)
+	}
+	return nil
+}
+
+func (c *MemoryCacheDB) SMembers(ctx context.Context, key string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	set, err := c.set(key, false)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]string, 0, len(set))
+	for member := range set {
+		values = append(values, member)
+	}
+	return values, nil
+}
+
+func (c *MemoryCacheDB) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := c.entry(key)
+	if entry == nil {
+		return nil
+	}
+	// Like Redis, a non-positive expiration deletes the key.
+	if expiration <= 0 {
+		delete(c.entries, key)
+		return nil
+	}
+	entry.expiresAt = time.Now().Add(expiration)
+	return nil
+}
+
+// entry returns the entry of the key, removing it if it has expired. The caller must hold c.mu.
+func (c *MemoryCacheDB) entry(key string) *memoryEntry {
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	if entry.expired(time.Now()) {
+		delete(c.entries, key)
+		return nil
+	}
+	return entry
+}
+
+func (c *MemoryCacheDB) hash(key string, create bool) (map[string]string, error) {
+	entry := c.entry(key)
+	if entry == nil {
+		if !create {
+			return nil, nil
+		}
+		entry = &memoryEntry{value: make(map[string]string)}
+		c.entries[key] = entry
+	}
+
+	hash, ok := entry.value.(map[string]string)
+	if !ok {
+		return nil, ErrWrongType
+	}
+	return hash, nil
+}
+
+func (c *MemoryCacheDB) set(key string, create bool) (map[string]struct{}, error) {
+	entry := c.entry(key)
+	if entry == nil {
+		if !create {
+			return nil, nil
+		}
+		entry = &memoryEntry{value: make(map[string]struct{})}
+		c.entries[key] = entry
+	}
+
+	set, ok := entry.value.(map[string]struct{})
+	if !ok {
+		return nil, ErrWrongType
+	}
+	return set, nil
+}
+
+// flatten expands slices and maps of the arguments, as the Redis client does.
+func flatten(values []interface{}) []string {
+	args := make([]string, 0, len(values))
+	for _, value := range values {
+		switch v := value.(type) {
+		case []string:
+			args = append(args, v...)
+		case []interface{}:
+			args = append(args, flatten(v)...)
+		case map[string]interface{}:
+			for k, val := range v {
+				args = append(args, k, toString(val))
+			}
+		case map[string]string:
+			for k, val := range v {
+				args = append(args, k, val)
+			}
+		default:
+			args = append(args, toString(v))
+		}
+	}
+	return args
+}
+
+// toString converts the value to the string stored by Redis.
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case time.Duration:
+		return strconv.FormatInt(v.Nanoseconds(), 10)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryCacheDB(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+
+	t.Run("when key is set and deleted", func(t *testing.T) {
+		//t.Parallel()
+		cache := NewMemoryCacheDB()
+
+		assert.Nil(t, cache.Set(ctx, "user:1", "H01", 0))
+		assert.True(t, cache.Key(ctx, "user:1"))
+		assert.True(t, cache.Key(ctx, "user:*"))
+
+		val, err := cache.Get(ctx, "user:1")
+		assert.Nil(t, err)
+		assert.Equal(t, "H01", val)
+
+		assert.Nil(t, cache.Del(ctx, "user:1"))
+		assert.False(t, cache.Key(ctx, "user:1"))
+
+		val, err = cache.Get(ctx, "user:1")
+		assert.Nil(t, err)
+		assert.Empty(t, val)
+	})
+
+	t.Run("when key expires", func(t *testing.T) {
+		//t.Parallel()
+		cache := NewMemoryCacheDB()
+
+		assert.Nil(t, cache.Set(ctx, "valid", "true", time.Millisecond))
+		time.Sleep(5 * time.Millisecond)
+		assert.False(t, cache.Key(ctx, "valid"))
+
+		assert.Nil(t, cache.SAdd(ctx, "group", []string{"a", "b"}))
+		assert.Nil(t, cache.Expire(ctx, "group", 0))
+		assert.False(t, cache.Key(ctx, "group"))
+	})
+
+	t.Run("when set members are added and removed", func(t *testing.T) {
+		//t.Parallel()
+		cache := NewMemoryCacheDB()
+
+		assert.Nil(t, cache.SAdd(ctx, "group", []string{"a", "b"}))
+		assert.Nil(t, cache.SAdd(ctx, "group", "c"))
+		assert.Nil(t, cache.SRem(ctx, "group", "b"))
+
+		members, err := cache.SMembers(ctx, "group")
+		assert.Nil(t, err)
+		sort.Strings(members)
+		assert.Equal(t, []string{"a", "c"}, members)
+
+		_, err = cache.Get(ctx, "group")
+		assert.ErrorIs(t, err, ErrWrongType)
+	})
+
+	t.Run("when hash fields are set and deleted", func(t *testing.T) {
+		//t.Parallel()
+		cache := NewMemoryCacheDB()
+
+		assert.Nil(t, cache.HSet(ctx, "hash", "k1", "v1", "k2", true))
+
+		val, err := cache.HGet(ctx, "hash", "k2")
+		assert.Nil(t, err)
+		assert.Equal(t, "1", val)
+
+		assert.Nil(t, cache.HDel(ctx, "hash", "k1", "k2"))
+		assert.False(t, cache.Key(ctx, "hash"))
+	})
+}
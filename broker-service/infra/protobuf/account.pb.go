@@ -0,0 +1,311 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.14.0
+// source: broker/account.proto
+
+package protobuf
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AccountEventType int32
+
+const (
+	AccountEventType_UserDeleted     AccountEventType = 0
+	AccountEventType_UserCreated     AccountEventType = 1
+	AccountEventType_UserUpdated     AccountEventType = 2
+	AccountEventType_PasswordChanged AccountEventType = 3
+)
+
+// Enum value maps for AccountEventType.
+var (
+	AccountEventType_name = map[int32]string{
+		0: "UserDeleted",
+		1: "UserCreated",
+		2: "UserUpdated",
+		3: "PasswordChanged",
+	}
+	AccountEventType_value = map[string]int32{
+		"UserDeleted":     0,
+		"UserCreated":     1,
+		"UserUpdated":     2,
+		"PasswordChanged": 3,
+	}
+)
+
+func (x AccountEventType) Enum() *AccountEventType {
+	p := new(AccountEventType)
+	*p = x
+	return p
+}
+
+func (x AccountEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AccountEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_broker_account_proto_enumTypes[0].Descriptor()
+}
+
+func (AccountEventType) Type() protoreflect.EnumType {
+	return &file_broker_account_proto_enumTypes[0]
+}
+
+func (x AccountEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AccountEventType.Descriptor instead.
+func (AccountEventType) EnumDescriptor() ([]byte, []int) {
+	return file_broker_account_proto_rawDescGZIP(), []int{0}
+}
+
+type AccountEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId    string           `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Event     AccountEventType `protobuf:"varint,2,opt,name=event,proto3,enum=account.AccountEventType" json:"event,omitempty"`
+	EventDate int64            `protobuf:"varint,3,opt,name=event_date,json=eventDate,proto3" json:"event_date,omitempty"`
+}
+
+func (x *AccountEvent) Reset() {
+	*x = AccountEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_broker_account_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AccountEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountEvent) ProtoMessage() {}
+
+func (x *AccountEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_broker_account_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountEvent.ProtoReflect.Descriptor instead.
+func (*AccountEvent) Descriptor() ([]byte, []int) {
+	return file_broker_account_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AccountEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AccountEvent) GetEvent() AccountEventType {
+	if x != nil {
+		return x.Event
+	}
+	return AccountEventType_UserDeleted
+}
+
+func (x *AccountEvent) GetEventDate() int64 {
+	if x != nil {
+		return x.EventDate
+	}
+	return 0
+}
+
+type DeletionReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId      string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Service     string `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	CompletedAt int64  `protobuf:"varint,3,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+}
+
+func (x *DeletionReport) Reset() {
+	*x = DeletionReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_broker_account_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeletionReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletionReport) ProtoMessage() {}
+
+func (x *DeletionReport) ProtoReflect() protoreflect.Message {
+	mi := &file_broker_account_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletionReport.ProtoReflect.Descriptor instead.
+func (*DeletionReport) Descriptor() ([]byte, []int) {
+	return file_broker_account_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DeletionReport) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeletionReport) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *DeletionReport) GetCompletedAt() int64 {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return 0
+}
+
+var File_broker_account_proto protoreflect.FileDescriptor
+
+var file_broker_account_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x7e, 0x0a, 0x0c, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x36, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20,
+	0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e,
+	0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x44, 0x61, 0x74, 0x65, 0x22, 0x66, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63,
+	0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x74, 0x2a, 0x5a,
+	0x0a, 0x10, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x64, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x73, 0x65, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x73, 0x65, 0x72, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x64, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x10, 0x03, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x73, 0x6d, 0x77, 0x65, 0x62, 0x2f,
+	0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x69,
+	0x6e, 0x66, 0x72, 0x61, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_broker_account_proto_rawDescOnce sync.Once
+	file_broker_account_proto_rawDescData = file_broker_account_proto_rawDesc
+)
+
+func file_broker_account_proto_rawDescGZIP() []byte {
+	file_broker_account_proto_rawDescOnce.Do(func() {
+		file_broker_account_proto_rawDescData = protoimpl.X.CompressGZIP(file_broker_account_proto_rawDescData)
+	})
+	return file_broker_account_proto_rawDescData
+}
+
+var file_broker_account_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_broker_account_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_broker_account_proto_goTypes = []interface{}{
+	(AccountEventType)(0),  // 0: account.AccountEventType
+	(*AccountEvent)(nil),   // 1: account.AccountEvent
+	(*DeletionReport)(nil), // 2: account.DeletionReport
+}
+var file_broker_account_proto_depIdxs = []int32{
+	0, // 0: account.AccountEvent.event:type_name -> account.AccountEventType
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_broker_account_proto_init() }
+func file_broker_account_proto_init() {
+	if File_broker_account_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_broker_account_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AccountEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_broker_account_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeletionReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_broker_account_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_broker_account_proto_goTypes,
+		DependencyIndexes: file_broker_account_proto_depIdxs,
+		EnumInfos:         file_broker_account_proto_enumTypes,
+		MessageInfos:      file_broker_account_proto_msgTypes,
+	}.Build()
+	File_broker_account_proto = out.File
+	file_broker_account_proto_rawDesc = nil
+	file_broker_account_proto_goTypes = nil
+	file_broker_account_proto_depIdxs = nil
+}
@@ -271,3 +271,17 @@ func (r *messageRepository) DeleteAllMessages(ctx context.Context, userID string
 
 	return nil
 }
+
+// PurgeMessages deletes all messages addressed to userID, delivered or not.
+func (r *messageRepository) PurgeMessages(ctx context.Context, userID string) error {
+	stmt, err := r.database.DB().PrepareContext(ctx, `
+		DELETE FROM offline_message
+		WHERE msg_to = $1`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, userID)
+	return err
+}
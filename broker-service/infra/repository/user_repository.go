@@ -142,7 +142,7 @@ func (r *userRepository) IsValidUser(ctx context.Context, userID string) (bool,
 }
 
 func (r *userRepository) isValidUser(ctx context.Context, userID string) (bool, error) {
-	stmt, err := r.database.DB().PrepareContext(ctx, `SELECT id FROM "user" WHERE id = $1`)
+	stmt, err := r.database.DB().PrepareContext(ctx, `SELECT id FROM "user" WHERE id = $1 AND deleted_at IS NULL`)
 	if err != nil {
 		return false, err
 	}
@@ -221,6 +221,22 @@ func (r *userRepository) UpdateBlockedUserCache(ctx context.Context, userID stri
 	return nil
 }
 
+// InvalidateUserCache removes the user presence from cache and caches the user as invalid.
+func (r *userRepository) InvalidateUserCache(ctx context.Context, userID string) error {
+	if err := r.cache.Del(ctx, userID); err != nil {
+		return err
+	}
+	return r.cache.Set(ctx, fmt.Sprintf(validUserKey, userID), validUserFalse,
+		validUserExpiration)
+}
+
+// UpdateValidUserCache refresh valid users cache.
+func (r *userRepository) UpdateValidUserCache(ctx context.Context, userID string,
+	valid bool) error {
+	return r.cache.Set(ctx, fmt.Sprintf(validUserKey, userID), strconv.FormatBool(valid),
+		validUserExpiration)
+}
+
 // GetAllContactsOnline returns all online contacts by userID.
 func (r *userRepository) GetAllContactsOnline(ctx context.Context,
 	userID string) ([]string, error) {
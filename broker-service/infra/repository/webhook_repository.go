@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/tsmweb/broker-service/broker/webhook"
+	"github.com/tsmweb/broker-service/infra/db"
+)
+
+const (
+	webhookKey  = "webhook:user:%s"
+	webhookNone = "none"
+
+	// The webhooks are registered in auth-service, which does not notify the broker,
+	// so the cache expires quickly.
+	webhookExpiration = time.Minute
+)
+
+// webhookRepository implementation for webhook.Repository interface.
+type webhookRepository struct {
+	database db.Database
+	cache    db.CacheDB
+}
+
+// NewWebhookRepository creates a new instance of webhook.Repository.
+func NewWebhookRepository(database db.Database, cache db.CacheDB) webhook.Repository {
+	return &webhookRepository{
+		database: database,
+		cache:    cache,
+	}
+}
+
+// GetWebhook returns the webhook of the user, or nil if the user has no webhook.
+func (r *webhookRepository) GetWebhook(ctx context.Context, userID string) (*webhook.Webhook, error) {
+	_webhookKey := fmt.Sprintf(webhookKey, userID)
+	cached, err := r.cache.Get(ctx, _webhookKey)
+	if err != nil {
+		return nil, err
+	}
+	if cached == webhookNone {
+		return nil, nil
+	}
+	if cached != "" {
+		var hook webhook.Webhook
+		if err = json.Unmarshal([]byte(cached), &hook); err == nil {
+			return &hook, nil
+		}
+	}
+
+	hook, err := r.getWebhook(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	value := webhookNone
+	if hook != nil {
+		data, err := json.Marshal(hook)
+		if err != nil {
+			return nil, err
+		}
+		value = string(data)
+	}
+	if err = r.cache.Set(ctx, _webhookKey, value, webhookExpiration); err != nil {
+		return nil, err
+	}
+
+	return hook, nil
+}
+
+func (r *webhookRepository) getWebhook(ctx context.Context, userID string) (*webhook.Webhook, error) {
+	stmt, err := r.database.DB().PrepareContext(ctx, `
+		SELECT w.url, w.secret
+		FROM webhook w
+		INNER JOIN oauth_client c ON c.id = w.client_id
+		WHERE w.client_id = $1
+		AND c.revoked_at IS NULL`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	hook := &webhook.Webhook{UserID: userID}
+	err = stmt.QueryRowContext(ctx, userID).Scan(&hook.URL, &hook.Secret)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return hook, nil
+}
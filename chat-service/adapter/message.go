@@ -33,6 +33,7 @@ func protobufFromMessage(m *message.Message) *protobuf.Message {
 		Date:        m.Date.Unix(),
 		ContentType: protobuf.ContentType(protobuf.ContentType_value[m.ContentType]),
 		Content:     m.Content,
+		Callback:    m.Callback,
 	}
 }
 
@@ -44,4 +45,5 @@ func protobufToMessage(mpb *protobuf.Message, m *message.Message) {
 	m.Date = time.Unix(mpb.GetDate(), 0)
 	m.ContentType = mpb.GetContentType().String()
 	m.Content = mpb.GetContent()
+	m.Callback = mpb.GetCallback()
 }
@@ -0,0 +1,27 @@
+package adapter
+
+import (
+	"time"
+
+	"github.com/tsmweb/chat-service/infra/protobuf"
+	"github.com/tsmweb/chat-service/server/token"
+	"google.golang.org/protobuf/proto"
+)
+
+// RevocationUnmarshal is a protobuf.TokenRevocation decoder for token.Revocation.
+func RevocationUnmarshal(in []byte, r *token.Revocation) error {
+	rpb := new(protobuf.TokenRevocation)
+	if err := proto.Unmarshal(in, rpb); err != nil {
+		return err
+	}
+	protobufToRevocation(rpb, r)
+	return nil
+}
+
+func protobufToRevocation(rpb *protobuf.TokenRevocation, r *token.Revocation) {
+	r.UserID = rpb.GetUserId()
+	r.TokenID = rpb.GetTokenId()
+	r.SessionID = rpb.GetSessionId()
+	r.Reason = rpb.GetReason().String()
+	r.RevokedAt = time.Unix(rpb.GetRevokedAt(), 0).UTC()
+}
@@ -2,23 +2,85 @@ package adapter
 
 import (
 	"encoding/json"
-	"github.com/gobwas/ws"
-	"github.com/gobwas/ws/wsutil"
+	"errors"
 	"io"
 	"net"
+
+	"github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+	"github.com/tsmweb/chat-service/server"
 )
 
 // ReaderWS is a net.Conn websocket reader.
 func ReaderWS(conn net.Conn) (io.Reader, error) {
-	h, r, err := wsutil.NextReader(conn, ws.StateServerSide)
+	return readerWS(conn, 0)
+}
+
+// NewReaderWS returns a net.Conn websocket reader that accepts messages of at most maxSize bytes,
+// whether sent in a single frame or fragmented. Oversized messages close the connection with
+// ws.StatusMessageTooBig and the reader returns server.ErrMessageTooLarge.
+// A maxSize less than or equal to zero means there is no limit.
+func NewReaderWS(maxSize int64) server.ConnReaderFunc {
+	return func(conn net.Conn) (io.Reader, error) {
+		return readerWS(conn, maxSize)
+	}
+}
+
+func readerWS(conn net.Conn, maxSize int64) (io.Reader, error) {
+	rd := &wsutil.Reader{
+		Source:         conn,
+		State:          ws.StateServerSide,
+		MaxFrameSize:   maxSize,
+		OnIntermediate: wsutil.ControlFrameHandler(conn, ws.StateServerSide),
+	}
+
+	h, err := rd.NextFrame()
 	if err != nil {
+		if errors.Is(err, wsutil.ErrFrameTooLarge) {
+			return nil, closeMessageTooBig(conn)
+		}
 		return nil, err
 	}
 	if h.OpCode.IsControl() {
-		return nil, wsutil.ControlFrameHandler(conn, ws.StateServerSide)(h, r)
+		return nil, wsutil.ControlFrameHandler(conn, ws.StateServerSide)(h, rd)
 	}
+	if maxSize <= 0 {
+		return rd, nil
+	}
+
+	return &limitedReaderWS{conn: conn, r: rd, n: maxSize}, nil
+}
 
-	return r, nil
+// limitedReaderWS reads at most n bytes of a message, which can be split into several frames.
+type limitedReaderWS struct {
+	conn net.Conn
+	r    io.Reader
+	n    int64
+}
+
+func (l *limitedReaderWS) Read(p []byte) (int, error) {
+	// Reads one byte beyond the limit to tell an oversized message from one that fits exactly.
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+
+	n, err := l.r.Read(p)
+	if int64(n) > l.n {
+		return 0, closeMessageTooBig(l.conn)
+	}
+	l.n -= int64(n)
+
+	if errors.Is(err, wsutil.ErrFrameTooLarge) {
+		return n, closeMessageTooBig(l.conn)
+	}
+	return n, err
+}
+
+func closeMessageTooBig(conn net.Conn) error {
+	body := ws.NewCloseFrameBody(ws.StatusMessageTooBig, server.ErrMessageTooLarge.Error())
+	// The connection is closed by the caller, even if the close frame could not be written.
+	_ = ws.WriteFrame(conn, ws.NewCloseFrame(body))
+	return server.ErrMessageTooLarge
 }
 
 // WriterWS is a net.Conn websocket writer.
@@ -32,3 +94,20 @@ func WriterWS(conn net.Conn, data interface{}) error {
 
 	return w.Flush()
 }
+
+// CloserWS is a net.Conn websocket closer, which informs the reason to the user with
+// ws.StatusGoingAway for server maintenance, or ws.StatusPolicyViolation otherwise,
+// before closing the connection.
+func CloserWS(conn net.Conn, reason server.CloseReason) error {
+	code := ws.StatusPolicyViolation
+	if reason == server.CloseMaintenance {
+		code = ws.StatusGoingAway
+	}
+
+	body := ws.NewCloseFrameBody(code, reason.String())
+	err := ws.WriteFrame(conn, ws.NewCloseFrame(body))
+	if errClose := conn.Close(); err == nil {
+		err = errClose
+	}
+	return err
+}
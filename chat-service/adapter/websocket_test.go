@@ -0,0 +1,80 @@
+package adapter
+
+import (
+	"io"
+	"net"
+	"testing"
+
+	"github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/tsmweb/chat-service/server"
+)
+
+func TestNewReaderWS(t *testing.T) {
+	//t.Parallel()
+	const maxSize = 8
+
+	t.Run("when message fits the limit", func(t *testing.T) {
+		//t.Parallel()
+		srv, cli := net.Pipe()
+		defer srv.Close()
+		defer cli.Close()
+
+		go wsutil.WriteClientText(cli, []byte("12345678"))
+
+		r, err := NewReaderWS(maxSize)(srv)
+		assert.Nil(t, err)
+		b, err := io.ReadAll(r)
+		assert.Nil(t, err)
+		assert.Equal(t, "12345678", string(b))
+	})
+
+	t.Run("when frame exceeds the limit", func(t *testing.T) {
+		//t.Parallel()
+		srv, cli := net.Pipe()
+		defer srv.Close()
+		defer cli.Close()
+
+		go wsutil.WriteClientText(cli, []byte("123456789"))
+		chClose := readCloseFrame(cli)
+
+		_, err := NewReaderWS(maxSize)(srv)
+		assert.ErrorIs(t, err, server.ErrMessageTooLarge)
+		assert.Equal(t, ws.StatusMessageTooBig, <-chClose)
+	})
+
+	t.Run("when fragmented message exceeds the limit", func(t *testing.T) {
+		//t.Parallel()
+		srv, cli := net.Pipe()
+		defer srv.Close()
+		defer cli.Close()
+
+		go func() {
+			ws.WriteFrame(cli, ws.MaskFrame(ws.NewFrame(ws.OpText, false, []byte("12345"))))
+			ws.WriteFrame(cli, ws.MaskFrame(ws.NewFrame(ws.OpContinuation, true, []byte("6789"))))
+		}()
+
+		r, err := NewReaderWS(maxSize)(srv)
+		assert.Nil(t, err)
+
+		chClose := readCloseFrame(cli)
+		_, err = io.Copy(io.Discard, r)
+		assert.ErrorIs(t, err, server.ErrMessageTooLarge)
+		assert.Equal(t, ws.StatusMessageTooBig, <-chClose)
+	})
+}
+
+func readCloseFrame(conn net.Conn) <-chan ws.StatusCode {
+	ch := make(chan ws.StatusCode, 1)
+	go func() {
+		defer close(ch)
+		frame, err := ws.ReadFrame(conn)
+		if err != nil || frame.Header.OpCode != ws.OpClose {
+			return
+		}
+		code, _ := ws.ParseCloseFrameData(frame.Payload)
+		ch <- code
+	}()
+	return ch
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/tsmweb/chat-service/config"
+	"github.com/tsmweb/chat-service/di"
 	"github.com/tsmweb/go-helper-api/middleware"
 	"github.com/tsmweb/go-helper-api/observability/event"
 	"github.com/urfave/negroni"
@@ -57,7 +58,7 @@ func main() {
 		cancelFunc()
 	}()
 
-	provider := CreateProvider(ctx)
+	provider := di.CreateProvider(ctx)
 
 	// Initializes the service's event producer.
 	producerEvents := provider.NewKafkaProducer(config.KafkaEventsTopic())
@@ -66,11 +67,29 @@ func main() {
 	}
 	defer event.Close()
 
+	// Enable the metrics on an internal listener, they are not exposed on the public router.
+	if port := config.MetricsPort(); port > 0 {
+		metricsRouter := mux.NewRouter()
+		provider.MetricsRouter(metricsRouter)
+
+		log.Printf("[INFO] starting metrics server on :%d\n", port)
+		go func() {
+			log.Printf("[ERROR] metrics server error: %v\n",
+				http.ListenAndServe(fmt.Sprintf(":%d", port), metricsRouter))
+		}()
+	}
+
 	// starts API server
 	router := mux.NewRouter()
 	if err := provider.ChatRouter(router); err != nil {
 		log.Fatalf("[ERROR] error when starting server: %s\n", err.Error())
 	}
+	if err := provider.AdminRouter(router); err != nil {
+		log.Fatalf("[ERROR] error when starting server: %s\n", err.Error())
+	}
+	if err := provider.MessageRouter(router); err != nil {
+		log.Fatalf("[ERROR] error when starting server: %s\n", err.Error())
+	}
 
 	handler := middleware.GZIP(router)
 	handler = middleware.CORS(handler)
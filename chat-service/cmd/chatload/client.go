@@ -0,0 +1,305 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+	"github.com/tsmweb/chat-service/server/message"
+)
+
+// contentPrefix identifies the messages generated by the load test.
+const contentPrefix = "chatload-"
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// signUp verifies the phone number of the user with the code written to the SMS file of
+// auth-service and creates the user, the user may already exist.
+func signUp(ctx context.Context, authURL, smsFile, userID, password string) error {
+	_, err := doJSON(ctx, http.MethodPost, authURL+"/v1/verification", "",
+		map[string]string{"id": userID}, http.StatusAccepted)
+	if err != nil {
+		return err
+	}
+
+	code, err := readCode(ctx, smsFile, userID)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]string{
+		"id":       userID,
+		"name":     "chatload",
+		"lastname": userID,
+		"password": password,
+		"code":     code,
+	}
+	_, err = doJSON(ctx, http.MethodPost, authURL+"/v1/user", "", body,
+		http.StatusCreated, http.StatusConflict)
+	return err
+}
+
+var codeRegexp = regexp.MustCompile(`\b[0-9]{6}\b`)
+
+// readCode waits for the last verification code sent to the phone number in the SMS file,
+// written by auth-service with SMS_SENDER=file as lines of "<time> <phone> <text>".
+func readCode(ctx context.Context, smsFile, phone string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		data, err := os.ReadFile(smsFile)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+
+		var code string
+		for _, line := range strings.Split(string(data), "\n") {
+			fields := strings.SplitN(line, " ", 3)
+			if len(fields) == 3 && fields[1] == phone {
+				code = codeRegexp.FindString(fields[2])
+			}
+		}
+		if code != "" {
+			return code, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("no verification code sent to %s in %s", phone, smsFile)
+		case <-ticker.C:
+		}
+	}
+}
+
+// login returns the access token of the user issued by auth-service.
+func login(ctx context.Context, authURL, userID, password string) (string, error) {
+	body := map[string]string{
+		"id":       userID,
+		"password": password,
+	}
+	res, err := doJSON(ctx, http.MethodPost, authURL+"/v1/login", "", body, http.StatusOK)
+	if err != nil {
+		return "", err
+	}
+
+	var tokenAuth struct {
+		Token string `json:"token"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&tokenAuth); err != nil {
+		return "", err
+	}
+	return tokenAuth.Token, nil
+}
+
+// createGroup creates a group owned by the user of the token in user-service,
+// returning its ID.
+func createGroup(ctx context.Context, userURL, token, name string) (string, error) {
+	body := map[string]string{
+		"name":        name,
+		"description": "chatload",
+	}
+	res, err := doJSON(ctx, http.MethodPost, userURL+"/v1/group", token, body,
+		http.StatusCreated)
+	if err != nil {
+		return "", err
+	}
+
+	location := res.Header.Get("Location")
+	id := location[strings.LastIndex(location, "/")+1:]
+	if id == "" {
+		return "", fmt.Errorf("group %s created without location", name)
+	}
+	return id, nil
+}
+
+// addMember adds the user to the group in user-service.
+func addMember(ctx context.Context, userURL, token, groupID, userID string) error {
+	body := map[string]interface{}{
+		"group_id": groupID,
+		"user_id":  userID,
+		"admin":    false,
+	}
+	_, err := doJSON(ctx, http.MethodPost, userURL+"/v1/group/member", token, body,
+		http.StatusCreated)
+	return err
+}
+
+// doJSON sends the body as JSON, returning an error if the response status is not
+// one of the expected ones. The response body is fully read before it is returned.
+func doJSON(ctx context.Context, method, url, token string, body interface{},
+	status ...int) (*http.Response, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	data, err = io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(data))
+
+	for _, s := range status {
+		if res.StatusCode == s {
+			return res, nil
+		}
+	}
+	return nil, fmt.Errorf("%s %s: status %d: %s", method, url, res.StatusCode,
+		strings.TrimSpace(string(data)))
+}
+
+// client is a WebSocket connection of a synthetic user to chat-service.
+type client struct {
+	userID  string
+	conn    net.Conn
+	tracker *tracker
+
+	mu      sync.Mutex // serializes writes
+	closing bool
+	done    chan struct{}
+}
+
+// dial connects the user to chat-service with the access token.
+func dial(ctx context.Context, url, userID, token string, t *tracker) (*client, error) {
+	dialer := ws.Dialer{
+		Header: ws.HandshakeHeaderHTTP(http.Header{
+			"Authorization": []string{"Bearer " + token},
+		}),
+	}
+
+	conn, _, _, err := dialer.Dial(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &client{
+		userID:  userID,
+		conn:    conn,
+		tracker: t,
+		done:    make(chan struct{}),
+	}
+	go c.readLoop()
+
+	return c, nil
+}
+
+// send sends a text message to the user or to the group, tracking its ACK and the
+// number of deliveries expected.
+func (c *client) send(kind, id, to, group, content string, recipients int) error {
+	msg := &message.Message{
+		ID:          id,
+		From:        c.userID,
+		To:          to,
+		Group:       group,
+		Date:        time.Now().UTC(),
+		ContentType: message.ContentTypeText.String(),
+		Content:     content,
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	c.tracker.sending(kind, id, contentKey(content), recipients)
+
+	c.mu.Lock()
+	err = wsutil.WriteClientText(c.conn, data)
+	c.mu.Unlock()
+
+	if err != nil {
+		c.tracker.sendFailed(kind, id)
+	}
+	return err
+}
+
+// close closes the connection and waits for the read loop to stop.
+func (c *client) close() {
+	c.mu.Lock()
+	c.closing = true
+	c.conn.Close()
+	c.mu.Unlock()
+
+	<-c.done
+}
+
+func (c *client) readLoop() {
+	defer close(c.done)
+
+	for {
+		data, err := wsutil.ReadServerText(c.conn)
+		if err != nil {
+			c.mu.Lock()
+			closing := c.closing
+			c.mu.Unlock()
+
+			if !closing {
+				c.tracker.disconnected()
+			}
+			return
+		}
+
+		msg := &message.Message{}
+		if err = json.Unmarshal(data, msg); err != nil {
+			continue
+		}
+
+		switch msg.ContentType {
+		case message.ContentTypeACK.String():
+			c.tracker.ack(msg.ID)
+		case message.ContentTypeError.String():
+			c.tracker.serverError(msg.ID)
+		case message.ContentTypeText.String():
+			if strings.HasPrefix(msg.Content, contentPrefix) {
+				c.tracker.deliver(contentKey(msg.Content))
+			}
+		}
+	}
+}
+
+// newContent returns the content of the message with the sequence, padded to size bytes.
+func newContent(seq int64, size int) string {
+	content := fmt.Sprintf("%s%d ", contentPrefix, seq)
+	if pad := size - len(content); pad > 0 {
+		content += strings.Repeat("x", pad)
+	}
+	return content
+}
+
+// contentKey returns the key that identifies the message by its content, as the group
+// messages are delivered with a new ID to each member.
+func contentKey(content string) string {
+	key, _, _ := strings.Cut(content, " ")
+	return key
+}
@@ -0,0 +1,356 @@
+// Command chatload measures how far a chat-service node scales. It logs in synthetic
+// users through auth-service, opens their WebSocket connections and sends 1:1 and group
+// messages at the configured rates, reporting the latencies of the ACKs and deliveries
+// and the error rates.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+var (
+	authURL     = flag.String("auth", "http://auth.infolab.com.br", "auth-service URL")
+	userURL     = flag.String("user", "http://user.infolab.com.br", "user-service URL, used to create the groups")
+	chatURL     = flag.String("chat", "ws://chat.infolab.com.br/v1/ws", "chat-service WebSocket URL")
+	users       = flag.Int("users", 100, "number of synthetic users")
+	prefix      = flag.String("prefix", "+5599", "prefix of the synthetic user IDs")
+	password    = flag.String("password", "chatload", "password of the synthetic users")
+	signup      = flag.Bool("signup", true, "create the synthetic users that do not exist")
+	smsFile     = flag.String("sms-file", "", "SMS file of auth-service (SMS_SENDER=file), read to verify the phone numbers on sign up")
+	groups      = flag.Int("groups", 0, "number of groups created for the group traffic")
+	groupSize   = flag.Int("group-size", 10, "number of members of each group")
+	rate        = flag.Float64("rate", 10, "1:1 messages sent per second by all users")
+	groupRate   = flag.Float64("group-rate", 0, "group messages sent per second by all users")
+	size        = flag.Int("size", 64, "content size of the messages in bytes")
+	concurrency = flag.Int("concurrency", 50, "concurrent logins and connections during the setup")
+	warmup      = flag.Duration("warmup", 2*time.Second, "time to wait after connecting, until the users are online in the broker")
+	duration    = flag.Duration("duration", 30*time.Second, "duration of the traffic")
+	drain       = flag.Duration("drain", 5*time.Second, "maximum time to wait for the outstanding ACKs and deliveries")
+	output      = flag.String("o", "", "file to export the results as JSON")
+)
+
+// member is a synthetic user.
+type member struct {
+	id     string
+	token  string
+	client *client
+}
+
+// group is a group of synthetic users.
+type group struct {
+	id      string
+	members []*member
+}
+
+func main() {
+	flag.Parse()
+
+	if *users < 2 {
+		log.Fatalln("[ERROR] at least 2 users are required")
+	}
+	if *concurrency < 1 {
+		log.Fatalln("[ERROR] concurrency must be at least 1")
+	}
+	if *groups > 0 && (*groupSize < 2 || *groupSize > *users) {
+		log.Fatalln("[ERROR] group-size must be between 2 and the number of users")
+	}
+	if *signup && *smsFile == "" {
+		log.Fatalln("[ERROR] sign up requires sms-file, or use -signup=false with existing users")
+	}
+
+	// Each user holds a connection.
+	var rLimit syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &rLimit); err == nil {
+		rLimit.Cur = rLimit.Max
+		syscall.Setrlimit(syscall.RLIMIT_NOFILE, &rLimit)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	t := newTracker()
+	report := &Report{StartedAt: time.Now().UTC(), Users: *users}
+
+	log.Printf("[INFO] logging in %d users\n", *users)
+	members := loginUsers(ctx, report)
+	if len(members) < 2 {
+		log.Fatalln("[ERROR] less than 2 users logged in")
+	}
+
+	var grps []*group
+	if *groups > 0 && *groupRate > 0 {
+		log.Printf("[INFO] creating %d groups of %d members\n", *groups, *groupSize)
+		grps = createGroups(ctx, members)
+		report.Groups = len(grps)
+	}
+
+	log.Printf("[INFO] connecting %d users\n", len(members))
+	online := connectUsers(ctx, members, t, report)
+	defer func() {
+		for _, m := range online {
+			m.client.close()
+		}
+	}()
+	if len(online) < 2 {
+		log.Fatalln("[ERROR] less than 2 users connected")
+	}
+
+	sleep(ctx, *warmup)
+
+	log.Printf("[INFO] sending messages for %s\n", *duration)
+	start := time.Now()
+	runCtx, stop := context.WithTimeout(ctx, *duration)
+	run(runCtx, online, grps)
+	stop()
+	elapsed := time.Since(start)
+
+	log.Printf("[INFO] waiting for %d outstanding messages\n", t.outstanding())
+	deadline := time.Now().Add(*drain)
+	for t.outstanding() > 0 && time.Now().Before(deadline) && ctx.Err() == nil {
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	t.report(report, elapsed)
+	fmt.Println()
+	report.Print(os.Stdout)
+
+	if *output != "" {
+		if err := report.WriteFile(*output); err != nil {
+			log.Fatalf("[ERROR] could not export the results: %s\n", err.Error())
+		}
+		log.Printf("[INFO] results exported to %s\n", *output)
+	}
+}
+
+// loginUsers signs up, when enabled, and logs in the synthetic users.
+func loginUsers(ctx context.Context, report *Report) []*member {
+	members := make([]*member, *users)
+	for i := range members {
+		members[i] = &member{id: fmt.Sprintf("%s%08d", *prefix, i)}
+	}
+
+	var errors int64
+	forEach(len(members), func(i int) {
+		m := members[i]
+		if *signup {
+			if err := signUp(ctx, *authURL, *smsFile, m.id, *password); err != nil {
+				log.Printf("[ERROR] sign up %s: %s\n", m.id, err.Error())
+				atomic.AddInt64(&errors, 1)
+				return
+			}
+		}
+
+		token, err := login(ctx, *authURL, m.id, *password)
+		if err != nil {
+			log.Printf("[ERROR] login %s: %s\n", m.id, err.Error())
+			atomic.AddInt64(&errors, 1)
+			return
+		}
+		m.token = token
+	})
+	report.LoginErrors = int(errors)
+
+	var logged []*member
+	for _, m := range members {
+		if m.token != "" {
+			logged = append(logged, m)
+		}
+	}
+	return logged
+}
+
+// createGroups creates the groups with consecutive users, the first one is the owner.
+func createGroups(ctx context.Context, members []*member) []*group {
+	grps := make([]*group, *groups)
+
+	forEach(len(grps), func(i int) {
+		g := &group{}
+		for j := 0; j < *groupSize; j++ {
+			g.members = append(g.members, members[(i**groupSize+j)%len(members)])
+		}
+
+		owner := g.members[0]
+		id, err := createGroup(ctx, *userURL, owner.token, fmt.Sprintf("chatload %d", i))
+		if err != nil {
+			log.Printf("[ERROR] create group %d: %s\n", i, err.Error())
+			return
+		}
+		g.id = id
+
+		for _, m := range g.members[1:] {
+			if err = addMember(ctx, *userURL, owner.token, id, m.id); err != nil {
+				log.Printf("[ERROR] add member %s to group %s: %s\n", m.id, id, err.Error())
+				g.id = ""
+				return
+			}
+		}
+		grps[i] = g
+	})
+
+	var created []*group
+	for _, g := range grps {
+		if g != nil && g.id != "" {
+			created = append(created, g)
+		}
+	}
+	return created
+}
+
+// connectUsers opens the WebSocket connections of the users.
+func connectUsers(ctx context.Context, members []*member, t *tracker, report *Report) []*member {
+	var errors int64
+	forEach(len(members), func(i int) {
+		m := members[i]
+		c, err := dial(ctx, *chatURL, m.id, m.token, t)
+		if err != nil {
+			log.Printf("[ERROR] connect %s: %s\n", m.id, err.Error())
+			atomic.AddInt64(&errors, 1)
+			return
+		}
+		m.client = c
+	})
+	report.DialErrors = int(errors)
+
+	var online []*member
+	for _, m := range members {
+		if m.client != nil {
+			online = append(online, m)
+		}
+	}
+	report.Connected = len(online)
+	return online
+}
+
+// run sends the messages at the configured rates until the context is done.
+func run(ctx context.Context, online []*member, grps []*group) {
+	runID := time.Now().UnixNano()
+	var seq int64
+	next := func() (string, string) {
+		n := atomic.AddInt64(&seq, 1)
+		return fmt.Sprintf("%s%d-%d", contentPrefix, runID, n), newContent(n, *size)
+	}
+
+	// Only the groups whose members are all connected are used, so that every member
+	// is expected to receive the messages.
+	connected := make(map[string]bool, len(online))
+	for _, m := range online {
+		connected[m.id] = true
+	}
+	var ready []*group
+	for _, g := range grps {
+		ok := true
+		for _, m := range g.members {
+			ok = ok && connected[m.id]
+		}
+		if ok {
+			ready = append(ready, g)
+		}
+	}
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		pace(ctx, *rate, func(r *rand.Rand) func() {
+			from := online[r.Intn(len(online))]
+			to := online[r.Intn(len(online))]
+			for to == from {
+				to = online[r.Intn(len(online))]
+			}
+
+			return func() {
+				id, content := next()
+				if err := from.client.send(kindDirect, id, to.id, "", content, 1); err != nil {
+					log.Printf("[ERROR] send %s: %s\n", from.id, err.Error())
+				}
+			}
+		})
+	}()
+
+	if len(ready) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pace(ctx, *groupRate, func(r *rand.Rand) func() {
+				g := ready[r.Intn(len(ready))]
+				from := g.members[r.Intn(len(g.members))]
+
+				return func() {
+					id, content := next()
+					err := from.client.send(kindGroup, id, "", g.id, content, len(g.members)-1)
+					if err != nil {
+						log.Printf("[ERROR] send %s: %s\n", from.id, err.Error())
+					}
+				}
+			})
+		}()
+	}
+
+	wg.Wait()
+}
+
+// pace picks the messages to send the given times per second until the context is done,
+// sending each one in its own goroutine so that a slow connection does not hold the rate.
+func pace(ctx context.Context, perSecond float64, pick func(r *rand.Rand) func()) {
+	if perSecond <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / perSecond))
+	defer ticker.Stop()
+
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			send := pick(r)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				send()
+			}()
+		}
+	}
+}
+
+// forEach calls fn for the indexes 0 to n-1 using up to concurrency goroutines.
+func forEach(n int, fn func(i int)) {
+	sem := make(chan struct{}, *concurrency)
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	select {
+	case <-time.After(d):
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// LatencySummary is the distribution of a latency in milliseconds.
+type LatencySummary struct {
+	Count int     `json:"count"`
+	Min   float64 `json:"min_ms"`
+	Mean  float64 `json:"mean_ms"`
+	P50   float64 `json:"p50_ms"`
+	P90   float64 `json:"p90_ms"`
+	P95   float64 `json:"p95_ms"`
+	P99   float64 `json:"p99_ms"`
+	Max   float64 `json:"max_ms"`
+}
+
+// Traffic is the result of the messages of a kind, 1:1 or group.
+type Traffic struct {
+	Sent        int            `json:"sent"`
+	SendErrors  int            `json:"send_errors"`
+	Delivered   int            `json:"delivered"`
+	Undelivered int            `json:"undelivered"`
+	Rate        float64        `json:"rate_per_second"`
+	Latency     LatencySummary `json:"latency"`
+}
+
+// Report is the result of a load test.
+type Report struct {
+	StartedAt    time.Time      `json:"started_at"`
+	Duration     float64        `json:"duration_seconds"`
+	Users        int            `json:"users"`
+	LoginErrors  int            `json:"login_errors"`
+	Connected    int            `json:"connected"`
+	DialErrors   int            `json:"dial_errors"`
+	Disconnects  int            `json:"disconnects"`
+	Groups       int            `json:"groups"`
+	Direct       Traffic        `json:"direct"`
+	Group        Traffic        `json:"group"`
+	Acks         int            `json:"acks"`
+	MissingAcks  int            `json:"missing_acks"`
+	AckLatency   LatencySummary `json:"ack_latency"`
+	ServerErrors int            `json:"server_errors"`
+	Unexpected   int            `json:"unexpected"`
+	ErrorRate    float64        `json:"error_rate"`
+}
+
+// report summarizes the messages tracked during the load test. The messages still
+// outstanding are counted as missing ACKs or undelivered.
+func (t *tracker) report(r *Report, elapsed time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	undelivered := map[string]int{}
+	for _, p := range t.messages {
+		if !p.acked {
+			r.MissingAcks++
+		}
+		undelivered[p.kind] += p.remaining
+	}
+
+	traffic := func(kind string) Traffic {
+		return Traffic{
+			Sent:        t.sent[kind],
+			SendErrors:  t.sendErrors[kind],
+			Delivered:   t.delivered[kind],
+			Undelivered: undelivered[kind],
+			Rate:        float64(t.sent[kind]) / elapsed.Seconds(),
+			Latency:     t.deliveryLatency[kind].summary(),
+		}
+	}
+
+	r.Duration = elapsed.Seconds()
+	r.Disconnects = t.disconnects
+	r.Direct = traffic(kindDirect)
+	r.Group = traffic(kindGroup)
+	r.Acks = t.acks
+	r.AckLatency = t.ackLatency.summary()
+	r.ServerErrors = t.serverErrors
+	r.Unexpected = t.unexpected
+
+	attempts := r.Direct.Sent + r.Direct.SendErrors + r.Group.Sent + r.Group.SendErrors
+	if attempts > 0 {
+		failures := r.Direct.SendErrors + r.Group.SendErrors + r.ServerErrors + r.MissingAcks
+		r.ErrorRate = float64(failures) / float64(attempts)
+	}
+}
+
+// Print writes the report in a human readable format.
+func (r *Report) Print(w io.Writer) {
+	fmt.Fprintf(w, "duration     %.1fs\n", r.Duration)
+	fmt.Fprintf(w, "users        %d (login errors %d)\n", r.Users, r.LoginErrors)
+	fmt.Fprintf(w, "connections  %d (dial errors %d, dropped %d)\n",
+		r.Connected, r.DialErrors, r.Disconnects)
+	fmt.Fprintf(w, "groups       %d\n", r.Groups)
+	fmt.Fprintf(w, "acks         %d (missing %d)\n", r.Acks, r.MissingAcks)
+	fmt.Fprintf(w, "errors       server %d, unexpected %d, error rate %.4f%%\n\n",
+		r.ServerErrors, r.Unexpected, r.ErrorRate*100)
+
+	fmt.Fprintf(w, "%-9s %8s %8s %10s %10s %9s\n",
+		"traffic", "sent", "errors", "delivered", "missing", "rate/s")
+	for _, t := range []struct {
+		name string
+		*Traffic
+	}{{kindDirect, &r.Direct}, {kindGroup, &r.Group}} {
+		fmt.Fprintf(w, "%-9s %8d %8d %10d %10d %9.1f\n",
+			t.name, t.Sent, t.SendErrors, t.Delivered, t.Undelivered, t.Rate)
+	}
+
+	fmt.Fprintf(w, "\n%-9s %8s %8s %8s %8s %8s %8s %8s %8s\n",
+		"latency", "count", "min", "mean", "p50", "p90", "p95", "p99", "max")
+	for _, l := range []struct {
+		name string
+		*LatencySummary
+	}{{"ack", &r.AckLatency}, {kindDirect, &r.Direct.Latency}, {kindGroup, &r.Group.Latency}} {
+		fmt.Fprintf(w, "%-9s %8d %8.2f %8.2f %8.2f %8.2f %8.2f %8.2f %8.2f\n",
+			l.name, l.Count, l.Min, l.Mean, l.P50, l.P90, l.P95, l.P99, l.Max)
+	}
+	fmt.Fprintln(w, "(latencies in milliseconds)")
+}
+
+// WriteFile exports the report as JSON.
+func (r *Report) WriteFile(name string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, data, 0o644)
+}
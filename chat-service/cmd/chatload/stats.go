@@ -0,0 +1,215 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Traffic kinds generated by the load test.
+const (
+	kindDirect = "direct"
+	kindGroup  = "group"
+)
+
+// latencies records durations and summarizes them in percentiles.
+type latencies struct {
+	mu      sync.Mutex
+	samples []time.Duration
+}
+
+func (l *latencies) add(d time.Duration) {
+	l.mu.Lock()
+	l.samples = append(l.samples, d)
+	l.mu.Unlock()
+}
+
+func (l *latencies) summary() LatencySummary {
+	l.mu.Lock()
+	samples := append([]time.Duration(nil), l.samples...)
+	l.mu.Unlock()
+
+	if len(samples) == 0 {
+		return LatencySummary{}
+	}
+
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+
+	var total time.Duration
+	for _, d := range samples {
+		total += d
+	}
+
+	return LatencySummary{
+		Count: len(samples),
+		Min:   millis(samples[0]),
+		Mean:  millis(total / time.Duration(len(samples))),
+		P50:   millis(percentile(samples, 50)),
+		P90:   millis(percentile(samples, 90)),
+		P95:   millis(percentile(samples, 95)),
+		P99:   millis(percentile(samples, 99)),
+		Max:   millis(samples[len(samples)-1]),
+	}
+}
+
+// percentile returns the nearest-rank percentile p of the sorted samples.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// pending is a message sent that is waiting for its ACK and deliveries.
+type pending struct {
+	kind      string
+	key       string
+	sentAt    time.Time
+	acked     bool
+	remaining int
+}
+
+// tracker matches the ACKs, errors and deliveries received by the clients with the
+// messages sent, measuring their latencies.
+type tracker struct {
+	mu       sync.Mutex
+	messages map[string]*pending // by message ID
+	keys     map[string]string   // message ID by content key
+
+	sent         map[string]int
+	delivered    map[string]int
+	sendErrors   map[string]int
+	acks         int
+	serverErrors int
+	unexpected   int
+	disconnects  int
+
+	ackLatency      latencies
+	deliveryLatency map[string]*latencies
+}
+
+func newTracker() *tracker {
+	return &tracker{
+		messages:   make(map[string]*pending),
+		keys:       make(map[string]string),
+		sent:       make(map[string]int),
+		delivered:  make(map[string]int),
+		sendErrors: make(map[string]int),
+		deliveryLatency: map[string]*latencies{
+			kindDirect: {},
+			kindGroup:  {},
+		},
+	}
+}
+
+// sending registers a message before it is written, as its ACK may arrive before
+// the write returns. Recipients is the number of deliveries expected.
+func (t *tracker) sending(kind, id, key string, recipients int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.messages[id] = &pending{
+		kind:      kind,
+		key:       key,
+		sentAt:    time.Now(),
+		remaining: recipients,
+	}
+	t.keys[key] = id
+	t.sent[kind]++
+}
+
+// sendFailed discards a message that could not be written.
+func (t *tracker) sendFailed(kind, id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if p, ok := t.messages[id]; ok {
+		delete(t.keys, p.key)
+		delete(t.messages, id)
+	}
+	t.sent[kind]--
+	t.sendErrors[kind]++
+}
+
+func (t *tracker) ack(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.messages[id]
+	if !ok || p.acked {
+		t.unexpected++
+		return
+	}
+
+	p.acked = true
+	t.acks++
+	t.ackLatency.add(time.Since(p.sentAt))
+	t.release(id, p)
+}
+
+// serverError discards the message refused by the server.
+func (t *tracker) serverError(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.serverErrors++
+	if p, ok := t.messages[id]; ok {
+		delete(t.keys, p.key)
+		delete(t.messages, id)
+	}
+}
+
+func (t *tracker) deliver(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	id, ok := t.keys[key]
+	if !ok {
+		t.unexpected++
+		return
+	}
+
+	p := t.messages[id]
+	if p.remaining == 0 {
+		t.unexpected++
+		return
+	}
+
+	p.remaining--
+	t.delivered[p.kind]++
+	t.deliveryLatency[p.kind].add(time.Since(p.sentAt))
+	t.release(id, p)
+}
+
+func (t *tracker) disconnected() {
+	t.mu.Lock()
+	t.disconnects++
+	t.mu.Unlock()
+}
+
+// release forgets the message once it was acknowledged and delivered to all recipients.
+func (t *tracker) release(id string, p *pending) {
+	if p.acked && p.remaining == 0 {
+		delete(t.keys, p.key)
+		delete(t.messages, id)
+	}
+}
+
+// outstanding returns the number of messages still waiting for an ACK or delivery.
+func (t *tracker) outstanding() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.messages)
+}
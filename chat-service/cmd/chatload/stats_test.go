@@ -0,0 +1,110 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPercentile(t *testing.T) {
+	//t.Parallel()
+
+	var samples []time.Duration
+	for i := 1; i <= 100; i++ {
+		samples = append(samples, time.Duration(i)*time.Millisecond)
+	}
+
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0, 1 * time.Millisecond},
+		{50, 50 * time.Millisecond},
+		{90, 90 * time.Millisecond},
+		{99, 99 * time.Millisecond},
+		{100, 100 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, percentile(samples, tt.p))
+	}
+	assert.Equal(t, time.Duration(0), percentile(nil, 50))
+}
+
+func TestTracker(t *testing.T) {
+	//t.Parallel()
+
+	t.Run("when direct message is acked and delivered", func(t *testing.T) {
+		//t.Parallel()
+		tr := newTracker()
+		content := newContent(1, 64)
+
+		tr.sending(kindDirect, "id1", contentKey(content), 1)
+		tr.ack("id1")
+		tr.deliver(contentKey(content))
+
+		r := &Report{}
+		tr.report(r, time.Second)
+		assert.Equal(t, 0, tr.outstanding())
+		assert.Equal(t, 1, r.Direct.Sent)
+		assert.Equal(t, 1, r.Direct.Delivered)
+		assert.Equal(t, 0, r.Direct.Undelivered)
+		assert.Equal(t, 1, r.Acks)
+		assert.Equal(t, 1, r.AckLatency.Count)
+		assert.Equal(t, 1, r.Direct.Latency.Count)
+		assert.Equal(t, 0.0, r.ErrorRate)
+	})
+
+	t.Run("when group message is partially delivered", func(t *testing.T) {
+		//t.Parallel()
+		tr := newTracker()
+		content := newContent(1, 0)
+
+		tr.sending(kindGroup, "id1", contentKey(content), 3)
+		tr.deliver(contentKey(content))
+		tr.deliver(contentKey(content))
+		tr.ack("id1")
+
+		r := &Report{}
+		tr.report(r, time.Second)
+		assert.Equal(t, 1, tr.outstanding())
+		assert.Equal(t, 2, r.Group.Delivered)
+		assert.Equal(t, 1, r.Group.Undelivered)
+		assert.Equal(t, 0, r.MissingAcks)
+	})
+
+	t.Run("when messages fail", func(t *testing.T) {
+		//t.Parallel()
+		tr := newTracker()
+
+		tr.sending(kindDirect, "id1", "chatload-1", 1)
+		tr.sendFailed(kindDirect, "id1")
+		tr.sending(kindDirect, "id2", "chatload-2", 1)
+		tr.serverError("id2")
+		tr.sending(kindDirect, "id3", "chatload-3", 1)
+		tr.sending(kindDirect, "id4", "chatload-4", 1)
+		tr.ack("id4")
+		tr.ack("id4")
+		tr.deliver("chatload-4")
+
+		r := &Report{}
+		tr.report(r, time.Second)
+		assert.Equal(t, 3, r.Direct.Sent)
+		assert.Equal(t, 1, r.Direct.SendErrors)
+		assert.Equal(t, 1, r.ServerErrors)
+		assert.Equal(t, 1, r.MissingAcks)
+		assert.Equal(t, 1, r.Direct.Undelivered)
+		assert.Equal(t, 1, r.Unexpected)
+		assert.Equal(t, 0.75, r.ErrorRate)
+	})
+}
+
+func TestContent(t *testing.T) {
+	//t.Parallel()
+
+	content := newContent(42, 64)
+	assert.Len(t, content, 64)
+	assert.Equal(t, "chatload-42", contentKey(content))
+	assert.Equal(t, "chatload-7 ", newContent(7, 0))
+}
@@ -7,6 +7,8 @@ import (
 	"path"
 	"runtime"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -15,9 +17,19 @@ var (
 	hostID                  string
 	goPoolSize              int
 	serverPort              int
+	metricsPort             int
+	maxFrameSize            int
+	maxTextContentSize      int
+	maxMediaContentSize     int
+	maxStatusContentSize    int
+	tokenCheckInterval      int
+	adminUsers              []string
 	keySecureFile           string
-	pubSecureFile           string
+	jwksURLs                []string
+	jwksCacheTTL            int
 	certSecureFile          string
+	redisHost               string
+	redisPassword           string
 	kafkaBootstrapServers   string
 	kafkaClientID           string
 	kafkaUsersTopic         string
@@ -27,6 +39,7 @@ var (
 	kafkaHostTopic          string
 	kafkaGroupID            string
 	kafkaEventsTopic        string
+	kafkaTokensTopic        string
 )
 
 func Load(workDir string) error {
@@ -44,11 +57,57 @@ func Load(workDir string) error {
 	if err != nil {
 		return err
 	}
+	metricsPort, err = strconv.Atoi(os.Getenv("METRICS_PORT"))
+	if err != nil {
+		metricsPort = 0 // disabled
+	}
+
+	maxFrameSize, err = strconv.Atoi(os.Getenv("MAX_FRAME_SIZE"))
+	if err != nil {
+		maxFrameSize = 65536
+	}
+	maxTextContentSize, err = strconv.Atoi(os.Getenv("MAX_TEXT_CONTENT_SIZE"))
+	if err != nil {
+		maxTextContentSize = 4096
+	}
+	maxMediaContentSize, err = strconv.Atoi(os.Getenv("MAX_MEDIA_CONTENT_SIZE"))
+	if err != nil {
+		maxMediaContentSize = 1024
+	}
+	maxStatusContentSize, err = strconv.Atoi(os.Getenv("MAX_STATUS_CONTENT_SIZE"))
+	if err != nil {
+		maxStatusContentSize = 256
+	}
+
+	tokenCheckInterval, err = strconv.Atoi(os.Getenv("TOKEN_CHECK_INTERVAL")) // seconds
+	if err != nil {
+		tokenCheckInterval = 30
+	}
+
+	adminUsers = nil
+	for _, id := range strings.Split(os.Getenv("ADMIN_USERS"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			adminUsers = append(adminUsers, id)
+		}
+	}
 
 	keySecureFile = workDir + "/config/cert/server.pem"
-	pubSecureFile = workDir + "/config/cert/server.pub"
 	certSecureFile = workDir + "/config/cert/server.crt"
 
+	jwksURLs = nil
+	for _, url := range strings.Split(os.Getenv("JWKS_URL"), ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			jwksURLs = append(jwksURLs, url)
+		}
+	}
+	jwksCacheTTL, err = strconv.Atoi(os.Getenv("JWKS_CACHE_TTL")) // second
+	if err != nil {
+		jwksCacheTTL = 300
+	}
+
+	redisHost = os.Getenv("REDIS_HOST")
+	redisPassword = os.Getenv("REDIS_PASSWORD")
+
 	kafkaBootstrapServers = os.Getenv("KAFKA_BOOTSTRAP_SERVERS")
 	kafkaClientID = os.Getenv("KAFKA_CLIENT_ID")
 	kafkaUsersTopic = os.Getenv("KAFKA_USERS_TOPIC")
@@ -58,6 +117,7 @@ func Load(workDir string) error {
 	kafkaHostTopic = fmt.Sprintf("%s_MESSAGES", hostID)
 	kafkaGroupID = os.Getenv("KAFKA_GROUP_ID")
 	kafkaEventsTopic = os.Getenv("KAFKA_EVENTS_TOPIC")
+	kafkaTokensTopic = os.Getenv("KAFKA_TOKENS_TOPIC")
 
 	return nil
 }
@@ -74,8 +134,12 @@ func KeySecureFile() string {
 	return keySecureFile
 }
 
-func PubSecureFile() string {
-	return pubSecureFile
+func JWKSURLs() []string {
+	return jwksURLs
+}
+
+func JWKSCacheTTL() int {
+	return jwksCacheTTL
 }
 
 func CertSecureFile() string {
@@ -86,6 +150,44 @@ func ServerPort() int {
 	return serverPort
 }
 
+// MetricsPort is the port of the internal listener of the Prometheus metrics,
+// 0 disables it.
+func MetricsPort() int {
+	return metricsPort
+}
+
+func MaxFrameSize() int {
+	return maxFrameSize
+}
+
+func MaxTextContentSize() int {
+	return maxTextContentSize
+}
+
+func MaxMediaContentSize() int {
+	return maxMediaContentSize
+}
+
+func MaxStatusContentSize() int {
+	return maxStatusContentSize
+}
+
+func TokenCheckInterval() time.Duration {
+	return time.Duration(tokenCheckInterval) * time.Second
+}
+
+func AdminUsers() []string {
+	return adminUsers
+}
+
+func RedisHost() string {
+	return redisHost
+}
+
+func RedisPassword() string {
+	return redisPassword
+}
+
 func KafkaBootstrapServers() string {
 	return kafkaBootstrapServers
 }
@@ -121,3 +223,7 @@ func KafkaGroupID() string {
 func KafkaEventsTopic() string {
 	return kafkaEventsTopic
 }
+
+func KafkaTokensTopic() string {
+	return kafkaTokensTopic
+}
@@ -1,15 +1,21 @@
-package main
+// Package di wires the dependencies of chat-service. It is used by cmd/chat and by the
+// all-in-one mode, which replaces Apache Kafka and Redis with in-memory implementations.
+package di
 
 import (
 	"context"
+	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/tsmweb/chat-server/pkg/jwks"
+	"github.com/tsmweb/chat-server/pkg/revocation"
 	"github.com/tsmweb/chat-service/adapter"
 	"github.com/tsmweb/chat-service/common/service"
 	"github.com/tsmweb/chat-service/config"
 	"github.com/tsmweb/chat-service/pkg/epoll"
 	"github.com/tsmweb/chat-service/server"
 	"github.com/tsmweb/chat-service/server/message"
+	"github.com/tsmweb/chat-service/server/token"
 	"github.com/tsmweb/chat-service/server/user"
 	"github.com/tsmweb/chat-service/web/api"
 	"github.com/tsmweb/easygo/netpoll"
@@ -18,20 +24,34 @@ import (
 	"github.com/tsmweb/go-helper-api/middleware"
 )
 
+// Provider creates the dependencies of chat-service on demand.
 type Provider struct {
-	ctx    context.Context
-	server *server.Server
-	jwt    auth.JWT
-	mAuth  middleware.Auth
-	kafka  kafka.Kafka
+	ctx             context.Context
+	server          *server.Server
+	jwt             auth.JWT
+	mAuth           middleware.Auth
+	revocationStore revocation.Store
+	kafka           kafka.Kafka
 }
 
+// CreateProvider creates a provider that connects to Apache Kafka and Redis.
 func CreateProvider(ctx context.Context) *Provider {
 	return &Provider{
 		ctx: ctx,
 	}
 }
 
+// CreateProviderWith creates a provider that uses k and revocationStore instead of
+// Apache Kafka and Redis. A nil value falls back to the default dependency.
+func CreateProviderWith(ctx context.Context, k kafka.Kafka,
+	revocationStore revocation.Store) *Provider {
+	return &Provider{
+		ctx:             ctx,
+		kafka:           k,
+		revocationStore: revocationStore,
+	}
+}
+
 func (p *Provider) ServerProvider() (*server.Server, error) {
 	if p.server == nil {
 		poll, err := p.EpollProvider()
@@ -39,15 +59,21 @@ func (p *Provider) ServerProvider() (*server.Server, error) {
 			return nil, err
 		}
 
-		connReader := server.ConnReaderFunc(adapter.ReaderWS)
+		connReader := adapter.NewReaderWS(int64(config.MaxFrameSize()))
 		connWriter := server.ConnWriterFunc(adapter.WriterWS)
+		connCloser := server.ConnCloserFunc(adapter.CloserWS)
 
 		messageDecoder := message.DecoderFunc(adapter.MessageUnmarshal)
+		revocationDecoder := token.DecoderFunc(adapter.RevocationUnmarshal)
+		tokenValidator := token.NewRevocationValidator(token.NewValidator(p.JwtProvider()),
+			p.RevocationProvider())
 		messageEncoder := message.EncoderFunc(adapter.MessageMarshal)
 		userEncoder := user.EncoderFunc(adapter.UserMarshal)
 
 		messageConsumer := p.KafkaProvider().NewConsumer(config.KafkaClientID(),
 			config.KafkaHostTopic())
+		revocationConsumer := p.KafkaProvider().NewConsumer(config.KafkaClientID(),
+			config.KafkaTokensTopic())
 		messageProducer := p.KafkaProvider().NewProducer(config.KafkaNewMessagesTopic())
 		offMessageProducer := p.KafkaProvider().NewProducer(config.KafkaOffMessagesTopic())
 		userProducer := p.KafkaProvider().NewProducer(config.KafkaUsersTopic())
@@ -63,8 +89,12 @@ func (p *Provider) ServerProvider() (*server.Server, error) {
 			poll,
 			connReader,
 			connWriter,
+			connCloser,
 			messageDecoder,
+			revocationDecoder,
+			tokenValidator,
 			messageConsumer,
+			revocationConsumer,
 			handleMessage,
 			handleOffMessage,
 			handleUserStatus,
@@ -105,18 +135,27 @@ func (p *Provider) KafkaProvider() kafka.Kafka {
 
 func (p *Provider) JwtProvider() auth.JWT {
 	if p.jwt == nil {
-		p.jwt = auth.NewJWT(config.KeySecureFile(), config.PubSecureFile())
+		ttl := time.Duration(config.JWKSCacheTTL()) * time.Second
+		p.jwt = jwks.NewJWT(jwks.NewCache(config.JWKSURLs(), ttl))
 	}
 	return p.jwt
 }
 
 func (p *Provider) AuthProvider() middleware.Auth {
 	if p.mAuth == nil {
-		p.mAuth = middleware.NewAuth(p.JwtProvider())
+		mAuth := middleware.NewAuth(p.JwtProvider())
+		p.mAuth = revocation.NewAuth(mAuth, p.JwtProvider(), p.RevocationProvider())
 	}
 	return p.mAuth
 }
 
+func (p *Provider) RevocationProvider() revocation.Store {
+	if p.revocationStore == nil {
+		p.revocationStore = revocation.NewRedisStore(config.RedisHost(), config.RedisPassword())
+	}
+	return p.revocationStore
+}
+
 func (p *Provider) ChatRouter(mr *mux.Router) error {
 	serv, err := p.ServerProvider()
 	if err != nil {
@@ -132,3 +171,39 @@ func (p *Provider) ChatRouter(mr *mux.Router) error {
 
 	return nil
 }
+
+func (p *Provider) AdminRouter(mr *mux.Router) error {
+	serv, err := p.ServerProvider()
+	if err != nil {
+		return err
+	}
+
+	api.MakeAdminRouter(
+		mr,
+		p.JwtProvider(),
+		p.AuthProvider(),
+		serv,
+	)
+
+	return nil
+}
+
+func (p *Provider) MessageRouter(mr *mux.Router) error {
+	serv, err := p.ServerProvider()
+	if err != nil {
+		return err
+	}
+
+	api.MakeMessageRouter(
+		mr,
+		p.JwtProvider(),
+		p.AuthProvider(),
+		serv,
+	)
+
+	return nil
+}
+
+func (p *Provider) MetricsRouter(mr *mux.Router) {
+	api.MakeMetricsRouter(mr)
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"net"
+	"sync/atomic"
+	"time"
+)
+
+// ConnInfo represents the data of a user's connection, such as the remote address,
+// the connection date and the bytes received and sent.
+type ConnInfo struct {
+	UserID      string
+	RemoteAddr  string
+	ConnectedAt time.Time
+	BytesIn     int64
+	BytesOut    int64
+}
+
+// connStats counts the bytes received and sent over a connection.
+type connStats struct {
+	bytesIn  atomic.Int64
+	bytesOut atomic.Int64
+}
+
+// statsConn is a net.Conn that updates the connStats on each read and write.
+type statsConn struct {
+	net.Conn
+	stats *connStats
+}
+
+// Read reads data from the connection, counting the bytes received.
+func (c *statsConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	c.stats.bytesIn.Add(int64(n))
+	return n, err
+}
+
+// Write writes data to the connection, counting the bytes sent.
+func (c *statsConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	c.stats.bytesOut.Add(int64(n))
+	return n, err
+}
@@ -17,7 +17,7 @@ const (
 
 // ContentType represents the type of message content,
 // such as ContentTypeACK, ContentTypeText, ContentTypeMedia, ContentTypeStatus,
-// ContentTypeInfo and ContentTypeError.
+// ContentTypeInfo, ContentTypeError and ContentTypeToken.
 type ContentType int
 
 const (
@@ -27,6 +27,7 @@ const (
 	ContentTypeStatus ContentType = 0x8
 	ContentTypeInfo   ContentType = 0x10
 	ContentTypeError  ContentType = 0x20
+	ContentTypeToken  ContentType = 0x40
 )
 
 func (ct ContentType) String() (str string) {
@@ -56,17 +57,39 @@ func (ct ContentType) String() (str string) {
 	if name(ContentTypeError, "error") {
 		return
 	}
+	if name(ContentTypeToken, "token") {
+		return
+	}
 
 	return
 }
 
+// contentTypes are the content types by name.
+var contentTypes = map[string]ContentType{
+	ContentTypeACK.String():    ContentTypeACK,
+	ContentTypeText.String():   ContentTypeText,
+	ContentTypeMedia.String():  ContentTypeMedia,
+	ContentTypeStatus.String(): ContentTypeStatus,
+	ContentTypeInfo.String():   ContentTypeInfo,
+	ContentTypeError.String():  ContentTypeError,
+	ContentTypeToken.String():  ContentTypeToken,
+}
+
+// ParseContentType returns the ContentType of the name, or false if the name is unknown.
+func ParseContentType(name string) (ContentType, bool) {
+	ct, ok := contentTypes[name]
+	return ct, ok
+}
+
 var (
 	ErrIDValidateModel          = &cerror.ErrValidateModel{Msg: "required id"}
 	ErrFromValidateModel        = &cerror.ErrValidateModel{Msg: "required from"}
 	ErrReceiverValidateModel    = &cerror.ErrValidateModel{Msg: "required to or group"}
 	ErrDateValidateModel        = &cerror.ErrValidateModel{Msg: "required date"}
 	ErrContentTypeValidateModel = &cerror.ErrValidateModel{Msg: "required content_type"}
+	ErrContentTypeUnknownModel  = &cerror.ErrValidateModel{Msg: "unknown content_type"}
 	ErrContentValidateModel     = &cerror.ErrValidateModel{Msg: "required content"}
+	ErrContentSizeValidateModel = &cerror.ErrValidateModel{Msg: "content too large"}
 )
 
 // Message represents data sent and received by users.
@@ -78,6 +101,10 @@ type Message struct {
 	Date        time.Time `json:"date"`
 	ContentType string    `json:"content_type"`
 	Content     string    `json:"content"`
+
+	// Callback is the URL informed by the sender to be notified of the delivery errors,
+	// instead of receiving them as messages. It is not exposed to the users.
+	Callback string `json:"-"`
 }
 
 // NewResponse creates and returns a new Message instance.
@@ -128,7 +155,8 @@ func (m *Message) GenerateID() {
 	}
 }
 
-// Validate verifies that the required attributes of the message are present.
+// Validate verifies that the required attributes of the message are present and that the
+// content type is known.
 func (m *Message) Validate() error {
 	if strings.TrimSpace(m.From) == "" {
 		return ErrFromValidateModel
@@ -142,12 +170,24 @@ func (m *Message) Validate() error {
 	if strings.TrimSpace(m.ContentType) == "" {
 		return ErrContentTypeValidateModel
 	}
+	if _, ok := ParseContentType(m.ContentType); !ok {
+		return ErrContentTypeUnknownModel
+	}
 	if strings.TrimSpace(m.Content) == "" {
 		return ErrContentValidateModel
 	}
 	return nil
 }
 
+// ValidateContentSize verifies that the content does not exceed maxSize bytes.
+// A maxSize less than or equal to zero means there is no limit.
+func (m *Message) ValidateContentSize(maxSize int) error {
+	if maxSize > 0 && len(m.Content) > maxSize {
+		return ErrContentSizeValidateModel
+	}
+	return nil
+}
+
 // IsGroupMessage returns true if the message is addressed to a group of users.
 func (m *Message) IsGroupMessage() bool {
 	return strings.TrimSpace(m.Group) != ""
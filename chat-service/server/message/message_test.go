@@ -71,3 +71,27 @@ func TestMessage_Validate(t *testing.T) {
 		assert.Equal(t, err, tc.want)
 	}
 }
+
+func TestMessage_ValidateContentSize(t *testing.T) {
+	//t.Parallel()
+	m, _ := NewMessage("+5518977777777", "+5518966666666", "", ContentTypeText,
+		"test")
+
+	assert.Nil(t, m.ValidateContentSize(0))
+	assert.Nil(t, m.ValidateContentSize(4))
+	assert.Equal(t, ErrContentSizeValidateModel, m.ValidateContentSize(3))
+}
+
+func TestMessage_ValidateContentType(t *testing.T) {
+	//t.Parallel()
+	m, _ := NewMessage("+5518977777777", "+5518966666666", "", ContentTypeText,
+		"test")
+
+	m.ContentType = "x"
+	assert.Equal(t, ErrContentTypeUnknownModel, m.Validate())
+
+	for _, ct := range []ContentType{ContentTypeText, ContentTypeMedia, ContentTypeStatus} {
+		m.ContentType = ct.String()
+		assert.Nil(t, m.Validate())
+	}
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"time"
 
 	"github.com/tsmweb/chat-service/server/message"
 	"github.com/tsmweb/go-helper-api/kafka"
@@ -39,7 +40,15 @@ func (h *handleMessage) Execute(ctx context.Context, msg *message.Message) error
 		return err
 	}
 
-	if err = h.producer.Publish(ctx, []byte(msg.ID), mpb); err != nil {
+	start := time.Now()
+	err = h.producer.Publish(ctx, []byte(msg.ID), mpb)
+	status := publishStatusOK
+	if err != nil {
+		status = publishStatusError
+	}
+	kafkaPublishSeconds.WithLabelValues(status).Observe(time.Since(start).Seconds())
+
+	if err != nil {
 		return err
 	}
 
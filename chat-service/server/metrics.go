@@ -0,0 +1,100 @@
+package server
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/tsmweb/chat-service/server/message"
+)
+
+const (
+	rejectReasonMessageSize = "message_size"
+	rejectReasonContentSize = "content_size"
+	rejectReasonScope       = "scope"
+)
+
+// contentTypeOther labels the messages of unknown content types, which are sent by the
+// users, so they do not grow the number of series.
+const contentTypeOther = "other"
+
+const (
+	directionIn  = "in"
+	directionOut = "out"
+)
+
+const (
+	publishStatusOK    = "ok"
+	publishStatusError = "error"
+)
+
+var (
+	rejectedMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "chat",
+		Name:      "rejected_messages_total",
+		Help:      "Total number of messages rejected for exceeding the size limits or the token scope.",
+	}, []string{"reason", "content_type"})
+
+	activeConnections = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "chat",
+		Name:      "active_connections",
+		Help:      "Number of users connected to the server.",
+	})
+
+	connects = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "chat",
+		Name:      "connects_total",
+		Help:      "Total number of user connections registered on the server.",
+	})
+
+	disconnects = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "chat",
+		Name:      "disconnects_total",
+		Help:      "Total number of user connections released by the server.",
+	})
+
+	messages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "chat",
+		Name:      "messages_total",
+		Help:      "Total number of messages received from (in) and written to (out) the users.",
+	}, []string{"direction", "content_type"})
+
+	responses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "chat",
+		Name:      "responses_total",
+		Help:      "Total number of ACK and error responses written to the users.",
+	}, []string{"content_type"})
+
+	poolQueueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "chat",
+		Name:      "pool_queue_depth",
+		Help:      "Number of tasks scheduled on the goroutine pool waiting for a worker.",
+	}, []string{"pool"})
+
+	poolWaitSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "chat",
+		Name:      "pool_wait_seconds",
+		Help:      "Time a task waits on the goroutine pool before being executed.",
+		Buckets:   []float64{.0001, .0005, .001, .005, .01, .05, .1, .5, 1, 5},
+	}, []string{"pool"})
+
+	kafkaPublishSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "chat",
+		Name:      "kafka_publish_seconds",
+		Help:      "Time taken to publish a message on Apache Kafka.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"status"})
+
+	epollCallbackSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "chat",
+		Name:      "epoll_callback_seconds",
+		Help:      "Time taken by the epoll callback that handles a readable connection.",
+		Buckets:   []float64{.00001, .00005, .0001, .0005, .001, .005, .01, .05, .1},
+	})
+)
+
+// contentTypeLabel returns the label of the content type, contentTypeOther if it is unknown.
+func contentTypeLabel(contentType string) string {
+	if _, ok := message.ParseContentType(contentType); !ok {
+		return contentTypeOther
+	}
+	return contentType
+}
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/tsmweb/go-helper-api/concurrent/gopool"
+)
+
+// pool is a gopool.Pool that measures the queue depth and the time tasks wait for a worker.
+type pool struct {
+	*gopool.Pool
+	name string
+}
+
+func newPool(name string, size, queue int) *pool {
+	return &pool{
+		Pool: gopool.New(size, queue),
+		name: name,
+	}
+}
+
+// Schedule schedules the task to be executed on the pool's workers.
+func (p *pool) Schedule(task func(ctx context.Context)) error {
+	scheduledAt := time.Now()
+	poolQueueDepth.WithLabelValues(p.name).Inc()
+
+	err := p.Pool.Schedule(func(ctx context.Context) {
+		poolQueueDepth.WithLabelValues(p.name).Dec()
+		poolWaitSeconds.WithLabelValues(p.name).Observe(time.Since(scheduledAt).Seconds())
+		task(ctx)
+	})
+	if err != nil {
+		poolQueueDepth.WithLabelValues(p.name).Dec()
+	}
+	return err
+}
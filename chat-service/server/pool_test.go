@@ -0,0 +1,38 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPool_Schedule(t *testing.T) {
+	//t.Parallel()
+
+	t.Run("when tasks are executed", func(t *testing.T) {
+		//t.Parallel()
+		p := newPool("test", 2, 1)
+		defer p.Close()
+
+		release := make(chan struct{})
+		var wg sync.WaitGroup
+		wg.Add(3)
+
+		for i := 0; i < 3; i++ {
+			go func() {
+				assert.Nil(t, p.Schedule(func(ctx context.Context) {
+					defer wg.Done()
+					<-release
+				}))
+			}()
+		}
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, float64(0), testutil.ToFloat64(poolQueueDepth.WithLabelValues("test")))
+		assert.Equal(t, 1, testutil.CollectAndCount(poolWaitSeconds))
+	})
+}
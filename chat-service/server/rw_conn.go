@@ -1,10 +1,15 @@
 package server
 
 import (
+	"errors"
 	"io"
 	"net"
 )
 
+// ErrMessageTooLarge is returned by a ConnReader when the message exceeds the maximum size
+// accepted by the server.
+var ErrMessageTooLarge = errors.New("message too large")
+
 // ConnReader is a net.Conn reader.
 type ConnReader interface {
 	Reader(conn net.Conn) (io.Reader, error)
@@ -34,3 +39,76 @@ type ConnWriterFunc func(conn net.Conn, data interface{}) error
 func (f ConnWriterFunc) Writer(conn net.Conn, data interface{}) error {
 	return f(conn, data)
 }
+
+// CloseReason represents the reason why the server closes the user's connection,
+// such as CloseTokenExpired, CloseTokenRevoked, CloseKicked, CloseMaintenance and CloseAbuse.
+type CloseReason int
+
+const (
+	CloseTokenExpired CloseReason = 0x1
+	CloseTokenRevoked CloseReason = 0x2
+	CloseKicked       CloseReason = 0x4
+	CloseMaintenance  CloseReason = 0x8
+	CloseAbuse        CloseReason = 0x10
+)
+
+var closeReasons = []CloseReason{
+	CloseTokenExpired,
+	CloseTokenRevoked,
+	CloseKicked,
+	CloseMaintenance,
+	CloseAbuse,
+}
+
+// ParseCloseReason returns the CloseReason with the given name, or zero if there is none.
+func ParseCloseReason(name string) CloseReason {
+	for _, reason := range closeReasons {
+		if reason.String() == name {
+			return reason
+		}
+	}
+	return 0
+}
+
+func (cr CloseReason) String() (str string) {
+	name := func(closeReason CloseReason, name string) bool {
+		if cr&closeReason == 0 {
+			return false
+		}
+		str = name
+		return true
+	}
+
+	if name(CloseTokenExpired, "token expired") {
+		return
+	}
+	if name(CloseTokenRevoked, "token revoked") {
+		return
+	}
+	if name(CloseKicked, "kicked") {
+		return
+	}
+	if name(CloseMaintenance, "maintenance") {
+		return
+	}
+	if name(CloseAbuse, "abuse") {
+		return
+	}
+
+	return
+}
+
+// ConnCloser is a net.Conn closer.
+type ConnCloser interface {
+	Closer(conn net.Conn, reason CloseReason) error
+}
+
+// The ConnCloserFunc type is an adapter to allow the use of ordinary functions as closers
+// of net.Conn.
+// If f is a function with the appropriate signature, ConnCloserFunc(f) is a ConnCloser that calls f.
+type ConnCloserFunc func(conn net.Conn, reason CloseReason) error
+
+// Closer calls f(conn, reason).
+func (f ConnCloserFunc) Closer(conn net.Conn, reason CloseReason) error {
+	return f(conn, reason)
+}
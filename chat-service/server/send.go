@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/tsmweb/chat-server/pkg/scope"
+	"github.com/tsmweb/chat-service/server/message"
+	"github.com/tsmweb/chat-service/server/token"
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+var (
+	// ErrContentTypeNotAllowed is returned by Send when the message is not a text or media message.
+	ErrContentTypeNotAllowed = &cerror.ErrValidateModel{Msg: "content_type must be text or media"}
+
+	// ErrCallbackValidateModel is returned by Send when the callback is not an HTTP URL.
+	ErrCallbackValidateModel = &cerror.ErrValidateModel{Msg: "callback must be an http or https URL"}
+)
+
+// Send sends a message of the token user received without a connection, such as over HTTP,
+// generating its ID. The message is validated as those received by the connections and
+// published to be delivered by the broker, so delivery errors are not returned; they are
+// POSTed by the broker to the msg.Callback URL, if informed, or sent to the user otherwise.
+func (s *Server) Send(ctx context.Context, t *token.Token, msg *message.Message) error {
+	msg.ID = ""
+	msg.From = t.UserID
+	if msg.Date.IsZero() {
+		msg.Date = time.Now().UTC()
+	}
+
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	if msg.ContentType != message.ContentTypeText.String() &&
+		msg.ContentType != message.ContentTypeMedia.String() {
+		return ErrContentTypeNotAllowed
+	}
+	if msg.Callback != "" && !isHTTPURL(msg.Callback) {
+		return ErrCallbackValidateModel
+	}
+	if err := msg.ValidateContentSize(s.maxContentSize[msg.ContentType]); err != nil {
+		rejectedMessages.WithLabelValues(rejectReasonContentSize, contentTypeLabel(msg.ContentType)).Inc()
+		return err
+	}
+	if !t.Scopes.AllowsMessage(msg.To, msg.Group) {
+		rejectedMessages.WithLabelValues(rejectReasonScope, contentTypeLabel(msg.ContentType)).Inc()
+		return scope.ErrInsufficientScope
+	}
+
+	msg.GenerateID()
+	messages.WithLabelValues(directionIn, contentTypeLabel(msg.ContentType)).Inc()
+
+	return s.handleMessage.Execute(ctx, msg)
+}
+
+// isHTTPURL checks the form of the callback only, the addresses are checked by broker-service
+// when dialing, after the host name is resolved.
+func isHTTPURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsmweb/chat-server/pkg/scope"
+	"github.com/tsmweb/chat-service/server/message"
+	"github.com/tsmweb/chat-service/server/token"
+)
+
+func TestServer_Send(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+	tk := &token.Token{UserID: "svc_A1B2C3", Scopes: scope.Parse(scope.Group("G1"))}
+
+	newServer := func(err error) (*Server, *fakeHandleMessage) {
+		h := &fakeHandleMessage{err: err}
+		return &Server{
+			handleMessage:  h,
+			maxContentSize: map[string]int{message.ContentTypeText.String(): 10},
+		}, h
+	}
+
+	tests := []struct {
+		name string
+		msg  *message.Message
+		want error
+	}{
+		{"when message has no content",
+			&message.Message{Group: "G1", ContentType: message.ContentTypeText.String()},
+			message.ErrContentValidateModel},
+		{"when content type is not allowed",
+			&message.Message{Group: "G1", ContentType: message.ContentTypeStatus.String(),
+				Content: "typing"},
+			ErrContentTypeNotAllowed},
+		{"when callback is not an HTTP URL",
+			&message.Message{Group: "G1", ContentType: message.ContentTypeText.String(),
+				Content: "hello", Callback: "ftp://alerts.example.com"},
+			ErrCallbackValidateModel},
+		{"when content is too large",
+			&message.Message{Group: "G1", ContentType: message.ContentTypeText.String(),
+				Content: "hello world!"},
+			message.ErrContentSizeValidateModel},
+		{"when scope does not allow the group",
+			&message.Message{Group: "G2", ContentType: message.ContentTypeText.String(),
+				Content: "hello"},
+			scope.ErrInsufficientScope},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//t.Parallel()
+			s, h := newServer(nil)
+
+			err := s.Send(ctx, tk, tc.msg)
+			assert.Equal(t, tc.want, err)
+			assert.Nil(t, h.msg)
+		})
+	}
+
+	t.Run("when publishing fails", func(t *testing.T) {
+		//t.Parallel()
+		s, _ := newServer(errors.New("error"))
+
+		err := s.Send(ctx, tk, &message.Message{Group: "G1",
+			ContentType: message.ContentTypeText.String(), Content: "hello"})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when message is sent", func(t *testing.T) {
+		//t.Parallel()
+		s, h := newServer(nil)
+		msg := &message.Message{ID: "client-id", From: "+5518977777777", Group: "G1",
+			ContentType: message.ContentTypeText.String(), Content: "hello",
+			Callback: "https://alerts.example.com/errors"}
+
+		err := s.Send(ctx, tk, msg)
+		assert.Nil(t, err)
+		assert.Equal(t, msg, h.msg)
+		assert.Equal(t, tk.UserID, msg.From)
+		assert.NotEqual(t, "client-id", msg.ID)
+		assert.NotEmpty(t, msg.ID)
+		assert.False(t, msg.Date.IsZero())
+		assert.Equal(t, "https://alerts.example.com/errors", h.msg.Callback)
+	})
+}
+
+// fakeHandleMessage records the message published.
+type fakeHandleMessage struct {
+	err error
+	msg *message.Message
+}
+
+func (f *fakeHandleMessage) Execute(ctx context.Context, msg *message.Message) error {
+	f.msg = msg
+	return f.err
+}
+
+func (f *fakeHandleMessage) Close() {}
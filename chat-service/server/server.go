@@ -3,19 +3,24 @@ package server
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"log"
 	"net"
+	"time"
 
 	"github.com/tsmweb/chat-service/common/service"
 	"github.com/tsmweb/chat-service/config"
 	"github.com/tsmweb/chat-service/pkg/epoll"
 	"github.com/tsmweb/chat-service/server/message"
+	"github.com/tsmweb/chat-service/server/token"
 	"github.com/tsmweb/chat-service/server/user"
-	"github.com/tsmweb/go-helper-api/concurrent/gopool"
 	"github.com/tsmweb/go-helper-api/kafka"
 )
 
+// ErrUserNotConnected is returned when the user has no connection on the server.
+var ErrUserNotConnected = errors.New("user not connected")
+
 // Server registers the user's net.Conn connection and handles the data received and sent over
 // the connection.
 // It also produces and consumes Apache Kafka data to communicate with the cluster of services.
@@ -23,17 +28,24 @@ type Server struct {
 	tag              string
 	ctx              context.Context
 	poller           epoll.EPoll
-	poolUsers        *gopool.Pool
-	poolSendMessages *gopool.Pool
-	poolRecvMessages *gopool.Pool
-
-	chUserIN       chan *UserConn
-	chUserOUT      chan string
-	chRecvMessage  chan message.Message
-	connReader     ConnReader
-	connWriter     ConnWriter
-	msgDecoder     message.Decoder
-	consumeMessage kafka.Consumer
+	poolUsers        *pool
+	poolSendMessages *pool
+	poolRecvMessages *pool
+
+	chUserIN          chan *UserConn
+	chUserOUT         chan string
+	chRecvMessage     chan message.Message
+	chRevocation      chan token.Revocation
+	chInspect         chan func(users map[string]*UserConn)
+	connReader        ConnReader
+	connWriter        ConnWriter
+	connCloser        ConnCloser
+	msgDecoder        message.Decoder
+	revocationDecoder token.Decoder
+	tokenValidator    token.Validator
+	consumeMessage    kafka.Consumer
+	consumeRevocation kafka.Consumer
+	maxContentSize    map[string]int
 
 	handleMessage    HandleMessage
 	handleOffMessage HandleMessage
@@ -46,26 +58,44 @@ func NewServer(
 	poll epoll.EPoll,
 	connReader ConnReader,
 	connWriter ConnWriter,
+	connCloser ConnCloser,
 	msgDecoder message.Decoder,
+	revocationDecoder token.Decoder,
+	tokenValidator token.Validator,
 	consumeMessage kafka.Consumer,
+	consumeRevocation kafka.Consumer,
 	handleMessage HandleMessage,
 	handleOffMessage HandleMessage,
 	handleUserStatus HandleUserStatus,
 ) *Server {
+	// Maximum content size in bytes by content type.
+	maxContentSize := map[string]int{
+		message.ContentTypeText.String():   config.MaxTextContentSize(),
+		message.ContentTypeMedia.String():  config.MaxMediaContentSize(),
+		message.ContentTypeStatus.String(): config.MaxStatusContentSize(),
+	}
+
 	server := &Server{
-		tag:              "server::Server",
-		ctx:              ctx,
-		poller:           poll,
-		chUserIN:         make(chan *UserConn),
-		chUserOUT:        make(chan string),
-		chRecvMessage:    make(chan message.Message),
-		connReader:       connReader,
-		connWriter:       connWriter,
-		msgDecoder:       msgDecoder,
-		consumeMessage:   consumeMessage,
-		handleMessage:    handleMessage,
-		handleOffMessage: handleOffMessage,
-		handleUserStatus: handleUserStatus,
+		tag:               "server::Server",
+		ctx:               ctx,
+		poller:            poll,
+		chUserIN:          make(chan *UserConn),
+		chUserOUT:         make(chan string),
+		chRecvMessage:     make(chan message.Message),
+		chRevocation:      make(chan token.Revocation),
+		chInspect:         make(chan func(users map[string]*UserConn)),
+		connReader:        connReader,
+		connWriter:        connWriter,
+		connCloser:        connCloser,
+		msgDecoder:        msgDecoder,
+		revocationDecoder: revocationDecoder,
+		tokenValidator:    tokenValidator,
+		consumeMessage:    consumeMessage,
+		consumeRevocation: consumeRevocation,
+		maxContentSize:    maxContentSize,
+		handleMessage:     handleMessage,
+		handleOffMessage:  handleOffMessage,
+		handleUserStatus:  handleUserStatus,
 	}
 
 	server.run()
@@ -74,14 +104,19 @@ func NewServer(
 }
 
 // Register registers the user's net.Conn connection and handles the data received and sent over
-// the connection.
-func (s *Server) Register(userID string, conn net.Conn) error {
+// the connection. The connection is closed when the access token t expires or is revoked.
+func (s *Server) Register(t *token.Token, conn net.Conn) error {
 	userConn := &UserConn{
-		userID: userID,
-		conn:   conn,
-		reader: s.connReader,
-		writer: s.connWriter,
+		userID:         t.UserID,
+		connectedAt:    time.Now().UTC(),
+		reader:         s.connReader,
+		writer:         s.connWriter,
+		closer:         s.connCloser,
+		maxContentSize: s.maxContentSize,
+		token:          t,
+		tokenValidator: s.tokenValidator,
 	}
+	userConn.conn = &statsConn{Conn: conn, stats: &userConn.stats}
 
 	var fdConn net.Conn
 
@@ -99,10 +134,18 @@ func (s *Server) Register(userID string, conn net.Conn) error {
 		return err
 	}
 
+	userConn.onRelease = func() {
+		observer.Stop()
+		s.chUserOUT <- userConn.userID
+	}
+
 	err = observer.Start(func(closed bool, errPoller error) {
+		defer func(start time.Time) {
+			epollCallbackSeconds.Observe(time.Since(start).Seconds())
+		}(time.Now())
+
 		if closed || errPoller != nil {
-			observer.Stop()
-			s.chUserOUT <- userConn.userID
+			userConn.release()
 			if errPoller != nil {
 				service.Error(userConn.userID, s.tag,
 					fmt.Errorf("epoll::Observer: %s", errPoller.Error()))
@@ -113,8 +156,7 @@ func (s *Server) Register(userID string, conn net.Conn) error {
 		s.poolSendMessages.Schedule(func(ctx context.Context) {
 			msg, err := userConn.Receive() // receive message from userConn connection
 			if err != nil {
-				observer.Stop()
-				s.chUserOUT <- userConn.userID
+				userConn.release()
 				return
 			}
 			if msg != nil {
@@ -153,18 +195,86 @@ func (s *Server) Register(userID string, conn net.Conn) error {
 	return nil
 }
 
+// Connections returns the data of all users connected to the server.
+func (s *Server) Connections(ctx context.Context) ([]*ConnInfo, error) {
+	var infos []*ConnInfo
+	err := s.inspect(ctx, func(users map[string]*UserConn) {
+		infos = make([]*ConnInfo, 0, len(users))
+		for _, userConn := range users {
+			infos = append(infos, userConn.Info())
+		}
+	})
+	return infos, err
+}
+
+// Connection returns the data of the user's connection, or ErrUserNotConnected if the user
+// is not connected to the server.
+func (s *Server) Connection(ctx context.Context, userID string) (*ConnInfo, error) {
+	var info *ConnInfo
+	err := s.inspect(ctx, func(users map[string]*UserConn) {
+		if userConn := users[userID]; userConn != nil {
+			info = userConn.Info()
+		}
+	})
+	if err == nil && info == nil {
+		err = ErrUserNotConnected
+	}
+	return info, err
+}
+
+// Disconnect closes the user's connection informing the reason, or returns ErrUserNotConnected
+// if the user is not connected to the server.
+func (s *Server) Disconnect(ctx context.Context, userID string, reason CloseReason) error {
+	var userConn *UserConn
+	err := s.inspect(ctx, func(users map[string]*UserConn) {
+		userConn = users[userID]
+	})
+	if err != nil {
+		return err
+	}
+	if userConn == nil {
+		return ErrUserNotConnected
+	}
+
+	// Scheduled after inspect returns, since scheduling may block waiting for a free worker.
+	s.closeConnTask(userConn, reason)
+	return nil
+}
+
+// inspect runs fn on the goroutine that owns the connected users,
+// so fn must not block.
+func (s *Server) inspect(ctx context.Context, fn func(users map[string]*UserConn)) error {
+	done := make(chan struct{})
+	task := func(users map[string]*UserConn) {
+		fn(users)
+		close(done)
+	}
+
+	select {
+	case s.chInspect <- task:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+
+	<-done
+	return nil
+}
+
 func (s *Server) run() {
 	// Executor to perform background processing,
 	// limiting resource consumption when executing a collection of jobs.
 	workerSize := config.GoPoolSize()
 	queueSize := 1
 
-	s.poolUsers = gopool.New(workerSize, queueSize)
-	s.poolSendMessages = gopool.New(workerSize, queueSize)
-	s.poolRecvMessages = gopool.New(workerSize, queueSize)
+	s.poolUsers = newPool("users", workerSize, queueSize)
+	s.poolSendMessages = newPool("send_messages", workerSize, queueSize)
+	s.poolRecvMessages = newPool("recv_messages", workerSize, queueSize)
 
 	go s.messageProcessor()
 	go s.messageConsumer()
+	go s.revocationConsumer()
 }
 
 func (s *Server) stop() {
@@ -180,6 +290,10 @@ func (s *Server) stop() {
 func (s *Server) messageProcessor() {
 	users := make(map[string]*UserConn) // all connected users
 
+	// checks periodically for connections with expired tokens
+	tokenTicker := time.NewTicker(config.TokenCheckInterval())
+	defer tokenTicker.Stop()
+
 loop:
 	for {
 		select {
@@ -189,12 +303,33 @@ loop:
 
 		case u := <-s.chUserIN:
 			users[u.userID] = u
+			connects.Inc()
+			activeConnections.Set(float64(len(users)))
 			s.userStatusTask(u.userID, user.Online)
 
 		case userID := <-s.chUserOUT:
 			delete(users, userID)
+			disconnects.Inc()
+			activeConnections.Set(float64(len(users)))
 			s.userStatusTask(userID, user.Offline)
 
+		case r := <-s.chRevocation:
+			if userConn := users[r.UserID]; userConn != nil && userConn.Token().IsRevokedBy(&r) {
+				s.closeConnsTask([]*UserConn{userConn}, CloseTokenRevoked)
+			}
+
+		case fn := <-s.chInspect:
+			fn(users)
+
+		case now := <-tokenTicker.C:
+			var expired []*UserConn
+			for _, userConn := range users {
+				if userConn.Token().IsExpired(now) {
+					expired = append(expired, userConn)
+				}
+			}
+			s.closeConnsTask(expired, CloseTokenExpired)
+
 		case <-s.ctx.Done():
 			break loop
 		}
@@ -227,6 +362,30 @@ func (s *Server) messageConsumer() {
 	s.consumeMessage.Subscribe(s.ctx, callbackFn)
 }
 
+func (s *Server) revocationConsumer() {
+	defer func() {
+		s.consumeRevocation.Close()
+		log.Println("[STOP] Server::consumeRevocation")
+	}()
+
+	callbackFn := func(event *kafka.Event, err error) {
+		if err != nil && err.Error() != "nil" {
+			service.Error("", s.tag, fmt.Errorf("kafka::Consumer: %s", err.Error()))
+			return
+		}
+
+		var r token.Revocation
+		if err = s.revocationDecoder.Unmarshal(event.Value, &r); err != nil {
+			service.Error("", s.tag, fmt.Errorf("kafka::Consumer: %s", err.Error()))
+			return
+		}
+
+		s.chRevocation <- r
+	}
+
+	s.consumeRevocation.Subscribe(s.ctx, callbackFn)
+}
+
 func (s *Server) recvMessageTask(msg message.Message, userConn *UserConn) {
 	s.poolRecvMessages.Schedule(func(ctx context.Context) {
 		if userConn != nil {
@@ -252,6 +411,31 @@ func (s *Server) sendOffMessage(ctx context.Context, msg *message.Message) {
 	}
 }
 
+// closeConnsTask closes the connections from another goroutine, since scheduling may block
+// waiting for a free worker, and the workers closing connections wait for messageProcessor
+// to receive the users out.
+func (s *Server) closeConnsTask(userConns []*UserConn, reason CloseReason) {
+	if len(userConns) == 0 {
+		return
+	}
+	go func() {
+		for _, userConn := range userConns {
+			s.closeConnTask(userConn, reason)
+		}
+	}()
+}
+
+func (s *Server) closeConnTask(userConn *UserConn, reason CloseReason) {
+	s.poolUsers.Schedule(func(ctx context.Context) {
+		if err := userConn.Close(reason); err != nil {
+			service.Error(userConn.userID, s.tag,
+				fmt.Errorf("server::UserConn: %s", err.Error()))
+			return
+		}
+		service.Info(userConn.userID, s.tag, fmt.Sprintf("connection closed: %s", reason))
+	})
+}
+
 func (s *Server) userStatusTask(userID string, status user.Status) {
 	s.poolUsers.Schedule(func(ctx context.Context) {
 		if err := s.handleUserStatus.Execute(s.ctx, userID, status); err != nil {
@@ -0,0 +1,29 @@
+package token
+
+import "time"
+
+// Revocation represents the revocation of all tokens issued to the user before RevokedAt,
+// or only of the token with TokenID or of the session with SessionID when it is informed,
+// published by the auth service.
+type Revocation struct {
+	UserID    string
+	TokenID   string
+	SessionID string
+	Reason    string
+	RevokedAt time.Time
+}
+
+// Decoder is a byte slice decoder for Revocation.
+type Decoder interface {
+	Unmarshal(in []byte, r *Revocation) error
+}
+
+// The DecoderFunc type is an adapter to allow the use of ordinary functions as decoders
+// of byte slice for Revocation.
+// If f is a function with the appropriate signature, DecoderFunc(f) is a Decoder that calls f.
+type DecoderFunc func(in []byte, r *Revocation) error
+
+// Unmarshal calls f(in, r).
+func (f DecoderFunc) Unmarshal(in []byte, r *Revocation) error {
+	return f(in, r)
+}
@@ -0,0 +1,184 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/tsmweb/chat-server/pkg/revocation"
+	"github.com/tsmweb/chat-server/pkg/scope"
+	"github.com/tsmweb/go-helper-api/auth"
+)
+
+// ErrInvalidToken is returned when the token does not carry the expected data.
+var ErrInvalidToken = errors.New("invalid token")
+
+// Token represents the access token presented by the user.
+type Token struct {
+	ID        string
+	SessionID string
+	UserID    string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+	Scopes    scope.Set // nil if the token is not restricted by scope
+}
+
+// IsExpired returns true if the token is expired at the given date.
+func (t *Token) IsExpired(now time.Time) bool {
+	return !t.ExpiresAt.IsZero() && !now.Before(t.ExpiresAt)
+}
+
+// IsRevokedBy returns true if the token was issued until the date of the revocation, or
+// if it is the token revoked, or if it belongs to the session revoked. Tokens without issue
+// date are revoked by any revocation of the user that does not inform the token nor the
+// session.
+//
+// The date of the revocation has a precision of one second, so the tokens issued in the
+// second of the revocation are revoked by it, even if issued right after it. Their users
+// connect again, as the connections are checked against the revocations with the precision
+// of a millisecond by NewRevocationValidator.
+func (t *Token) IsRevokedBy(r *Revocation) bool {
+	if t.UserID != r.UserID {
+		return false
+	}
+	if r.TokenID != "" {
+		return t.ID == r.TokenID
+	}
+	if r.SessionID != "" {
+		return t.SessionID == r.SessionID
+	}
+	endOfSecond := r.RevokedAt.Truncate(time.Second).Add(time.Second - time.Millisecond)
+	return revocation.IsRevokedAt(t.IssuedAt, endOfSecond)
+}
+
+// FromRequest returns the data of the access token authorized in the request.
+func FromRequest(jwt auth.JWT, r *http.Request) (*Token, error) {
+	data, err := jwt.GetDataToken(r, "id")
+	if err != nil {
+		return nil, err
+	}
+	userID, ok := data.(string)
+	if !ok || userID == "" {
+		return nil, ErrInvalidToken
+	}
+
+	expiresAt, err := dataTime(jwt, r, "exp")
+	if err != nil {
+		return nil, err
+	}
+
+	// Tokens issued before revocation support do not carry the issue date nor the ID.
+	issuedAt, _ := dataTime(jwt, r, "iat")
+	data, _ = jwt.GetDataToken(r, "jti")
+	tokenID, _ := data.(string)
+
+	// Tokens issued before session support do not carry the session ID.
+	data, _ = jwt.GetDataToken(r, "sid")
+	sessionID, _ := data.(string)
+
+	// Only the tokens of the service accounts are restricted by scope.
+	scopes, err := scope.FromRequest(jwt, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Token{
+		ID:        tokenID,
+		SessionID: sessionID,
+		UserID:    userID,
+		IssuedAt:  issuedAt,
+		ExpiresAt: expiresAt,
+		Scopes:    scopes,
+	}, nil
+}
+
+func dataTime(jwt auth.JWT, r *http.Request, key string) (time.Time, error) {
+	data, err := jwt.GetDataToken(r, key)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// the issue dates have a fraction of second since revocation.IssuedAt.
+	var sec float64
+	switch v := data.(type) {
+	case nil:
+		return time.Time{}, nil
+	case float64:
+		sec = v
+	case int64:
+		sec = float64(v)
+	case json.Number:
+		if sec, err = v.Float64(); err != nil {
+			return time.Time{}, ErrInvalidToken
+		}
+	case string:
+		if sec, err = strconv.ParseFloat(v, 64); err != nil {
+			return time.Time{}, ErrInvalidToken
+		}
+	default:
+		return time.Time{}, ErrInvalidToken
+	}
+
+	return time.UnixMilli(int64(math.Round(sec * 1e3))).UTC(), nil
+}
+
+// Validator validates the access tokens sent by the user over the connection.
+type Validator interface {
+	Validate(accessToken string) (*Token, error)
+}
+
+// The ValidatorFunc type is an adapter to allow the use of ordinary functions as validators
+// of access tokens.
+// If f is a function with the appropriate signature, ValidatorFunc(f) is a Validator that calls f.
+type ValidatorFunc func(accessToken string) (*Token, error)
+
+// Validate calls f(accessToken).
+func (f ValidatorFunc) Validate(accessToken string) (*Token, error) {
+	return f(accessToken)
+}
+
+// NewValidator returns a Validator that checks the access token with jwt.
+func NewValidator(jwt auth.JWT) Validator {
+	return ValidatorFunc(func(accessToken string) (*Token, error) {
+		// auth.JWT reads the token from the request, as sent in the websocket upgrade.
+		r, err := http.NewRequest(http.MethodGet, "/", nil)
+		if err != nil {
+			return nil, err
+		}
+		r.Header.Set("Authorization", "Bearer "+accessToken)
+
+		if _, err = jwt.ExtractToken(r); err != nil {
+			return nil, err
+		}
+		return FromRequest(jwt, r)
+	})
+}
+
+// NewRevocationValidator returns a Validator that also rejects the access tokens revoked
+// according to checker.
+func NewRevocationValidator(v Validator, checker revocation.Checker) Validator {
+	return ValidatorFunc(func(accessToken string) (*Token, error) {
+		t, err := v.Validate(accessToken)
+		if err != nil {
+			return nil, err
+		}
+
+		revoked, err := checker.IsRevoked(context.Background(), &revocation.Token{
+			ID:        t.ID,
+			SessionID: t.SessionID,
+			UserID:    t.UserID,
+			IssuedAt:  t.IssuedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, revocation.ErrRevokedToken
+		}
+		return t, nil
+	})
+}
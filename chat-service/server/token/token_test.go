@@ -0,0 +1,156 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsmweb/chat-server/pkg/revocation"
+	"github.com/tsmweb/chat-server/pkg/scope"
+)
+
+func TestToken_IsExpired(t *testing.T) {
+	//t.Parallel()
+	now := time.Now()
+
+	assert.False(t, (&Token{}).IsExpired(now))
+	assert.False(t, (&Token{ExpiresAt: now.Add(time.Minute)}).IsExpired(now))
+	assert.True(t, (&Token{ExpiresAt: now}).IsExpired(now))
+}
+
+func TestToken_IsRevokedBy(t *testing.T) {
+	//t.Parallel()
+	now := time.Now().UTC()
+	r := &Revocation{UserID: "+5518977777777", RevokedAt: now}
+
+	second := now.Truncate(time.Second) // the date of the revocation is in seconds
+
+	assert.True(t, (&Token{UserID: "+5518977777777"}).IsRevokedBy(r))
+	assert.True(t, (&Token{UserID: "+5518977777777", IssuedAt: second.Add(-time.Second)}).IsRevokedBy(r))
+	assert.True(t, (&Token{UserID: "+5518977777777", IssuedAt: second}).IsRevokedBy(r))
+	assert.True(t, (&Token{UserID: "+5518977777777",
+		IssuedAt: second.Add(time.Second - time.Millisecond)}).IsRevokedBy(r))
+	assert.False(t, (&Token{UserID: "+5518977777777", IssuedAt: second.Add(time.Second)}).IsRevokedBy(r))
+	assert.False(t, (&Token{UserID: "+5518966666666"}).IsRevokedBy(r))
+
+	r = &Revocation{UserID: "+5518977777777", TokenID: "A1B2C3", RevokedAt: now}
+
+	assert.True(t, (&Token{ID: "A1B2C3", UserID: "+5518977777777", IssuedAt: now}).IsRevokedBy(r))
+	assert.False(t, (&Token{ID: "D4E5F6", UserID: "+5518977777777", IssuedAt: now}).IsRevokedBy(r))
+	assert.False(t, (&Token{UserID: "+5518977777777"}).IsRevokedBy(r))
+
+	r = &Revocation{UserID: "+5518977777777", SessionID: "S1S2S3", RevokedAt: now}
+
+	assert.True(t, (&Token{ID: "A1B2C3", SessionID: "S1S2S3", UserID: "+5518977777777",
+		IssuedAt: now}).IsRevokedBy(r))
+	assert.False(t, (&Token{ID: "D4E5F6", SessionID: "S4S5S6", UserID: "+5518977777777",
+		IssuedAt: now}).IsRevokedBy(r))
+	assert.False(t, (&Token{UserID: "+5518977777777"}).IsRevokedBy(r))
+}
+
+func TestFromRequest(t *testing.T) {
+	//t.Parallel()
+	req := httptest.NewRequest(http.MethodGet, "/v1/ws", nil)
+
+	t.Run("when JWT fails", func(t *testing.T) {
+		//t.Parallel()
+		_, err := FromRequest(&fakeJWT{err: errors.New("error")}, req)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when token has no id", func(t *testing.T) {
+		//t.Parallel()
+		_, err := FromRequest(&fakeJWT{claims: map[string]interface{}{}}, req)
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+
+	t.Run("when token is valid", func(t *testing.T) {
+		//t.Parallel()
+		tk, err := FromRequest(&fakeJWT{claims: map[string]interface{}{
+			"id":  "+5518977777777",
+			"jti": "A1B2C3",
+			"sid": "S1S2S3",
+			"iat": float64(1600000000),
+			"exp": float64(1600086400),
+		}}, req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "A1B2C3", tk.ID)
+		assert.Equal(t, "S1S2S3", tk.SessionID)
+		assert.Equal(t, "+5518977777777", tk.UserID)
+		assert.Equal(t, time.Unix(1600000000, 0).UTC(), tk.IssuedAt)
+		assert.Equal(t, time.Unix(1600086400, 0).UTC(), tk.ExpiresAt)
+		assert.False(t, tk.Scopes.Restricted())
+	})
+
+	t.Run("when token has invalid scope", func(t *testing.T) {
+		//t.Parallel()
+		_, err := FromRequest(&fakeJWT{claims: map[string]interface{}{
+			"id":    "svc_A1B2C3",
+			"scope": float64(1),
+		}}, req)
+		assert.Equal(t, scope.ErrInvalidScope, err)
+	})
+
+	t.Run("when token is restricted by scope", func(t *testing.T) {
+		//t.Parallel()
+		tk, err := FromRequest(&fakeJWT{claims: map[string]interface{}{
+			"id":    "svc_A1B2C3",
+			"scope": scope.Group("G1"),
+		}}, req)
+
+		assert.Nil(t, err)
+		assert.True(t, tk.Scopes.Restricted())
+		assert.True(t, tk.Scopes.AllowsMessage("", "G1"))
+		assert.False(t, tk.Scopes.AllowsMessage("+5518977777777", ""))
+	})
+}
+
+func TestNewRevocationValidator(t *testing.T) {
+	//t.Parallel()
+	now := time.Now().UTC()
+	validator := ValidatorFunc(func(accessToken string) (*Token, error) {
+		if accessToken == "" {
+			return nil, ErrInvalidToken
+		}
+		return &Token{ID: accessToken, UserID: "+5518977777777", IssuedAt: now}, nil
+	})
+	store := revocation.NewMemoryStore()
+	_ = store.RevokeToken(context.Background(), "A1B2C3", now.Add(time.Hour))
+	v := NewRevocationValidator(validator, store)
+
+	_, err := v.Validate("")
+	assert.Equal(t, ErrInvalidToken, err)
+
+	_, err = v.Validate("A1B2C3")
+	assert.Equal(t, revocation.ErrRevokedToken, err)
+
+	tk, err := v.Validate("D4E5F6")
+	assert.Nil(t, err)
+	assert.Equal(t, "D4E5F6", tk.ID)
+}
+
+// fakeJWT returns the claims of a token already validated.
+type fakeJWT struct {
+	claims map[string]interface{}
+	err    error
+}
+
+func (f *fakeJWT) GenerateToken(payload map[string]interface{}, exp int) (string, error) {
+	return "", nil
+}
+
+func (f *fakeJWT) ExtractToken(r *http.Request) (string, error) {
+	return "", f.err
+}
+
+func (f *fakeJWT) GetDataToken(r *http.Request, key string) (interface{}, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.claims[key], nil
+}
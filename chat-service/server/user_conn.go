@@ -2,27 +2,46 @@ package server
 
 import (
 	"encoding/json"
+	"errors"
 	"net"
 	"sync"
+	"time"
 
+	"github.com/tsmweb/chat-server/pkg/scope"
 	"github.com/tsmweb/chat-service/server/message"
+	"github.com/tsmweb/chat-service/server/token"
 )
 
 // UserConn type that represents the user connection.
 type UserConn struct {
 	userID string
 
-	io   sync.Mutex
-	conn net.Conn
+	io          sync.Mutex
+	conn        net.Conn
+	stats       connStats
+	connectedAt time.Time
 
 	reader ConnReader
 	writer ConnWriter
+	closer ConnCloser
+
+	maxContentSize map[string]int // maximum content size in bytes by content type
+
+	tk             sync.RWMutex
+	token          *token.Token
+	tokenValidator token.Validator
+
+	releaseOnce sync.Once
+	onRelease   func() // stops observing the connection and unregisters it from the server
 }
 
 // Receive read user connection data.
 func (u *UserConn) Receive() (*message.Message, error) {
 	msg, err := u.readMessage()
 	if err != nil {
+		if errors.Is(err, ErrMessageTooLarge) {
+			rejectedMessages.WithLabelValues(rejectReasonMessageSize, "").Inc()
+		}
 		u.conn.Close()
 		return nil, err
 	}
@@ -33,11 +52,24 @@ func (u *UserConn) Receive() (*message.Message, error) {
 
 	msg.From = u.userID
 
+	if msg.ContentType == message.ContentTypeToken.String() {
+		return nil, u.refreshToken(msg)
+	}
+
 	if err = msg.Validate(); err != nil {
 		return nil, u.WriteResponse(msg.ID, message.ContentTypeError, err.Error())
 	}
+	if err = msg.ValidateContentSize(u.maxContentSize[msg.ContentType]); err != nil {
+		rejectedMessages.WithLabelValues(rejectReasonContentSize, contentTypeLabel(msg.ContentType)).Inc()
+		return nil, u.WriteResponse(msg.ID, message.ContentTypeError, err.Error())
+	}
+	if !u.Token().Scopes.AllowsMessage(msg.To, msg.Group) {
+		rejectedMessages.WithLabelValues(rejectReasonScope, contentTypeLabel(msg.ContentType)).Inc()
+		return nil, u.WriteResponse(msg.ID, message.ContentTypeError, scope.ErrInsufficientScope.Error())
+	}
 
 	msg.GenerateID()
+	messages.WithLabelValues(directionIn, contentTypeLabel(msg.ContentType)).Inc()
 
 	return msg, nil
 }
@@ -47,7 +79,11 @@ func (u *UserConn) WriteMessage(msg *message.Message) error {
 	u.io.Lock()
 	defer u.io.Unlock()
 
-	return u.writer.Writer(u.conn, msg)
+	if err := u.writer.Writer(u.conn, msg); err != nil {
+		return err
+	}
+	messages.WithLabelValues(directionOut, contentTypeLabel(msg.ContentType)).Inc()
+	return nil
 }
 
 // WriteResponse write a response message on the user's connection.
@@ -56,7 +92,59 @@ func (u *UserConn) WriteResponse(msgID string, contentType message.ContentType,
 	defer u.io.Unlock()
 
 	res := message.NewResponse(msgID, contentType, content)
-	return u.writer.Writer(u.conn, res)
+	if err := u.writer.Writer(u.conn, res); err != nil {
+		return err
+	}
+	responses.WithLabelValues(contentType.String()).Inc()
+	return nil
+}
+
+// Token returns the access token of the user's connection.
+func (u *UserConn) Token() *token.Token {
+	u.tk.RLock()
+	defer u.tk.RUnlock()
+
+	return u.token
+}
+
+// Info returns the data of the user's connection.
+func (u *UserConn) Info() *ConnInfo {
+	return &ConnInfo{
+		UserID:      u.userID,
+		RemoteAddr:  u.conn.RemoteAddr().String(),
+		ConnectedAt: u.connectedAt,
+		BytesIn:     u.stats.bytesIn.Load(),
+		BytesOut:    u.stats.bytesOut.Load(),
+	}
+}
+
+// Close closes the user's connection informing the reason.
+func (u *UserConn) Close(reason CloseReason) error {
+	u.io.Lock()
+	err := u.closer.Closer(u.conn, reason)
+	u.io.Unlock()
+
+	u.release()
+	return err
+}
+
+// refreshToken replaces the access token of the connection by the token received in the message
+// content, allowing the connection to remain open after the expiration of the previous token.
+func (u *UserConn) refreshToken(msg *message.Message) error {
+	t, err := u.tokenValidator.Validate(msg.Content)
+	if err != nil || t.UserID != u.userID || t.IsExpired(time.Now()) {
+		return u.WriteResponse(msg.ID, message.ContentTypeError, token.ErrInvalidToken.Error())
+	}
+
+	u.tk.Lock()
+	u.token = t
+	u.tk.Unlock()
+
+	return u.WriteResponse(msg.ID, message.ContentTypeACK, message.AckMessage)
+}
+
+func (u *UserConn) release() {
+	u.releaseOnce.Do(u.onRelease)
 }
 
 func (u *UserConn) readMessage() (*message.Message, error) {
@@ -67,6 +155,9 @@ func (u *UserConn) readMessage() (*message.Message, error) {
 	if err != nil {
 		return nil, err
 	}
+	if r == nil { // control frame
+		return nil, nil
+	}
 
 	msg := new(message.Message)
 	decoder := json.NewDecoder(r)
@@ -0,0 +1,160 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsmweb/chat-server/pkg/scope"
+	"github.com/tsmweb/chat-service/server/message"
+	"github.com/tsmweb/chat-service/server/token"
+)
+
+func TestUserConn_RefreshToken(t *testing.T) {
+	//t.Parallel()
+	const userID = "+5518977777777"
+	expiresAt := time.Now().Add(time.Hour)
+
+	validator := token.ValidatorFunc(func(accessToken string) (*token.Token, error) {
+		switch accessToken {
+		case "valid":
+			return &token.Token{UserID: userID, ExpiresAt: expiresAt}, nil
+		case "other":
+			return &token.Token{UserID: "+5518966666666", ExpiresAt: expiresAt}, nil
+		default:
+			return nil, errors.New("error")
+		}
+	})
+
+	tests := []struct {
+		accessToken string
+		contentType string
+		expiresAt   time.Time
+	}{
+		{"invalid", message.ContentTypeError.String(), time.Time{}},
+		{"other", message.ContentTypeError.String(), time.Time{}},
+		{"valid", message.ContentTypeACK.String(), expiresAt},
+	}
+
+	for _, tc := range tests {
+		srv, cli := net.Pipe()
+		u := newTestUserConn(srv, userID, validator)
+
+		go writeJSON(cli, &message.Message{
+			ID:          "1",
+			ContentType: message.ContentTypeToken.String(),
+			Content:     tc.accessToken,
+		})
+		chRes := readJSON(cli)
+
+		msg, err := u.Receive()
+		assert.Nil(t, err)
+		assert.Nil(t, msg)
+
+		res := <-chRes
+		assert.Equal(t, "1", res.ID)
+		assert.Equal(t, tc.contentType, res.ContentType)
+		assert.Equal(t, tc.expiresAt, u.Token().ExpiresAt)
+
+		srv.Close()
+		cli.Close()
+	}
+}
+
+func TestUserConn_ReceiveScope(t *testing.T) {
+	//t.Parallel()
+	const userID = "svc_A1B2C3"
+
+	tests := []struct {
+		group    string
+		accepted bool
+	}{
+		{"G1", true},
+		{"G2", false},
+	}
+
+	for _, tc := range tests {
+		srv, cli := net.Pipe()
+		u := newTestUserConn(srv, userID, nil)
+		u.token.Scopes = scope.Parse(scope.Group("G1"))
+
+		go writeJSON(cli, &message.Message{
+			ID:          "1",
+			Group:       tc.group,
+			Date:        time.Now(),
+			ContentType: message.ContentTypeText.String(),
+			Content:     "hello",
+		})
+		chRes := readJSON(cli)
+
+		msg, err := u.Receive()
+		assert.Nil(t, err)
+
+		if tc.accepted {
+			assert.NotNil(t, msg)
+			assert.Equal(t, tc.group, msg.Group)
+		} else {
+			assert.Nil(t, msg)
+			res := <-chRes
+			assert.Equal(t, "1", res.ID)
+			assert.Equal(t, message.ContentTypeError.String(), res.ContentType)
+			assert.Equal(t, scope.ErrInsufficientScope.Error(), res.Content)
+		}
+
+		srv.Close()
+		cli.Close()
+	}
+}
+
+func TestUserConn_Close(t *testing.T) {
+	//t.Parallel()
+	srv, cli := net.Pipe()
+	defer cli.Close()
+
+	var closeReason CloseReason
+	released := 0
+
+	u := newTestUserConn(srv, "+5518977777777", nil)
+	u.closer = ConnCloserFunc(func(conn net.Conn, reason CloseReason) error {
+		closeReason = reason
+		return conn.Close()
+	})
+	u.onRelease = func() { released++ }
+
+	assert.Nil(t, u.Close(CloseTokenExpired))
+	u.release()
+
+	assert.Equal(t, CloseTokenExpired, closeReason)
+	assert.Equal(t, 1, released)
+}
+
+func newTestUserConn(conn net.Conn, userID string, validator token.Validator) *UserConn {
+	return &UserConn{
+		userID: userID,
+		conn:   conn,
+		reader: ConnReaderFunc(func(conn net.Conn) (io.Reader, error) {
+			return conn, nil
+		}),
+		writer:         ConnWriterFunc(writeJSON),
+		token:          &token.Token{UserID: userID},
+		tokenValidator: validator,
+	}
+}
+
+func writeJSON(conn net.Conn, data interface{}) error {
+	return json.NewEncoder(conn).Encode(data)
+}
+
+func readJSON(conn net.Conn) <-chan *message.Message {
+	ch := make(chan *message.Message, 1)
+	go func() {
+		msg := new(message.Message)
+		json.NewDecoder(conn).Decode(msg)
+		ch <- msg
+	}()
+	return ch
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tsmweb/chat-service/config"
+	"github.com/tsmweb/chat-service/server"
+	"github.com/tsmweb/chat-service/web/api/dto"
+	"github.com/tsmweb/go-helper-api/auth"
+	"github.com/tsmweb/go-helper-api/httputil"
+	"github.com/tsmweb/go-helper-api/middleware"
+	"github.com/urfave/negroni"
+)
+
+// ConnManager inspects and closes the connections of the users connected to the chat server.
+type ConnManager interface {
+	Connections(ctx context.Context) ([]*server.ConnInfo, error)
+	Connection(ctx context.Context, userID string) (*server.ConnInfo, error)
+	Disconnect(ctx context.Context, userID string, reason server.CloseReason) error
+}
+
+// RequireAdmin only allows the request to proceed if the token user is set in config.AdminUsers.
+func RequireAdmin(jwt auth.JWT) negroni.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+		data, err := jwt.GetDataToken(r, "id")
+		if err != nil || data == nil {
+			log.Println("[ERROR] RequireAdmin: could not get token user")
+			httputil.RespondWithError(w, http.StatusInternalServerError,
+				http.StatusText(http.StatusInternalServerError))
+			return
+		}
+		userID, _ := data.(string)
+
+		for _, adminID := range config.AdminUsers() {
+			if adminID == userID {
+				next(w, r)
+				return
+			}
+		}
+
+		httputil.RespondWithError(w, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+	}
+}
+
+// GetConnections returns the connections of all users connected to the chat server.
+func GetConnections(manager ConnManager) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		infos, err := manager.Connections(r.Context())
+		if err != nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		httputil.RespondWithJSON(w, http.StatusOK, dto.EntityToConnectionDTO(infos...))
+	})
+}
+
+// GetConnection returns the user's connection.
+func GetConnection(manager ConnManager) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		userID := vars["id"]
+
+		info, err := manager.Connection(r.Context(), userID)
+		if err != nil {
+			log.Println(err.Error())
+
+			if errors.Is(err, server.ErrUserNotConnected) {
+				httputil.RespondWithError(w, http.StatusNotFound, err.Error())
+				return
+			}
+
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		vm := &dto.Connection{}
+		vm.FromEntity(info)
+
+		httputil.RespondWithJSON(w, http.StatusOK, vm)
+	})
+}
+
+// Disconnect forces the closing of the user's connection informing the reason.
+func Disconnect(manager ConnManager) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !httputil.HasContentType(r, httputil.MimeApplicationJSON) {
+			httputil.RespondWithError(w, http.StatusUnsupportedMediaType,
+				http.StatusText(http.StatusUnsupportedMediaType))
+			return
+		}
+
+		input := &dto.Disconnect{}
+		if err := json.NewDecoder(r.Body).Decode(input); err != nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusUnprocessableEntity, "Malformed JSON")
+			return
+		}
+
+		if input.UserID == "" {
+			httputil.RespondWithError(w, http.StatusBadRequest, "required user_id")
+			return
+		}
+
+		reason := server.CloseKicked
+		if input.Reason != "" {
+			if reason = server.ParseCloseReason(input.Reason); reason == 0 {
+				httputil.RespondWithError(w, http.StatusBadRequest, "invalid reason")
+				return
+			}
+		}
+
+		if err := manager.Disconnect(r.Context(), input.UserID, reason); err != nil {
+			log.Println(err.Error())
+
+			if errors.Is(err, server.ErrUserNotConnected) {
+				httputil.RespondWithError(w, http.StatusNotFound, err.Error())
+				return
+			}
+
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+const adminApiVersion string = "v1"
+
+var adminResource string
+
+func init() {
+	adminResource = fmt.Sprintf("/%s/admin/connection", adminApiVersion)
+}
+
+// MakeAdminRouter creates a router for the administration of the users' connections.
+func MakeAdminRouter(
+	r *mux.Router,
+	jwt auth.JWT,
+	auth middleware.Auth,
+	manager ConnManager) {
+
+	// admin/connection [GET]
+	r.Handle(adminResource, negroni.New(
+		negroni.HandlerFunc(auth.RequireTokenAuth),
+		RequireAdmin(jwt),
+		negroni.Wrap(GetConnections(manager))),
+	).Methods(http.MethodGet)
+
+	// admin/connection/{id} [GET]
+	r.Handle(fmt.Sprintf("%s/{id}", adminResource), negroni.New(
+		negroni.HandlerFunc(auth.RequireTokenAuth),
+		RequireAdmin(jwt),
+		negroni.Wrap(GetConnection(manager))),
+	).Methods(http.MethodGet)
+
+	// admin/connection/disconnect [POST]
+	r.Handle(fmt.Sprintf("%s/disconnect", adminResource), negroni.New(
+		negroni.HandlerFunc(auth.RequireTokenAuth),
+		RequireAdmin(jwt),
+		negroni.Wrap(Disconnect(manager))),
+	).Methods(http.MethodPost)
+}
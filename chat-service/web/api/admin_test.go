@@ -0,0 +1,165 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/chat-service/config"
+	"github.com/tsmweb/chat-service/server"
+	"github.com/tsmweb/chat-service/web/api/dto"
+	"github.com/tsmweb/go-helper-api/middleware"
+)
+
+const adminID = "+5518977777777"
+
+func newAdminRouter(t *testing.T, userID string, manager ConnManager) *mux.Router {
+	t.Setenv("ADMIN_USERS", adminID)
+	if err := config.Load("../../"); err != nil {
+		t.Fatal(err)
+	}
+
+	mJWT := new(MockJWT)
+	mJWT.On("ExtractToken", mock.Anything).Return("token", nil)
+	mJWT.On("GetDataToken", mock.Anything, "id").Return(userID, nil)
+
+	router := mux.NewRouter()
+	MakeAdminRouter(router, mJWT, middleware.NewAuth(mJWT), manager)
+	return router
+}
+
+func TestHandler_GetConnections(t *testing.T) {
+	//t.Parallel()
+
+	t.Run("when user is not admin", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodGet, adminResource, nil)
+		rec := httptest.NewRecorder()
+
+		manager := new(mockConnManager)
+		newAdminRouter(t, "+5518966666666", manager).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		manager.AssertNotCalled(t, "Connections", mock.Anything)
+	})
+
+	t.Run("when manager return connections", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodGet, adminResource, nil)
+		rec := httptest.NewRecorder()
+
+		manager := new(mockConnManager)
+		manager.On("Connections", mock.Anything).
+			Return([]*server.ConnInfo{{
+				UserID:      "+5518911111111",
+				RemoteAddr:  "127.0.0.1:4321",
+				ConnectedAt: time.Now().UTC(),
+				BytesIn:     10,
+				BytesOut:    20,
+			}}, nil).
+			Once()
+		newAdminRouter(t, adminID, manager).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var connections []*dto.Connection
+		assert.Nil(t, json.NewDecoder(rec.Body).Decode(&connections))
+		assert.Len(t, connections, 1)
+		assert.Equal(t, "+5518911111111", connections[0].UserID)
+		assert.Equal(t, int64(10), connections[0].BytesIn)
+		assert.Equal(t, int64(20), connections[0].BytesOut)
+	})
+}
+
+func TestHandler_GetConnection(t *testing.T) {
+	//t.Parallel()
+
+	t.Run("when user is not connected", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodGet,
+			fmt.Sprintf("%s/%s", adminResource, "+5518911111111"), nil)
+		rec := httptest.NewRecorder()
+
+		manager := new(mockConnManager)
+		manager.On("Connection", mock.Anything, "+5518911111111").
+			Return(nil, server.ErrUserNotConnected).
+			Once()
+		newAdminRouter(t, adminID, manager).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("when user is connected", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodGet,
+			fmt.Sprintf("%s/%s", adminResource, "+5518911111111"), nil)
+		rec := httptest.NewRecorder()
+
+		manager := new(mockConnManager)
+		manager.On("Connection", mock.Anything, "+5518911111111").
+			Return(&server.ConnInfo{UserID: "+5518911111111"}, nil).
+			Once()
+		newAdminRouter(t, adminID, manager).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestHandler_Disconnect(t *testing.T) {
+	//t.Parallel()
+
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost,
+			fmt.Sprintf("%s/disconnect", adminResource), bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("when reason is invalid", func(t *testing.T) {
+		//t.Parallel()
+		req := newRequest(`{"user_id":"+5518911111111","reason":"invalid"}`)
+		rec := httptest.NewRecorder()
+
+		manager := new(mockConnManager)
+		newAdminRouter(t, adminID, manager).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		manager.AssertNotCalled(t, "Disconnect", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when user is not connected", func(t *testing.T) {
+		//t.Parallel()
+		req := newRequest(`{"user_id":"+5518911111111","reason":"abuse"}`)
+		rec := httptest.NewRecorder()
+
+		manager := new(mockConnManager)
+		manager.On("Disconnect", mock.Anything, "+5518911111111", server.CloseAbuse).
+			Return(server.ErrUserNotConnected).
+			Once()
+		newAdminRouter(t, adminID, manager).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("when user is disconnected without reason", func(t *testing.T) {
+		//t.Parallel()
+		req := newRequest(`{"user_id":"+5518911111111"}`)
+		rec := httptest.NewRecorder()
+
+		manager := new(mockConnManager)
+		manager.On("Disconnect", mock.Anything, "+5518911111111", server.CloseKicked).
+			Return(nil).
+			Once()
+		newAdminRouter(t, adminID, manager).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		manager.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/chat-service/server"
+)
+
+// mockConnManager injects mock dependency into Controller layer.
+type mockConnManager struct {
+	mock.Mock
+}
+
+// Connections represents the simulated method for the list connections feature in the mockConnManager.
+func (m *mockConnManager) Connections(ctx context.Context) ([]*server.ConnInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*server.ConnInfo), nil
+}
+
+// Connection represents the simulated method for the get connection feature in the mockConnManager.
+func (m *mockConnManager) Connection(ctx context.Context, userID string) (*server.ConnInfo, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*server.ConnInfo), nil
+}
+
+// Disconnect represents the simulated method for the disconnect feature in the mockConnManager.
+func (m *mockConnManager) Disconnect(ctx context.Context, userID string, reason server.CloseReason) error {
+	args := m.Called(ctx, userID, reason)
+	return args.Error(0)
+}
@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/tsmweb/chat-service/server"
+)
+
+// Connection data
+type Connection struct {
+	UserID      string    `json:"user_id"`
+	RemoteAddr  string    `json:"remote_addr"`
+	ConnectedAt time.Time `json:"connected_at"`
+	BytesIn     int64     `json:"bytes_in"`
+	BytesOut    int64     `json:"bytes_out"`
+}
+
+// FromEntity mapper server.ConnInfo to dto.Connection
+func (c *Connection) FromEntity(entity *server.ConnInfo) {
+	c.UserID = entity.UserID
+	c.RemoteAddr = entity.RemoteAddr
+	c.ConnectedAt = entity.ConnectedAt
+	c.BytesIn = entity.BytesIn
+	c.BytesOut = entity.BytesOut
+}
+
+// EntityToConnectionDTO mapper []server.ConnInfo to []dto.Connection
+func EntityToConnectionDTO(entities ...*server.ConnInfo) []*Connection {
+	connections := make([]*Connection, 0, len(entities))
+
+	for _, entity := range entities {
+		c := &Connection{}
+		c.FromEntity(entity)
+		connections = append(connections, c)
+	}
+
+	return connections
+}
+
+// Disconnect data
+type Disconnect struct {
+	UserID string `json:"user_id"`
+	Reason string `json:"reason"`
+}
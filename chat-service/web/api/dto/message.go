@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"github.com/tsmweb/chat-service/server/message"
+)
+
+// Message data
+type Message struct {
+	To          string `json:"to,omitempty"`
+	Group       string `json:"group,omitempty"`
+	ContentType string `json:"content_type"`
+	Content     string `json:"content"`
+	Callback    string `json:"callback,omitempty"`
+}
+
+// ToEntity mapper dto.Message to message.Message
+func (m *Message) ToEntity() *message.Message {
+	return &message.Message{
+		To:          m.To,
+		Group:       m.Group,
+		ContentType: m.ContentType,
+		Content:     m.Content,
+		Callback:    m.Callback,
+	}
+}
+
+// MessageSent data
+type MessageSent struct {
+	ID string `json:"id"`
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/gobwas/ws"
 	"github.com/gorilla/mux"
 	"github.com/tsmweb/chat-service/server"
+	"github.com/tsmweb/chat-service/server/token"
 	"github.com/tsmweb/go-helper-api/auth"
 	"github.com/tsmweb/go-helper-api/httputil"
 	"github.com/tsmweb/go-helper-api/middleware"
@@ -16,14 +17,13 @@ import (
 // HandleWS entry point for chat (websocket).
 func HandleWS(jwt auth.JWT, server *server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		data, err := jwt.GetDataToken(r, "id")
-		if err != nil || data == nil {
+		t, err := token.FromRequest(jwt, r)
+		if err != nil {
 			log.Println(err.Error())
 			httputil.RespondWithError(w, http.StatusInternalServerError,
 				http.StatusText(http.StatusInternalServerError))
 			return
 		}
-		userID := data.(string)
 
 		// upgrade connection
 		conn, _, _, err := ws.UpgradeHTTP(r, w)
@@ -34,7 +34,7 @@ func HandleWS(jwt auth.JWT, server *server.Server) http.Handler {
 		}
 
 		// Register incoming connection in server.
-		if err = server.Register(userID, conn); err != nil {
+		if err = server.Register(t, conn); err != nil {
 			log.Println(err.Error())
 			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
 			return
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tsmweb/chat-server/pkg/scope"
+	"github.com/tsmweb/chat-service/server/message"
+	"github.com/tsmweb/chat-service/server/token"
+	"github.com/tsmweb/chat-service/web/api/dto"
+	"github.com/tsmweb/go-helper-api/auth"
+	"github.com/tsmweb/go-helper-api/cerror"
+	"github.com/tsmweb/go-helper-api/httputil"
+	"github.com/tsmweb/go-helper-api/middleware"
+	"github.com/urfave/negroni"
+)
+
+// MessageSender sends the messages received over HTTP, such as the replies of the bots.
+type MessageSender interface {
+	Send(ctx context.Context, t *token.Token, msg *message.Message) error
+}
+
+// SendMessage sends a message of the token user without a WebSocket connection.
+func SendMessage(jwt auth.JWT, sender MessageSender) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !httputil.HasContentType(r, httputil.MimeApplicationJSON) {
+			httputil.RespondWithError(w, http.StatusUnsupportedMediaType,
+				http.StatusText(http.StatusUnsupportedMediaType))
+			return
+		}
+
+		t, err := token.FromRequest(jwt, r)
+		if err != nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusUnauthorized,
+				http.StatusText(http.StatusUnauthorized))
+			return
+		}
+
+		input := &dto.Message{}
+		if err := json.NewDecoder(r.Body).Decode(input); err != nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusUnprocessableEntity, "Malformed JSON")
+			return
+		}
+
+		msg := input.ToEntity()
+		if err := sender.Send(r.Context(), t, msg); err != nil {
+			log.Println(err.Error())
+
+			var errValidateModel *cerror.ErrValidateModel
+			if errors.As(err, &errValidateModel) {
+				httputil.RespondWithError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			if errors.Is(err, scope.ErrInsufficientScope) {
+				httputil.RespondWithError(w, http.StatusForbidden, err.Error())
+				return
+			}
+
+			httputil.RespondWithError(w, http.StatusInternalServerError,
+				http.StatusText(http.StatusInternalServerError))
+			return
+		}
+
+		httputil.RespondWithJSON(w, http.StatusAccepted, &dto.MessageSent{ID: msg.ID})
+	})
+}
+
+const messageApiVersion string = "v1"
+
+var messageResource string
+
+func init() {
+	messageResource = fmt.Sprintf("/%s/messages", messageApiVersion)
+}
+
+// MakeMessageRouter creates a router for sending messages over HTTP.
+func MakeMessageRouter(
+	r *mux.Router,
+	jwt auth.JWT,
+	auth middleware.Auth,
+	sender MessageSender) {
+
+	// messages [POST]
+	r.Handle(messageResource, negroni.New(
+		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.Wrap(SendMessage(jwt, sender))),
+	).Methods(http.MethodPost)
+}
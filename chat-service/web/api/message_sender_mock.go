@@ -0,0 +1,23 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/chat-service/server/message"
+	"github.com/tsmweb/chat-service/server/token"
+)
+
+// mockMessageSender injects mock dependency into Controller layer.
+type mockMessageSender struct {
+	mock.Mock
+}
+
+// Send represents the simulated method for the send message feature in the mockMessageSender.
+func (m *mockMessageSender) Send(ctx context.Context, t *token.Token, msg *message.Message) error {
+	args := m.Called(ctx, t, msg)
+	if id := args.String(1); id != "" {
+		msg.ID = id
+	}
+	return args.Error(0)
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/chat-server/pkg/scope"
+	"github.com/tsmweb/chat-service/server"
+	"github.com/tsmweb/chat-service/server/message"
+	"github.com/tsmweb/chat-service/server/token"
+	"github.com/tsmweb/chat-service/web/api/dto"
+	"github.com/tsmweb/go-helper-api/middleware"
+)
+
+const botID = "svc_A1B2C3"
+
+func newMessageRouter(sender MessageSender) *mux.Router {
+	mJWT := new(MockJWT)
+	mJWT.On("ExtractToken", mock.Anything).Return("token", nil)
+	mJWT.On("GetDataToken", mock.Anything, "id").Return(botID, nil)
+	mJWT.On("GetDataToken", mock.Anything, "exp").
+		Return(strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10), nil)
+	mJWT.On("GetDataToken", mock.Anything, "iat").
+		Return(strconv.FormatInt(time.Now().Unix(), 10), nil)
+	mJWT.On("GetDataToken", mock.Anything, mock.Anything).Return(nil, nil)
+
+	router := mux.NewRouter()
+	MakeMessageRouter(router, mJWT, middleware.NewAuth(mJWT), sender)
+	return router
+}
+
+func newSendMessageRequest(body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, messageResource, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func TestHandler_SendMessage(t *testing.T) {
+	//t.Parallel()
+	body := `{"to":"+5518911111111","content_type":"text","content":"hello",` +
+		`"callback":"https://alerts.example.com/errors"}`
+	isBotToken := mock.MatchedBy(func(t *token.Token) bool { return t.UserID == botID })
+
+	t.Run("when content type is invalid", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodPost, messageResource, bytes.NewBufferString(body))
+		rec := httptest.NewRecorder()
+
+		sender := new(mockMessageSender)
+		newMessageRouter(sender).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+		sender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when JSON is malformed", func(t *testing.T) {
+		//t.Parallel()
+		rec := httptest.NewRecorder()
+
+		sender := new(mockMessageSender)
+		newMessageRouter(sender).ServeHTTP(rec, newSendMessageRequest(`{"to":`))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		sender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when message is invalid", func(t *testing.T) {
+		//t.Parallel()
+		rec := httptest.NewRecorder()
+
+		sender := new(mockMessageSender)
+		sender.On("Send", mock.Anything, isBotToken, mock.Anything).
+			Return(server.ErrContentTypeNotAllowed, "").
+			Once()
+		newMessageRouter(sender).ServeHTTP(rec, newSendMessageRequest(body))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("when scope does not allow the receiver", func(t *testing.T) {
+		//t.Parallel()
+		rec := httptest.NewRecorder()
+
+		sender := new(mockMessageSender)
+		sender.On("Send", mock.Anything, isBotToken, mock.Anything).
+			Return(scope.ErrInsufficientScope, "").
+			Once()
+		newMessageRouter(sender).ServeHTTP(rec, newSendMessageRequest(body))
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("when sender fails", func(t *testing.T) {
+		//t.Parallel()
+		rec := httptest.NewRecorder()
+
+		sender := new(mockMessageSender)
+		sender.On("Send", mock.Anything, isBotToken, mock.Anything).
+			Return(errors.New("error"), "").
+			Once()
+		newMessageRouter(sender).ServeHTTP(rec, newSendMessageRequest(body))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("when message is sent", func(t *testing.T) {
+		//t.Parallel()
+		rec := httptest.NewRecorder()
+
+		sender := new(mockMessageSender)
+		sender.On("Send", mock.Anything, isBotToken, mock.MatchedBy(func(msg *message.Message) bool {
+			return msg.To == "+5518911111111" && msg.ContentType == "text" &&
+				msg.Content == "hello" && msg.Callback == "https://alerts.example.com/errors"
+		})).
+			Return(nil, "M1").
+			Once()
+		newMessageRouter(sender).ServeHTTP(rec, newSendMessageRequest(body))
+
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+
+		var sent dto.MessageSent
+		assert.Nil(t, json.NewDecoder(rec.Body).Decode(&sent))
+		assert.Equal(t, "M1", sent.ID)
+		sender.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,17 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const metricsResource string = "/metrics"
+
+// MakeMetricsRouter creates a router for the Prometheus metrics. It must only be mounted
+// on the internal listener, the metrics are not authenticated.
+func MakeMetricsRouter(r *mux.Router) {
+	// metrics [GET]
+	r.Handle(metricsResource, promhttp.Handler()).Methods(http.MethodGet)
+}
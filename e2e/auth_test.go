@@ -0,0 +1,104 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"testing"
+
+	. "github.com/tsmweb/e2e/harness"
+)
+
+func TestAuth_Logout(t *testing.T) {
+	newDriver(t).Run(
+		SignUp("alice"),
+		Connect("alice"),
+		ExpectOnline("alice"),
+		Logout("alice", false),
+		ExpectClosed("alice"),
+		ExpectRevoked("alice"),
+		Login("alice"),
+		Connect("alice"),
+	)
+}
+
+func TestAuth_LogoutEverywhere(t *testing.T) {
+	newDriver(t).Run(
+		SignUp("alice"),
+		Connect("alice"),
+		ExpectOnline("alice"),
+		Logout("alice", true),
+		ExpectClosed("alice"),
+		ExpectRevoked("alice"),
+	)
+}
+
+func TestAuth_RevokeSession(t *testing.T) {
+	newDriver(t).Run(
+		SignUp("alice"),
+		Connect("alice"),
+		ExpectOnline("alice"),
+		RevokeSession("alice"),
+		ExpectClosed("alice"),
+		ExpectRevoked("alice"),
+		Login("alice"),
+		Connect("alice"),
+	)
+}
+
+func TestAuth_ResetPassword(t *testing.T) {
+	newDriver(t).Run(
+		SignUp("alice"),
+		Connect("alice"),
+		ExpectOnline("alice"),
+		ResetPassword("alice"),
+		ExpectClosed("alice"),
+		ExpectRevoked("alice"),
+		Login("alice"),
+		Connect("alice"),
+	)
+}
+
+func TestAuth_TwoFactor(t *testing.T) {
+	newDriver(t).Run(
+		SignUp("alice"),
+		EnableTwoFactor("alice"),
+		LoginTwoFactor("alice", false),
+		LoginTwoFactor("alice", true),
+		Connect("alice"),
+	)
+}
+
+func TestAuth_LoginLocked(t *testing.T) {
+	newDriver(t).Run(
+		SignUp("alice"),
+		ExpectLoginLocked("alice"),
+	)
+}
+
+func TestAuth_DeleteAccount(t *testing.T) {
+	newDriver(t).Run(
+		SignUp("alice"),
+		Connect("alice"),
+		ExpectOnline("alice"),
+		DeleteAccount("alice"),
+		ExpectClosed("alice"),
+		ExpectRevoked("alice"),
+		ExpectLoginRejected("alice"),
+	)
+}
+
+func TestAuth_ServiceAccount(t *testing.T) {
+	newDriver(t).Run(
+		SignUp("alice", "bob"),
+		ServiceAccount("bot", "alice"),
+		Connect("bot"),
+		Connect("alice"),
+		ExpectOnline("alice"),
+		Send("allowed", "bot", "alice", "build passed"),
+		ExpectAck("allowed"),
+		ExpectMessage("alice", "allowed"),
+		Send("denied", "bot", "bob", "build passed"),
+		ExpectRejected("denied"),
+		ExpectForbidden("bot"),
+	)
+}
@@ -0,0 +1,112 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	. "github.com/tsmweb/e2e/harness"
+)
+
+var (
+	h       *Harness
+	skipErr error // why the scenarios are skipped, if the harness did not start
+)
+
+func TestMain(m *testing.M) {
+	var err error
+	h, err = Start()
+	if err != nil {
+		if !errors.Is(err, ErrNoDatabase) {
+			fmt.Println("e2e harness:", err)
+			os.Exit(1)
+		}
+		skipErr = err
+	}
+
+	code := m.Run()
+	if h != nil {
+		h.Close()
+	}
+	os.Exit(code)
+}
+
+// newDriver creates a Driver for the scenario, which is skipped if the harness did not
+// start for the lack of a database.
+func newDriver(t *testing.T) *Driver {
+	t.Helper()
+	if skipErr != nil {
+		t.Skip("skipping e2e scenario:", skipErr)
+	}
+	return NewDriver(t, h)
+}
+
+func TestChat_SendToOnlineUser(t *testing.T) {
+	newDriver(t).Run(
+		SignUp("alice", "bob"),
+		Connect("alice"),
+		Connect("bob"),
+		ExpectOnline("alice", "bob"),
+		Send("hello", "alice", "bob", "hello bob"),
+		ExpectAck("hello"),
+		ExpectMessage("bob", "hello"),
+		Send("reply", "bob", "alice", "hi alice"),
+		ExpectAck("reply"),
+		ExpectMessage("alice", "reply"),
+	)
+}
+
+func TestChat_SendToOfflineUser(t *testing.T) {
+	newDriver(t).Run(
+		SignUp("alice", "bob"),
+		Connect("alice"),
+		Send("offline", "alice", "bob", "are you there?"),
+		ExpectAck("offline"),
+		ExpectStored("bob", "offline"),
+		Connect("bob"),
+		ExpectMessage("bob", "offline"),
+	)
+}
+
+func TestChat_StoreMessagesOnDisconnect(t *testing.T) {
+	newDriver(t).Run(
+		SignUp("alice", "bob"),
+		Connect("alice"),
+		Connect("bob"),
+		ExpectOnline("alice", "bob"),
+		Disconnect("bob"),
+		ExpectOffline("bob"),
+		Send("later", "alice", "bob", "see you later"),
+		ExpectAck("later"),
+		ExpectStored("bob", "later"),
+		Connect("bob"),
+		ExpectMessage("bob", "later"),
+	)
+}
+
+func TestChat_BotWebhook(t *testing.T) {
+	newDriver(t).Run(
+		SignUp("alice"),
+		ServiceAccount("bot", "alice"),
+		Webhook("bot"),
+		Connect("alice"),
+		ExpectOnline("alice"),
+		Send("question", "alice", "bot", "status?"),
+		ExpectAck("question"),
+		ExpectWebhook("bot", "question"),
+		Reply("answer", "bot", "alice", "all systems go"),
+		ExpectMessage("alice", "answer"),
+	)
+}
+
+func TestChat_SendDeliveryErrorCallback(t *testing.T) {
+	newDriver(t).Run(
+		ServiceAccount("alerts", "nobody"),
+		DeliveryErrors("alerts"),
+		Reply("alert", "alerts", "nobody", "disk full"),
+		ExpectDeliveryError("alerts", "alert"),
+	)
+}
@@ -0,0 +1,59 @@
+module github.com/tsmweb/e2e
+
+go 1.19
+
+require (
+	github.com/gobwas/ws v1.1.0
+	github.com/gorilla/mux v1.8.0
+	github.com/lib/pq v1.10.7
+	github.com/tsmweb/allinone v0.0.0
+	github.com/tsmweb/auth-service v0.0.0
+	github.com/tsmweb/broker-service v0.0.0
+	github.com/tsmweb/chat-server/pkg v0.0.0
+	github.com/tsmweb/chat-service v0.0.0
+	github.com/tsmweb/easygo v0.0.0-20190618140210-3c14a0dc985f
+	github.com/tsmweb/go-helper-api v1.4.3
+	github.com/tsmweb/user-service v0.0.0
+)
+
+require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.1.2 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/felixge/httpsnoop v1.0.3 // indirect
+	github.com/go-redis/redis/v8 v8.11.5 // indirect
+	github.com/gobwas/httphead v0.1.0 // indirect
+	github.com/gobwas/pool v0.2.1 // indirect
+	github.com/golang-jwt/jwt/v4 v4.4.2 // indirect
+	github.com/golang/protobuf v1.5.2 // indirect
+	github.com/gorilla/handlers v1.5.1 // indirect
+	github.com/joho/godotenv v1.4.0 // indirect
+	github.com/klauspost/compress v1.15.10 // indirect
+	github.com/matttproud/golang_protobuf_extensions v1.0.1 // indirect
+	github.com/pierrec/lz4/v4 v4.1.16 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/prometheus/client_golang v1.13.0 // indirect
+	github.com/prometheus/client_model v0.2.0 // indirect
+	github.com/prometheus/common v0.37.0 // indirect
+	github.com/prometheus/procfs v0.8.0 // indirect
+	github.com/segmentio/kafka-go v0.4.35 // indirect
+	github.com/stretchr/objx v0.4.0 // indirect
+	github.com/stretchr/testify v1.8.0 // indirect
+	github.com/urfave/negroni v1.0.0 // indirect
+	github.com/xi2/httpgzip v0.0.0-20190509075255-932ab5e254ae // indirect
+	golang.org/x/crypto v0.1.0 // indirect
+	golang.org/x/sync v0.0.0-20220907140024-f12130a52804 // indirect
+	golang.org/x/sys v0.1.0 // indirect
+	google.golang.org/protobuf v1.28.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace (
+	github.com/tsmweb/allinone => ../allinone
+	github.com/tsmweb/auth-service => ../auth-service
+	github.com/tsmweb/broker-service => ../broker-service
+	github.com/tsmweb/chat-server/pkg => ../pkg
+	github.com/tsmweb/chat-service => ../chat-service
+	github.com/tsmweb/user-service => ../user-service
+)
@@ -0,0 +1,513 @@
+package harness
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+	"github.com/tsmweb/auth-service/common/totp"
+	"github.com/tsmweb/chat-service/server/message"
+)
+
+// ErrTimeout is returned when an expected message is not received, or an expected state is
+// not reached, in time.
+var ErrTimeout = errors.New("harness: timeout waiting for message or state")
+
+// SignUp verifies the phone number of the user with the code sent by SMS and creates
+// the user in auth-service.
+func (h *Harness) SignUp(ctx context.Context, userID, password string) error {
+	_, err := h.doJSON(ctx, http.MethodPost, h.AuthURL+"/v1/verification", "",
+		map[string]string{"id": userID}, http.StatusAccepted)
+	if err != nil {
+		return err
+	}
+	code, ok := h.sms.code(userID)
+	if !ok {
+		return fmt.Errorf("harness: no verification code sent to %s", userID)
+	}
+
+	body := map[string]string{
+		"id":       userID,
+		"name":     userID,
+		"lastname": "e2e",
+		"password": password,
+		"code":     code,
+	}
+	_, err = h.doJSON(ctx, http.MethodPost, h.AuthURL+"/v1/user", "", body, http.StatusCreated)
+	return err
+}
+
+// Login returns the access token of the user issued by auth-service.
+func (h *Harness) Login(ctx context.Context, userID, password string) (string, error) {
+	body := map[string]string{
+		"id":       userID,
+		"password": password,
+	}
+	data, err := h.doJSON(ctx, http.MethodPost, h.AuthURL+"/v1/login", "", body, http.StatusOK)
+	if err != nil {
+		return "", err
+	}
+
+	var res struct {
+		Token string `json:"token"`
+	}
+	if err = json.Unmarshal(data, &res); err != nil {
+		return "", err
+	}
+	return res.Token, nil
+}
+
+// LoginTwoFactor logs in the user with two-factor authentication enabled, exchanging the
+// challenge token returned by the password login and the code for the access token.
+func (h *Harness) LoginTwoFactor(ctx context.Context, userID, password, code string) (string, error) {
+	body := map[string]string{
+		"id":       userID,
+		"password": password,
+	}
+	data, err := h.doJSON(ctx, http.MethodPost, h.AuthURL+"/v1/login", "", body, http.StatusOK)
+	if err != nil {
+		return "", err
+	}
+
+	var challenge struct {
+		ChallengeToken string `json:"challenge_token"`
+	}
+	if err = json.Unmarshal(data, &challenge); err != nil {
+		return "", err
+	}
+	if challenge.ChallengeToken == "" {
+		return "", errors.New("harness: login did not return a challenge token")
+	}
+
+	body = map[string]string{
+		"challenge_token": challenge.ChallengeToken,
+		"code":            code,
+	}
+	data, err = h.doJSON(ctx, http.MethodPost, h.AuthURL+"/v1/login/2fa", "", body, http.StatusOK)
+	if err != nil {
+		return "", err
+	}
+
+	var res struct {
+		Token string `json:"token"`
+	}
+	if err = json.Unmarshal(data, &res); err != nil {
+		return "", err
+	}
+	return res.Token, nil
+}
+
+// EnableTwoFactor enrols the user of the token in the two-factor authentication and
+// confirms it, returning the TOTP secret and the recovery codes.
+func (h *Harness) EnableTwoFactor(ctx context.Context, token string) (string, []string, error) {
+	data, err := h.doJSON(ctx, http.MethodPost, h.AuthURL+"/v1/2fa/enrol", token, nil, http.StatusOK)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var enrolment struct {
+		Secret string `json:"secret"`
+	}
+	if err = json.Unmarshal(data, &enrolment); err != nil {
+		return "", nil, err
+	}
+
+	code, err := totp.Code(enrolment.Secret, totp.Step(time.Now()))
+	if err != nil {
+		return "", nil, err
+	}
+	data, err = h.doJSON(ctx, http.MethodPost, h.AuthURL+"/v1/2fa/confirm", token,
+		map[string]string{"code": code}, http.StatusOK)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var res struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	}
+	if err = json.Unmarshal(data, &res); err != nil {
+		return "", nil, err
+	}
+	return enrolment.Secret, res.RecoveryCodes, nil
+}
+
+// ResetPassword sets the password of the user with the reset token sent by SMS.
+func (h *Harness) ResetPassword(ctx context.Context, userID, password string) error {
+	_, err := h.doJSON(ctx, http.MethodPost, h.AuthURL+"/v1/password/reset", "",
+		map[string]string{"id": userID}, http.StatusAccepted)
+	if err != nil {
+		return err
+	}
+	token, ok := h.sms.resetToken(userID)
+	if !ok {
+		return fmt.Errorf("harness: no reset token sent to %s", userID)
+	}
+
+	body := map[string]string{
+		"token":    token,
+		"password": password,
+	}
+	_, err = h.doJSON(ctx, http.MethodPost, h.AuthURL+"/v1/password/reset/confirm", "", body,
+		http.StatusOK)
+	return err
+}
+
+// Logout revokes the access token in auth-service, or all the tokens of the user when
+// everywhere is true.
+func (h *Harness) Logout(ctx context.Context, token string, everywhere bool) error {
+	body := map[string]bool{
+		"everywhere": everywhere,
+	}
+	_, err := h.doJSON(ctx, http.MethodPost, h.AuthURL+"/v1/logout", token, body, http.StatusOK)
+	return err
+}
+
+// Session is an active session of the user listed by auth-service.
+type Session struct {
+	ID         string `json:"id"`
+	DeviceName string `json:"device_name"`
+	Current    bool   `json:"current"`
+}
+
+// Sessions lists the active sessions of the user of the token.
+func (h *Harness) Sessions(ctx context.Context, token string) ([]*Session, error) {
+	data, err := h.doJSON(ctx, http.MethodGet, h.AuthURL+"/v1/session", token, nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []*Session
+	if err = json.Unmarshal(data, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// RevokeSession revokes the session of the user of the token.
+func (h *Harness) RevokeSession(ctx context.Context, token, sessionID string) error {
+	_, err := h.doJSON(ctx, http.MethodDelete, h.AuthURL+"/v1/session/"+sessionID, token, nil,
+		http.StatusOK)
+	return err
+}
+
+// DeleteAccount deletes the account of the user in auth-service, confirmed by its password.
+func (h *Harness) DeleteAccount(ctx context.Context, token, password string) error {
+	body := map[string]string{"password": password}
+	_, err := h.doJSON(ctx, http.MethodDelete, h.AuthURL+"/v1/user", token, body,
+		http.StatusAccepted)
+	return err
+}
+
+// AdminToken returns an access token of the administrator, signing it up on the first call.
+func (h *Harness) AdminToken(ctx context.Context, password string) (string, error) {
+	h.adminMu.Lock()
+	defer h.adminMu.Unlock()
+
+	if !h.adminSignedUp {
+		if err := h.SignUp(ctx, AdminUserID, password); err != nil {
+			return "", err
+		}
+		h.adminSignedUp = true
+	}
+	return h.Login(ctx, AdminUserID, password)
+}
+
+// CreateClient creates a service account restricted to the scopes with the token of an
+// administrator, returning its client ID and secret.
+func (h *Harness) CreateClient(ctx context.Context, token, name string,
+	scopes []string) (string, string, error) {
+	body := map[string]interface{}{
+		"name":   name,
+		"scopes": scopes,
+	}
+	data, err := h.doJSON(ctx, http.MethodPost, h.AuthURL+"/v1/admin/client", token, body,
+		http.StatusCreated)
+	if err != nil {
+		return "", "", err
+	}
+
+	var res struct {
+		ID     string `json:"id"`
+		Secret string `json:"secret"`
+	}
+	if err = json.Unmarshal(data, &res); err != nil {
+		return "", "", err
+	}
+	return res.ID, res.Secret, nil
+}
+
+// ClientToken returns the access token of the service account issued by auth-service
+// with the OAuth2 client credentials grant.
+func (h *Harness) ClientToken(ctx context.Context, clientID, secret string) (string, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.AuthURL+"/v1/oauth/token",
+		strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(clientID, secret)
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(res.Body)
+		return "", fmt.Errorf("harness: POST /v1/oauth/token: status %d: %s",
+			res.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	var tk struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&tk); err != nil {
+		return "", err
+	}
+	return tk.AccessToken, nil
+}
+
+// SetWebhook registers the webhook URL of the service account of the token, returning the
+// secret that signs the deliveries.
+func (h *Harness) SetWebhook(ctx context.Context, token, webhookURL string) (string, error) {
+	body := map[string]interface{}{"url": webhookURL}
+	data, err := h.doJSON(ctx, http.MethodPut, h.AuthURL+"/v1/client/webhook", token, body,
+		http.StatusOK)
+	if err != nil {
+		return "", err
+	}
+
+	var res struct {
+		Secret string `json:"secret"`
+	}
+	if err = json.Unmarshal(data, &res); err != nil {
+		return "", err
+	}
+	return res.Secret, nil
+}
+
+// SendMessage sends a text message with the token through the REST endpoint of chat-service,
+// returning the ID of the message. The delivery errors are POSTed to the callback URL, if
+// not empty.
+func (h *Harness) SendMessage(ctx context.Context, token, to, content,
+	callback string) (string, error) {
+	body := map[string]interface{}{
+		"to":           to,
+		"content_type": message.ContentTypeText.String(),
+		"content":      content,
+	}
+	if callback != "" {
+		body["callback"] = callback
+	}
+	data, err := h.doJSON(ctx, http.MethodPost, h.ChatURL+"/v1/messages", token, body,
+		http.StatusAccepted)
+	if err != nil {
+		return "", err
+	}
+
+	var res struct {
+		ID string `json:"id"`
+	}
+	if err = json.Unmarshal(data, &res); err != nil {
+		return "", err
+	}
+	return res.ID, nil
+}
+
+// Do sends a JSON request authenticated by the token to the URL and returns the response body,
+// or an error if the response status is not the expected one.
+func (h *Harness) Do(ctx context.Context, method, url, token string, body interface{},
+	status int) ([]byte, error) {
+	return h.doJSON(ctx, method, url, token, body, status)
+}
+
+func (h *Harness) doJSON(ctx context.Context, method, url, token string, body interface{},
+	status int) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != status {
+		return nil, fmt.Errorf("harness: %s %s: status %d, want %d: %s",
+			method, url, res.StatusCode, status, strings.TrimSpace(string(data)))
+	}
+	return data, nil
+}
+
+// Client is a WebSocket connection of a user to chat-service.
+type Client struct {
+	UserID string
+
+	conn     net.Conn
+	mu       sync.Mutex
+	received []*message.Message
+	notify   chan struct{}
+	done     chan struct{}
+	err      error
+}
+
+// Dial connects the user to chat-service with the access token.
+func (h *Harness) Dial(ctx context.Context, userID, token string) (*Client, error) {
+	dialer := ws.Dialer{
+		Header: ws.HandshakeHeaderHTTP(http.Header{
+			"Authorization": []string{"Bearer " + token},
+		}),
+	}
+
+	url := "ws" + strings.TrimPrefix(h.ChatURL, "http") + "/v1/ws"
+	conn, _, _, err := dialer.Dial(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		UserID: userID,
+		conn:   conn,
+		notify: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+	go c.readLoop()
+
+	return c, nil
+}
+
+// Send sends the message to the user, or to the group when group is informed,
+// and returns the message sent.
+func (c *Client) Send(to, group string, contentType message.ContentType,
+	content string) (*message.Message, error) {
+	msg := &message.Message{
+		ID:          fmt.Sprintf("%s-%d", c.UserID, time.Now().UnixNano()),
+		From:        c.UserID,
+		To:          to,
+		Group:       group,
+		Date:        time.Now().UTC(),
+		ContentType: contentType.String(),
+		Content:     content,
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	if err = wsutil.WriteClientText(c.conn, data); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// Expect waits for a message that satisfies match, returning it and removing it from the
+// messages received. Messages that do not satisfy match are kept for later calls.
+func (c *Client) Expect(timeout time.Duration,
+	match func(msg *message.Message) bool) (*message.Message, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		c.mu.Lock()
+		for i, msg := range c.received {
+			if match(msg) {
+				c.received = append(c.received[:i], c.received[i+1:]...)
+				c.mu.Unlock()
+				return msg, nil
+			}
+		}
+		err := c.err
+		c.mu.Unlock()
+
+		if err != nil {
+			return nil, err
+		}
+
+		select {
+		case <-c.notify:
+		case <-timer.C:
+			return nil, ErrTimeout
+		}
+	}
+}
+
+// Received returns the messages received that were not taken by Expect.
+func (c *Client) Received() []*message.Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*message.Message(nil), c.received...)
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	err := c.conn.Close()
+	<-c.done
+	return err
+}
+
+// Closed returns a channel that is closed when the connection is closed by either side.
+func (c *Client) Closed() <-chan struct{} {
+	return c.done
+}
+
+func (c *Client) readLoop() {
+	defer close(c.done)
+
+	for {
+		data, err := wsutil.ReadServerText(c.conn)
+
+		c.mu.Lock()
+		if err != nil {
+			c.err = err
+		} else {
+			msg := &message.Message{}
+			if errJSON := json.Unmarshal(data, msg); errJSON == nil {
+				c.received = append(c.received, msg)
+			}
+		}
+		c.mu.Unlock()
+
+		select {
+		case c.notify <- struct{}{}:
+		default:
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
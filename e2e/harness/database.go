@@ -0,0 +1,135 @@
+package harness
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// ddlSchema is the schema name used by infra/database/DDL.sql.
+const ddlSchema = "chat_db"
+
+type databaseSettings struct {
+	host     string
+	port     int
+	user     string
+	password string
+	name     string
+}
+
+func databaseSettingsFromEnv() (*databaseSettings, error) {
+	s := &databaseSettings{
+		host:     os.Getenv("E2E_DB_HOST"),
+		port:     5432,
+		user:     os.Getenv("E2E_DB_USER"),
+		password: os.Getenv("E2E_DB_PASSWORD"),
+		name:     os.Getenv("E2E_DB_DATABASE"),
+	}
+	if s.host == "" {
+		return nil, ErrNoDatabase
+	}
+	if port := os.Getenv("E2E_DB_PORT"); port != "" {
+		var err error
+		if s.port, err = strconv.Atoi(port); err != nil {
+			return nil, fmt.Errorf("harness: E2E_DB_PORT: %w", err)
+		}
+	}
+	if s.user == "" {
+		s.user = "postgres"
+	}
+	if s.name == "" {
+		s.name = "postgres"
+	}
+	return s, nil
+}
+
+// database is a schema created for the run from infra/database/DDL.sql,
+// which is dropped when the harness is closed.
+type database struct {
+	db     *sql.DB
+	schema string
+}
+
+func createDatabase(s *databaseSettings) (*database, error) {
+	connStr := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
+		s.host, s.port, s.user, s.password, s.name)
+	db, err := sql.Open("postgres", connStr)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err = db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("harness: postgres: %w", err)
+	}
+
+	d := &database{
+		db:     db,
+		schema: fmt.Sprintf("e2e_%d", time.Now().UnixNano()),
+	}
+
+	ddl, err := schemaDDL(d.schema)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	if _, err = db.ExecContext(ctx, ddl); err != nil {
+		d.drop()
+		return nil, fmt.Errorf("harness: create schema: %w", err)
+	}
+
+	return d, nil
+}
+
+func (d *database) drop() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	d.db.ExecContext(ctx, fmt.Sprintf("DROP SCHEMA IF EXISTS %s CASCADE", d.schema))
+	d.db.Close()
+}
+
+// schemaDDL returns the statements of infra/database/DDL.sql creating the tables in the
+// schema, without the role and schema statements.
+func schemaDDL(schema string) (string, error) {
+	_, file, _, _ := runtime.Caller(0)
+	path := filepath.Join(filepath.Dir(file), "..", "..", "infra", "database", "DDL.sql")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("harness: %w", err)
+	}
+
+	statements := []string{fmt.Sprintf("CREATE SCHEMA %s", schema)}
+	for _, stmt := range strings.Split(string(data), ";") {
+		stmt = strings.TrimSpace(stripComments(stmt))
+		if stmt == "" || !strings.Contains(stmt, ddlSchema+".") {
+			continue
+		}
+		statements = append(statements, strings.ReplaceAll(stmt, ddlSchema+".", schema+"."))
+	}
+
+	return strings.Join(statements, ";\n") + ";", nil
+}
+
+func stripComments(stmt string) string {
+	lines := strings.Split(stmt, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(line), "--") {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
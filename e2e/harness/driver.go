@@ -0,0 +1,752 @@
+package harness
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tsmweb/auth-service/common/totp"
+	"github.com/tsmweb/broker-service/broker/webhook/webhooktest"
+	"github.com/tsmweb/chat-server/pkg/scope"
+	"github.com/tsmweb/chat-service/server/message"
+)
+
+// DefaultTimeout is the time a step waits for an expected message or state.
+const DefaultTimeout = 5 * time.Second
+
+// pollInterval is the interval at which a step checks the state of the services.
+const pollInterval = 10 * time.Millisecond
+
+const password = "e2e-password"
+
+// Step is an action of a scenario run by the Driver.
+type Step struct {
+	Name string
+	Run  func(ctx context.Context, d *Driver) error
+}
+
+// Driver runs scripted scenarios with several users, each one referred by an alias
+// such as "alice", which is mapped to a user ID unique to the driver.
+type Driver struct {
+	H       *Harness
+	Timeout time.Duration
+
+	t       testing.TB
+	mu      sync.Mutex
+	users   map[string]string
+	tokens  map[string]string
+	secrets map[string]*twoFactor
+	clients map[string]*Client
+	hooks   map[string]*webhooktest.Receiver
+	errors  map[string]*webhooktest.Receiver
+	sent    map[string]*message.Message
+}
+
+// NewDriver creates a Driver for the test, closing its connections and webhook receivers
+// when the test ends.
+func NewDriver(t testing.TB, h *Harness) *Driver {
+	d := &Driver{
+		H:       h,
+		Timeout: DefaultTimeout,
+		t:       t,
+		users:   make(map[string]string),
+		tokens:  make(map[string]string),
+		secrets: make(map[string]*twoFactor),
+		clients: make(map[string]*Client),
+		hooks:   make(map[string]*webhooktest.Receiver),
+		errors:  make(map[string]*webhooktest.Receiver),
+		sent:    make(map[string]*message.Message),
+	}
+	t.Cleanup(d.closeAll)
+	return d
+}
+
+// Run runs the steps in order, failing the test at the first step that fails.
+func (d *Driver) Run(steps ...Step) {
+	d.t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	for i, step := range steps {
+		if err := step.Run(ctx, d); err != nil {
+			d.t.Fatalf("step %d (%s): %v", i+1, step.Name, err)
+		}
+	}
+}
+
+// UserID returns the user ID of the alias.
+func (d *Driver) UserID(alias string) string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	id, ok := d.users[alias]
+	if !ok {
+		id = fmt.Sprintf("+55%011d", rand.Int63n(1e11))
+		d.users[alias] = id
+	}
+	return id
+}
+
+// Token returns the access token of the alias obtained by the Login step.
+func (d *Driver) Token(alias string) string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.tokens[alias]
+}
+
+// Client returns the connection of the alias opened by the Connect step.
+func (d *Driver) Client(alias string) (*Client, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	c, ok := d.clients[alias]
+	if !ok {
+		return nil, fmt.Errorf("%s is not connected", alias)
+	}
+	return c, nil
+}
+
+// Sent returns the last message sent by the Send step with the given label.
+func (d *Driver) Sent(label string) *message.Message {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.sent[label]
+}
+
+func (d *Driver) closeAll() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for alias, c := range d.clients {
+		c.Close()
+		delete(d.clients, alias)
+	}
+	for alias, recv := range d.hooks {
+		recv.Close()
+		delete(d.hooks, alias)
+	}
+	for alias, recv := range d.errors {
+		recv.Close()
+		delete(d.errors, alias)
+	}
+}
+
+// SignUp creates the users of the aliases and logs them in.
+func SignUp(aliases ...string) Step {
+	return Step{
+		Name: fmt.Sprintf("sign up %v", aliases),
+		Run: func(ctx context.Context, d *Driver) error {
+			for _, alias := range aliases {
+				if err := d.H.SignUp(ctx, d.UserID(alias), password); err != nil {
+					return err
+				}
+				if err := Login(alias).Run(ctx, d); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// Login obtains a new access token for the alias.
+func Login(alias string) Step {
+	return Step{
+		Name: "login " + alias,
+		Run: func(ctx context.Context, d *Driver) error {
+			token, err := d.H.Login(ctx, d.UserID(alias), password)
+			if err != nil {
+				return err
+			}
+
+			d.mu.Lock()
+			d.tokens[alias] = token
+			d.mu.Unlock()
+			return nil
+		},
+	}
+}
+
+// ExpectLoginLocked logs in the alias with a wrong password until auth-service throttles
+// the logins, then checks that the right password is rejected as well.
+func ExpectLoginLocked(alias string) Step {
+	return Step{
+		Name: "expect login locked of " + alias,
+		Run: func(ctx context.Context, d *Driver) error {
+			url := d.H.AuthURL + "/v1/login"
+			wrong := map[string]string{"id": d.UserID(alias), "password": "wrong" + password}
+
+			for i := 0; i < 10; i++ {
+				if _, err := d.H.Do(ctx, http.MethodPost, url, "", wrong,
+					http.StatusUnauthorized); err != nil {
+					break
+				}
+			}
+
+			right := map[string]string{"id": d.UserID(alias), "password": password}
+			_, err := d.H.Do(ctx, http.MethodPost, url, "", right, http.StatusTooManyRequests)
+			return err
+		},
+	}
+}
+
+// twoFactor is the TOTP secret and the unused recovery codes of an alias.
+type twoFactor struct {
+	secret        string
+	recoveryCodes []string
+}
+
+// EnableTwoFactor enables the two-factor authentication of the alias.
+func EnableTwoFactor(alias string) Step {
+	return Step{
+		Name: "enable two-factor authentication of " + alias,
+		Run: func(ctx context.Context, d *Driver) error {
+			secret, codes, err := d.H.EnableTwoFactor(ctx, d.Token(alias))
+			if err != nil {
+				return err
+			}
+
+			d.mu.Lock()
+			d.secrets[alias] = &twoFactor{secret: secret, recoveryCodes: codes}
+			d.mu.Unlock()
+			return nil
+		},
+	}
+}
+
+// LoginTwoFactor obtains a new access token for the alias with two-factor authentication
+// enabled, using a TOTP code or, when recovery is true, one of the recovery codes.
+func LoginTwoFactor(alias string, recovery bool) Step {
+	return Step{
+		Name: fmt.Sprintf("login %s with two-factor authentication (recovery: %t)", alias, recovery),
+		Run: func(ctx context.Context, d *Driver) error {
+			d.mu.Lock()
+			tf, ok := d.secrets[alias]
+			d.mu.Unlock()
+			if !ok {
+				return fmt.Errorf("two-factor authentication of %s is not enabled", alias)
+			}
+
+			var code string
+			if recovery {
+				d.mu.Lock()
+				code, tf.recoveryCodes = tf.recoveryCodes[0], tf.recoveryCodes[1:]
+				d.mu.Unlock()
+			} else {
+				// each code is accepted once, the code of the next time step is still
+				// accepted within the clock drift tolerated.
+				var err error
+				if code, err = totp.Code(tf.secret, totp.Step(time.Now())+1); err != nil {
+					return err
+				}
+			}
+
+			token, err := d.H.LoginTwoFactor(ctx, d.UserID(alias), password, code)
+			if err != nil {
+				return err
+			}
+
+			d.mu.Lock()
+			d.tokens[alias] = token
+			d.mu.Unlock()
+			return nil
+		},
+	}
+}
+
+// Logout revokes the access token of the alias, or all its tokens when everywhere is true.
+func Logout(alias string, everywhere bool) Step {
+	return Step{
+		Name: fmt.Sprintf("logout %s (everywhere: %t)", alias, everywhere),
+		Run: func(ctx context.Context, d *Driver) error {
+			return d.H.Logout(ctx, d.Token(alias), everywhere)
+		},
+	}
+}
+
+// RevokeSession revokes the session of the current access token of the alias, listed
+// by auth-service along with the other sessions of the user.
+func RevokeSession(alias string) Step {
+	return Step{
+		Name: "revoke session of " + alias,
+		Run: func(ctx context.Context, d *Driver) error {
+			sessions, err := d.H.Sessions(ctx, d.Token(alias))
+			if err != nil {
+				return err
+			}
+			for _, s := range sessions {
+				if s.Current {
+					return d.H.RevokeSession(ctx, d.Token(alias), s.ID)
+				}
+			}
+			return fmt.Errorf("no current session listed for %s", alias)
+		},
+	}
+}
+
+// ServiceAccount creates the service account of the alias, allowed only to send messages
+// to the aliases informed and to manage its webhook, and obtains its access token. The service account is created
+// by the administrator, which is signed up by the first call.
+func ServiceAccount(alias string, to ...string) Step {
+	return Step{
+		Name: fmt.Sprintf("create service account %s sending to %v", alias, to),
+		Run: func(ctx context.Context, d *Driver) error {
+			adminToken, err := d.H.AdminToken(ctx, password)
+			if err != nil {
+				return err
+			}
+
+			scopes := []string{scope.WebhookWrite}
+			for _, a := range to {
+				scopes = append(scopes, scope.User(d.UserID(a)))
+			}
+			clientID, secret, err := d.H.CreateClient(ctx, adminToken, alias, scopes)
+			if err != nil {
+				return err
+			}
+			token, err := d.H.ClientToken(ctx, clientID, secret)
+			if err != nil {
+				return err
+			}
+
+			d.mu.Lock()
+			d.users[alias] = clientID
+			d.tokens[alias] = token
+			d.mu.Unlock()
+			return nil
+		},
+	}
+}
+
+// Webhook registers a webhook of the service account of the alias, delivered to a local
+// receiver started for it.
+func Webhook(alias string) Step {
+	return Step{
+		Name: "register webhook of " + alias,
+		Run: func(ctx context.Context, d *Driver) error {
+			recv := webhooktest.NewReceiver()
+			secret, err := d.H.SetWebhook(ctx, d.Token(alias), recv.URL)
+			if err != nil {
+				recv.Close()
+				return err
+			}
+			recv.SetSecret(secret)
+
+			d.mu.Lock()
+			d.hooks[alias] = recv
+			d.mu.Unlock()
+			return nil
+		},
+	}
+}
+
+// ResetPassword resets the password of the alias, which keeps the password of the driver.
+func ResetPassword(alias string) Step {
+	return Step{
+		Name: "reset password of " + alias,
+		Run: func(ctx context.Context, d *Driver) error {
+			return d.H.ResetPassword(ctx, d.UserID(alias), password)
+		},
+	}
+}
+
+// DeleteAccount deletes the account of the alias.
+func DeleteAccount(alias string) Step {
+	return Step{
+		Name: "delete account of " + alias,
+		Run: func(ctx context.Context, d *Driver) error {
+			return d.H.DeleteAccount(ctx, d.Token(alias), password)
+		},
+	}
+}
+
+// ExpectLoginRejected checks that auth-service rejects the password of the alias.
+func ExpectLoginRejected(alias string) Step {
+	return Step{
+		Name: "expect login rejected of " + alias,
+		Run: func(ctx context.Context, d *Driver) error {
+			body := map[string]string{"id": d.UserID(alias), "password": password}
+			_, err := d.H.Do(ctx, http.MethodPost, d.H.AuthURL+"/v1/login", "", body,
+				http.StatusUnauthorized)
+			return err
+		},
+	}
+}
+
+// ExpectRevoked checks that user-service rejects the access token of the alias.
+func ExpectRevoked(alias string) Step {
+	return Step{
+		Name: "expect token revoked of " + alias,
+		Run: func(ctx context.Context, d *Driver) error {
+			_, err := d.H.Do(ctx, http.MethodGet, d.H.UserURL+"/v1/contact", d.Token(alias), nil,
+				http.StatusUnauthorized)
+			return err
+		},
+	}
+}
+
+// Connect opens the WebSocket connection of the alias.
+func Connect(alias string) Step {
+	return Step{
+		Name: "connect " + alias,
+		Run: func(ctx context.Context, d *Driver) error {
+			c, err := d.H.Dial(ctx, d.UserID(alias), d.Token(alias))
+			if err != nil {
+				return err
+			}
+
+			d.mu.Lock()
+			d.clients[alias] = c
+			d.mu.Unlock()
+			return nil
+		},
+	}
+}
+
+// Disconnect closes the WebSocket connection of the alias.
+func Disconnect(alias string) Step {
+	return Step{
+		Name: "disconnect " + alias,
+		Run: func(ctx context.Context, d *Driver) error {
+			c, err := d.Client(alias)
+			if err != nil {
+				return err
+			}
+
+			d.mu.Lock()
+			delete(d.clients, alias)
+			d.mu.Unlock()
+			return c.Close()
+		},
+	}
+}
+
+// Send sends a text message from one alias to another, saving it with the label
+// to be checked by the ExpectMessage and ExpectAck steps.
+func Send(label, from, to, content string) Step {
+	return Step{
+		Name: fmt.Sprintf("send %q from %s to %s", label, from, to),
+		Run: func(ctx context.Context, d *Driver) error {
+			c, err := d.Client(from)
+			if err != nil {
+				return err
+			}
+
+			msg, err := c.Send(d.UserID(to), "", message.ContentTypeText, content)
+			if err != nil {
+				return err
+			}
+
+			d.mu.Lock()
+			d.sent[label] = msg
+			d.mu.Unlock()
+			return nil
+		},
+	}
+}
+
+// Reply sends a text message from the alias through the REST endpoint of chat-service,
+// as bots do, saving it with the label to be checked by the ExpectMessage step. The
+// delivery errors are reported to the receiver started by the DeliveryErrors step, if any.
+func Reply(label, from, to, content string) Step {
+	return Step{
+		Name: fmt.Sprintf("reply %q from %s to %s", label, from, to),
+		Run: func(ctx context.Context, d *Driver) error {
+			var callback string
+			d.mu.Lock()
+			if recv := d.errors[from]; recv != nil {
+				callback = recv.URL
+			}
+			d.mu.Unlock()
+
+			id, err := d.H.SendMessage(ctx, d.Token(from), d.UserID(to), content, callback)
+			if err != nil {
+				return err
+			}
+
+			d.mu.Lock()
+			d.sent[label] = &message.Message{
+				ID:          id,
+				From:        d.UserID(from),
+				To:          d.UserID(to),
+				ContentType: message.ContentTypeText.String(),
+				Content:     content,
+			}
+			d.mu.Unlock()
+			return nil
+		},
+	}
+}
+
+// DeliveryErrors starts a local receiver of the delivery errors of the messages that the
+// alias sends with the Reply step.
+func DeliveryErrors(alias string) Step {
+	return Step{
+		Name: "receive delivery errors of " + alias,
+		Run: func(ctx context.Context, d *Driver) error {
+			d.mu.Lock()
+			d.errors[alias] = webhooktest.NewReceiver()
+			d.mu.Unlock()
+			return nil
+		},
+	}
+}
+
+// ExpectAck waits for the ACK of the message with the label by its sender.
+func ExpectAck(label string) Step {
+	return Step{
+		Name: fmt.Sprintf("expect ack of %q", label),
+		Run: func(ctx context.Context, d *Driver) error {
+			sent := d.Sent(label)
+			if sent == nil {
+				return fmt.Errorf("message %q was not sent", label)
+			}
+			return d.expect(sent.From, func(msg *message.Message) bool {
+				return msg.ID == sent.ID && msg.ContentType == message.ContentTypeACK.String()
+			})
+		},
+	}
+}
+
+// ExpectRejected waits for the error response to the message with the label by its sender.
+func ExpectRejected(label string) Step {
+	return Step{
+		Name: fmt.Sprintf("expect rejection of %q", label),
+		Run: func(ctx context.Context, d *Driver) error {
+			sent := d.Sent(label)
+			if sent == nil {
+				return fmt.Errorf("message %q was not sent", label)
+			}
+			return d.expect(sent.From, func(msg *message.Message) bool {
+				return msg.ID == sent.ID && msg.ContentType == message.ContentTypeError.String()
+			})
+		},
+	}
+}
+
+// ExpectForbidden checks that user-service rejects the contacts request of the alias
+// for the lack of scope.
+func ExpectForbidden(alias string) Step {
+	return Step{
+		Name: "expect contacts forbidden to " + alias,
+		Run: func(ctx context.Context, d *Driver) error {
+			_, err := d.H.Do(ctx, http.MethodGet, d.H.UserURL+"/v1/contact", d.Token(alias), nil,
+				http.StatusForbidden)
+			return err
+		},
+	}
+}
+
+// ExpectMessage waits for the delivery of the message with the label to the alias.
+func ExpectMessage(alias, label string) Step {
+	return Step{
+		Name: fmt.Sprintf("expect %q by %s", label, alias),
+		Run: func(ctx context.Context, d *Driver) error {
+			sent := d.Sent(label)
+			if sent == nil {
+				return fmt.Errorf("message %q was not sent", label)
+			}
+			return d.expectAlias(alias, func(msg *message.Message) bool {
+				return msg.From == sent.From && msg.ContentType == sent.ContentType &&
+					msg.Content == sent.Content
+			})
+		},
+	}
+}
+
+// ExpectWebhook waits for the delivery of the message with the label to the webhook of
+// the alias.
+func ExpectWebhook(alias, label string) Step {
+	return Step{
+		Name: fmt.Sprintf("expect %q by webhook of %s", label, alias),
+		Run: func(ctx context.Context, d *Driver) error {
+			sent := d.Sent(label)
+			if sent == nil {
+				return fmt.Errorf("message %q was not sent", label)
+			}
+
+			d.mu.Lock()
+			recv := d.hooks[alias]
+			d.mu.Unlock()
+			if recv == nil {
+				return fmt.Errorf("%s has no webhook", alias)
+			}
+
+			_, err := recv.Expect(d.Timeout, func(dv *webhooktest.Delivery) bool {
+				return dv.Message.From == sent.From && dv.Message.Content == sent.Content
+			})
+			return err
+		},
+	}
+}
+
+// ExpectDeliveryError waits for the delivery error of the message with the label sent by
+// the alias with the Reply step.
+func ExpectDeliveryError(alias, label string) Step {
+	return Step{
+		Name: fmt.Sprintf("expect delivery error of %q by %s", label, alias),
+		Run: func(ctx context.Context, d *Driver) error {
+			sent := d.Sent(label)
+			if sent == nil {
+				return fmt.Errorf("message %q was not sent", label)
+			}
+
+			d.mu.Lock()
+			recv := d.errors[alias]
+			d.mu.Unlock()
+			if recv == nil {
+				return fmt.Errorf("%s does not receive delivery errors", alias)
+			}
+
+			_, err := recv.Expect(d.Timeout, func(dv *webhooktest.Delivery) bool {
+				return dv.Message.ID == sent.ID &&
+					dv.Message.ContentType == message.ContentTypeError.String()
+			})
+			return err
+		},
+	}
+}
+
+// ExpectClosed waits for chat-service to close the connection of the alias.
+func ExpectClosed(alias string) Step {
+	return Step{
+		Name: "expect connection closed of " + alias,
+		Run: func(ctx context.Context, d *Driver) error {
+			c, err := d.Client(alias)
+			if err != nil {
+				return err
+			}
+
+			select {
+			case <-c.Closed():
+				d.mu.Lock()
+				delete(d.clients, alias)
+				d.mu.Unlock()
+				return nil
+			case <-time.After(d.Timeout):
+				return ErrTimeout
+			}
+		},
+	}
+}
+
+// ExpectOnline waits for broker-service to route the messages of the aliases to their
+// connections, which are then registered by chat-service as well.
+func ExpectOnline(aliases ...string) Step {
+	return Step{
+		Name: fmt.Sprintf("expect online %v", aliases),
+		Run: func(ctx context.Context, d *Driver) error {
+			for _, alias := range aliases {
+				userID := d.UserID(alias)
+				err := d.eventually(ctx, func() (bool, error) {
+					server, err := d.H.UserServer(ctx, userID)
+					return server != "", err
+				})
+				if err != nil {
+					return fmt.Errorf("%s: %w", alias, err)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// ExpectOffline waits for broker-service to store the messages to the alias instead of
+// routing them to its connection.
+func ExpectOffline(alias string) Step {
+	return Step{
+		Name: "expect offline " + alias,
+		Run: func(ctx context.Context, d *Driver) error {
+			userID := d.UserID(alias)
+			return d.eventually(ctx, func() (bool, error) {
+				server, err := d.H.UserServer(ctx, userID)
+				return server == "", err
+			})
+		},
+	}
+}
+
+// ExpectStored waits for broker-service to store the message with the label to the alias,
+// which is offline.
+func ExpectStored(alias, label string) Step {
+	return Step{
+		Name: fmt.Sprintf("expect %q stored to %s", label, alias),
+		Run: func(ctx context.Context, d *Driver) error {
+			sent := d.Sent(label)
+			if sent == nil {
+				return fmt.Errorf("message %q was not sent", label)
+			}
+
+			userID := d.UserID(alias)
+			return d.eventually(ctx, func() (bool, error) {
+				messages, err := d.H.OfflineMessages(ctx, userID)
+				if err != nil {
+					return false, err
+				}
+				for _, msg := range messages {
+					if msg.ID == sent.ID {
+						return true, nil
+					}
+				}
+				return false, nil
+			})
+		},
+	}
+}
+
+// eventually polls cond until it is satisfied, returning ErrTimeout if it is not satisfied
+// within the timeout of the driver.
+func (d *Driver) eventually(ctx context.Context, cond func() (bool, error)) error {
+	timer := time.NewTimer(d.Timeout)
+	defer timer.Stop()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		ok, err := cond()
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-timer.C:
+			return ErrTimeout
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (d *Driver) expect(userID string, match func(msg *message.Message) bool) error {
+	d.mu.Lock()
+	var alias string
+	for a, id := range d.users {
+		if id == userID {
+			alias = a
+		}
+	}
+	d.mu.Unlock()
+
+	return d.expectAlias(alias, match)
+}
+
+func (d *Driver) expectAlias(alias string, match func(msg *message.Message) bool) error {
+	c, err := d.Client(alias)
+	if err != nil {
+		return err
+	}
+
+	if _, err = c.Expect(d.Timeout, match); err != nil {
+		return fmt.Errorf("%w (received %d other messages)", err, len(c.Received()))
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+// Package harness starts auth-service, user-service, chat-service and broker-service in the
+// same process, connected through an in-memory message bus and a Postgres schema created for
+// the run, so that scenario tests can exercise the full flow of the chat.
+package harness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tsmweb/allinone/pkg/memkafka"
+	authconfig "github.com/tsmweb/auth-service/config"
+	authjwks "github.com/tsmweb/auth-service/pkg/jwks"
+	brokerconfig "github.com/tsmweb/broker-service/config"
+	brokerdi "github.com/tsmweb/broker-service/di"
+	brokerdb "github.com/tsmweb/broker-service/infra/db"
+	"github.com/tsmweb/chat-server/pkg/jwks"
+	"github.com/tsmweb/chat-server/pkg/revocation"
+	chatconfig "github.com/tsmweb/chat-service/config"
+	"github.com/tsmweb/go-helper-api/kafka"
+	"github.com/tsmweb/go-helper-api/observability/event"
+	userconfig "github.com/tsmweb/user-service/config"
+)
+
+var (
+	// ErrNoDatabase is returned by Start when the Postgres settings are not informed.
+	ErrNoDatabase = errors.New("harness: E2E_DB_HOST is not set")
+
+	// ErrAlreadyStarted is returned by Start when the services are already running,
+	// as their settings are global to the process.
+	ErrAlreadyStarted = errors.New("harness: already started")
+)
+
+// AdminUserID is the user allowed to use the admin APIs of auth-service and chat-service.
+const AdminUserID = "+5500000000000"
+
+var startMu sync.Mutex
+var started bool
+
+// Harness holds the services running in the process.
+type Harness struct {
+	AuthURL string
+	UserURL string
+	ChatURL string
+	Kafka   kafka.Kafka
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	workDir string
+	db      *database
+	servers []*httptest.Server
+	sms     *smsInbox
+	broker  *brokerdi.Provider
+
+	adminMu       sync.Mutex
+	adminSignedUp bool
+}
+
+// Start loads the settings of the services, creates the database schema and starts
+// the services. The Postgres server is informed by the environment variables E2E_DB_HOST,
+// E2E_DB_PORT, E2E_DB_USER, E2E_DB_PASSWORD and E2E_DB_DATABASE.
+func Start() (*Harness, error) {
+	startMu.Lock()
+	defer startMu.Unlock()
+
+	if started {
+		return nil, ErrAlreadyStarted
+	}
+
+	dbSettings, err := databaseSettingsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	h := &Harness{}
+	h.ctx, h.cancel = context.WithCancel(context.Background())
+
+	if err = h.start(dbSettings); err != nil {
+		h.Close()
+		return nil, err
+	}
+
+	started = true
+	return h, nil
+}
+
+func (h *Harness) start(dbSettings *databaseSettings) error {
+	var err error
+
+	if h.workDir, err = os.MkdirTemp("", "chat-e2e-"); err != nil {
+		return err
+	}
+	if err = writeKeys(filepath.Join(h.workDir, "config", "cert")); err != nil {
+		return err
+	}
+	// The settings are informed by the environment, the .env file only needs to exist.
+	if err = os.WriteFile(filepath.Join(h.workDir, ".env"), nil, 0o600); err != nil {
+		return err
+	}
+
+	if h.db, err = createDatabase(dbSettings); err != nil {
+		return err
+	}
+
+	if err = setEnv(dbSettings, h.db.schema); err != nil {
+		return err
+	}
+	if err = loadConfig(h.workDir); err != nil {
+		return err
+	}
+
+	h.Kafka = memkafka.New()
+	if err = event.Init(h.Kafka.NewProducer(chatconfig.KafkaEventsTopic())); err != nil {
+		return err
+	}
+
+	keyring, err := authjwks.LoadKeyFile(authconfig.KeySecureFile())
+	if err != nil {
+		return err
+	}
+	revoked := revocation.NewMemoryStore()
+
+	// auth-service signs the access tokens and publishes the keys fetched by the other
+	// services to verify them.
+	h.sms = newSMSInbox()
+	h.AuthURL = h.serve(authRouter(authjwks.NewJWT(keyring), keyring, h.Kafka, revoked, h.sms))
+
+	ttl := time.Duration(chatconfig.JWKSCacheTTL()) * time.Second
+	jwt := jwks.NewJWT(jwks.NewCache([]string{h.AuthURL + "/.well-known/jwks.json"}, ttl))
+
+	h.broker = brokerdi.CreateProviderWith(h.ctx, h.Kafka, brokerdb.NewMemoryCacheDB())
+	go h.broker.BrokerProvider().Start()
+	go newReportConsumer(h.Kafka).Start(h.ctx)
+	go newAccountConsumer(h.Kafka).Start(h.ctx)
+
+	chat, err := chatRouter(h.ctx, jwt, h.Kafka, revoked)
+	if err != nil {
+		return err
+	}
+
+	h.UserURL = h.serve(userRouter(jwt, h.Kafka, revoked))
+	h.ChatURL = h.serve(chat)
+
+	return nil
+}
+
+func (h *Harness) serve(handler http.Handler) string {
+	srv := httptest.NewServer(handler)
+	h.servers = append(h.servers, srv)
+	return srv.URL
+}
+
+// Close stops the services and drops the database schema.
+func (h *Harness) Close() {
+	for _, srv := range h.servers {
+		srv.Close()
+	}
+	h.cancel()
+
+	// Gives the consumers time to stop before the schema is dropped.
+	time.Sleep(100 * time.Millisecond)
+
+	if h.db != nil {
+		h.db.drop()
+	}
+	if h.workDir != "" {
+		os.RemoveAll(h.workDir)
+	}
+}
+
+func setEnv(s *databaseSettings, schema string) error {
+	env := map[string]string{
+		"HOST_ID":                     "E2E01",
+		"SERVER_PORT":                 "0",
+		"GOPOOL_SIZE":                 "16",
+		"EXPIRE_TOKEN":                "1",
+		"TOKEN_CHECK_INTERVAL":        "1",
+		"ADMIN_USERS":                 AdminUserID,
+		"DB_HOST":                     s.host,
+		"DB_PORT":                     strconv.Itoa(s.port),
+		"DB_USER":                     s.user,
+		"DB_PASSWORD":                 s.password,
+		"DB_DATABASE":                 s.name,
+		"DB_SCHEMA":                   schema,
+		"KAFKA_CLIENT_ID":             "E2E01_SERVICE",
+		"KAFKA_GROUP_ID":              "E2E_SERVICE",
+		"KAFKA_USERS_TOPIC":           "USERS",
+		"KAFKA_USERS_PRESENCE_TOPIC":  "USERS_PRESENCE",
+		"KAFKA_NEW_MESSAGES_TOPIC":    "NEW_MESSAGES",
+		"KAFKA_OFF_MESSAGES_TOPIC":    "OFF_MESSAGES",
+		"KAFKA_GROUP_EVENT_TOPIC":     "GROUP_EVENTS",
+		"KAFKA_CONTACT_EVENT_TOPIC":   "CONTACT_EVENTS",
+		"KAFKA_ACCOUNT_EVENT_TOPIC":   "ACCOUNT_EVENTS",
+		"KAFKA_DELETION_REPORT_TOPIC": "DELETION_REPORTS",
+		"KAFKA_HOST_TOPIC":            "MESSAGES",
+		"KAFKA_EVENTS_TOPIC":          "EVENTS",
+		"KAFKA_TOKENS_TOPIC":          "TOKENS",
+		"OTP_SECRET":                  "E2E_OTP_SECRET",
+		// The webhook receivers of the tests listen on loopback addresses.
+		"WEBHOOK_ALLOW_PRIVATE": "true",
+	}
+
+	for key, value := range env {
+		if err := os.Setenv(key, value); err != nil {
+			return fmt.Errorf("harness: setenv %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func loadConfig(workDir string) error {
+	loaders := []func(string) error{
+		authconfig.Load,
+		userconfig.Load,
+		chatconfig.Load,
+		brokerconfig.Load,
+	}
+
+	for _, load := range loaders {
+		if err := load(workDir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package harness
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+)
+
+// writeKeys writes the RSA key used to sign the access tokens in the file read by the
+// auth-service config package.
+func writeKeys(dir string) error {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	return os.WriteFile(filepath.Join(dir, "server.pem"), pem.EncodeToMemory(block), 0o600)
+}
@@ -0,0 +1,301 @@
+package harness
+
+import (
+	"context"
+	"time"
+
+	"github.com/gorilla/mux"
+	authadapter "github.com/tsmweb/auth-service/adapter"
+	authaccount "github.com/tsmweb/auth-service/app/account"
+	authclient "github.com/tsmweb/auth-service/app/client"
+	"github.com/tsmweb/auth-service/app/login"
+	"github.com/tsmweb/auth-service/app/reset"
+	authsession "github.com/tsmweb/auth-service/app/session"
+	authtoken "github.com/tsmweb/auth-service/app/token"
+	"github.com/tsmweb/auth-service/app/twofactor"
+	authuser "github.com/tsmweb/auth-service/app/user"
+	"github.com/tsmweb/auth-service/app/verification"
+	authconfig "github.com/tsmweb/auth-service/config"
+	authdb "github.com/tsmweb/auth-service/infra/db"
+	authrepository "github.com/tsmweb/auth-service/infra/repository"
+	authjwks "github.com/tsmweb/auth-service/pkg/jwks"
+	authhandler "github.com/tsmweb/auth-service/web/api/handler"
+	brokermessage "github.com/tsmweb/broker-service/broker/message"
+	brokerrepository "github.com/tsmweb/broker-service/infra/repository"
+	"github.com/tsmweb/chat-server/pkg/ratelimit"
+	"github.com/tsmweb/chat-server/pkg/revocation"
+	chatadapter "github.com/tsmweb/chat-service/adapter"
+	chatconfig "github.com/tsmweb/chat-service/config"
+	"github.com/tsmweb/chat-service/pkg/epoll"
+	"github.com/tsmweb/chat-service/server"
+	chatmessage "github.com/tsmweb/chat-service/server/message"
+	"github.com/tsmweb/chat-service/server/token"
+	chatuser "github.com/tsmweb/chat-service/server/user"
+	chatapi "github.com/tsmweb/chat-service/web/api"
+	"github.com/tsmweb/easygo/netpoll"
+	"github.com/tsmweb/go-helper-api/auth"
+	"github.com/tsmweb/go-helper-api/kafka"
+	"github.com/tsmweb/go-helper-api/middleware"
+	useradapter "github.com/tsmweb/user-service/adapter"
+	"github.com/tsmweb/user-service/app/account"
+	"github.com/tsmweb/user-service/app/contact"
+	"github.com/tsmweb/user-service/app/group"
+	userconfig "github.com/tsmweb/user-service/config"
+	userdb "github.com/tsmweb/user-service/infra/db"
+	userrepository "github.com/tsmweb/user-service/infra/repository"
+	userhandler "github.com/tsmweb/user-service/web/api/handler"
+)
+
+// The functions below wire each service the same way as its cmd/<service>/di.go, or its
+// di package, replacing Apache Kafka and Redis with the in-memory implementations.
+
+func authRouter(jwt auth.JWT, keyring *authjwks.Keyring, queue kafka.Kafka,
+	revoked revocation.Store, sender verification.SMSSender) *mux.Router {
+	mAuth := revocation.NewAuth(middleware.NewAuth(jwt), jwt, revoked)
+	database := authdb.NewPostgresDatabase()
+	attemptRepository := authrepository.NewLoginAttemptRepositoryMemory()
+	r := mux.NewRouter()
+
+	authhandler.MakeJWKSHandlers(
+		r,
+		keyring,
+		time.Duration(authconfig.JWKSMaxAge())*time.Second)
+
+	verificationRepository := authrepository.NewVerificationRepositoryPostgres(database)
+	authhandler.MakeVerificationHandlers(
+		r,
+		verification.NewRequestUseCase(verificationRepository, attemptRepository, sender))
+
+	lockout := time.Duration(authconfig.LoginLockout()) * time.Minute
+	delay := time.Duration(authconfig.LoginDelay()) * time.Second
+	throttle := login.NewThrottle(attemptRepository,
+		login.Policy{
+			FreeAttempts: authconfig.LoginFreeAttempts(),
+			Delay:        delay,
+			MaxAttempts:  authconfig.LoginMaxAttempts(),
+			Lockout:      lockout,
+		},
+		login.Policy{
+			FreeAttempts: authconfig.LoginIPFreeAttempts(),
+			Delay:        delay,
+			MaxAttempts:  authconfig.LoginIPMaxAttempts(),
+			Lockout:      lockout,
+		})
+
+	loginRepository := authrepository.NewLoginRepositoryPostgres(database)
+	userRepository := authrepository.NewUserRepositoryPostgres(database)
+	deletionRepository := authrepository.NewDeletionRepositoryPostgres(database)
+	accountEncoder := authaccount.EventEncoderFunc(authadapter.AccountEventMarshal)
+	accountProducer := queue.NewProducer(authconfig.KafkaAccountEventTopic())
+	authhandler.MakeUserHandlers(
+		r,
+		jwt,
+		mAuth,
+		authuser.NewGetUseCase(userRepository),
+		authuser.NewCreateUseCase(userRepository, verification.NewVerifier(verificationRepository),
+			accountEncoder, accountProducer),
+		authuser.NewUpdateUseCase(userRepository, accountEncoder, accountProducer),
+		authuser.NewDeleteUseCase(userRepository, loginRepository, throttle, revoked,
+			login.TokenRevocationEncoderFunc(authadapter.TokenRevocationMarshal),
+			queue.NewProducer(authconfig.KafkaTokensTopic()),
+			accountEncoder, accountProducer))
+
+	refreshTokenRepository := authrepository.NewRefreshTokenRepositoryPostgres(database)
+	sessionRepository := authrepository.NewSessionRepositoryPostgres(database)
+	issuer := authtoken.NewIssuer(refreshTokenRepository, sessionRepository, jwt)
+	revocationEncoder := login.TokenRevocationEncoderFunc(authadapter.TokenRevocationMarshal)
+	tokenProducer := queue.NewProducer(authconfig.KafkaTokensTopic())
+	revokeSessionUseCase := login.NewRevokeSessionUseCase(sessionRepository, revoked,
+		revocationEncoder, tokenProducer)
+	authhandler.MakeTokenHandlers(
+		r,
+		authtoken.NewRefreshUseCase(refreshTokenRepository, issuer, revokeSessionUseCase))
+
+	twoFactorRepository := authrepository.NewTwoFactorRepositoryPostgres(database)
+	authhandler.MakeTwoFactorHandlers(
+		r,
+		jwt,
+		mAuth,
+		twofactor.NewEnrolUseCase(twoFactorRepository),
+		twofactor.NewConfirmUseCase(twoFactorRepository),
+		twofactor.NewDisableUseCase(twoFactorRepository),
+		twofactor.NewVerifyUseCase(twoFactorRepository, issuer, attemptRepository,
+			twofactor.Lockout{
+				MaxAttempts: authconfig.TOTPUserMaxAttempts(),
+				Duration:    time.Duration(authconfig.TOTPUserLockout()) * time.Minute,
+			}))
+
+	authhandler.MakeAdminHandlers(
+		r,
+		jwt,
+		mAuth,
+		login.NewUnlockUseCase(throttle),
+		authuser.NewGetDeletionUseCase(deletionRepository),
+		authuser.NewRepublishDeletionUseCase(deletionRepository, revoked,
+			login.TokenRevocationEncoderFunc(authadapter.TokenRevocationMarshal),
+			queue.NewProducer(authconfig.KafkaTokensTopic()),
+			accountEncoder, accountProducer))
+
+	resetRepository := authrepository.NewResetRepositoryPostgres(database)
+	authhandler.MakeResetHandlers(
+		r,
+		reset.NewRequestUseCase(resetRepository, loginRepository, reset.NotifierFunc(sender.Send)),
+		reset.NewConfirmUseCase(resetRepository, loginRepository, refreshTokenRepository, revoked,
+			revocationEncoder, tokenProducer, accountEncoder, accountProducer))
+
+	authhandler.MakeLoginHandlers(
+		r,
+		jwt,
+		mAuth,
+		login.NewLoginUseCase(loginRepository, issuer, twofactor.NewChallenger(twoFactorRepository),
+			throttle),
+		login.NewUpdateUseCase(loginRepository, revoked, refreshTokenRepository,
+			revocationEncoder, tokenProducer, accountEncoder, accountProducer),
+		login.NewLogoutUseCase(refreshTokenRepository, revoked, revocationEncoder, tokenProducer))
+
+	authhandler.MakeSessionHandlers(
+		r,
+		jwt,
+		mAuth,
+		authsession.NewListUseCase(sessionRepository),
+		revokeSessionUseCase)
+
+	clientRepository := authrepository.NewClientRepositoryPostgres(database)
+	authhandler.MakeClientHandlers(
+		r,
+		jwt,
+		mAuth,
+		authclient.NewCreateUseCase(clientRepository),
+		authclient.NewGetAllUseCase(clientRepository),
+		authclient.NewRevokeUseCase(clientRepository, revoked, revocationEncoder, tokenProducer),
+		authclient.NewTokenUseCase(clientRepository, jwt),
+		authclient.NewSetWebhookUseCase(clientRepository),
+		authclient.NewDeleteWebhookUseCase(clientRepository))
+
+	return r
+}
+
+func newReportConsumer(queue kafka.Kafka) *authuser.ReportConsumer {
+	database := authdb.NewPostgresDatabase()
+	return authuser.NewReportConsumer(
+		queue.NewConsumer(authconfig.KafkaGroupID(), authconfig.KafkaDeletionReportTopic()),
+		authuser.DeletionReportDecoderFunc(authadapter.DeletionReportUnmarshal),
+		authuser.NewReportUseCase(authrepository.NewUserRepositoryPostgres(database),
+			authrepository.NewDeletionRepositoryPostgres(database)))
+}
+
+// newAccountConsumer subscribes with the client ID, the services share the settings and the
+// group ID already belongs to the broker on the account events topic.
+func newAccountConsumer(queue kafka.Kafka) *account.Consumer {
+	database := userdb.NewPostgresDatabase()
+	return account.NewConsumer(
+		queue.NewConsumer(userconfig.KafkaClientID(), userconfig.KafkaAccountEventTopic()),
+		account.EventDecoderFunc(useradapter.AccountEventUnmarshal),
+		account.NewDeleteUseCase(
+			contact.NewRemoveUserUseCase(userrepository.NewContactRepositoryPostgres(database)),
+			group.NewRemoveUserUseCase(userrepository.NewGroupRepositoryPostgres(database),
+				group.EventEncoderFunc(useradapter.GroupEventMarshal),
+				queue.NewProducer(userconfig.KafkaGroupEventTopic())),
+			account.ReportEncoderFunc(useradapter.DeletionReportMarshal),
+			queue.NewProducer(userconfig.KafkaDeletionReportTopic())))
+}
+
+func userRouter(jwt auth.JWT, queue kafka.Kafka, revoked revocation.Store) *mux.Router {
+	mAuth := revocation.NewAuth(middleware.NewAuth(jwt), jwt, revoked)
+	database := userdb.NewPostgresDatabase()
+	r := mux.NewRouter()
+
+	contactRepository := userrepository.NewContactRepositoryPostgres(database)
+	contactEncoder := contact.EventEncoderFunc(useradapter.ContactEventMarshal)
+	contactProducer := queue.NewProducer(userconfig.KafkaContactEventTopic())
+	limiter := ratelimit.NewMemoryLimiter()
+	userhandler.MakeContactRouters(
+		r,
+		jwt,
+		mAuth,
+		contact.NewGetUseCase(contactRepository),
+		contact.NewGetAllUseCase(contactRepository),
+		contact.NewGetPresenceUseCase(contactRepository),
+		contact.NewCreateUseCase(contactRepository),
+		contact.NewUpdateUseCase(contactRepository),
+		contact.NewDeleteUseCase(contactRepository),
+		contact.NewBlockUseCase(contactRepository, contactEncoder, contactProducer),
+		contact.NewUnblockUseCase(contactRepository, contactEncoder, contactProducer),
+		contact.NewImportUseCase(contactRepository, limiter),
+		contact.NewGetAllBlockedUseCase(contactRepository),
+		contact.NewReportUseCase(contactRepository, contactEncoder, contactProducer, limiter))
+
+	groupRepository := userrepository.NewGroupRepositoryPostgres(database)
+	groupEncoder := group.EventEncoderFunc(useradapter.GroupEventMarshal)
+	groupProducer := queue.NewProducer(userconfig.KafkaGroupEventTopic())
+	userhandler.MakeGroupRouters(
+		r,
+		jwt,
+		mAuth,
+		group.NewGetUseCase(groupRepository),
+		group.NewGetAllUseCase(groupRepository),
+		group.NewCreateUseCase(groupRepository),
+		group.NewUpdateUseCase(groupRepository, groupEncoder, groupProducer),
+		group.NewDeleteUseCase(groupRepository, groupEncoder, groupProducer),
+		group.NewAddMemberUseCase(groupRepository, groupEncoder, groupProducer),
+		group.NewRemoveMemberUseCase(groupRepository, groupEncoder, groupProducer),
+		group.NewSetAdminUseCase(groupRepository, groupEncoder, groupProducer),
+		group.NewLeaveGroupUseCase(groupRepository, groupEncoder, groupProducer),
+		group.NewTransferOwnershipUseCase(groupRepository, groupEncoder, groupProducer))
+
+	return r
+}
+
+func chatRouter(ctx context.Context, jwt auth.JWT, queue kafka.Kafka,
+	revoked revocation.Store) (*mux.Router, error) {
+	poller, err := netpoll.New(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	userEncoder := chatuser.EncoderFunc(chatadapter.UserMarshal)
+	messageEncoder := chatmessage.EncoderFunc(chatadapter.MessageMarshal)
+
+	serv := server.NewServer(
+		ctx,
+		epoll.NewEPoll(poller),
+		chatadapter.NewReaderWS(int64(chatconfig.MaxFrameSize())),
+		server.ConnWriterFunc(chatadapter.WriterWS),
+		server.ConnCloserFunc(chatadapter.CloserWS),
+		chatmessage.DecoderFunc(chatadapter.MessageUnmarshal),
+		token.DecoderFunc(chatadapter.RevocationUnmarshal),
+		token.NewRevocationValidator(token.NewValidator(jwt), revoked),
+		queue.NewConsumer(chatconfig.KafkaClientID(), chatconfig.KafkaHostTopic()),
+		queue.NewConsumer(chatconfig.KafkaClientID(), chatconfig.KafkaTokensTopic()),
+		server.NewHandleMessage(messageEncoder,
+			queue.NewProducer(chatconfig.KafkaNewMessagesTopic())),
+		server.NewHandleMessage(messageEncoder,
+			queue.NewProducer(chatconfig.KafkaOffMessagesTopic())),
+		server.NewHandleUserStatus(userEncoder,
+			queue.NewProducer(chatconfig.KafkaUsersTopic()),
+			queue.NewProducer(chatconfig.KafkaUsersPresenceTopic())),
+	)
+
+	r := mux.NewRouter()
+	mAuth := revocation.NewAuth(middleware.NewAuth(jwt), jwt, revoked)
+	chatapi.MakeChatRouter(r, jwt, mAuth, serv)
+	chatapi.MakeAdminRouter(r, jwt, mAuth, serv)
+	chatapi.MakeMessageRouter(r, jwt, mAuth, serv)
+
+	return r, nil
+}
+
+// UserServer returns the chat-service host to which broker-service routes the messages to the
+// user, or an empty string if the user is offline.
+func (h *Harness) UserServer(ctx context.Context, userID string) (string, error) {
+	userRepository := brokerrepository.NewUserRepository(h.broker.DatabaseProvider(),
+		h.broker.CacheDBProvider())
+	return userRepository.GetUserServer(ctx, userID)
+}
+
+// OfflineMessages returns the messages stored by broker-service while the user is offline.
+func (h *Harness) OfflineMessages(ctx context.Context, userID string) ([]*brokermessage.Message, error) {
+	messageRepository := brokerrepository.NewMessageRepository(h.broker.DatabaseProvider(),
+		h.broker.CacheDBProvider())
+	return messageRepository.GetAllMessages(ctx, userID)
+}
@@ -0,0 +1,55 @@
+package harness
+
+import (
+	"context"
+	"regexp"
+	"sync"
+)
+
+var (
+	codeRegexp       = regexp.MustCompile(`\b[0-9]{6}\b`)
+	resetTokenRegexp = regexp.MustCompile(`reset token is ([A-Za-z0-9_-]+)`)
+)
+
+// smsInbox replaces the SMS delivery of auth-service, keeping the last verification code
+// and password reset token sent to each phone number.
+type smsInbox struct {
+	mu          sync.Mutex
+	codes       map[string]string
+	resetTokens map[string]string
+}
+
+func newSMSInbox() *smsInbox {
+	return &smsInbox{
+		codes:       make(map[string]string),
+		resetTokens: make(map[string]string),
+	}
+}
+
+// Send implements the verification.SMSSender interface.
+func (s *smsInbox) Send(_ context.Context, phone, text string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if m := resetTokenRegexp.FindStringSubmatch(text); m != nil {
+		s.resetTokens[phone] = m[1]
+		return nil
+	}
+	s.codes[phone] = codeRegexp.FindString(text)
+	return nil
+}
+
+// code returns the last verification code sent to the phone number.
+func (s *smsInbox) code(phone string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	code, ok := s.codes[phone]
+	return code, ok && code != ""
+}
+
+// resetToken returns the last password reset token sent to the phone number.
+func (s *smsInbox) resetToken(phone string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token, ok := s.resetTokens[phone]
+	return token, ok
+}
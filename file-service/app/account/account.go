@@ -0,0 +1,66 @@
+package account
+
+import (
+	"time"
+)
+
+// ServiceName identifies file-service in the DeletionReport.
+const ServiceName = "file-service"
+
+// EventUserDeleted is the name of the event published by auth-service when an account is deleted.
+const EventUserDeleted = "UserDeleted"
+
+// Event represents the events of the user account published by auth-service.
+type Event struct {
+	UserID    string
+	Event     string
+	EventDate time.Time
+}
+
+// EventDecoder is a byte slice decoder for Event.
+type EventDecoder interface {
+	Unmarshal(in []byte, e *Event) error
+}
+
+// The EventDecoderFunc type is an adapter to allow the use of ordinary functions as decoders of
+// byte slice for Event.
+// If f is a function with the appropriate signature, EventDecoderFunc(f) is a EventDecoder that
+// calls f.
+type EventDecoderFunc func(in []byte, e *Event) error
+
+// Unmarshal calls f(in, e).
+func (f EventDecoderFunc) Unmarshal(in []byte, e *Event) error {
+	return f(in, e)
+}
+
+// DeletionReport reports to auth-service that the data of a deleted user was removed.
+type DeletionReport struct {
+	UserID      string
+	Service     string
+	CompletedAt time.Time
+}
+
+// NewDeletionReport return an instance of DeletionReport for file-service.
+func NewDeletionReport(userID string) *DeletionReport {
+	return &DeletionReport{
+		UserID:      userID,
+		Service:     ServiceName,
+		CompletedAt: time.Now().UTC(),
+	}
+}
+
+// ReportEncoder is a DeletionReport encoder for byte slice.
+type ReportEncoder interface {
+	Marshal(r *DeletionReport) ([]byte, error)
+}
+
+// The ReportEncoderFunc type is an adapter to allow the use of ordinary functions as encoders of
+// DeletionReport for byte slice.
+// If f is a function with the appropriate signature, ReportEncoderFunc(f) is a ReportEncoder
+// that calls f.
+type ReportEncoderFunc func(r *DeletionReport) ([]byte, error)
+
+// Marshal calls f(r).
+func (f ReportEncoderFunc) Marshal(r *DeletionReport) ([]byte, error) {
+	return f(r)
+}
@@ -0,0 +1,66 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/tsmweb/file-service/app/media"
+	"github.com/tsmweb/file-service/common/service"
+	"github.com/tsmweb/file-service/config"
+	"github.com/tsmweb/go-helper-api/kafka"
+)
+
+// DeleteUseCase removes the profile picture and the media files uploaded by a deleted user and
+// reports it to auth-service, otherwise an error is returned.
+type DeleteUseCase interface {
+	Execute(ctx context.Context, userID string) error
+}
+
+type deleteUseCase struct {
+	tag      string
+	encoder  ReportEncoder
+	producer kafka.Producer
+}
+
+// NewDeleteUseCase create a new instance of DeleteUseCase.
+func NewDeleteUseCase(encoder ReportEncoder, producer kafka.Producer) DeleteUseCase {
+	return &deleteUseCase{
+		tag:      "account::DeleteUseCase",
+		encoder:  encoder,
+		producer: producer,
+	}
+}
+
+// Execute performs the delete use case.
+func (u *deleteUseCase) Execute(ctx context.Context, userID string) error {
+	paths, err := filepath.Glob(filepath.Join(config.MediaFileDir(),
+		fmt.Sprintf("%s_*", media.OwnerPrefix(userID))))
+	if err != nil {
+		service.Error(userID, u.tag, err)
+		return err
+	}
+	paths = append(paths, filepath.Join(config.UserFileDir(), fmt.Sprintf("%s.jpg", userID)))
+
+	for _, path := range paths {
+		if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			service.Error(userID, u.tag, err)
+			return err
+		}
+	}
+
+	rpb, err := u.encoder.Marshal(NewDeletionReport(userID))
+	if err != nil {
+		service.Error(userID, u.tag, err)
+		return err
+	}
+	if err = u.producer.Publish(ctx, []byte(userID), rpb); err != nil {
+		service.Error(userID, u.tag, err)
+		return err
+	}
+
+	service.Info(userID, u.tag, "account files removed")
+	return nil
+}
@@ -0,0 +1,72 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/file-service/app/media"
+	"github.com/tsmweb/file-service/common/appmock"
+	"github.com/tsmweb/file-service/config"
+)
+
+func TestDeleteUseCase_Execute(t *testing.T) {
+	if err := config.Load("../../"); err != nil {
+		t.Error(err)
+	}
+
+	ctx := context.Background()
+	userID := "+5518999999999"
+	otherID := "+5518988888888"
+
+	encoder := ReportEncoderFunc(func(r *DeletionReport) ([]byte, error) {
+		return []byte(r.Service), nil
+	})
+
+	createFile := func(t *testing.T, path string) {
+		assert.Nil(t, os.WriteFile(path, []byte("test"), 0644))
+		t.Cleanup(func() { os.Remove(path) })
+	}
+
+	t.Run("when publishing the report fails", func(t *testing.T) {
+		producer := new(appmock.MockKafkaProducer)
+		producer.On("Publish", mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Once()
+
+		uc := NewDeleteUseCase(encoder, producer)
+		err := uc.Execute(ctx, userID)
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when use case succeeds", func(t *testing.T) {
+		userPicture := filepath.Join(config.UserFileDir(), fmt.Sprintf("%s.jpg", userID))
+		userMedia := filepath.Join(config.MediaFileDir(),
+			fmt.Sprintf("%s_%s.jpg", media.OwnerPrefix(userID), "test"))
+		otherMedia := filepath.Join(config.MediaFileDir(),
+			fmt.Sprintf("%s_%s.jpg", media.OwnerPrefix(otherID), "test"))
+		createFile(t, userPicture)
+		createFile(t, userMedia)
+		createFile(t, otherMedia)
+
+		producer := new(appmock.MockKafkaProducer)
+		producer.On("Publish", mock.Anything, []byte(userID), [][]byte{[]byte(ServiceName)}).
+			Return(nil).
+			Once()
+
+		uc := NewDeleteUseCase(encoder, producer)
+		err := uc.Execute(ctx, userID)
+
+		assert.Nil(t, err)
+		assert.NoFileExists(t, userPicture)
+		assert.NoFileExists(t, userMedia)
+		assert.FileExists(t, otherMedia)
+		producer.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,18 @@
+package media
+
+import (
+	"github.com/tsmweb/go-helper-api/util/hashutil"
+)
+
+// ownerPrefixLen is the length of the prefix of the media file names that identifies the owner.
+const ownerPrefixLen = 16
+
+// OwnerPrefix returns the prefix of the names of the media files uploaded by userID, which allows
+// to remove them when the account is deleted without exposing the userID in the file name.
+func OwnerPrefix(userID string) string {
+	hash, _ := hashutil.HashSHA256(userID)
+	if len(hash) > ownerPrefixLen {
+		return hash[:ownerPrefixLen]
+	}
+	return hash
+}
@@ -43,7 +43,7 @@ func (u *uploadUseCase) Execute(userID string, file multipart.File) (string, err
 	}
 
 	fileNameHash, _ := hashutil.HashSHA256(fmt.Sprintf("%s%v", userID, time.Now().UnixNano()))
-	fileName := fmt.Sprintf("%s.%s", fileNameHash, fileExtension)
+	fileName := fmt.Sprintf("%s_%s.%s", OwnerPrefix(userID), fileNameHash, fileExtension)
 
 	// Creates the file on the local file system.
 	path := filepath.Join(config.MediaFileDir(), fileName)
@@ -1,7 +1,13 @@
 package main
 
 import (
+	"time"
+
 	"github.com/gorilla/mux"
+	"github.com/tsmweb/chat-server/pkg/jwks"
+	"github.com/tsmweb/chat-server/pkg/revocation"
+	"github.com/tsmweb/file-service/adapter"
+	"github.com/tsmweb/file-service/app/account"
 	"github.com/tsmweb/file-service/app/group"
 	"github.com/tsmweb/file-service/app/media"
 	"github.com/tsmweb/file-service/app/user"
@@ -15,10 +21,11 @@ import (
 )
 
 type Provider struct {
-	jwt      auth.JWT
-	mAuth    middleware.Auth
-	dataBase db.Database
-	kafka    kafka.Kafka
+	jwt             auth.JWT
+	mAuth           middleware.Auth
+	revocationStore revocation.Store
+	dataBase        db.Database
+	kafka           kafka.Kafka
 }
 
 func CreateProvider() *Provider {
@@ -63,24 +70,44 @@ func (p *Provider) MediaRouter(mr *mux.Router) {
 		uploadUseCase)
 }
 
+func (p *Provider) AccountConsumerProvider() *account.Consumer {
+	deleteUseCase := account.NewDeleteUseCase(
+		account.ReportEncoderFunc(adapter.DeletionReportMarshal),
+		p.KafkaProvider().NewProducer(config.KafkaDeletionReportTopic()))
+
+	return account.NewConsumer(
+		p.KafkaProvider().NewConsumer(config.KafkaGroupID(), config.KafkaAccountEventTopic()),
+		account.EventDecoderFunc(adapter.AccountEventUnmarshal),
+		deleteUseCase)
+}
+
 func (p *Provider) NewKafkaProducer(topic string) kafka.Producer {
 	return p.KafkaProvider().NewProducer(topic)
 }
 
 func (p *Provider) JwtProvider() auth.JWT {
 	if p.jwt == nil {
-		p.jwt = auth.NewJWT(config.KeySecureFile(), config.PubSecureFile())
+		ttl := time.Duration(config.JWKSCacheTTL()) * time.Second
+		p.jwt = jwks.NewJWT(jwks.NewCache(config.JWKSURLs(), ttl))
 	}
 	return p.jwt
 }
 
 func (p *Provider) AuthProvider() middleware.Auth {
 	if p.mAuth == nil {
-		p.mAuth = middleware.NewAuth(p.JwtProvider())
+		mAuth := middleware.NewAuth(p.JwtProvider())
+		p.mAuth = revocation.NewAuth(mAuth, p.JwtProvider(), p.RevocationProvider())
 	}
 	return p.mAuth
 }
 
+func (p *Provider) RevocationProvider() revocation.Store {
+	if p.revocationStore == nil {
+		p.revocationStore = revocation.NewRedisStore(config.RedisHost(), config.RedisPassword())
+	}
+	return p.revocationStore
+}
+
 func (p *Provider) DatabaseProvider() db.Database {
 	if p.dataBase == nil {
 		p.dataBase = db.NewPostgresDatabase()
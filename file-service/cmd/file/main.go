@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -30,6 +31,9 @@ func main() {
 	}
 	defer event.Close()
 
+	// Removes the files of the deleted accounts.
+	go provider.AccountConsumerProvider().Start(context.Background())
+
 	// Configure the routes.
 	router := mux.NewRouter()
 	provider.UserRouter(router)
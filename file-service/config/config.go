@@ -6,30 +6,37 @@ import (
 	"path"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
 var (
-	hostID                string
-	serverPort            int
-	dbHost                string
-	dbPort                int
-	dbUser                string
-	dbPassword            string
-	dbName                string
-	dbSchema              string
-	maxUploadSize         int64
-	keySecureFile         string
-	pubSecureFile         string
-	certSecureFile        string
-	fileDir               string
-	userFileDir           string
-	groupFileDir          string
-	mediaFileDir          string
-	kafkaBootstrapServers string
-	kafkaClientID         string
-	kafkaEventsTopic      string
+	hostID                   string
+	serverPort               int
+	dbHost                   string
+	dbPort                   int
+	dbUser                   string
+	dbPassword               string
+	dbName                   string
+	dbSchema                 string
+	maxUploadSize            int64
+	keySecureFile            string
+	jwksURLs                 []string
+	jwksCacheTTL             int
+	certSecureFile           string
+	fileDir                  string
+	userFileDir              string
+	groupFileDir             string
+	mediaFileDir             string
+	redisHost                string
+	redisPassword            string
+	kafkaBootstrapServers    string
+	kafkaClientID            string
+	kafkaGroupID             string
+	kafkaEventsTopic         string
+	kafkaAccountEventTopic   string
+	kafkaDeletionReportTopic string
 )
 
 func Load(workDir string) error {
@@ -80,12 +87,28 @@ func Load(workDir string) error {
 	dbSchema = os.Getenv("DB_SCHEMA")
 
 	keySecureFile = workDir + "/config/cert/server.pem"
-	pubSecureFile = workDir + "/config/cert/server.pub"
 	certSecureFile = workDir + "/config/cert/server.crt"
 
+	jwksURLs = nil
+	for _, url := range strings.Split(os.Getenv("JWKS_URL"), ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			jwksURLs = append(jwksURLs, url)
+		}
+	}
+	jwksCacheTTL, err = strconv.Atoi(os.Getenv("JWKS_CACHE_TTL")) // second
+	if err != nil {
+		jwksCacheTTL = 300
+	}
+
+	redisHost = os.Getenv("REDIS_HOST")
+	redisPassword = os.Getenv("REDIS_PASSWORD")
+
 	kafkaBootstrapServers = os.Getenv("KAFKA_BOOTSTRAP_SERVERS")
 	kafkaClientID = os.Getenv("KAFKA_CLIENT_ID")
+	kafkaGroupID = os.Getenv("KAFKA_GROUP_ID")
 	kafkaEventsTopic = os.Getenv("KAFKA_EVENTS_TOPIC")
+	kafkaAccountEventTopic = os.Getenv("KAFKA_ACCOUNT_EVENT_TOPIC")
+	kafkaDeletionReportTopic = os.Getenv("KAFKA_DELETION_REPORT_TOPIC")
 
 	return nil
 }
@@ -134,8 +157,12 @@ func KeySecureFile() string {
 	return keySecureFile
 }
 
-func PubSecureFile() string {
-	return pubSecureFile
+func JWKSURLs() []string {
+	return jwksURLs
+}
+
+func JWKSCacheTTL() int {
+	return jwksCacheTTL
 }
 
 func CertSecureFile() string {
@@ -158,6 +185,14 @@ func MediaFileDir() string {
 	return mediaFileDir
 }
 
+func RedisHost() string {
+	return redisHost
+}
+
+func RedisPassword() string {
+	return redisPassword
+}
+
 func KafkaBootstrapServers() string {
 	return kafkaBootstrapServers
 }
@@ -166,6 +201,18 @@ func KafkaClientID() string {
 	return kafkaClientID
 }
 
+func KafkaGroupID() string {
+	return kafkaGroupID
+}
+
 func KafkaEventsTopic() string {
 	return kafkaEventsTopic
 }
+
+func KafkaAccountEventTopic() string {
+	return kafkaAccountEventTopic
+}
+
+func KafkaDeletionReportTopic() string {
+	return kafkaDeletionReportTopic
+}
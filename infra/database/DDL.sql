@@ -24,14 +24,25 @@ CREATE TABLE chat_db."user" (
 	lastname varchar(255) NULL,
 	created_at timestamp(0) NOT NULL DEFAULT CURRENT_TIMESTAMP,
 	updated_at timestamp(0) NULL,
+	deleted_at timestamp(0) NULL,
 	CONSTRAINT client_pkey PRIMARY KEY (id)
 );
 
+-- DROP FUNCTION chat_db.sha256_hex(text);
+
+-- Hex SHA-256 of the UTF-8 text, looking up the hashed phone numbers of the contact import.
+-- Declared immutable, unlike convert_to, so it can be indexed.
+CREATE FUNCTION chat_db.sha256_hex(text) RETURNS text
+	LANGUAGE sql IMMUTABLE STRICT PARALLEL SAFE
+	AS $$ SELECT encode(sha256(convert_to($1, 'UTF8')), 'hex') $$;
+
+CREATE INDEX user_id_sha256_idx ON chat_db."user" USING btree (chat_db.sha256_hex(id)) WHERE deleted_at IS NULL;
+
 -- DROP TABLE chat_db.login;
 
 CREATE TABLE chat_db.login (
 	user_id varchar(100) NOT NULL,
-	"password" varchar(100) NOT NULL,
+	"password" varchar(255) NOT NULL,
 	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
 	updated_at timestamp NULL,
 	CONSTRAINT user_pkey PRIMARY KEY (user_id)
@@ -41,6 +52,166 @@ CREATE TABLE chat_db.login (
 
 ALTER TABLE chat_db.login ADD CONSTRAINT login_user_id_fkey FOREIGN KEY (user_id) REFERENCES chat_db."user"(id);
 
+-- DROP TABLE chat_db.refresh_token;
+
+CREATE TABLE chat_db.refresh_token (
+	id varchar(64) NOT NULL,
+	family_id varchar(64) NOT NULL,
+	user_id varchar(100) NOT NULL,
+	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	expires_at timestamp NOT NULL,
+	used_at timestamp NULL,
+	revoked_at timestamp NULL,
+	CONSTRAINT refresh_token_pkey PRIMARY KEY (id)
+);
+CREATE INDEX refresh_token_family_id_idx ON chat_db.refresh_token USING btree (family_id);
+CREATE INDEX refresh_token_user_id_idx ON chat_db.refresh_token USING btree (user_id);
+
+-- chat_db.refresh_token foreign keys
+
+ALTER TABLE chat_db.refresh_token ADD CONSTRAINT refresh_token_user_id_fkey FOREIGN KEY (user_id) REFERENCES chat_db."user"(id);
+
+-- DROP TABLE chat_db.verification;
+
+CREATE TABLE chat_db.verification (
+	id varchar(100) NOT NULL,
+	code_hash varchar(255) NOT NULL,
+	attempts int4 NOT NULL DEFAULT 0,
+	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	expires_at timestamp NOT NULL,
+	CONSTRAINT verification_pkey PRIMARY KEY (id)
+);
+
+-- DROP TABLE chat_db.two_factor;
+
+CREATE TABLE chat_db.two_factor (
+	id varchar(100) NOT NULL,
+	secret varchar(64) NOT NULL,
+	enabled bool NOT NULL DEFAULT false,
+	last_step int8 NOT NULL DEFAULT 0,
+	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at timestamp NULL,
+	CONSTRAINT two_factor_pkey PRIMARY KEY (id)
+);
+
+-- chat_db.two_factor foreign keys
+
+ALTER TABLE chat_db.two_factor ADD CONSTRAINT two_factor_id_fkey FOREIGN KEY (id) REFERENCES chat_db."user"(id);
+
+-- DROP TABLE chat_db.recovery_code;
+
+CREATE TABLE chat_db.recovery_code (
+	user_id varchar(100) NOT NULL,
+	code_hash varchar(64) NOT NULL,
+	CONSTRAINT recovery_code_pkey PRIMARY KEY (user_id, code_hash)
+);
+
+-- chat_db.recovery_code foreign keys
+
+ALTER TABLE chat_db.recovery_code ADD CONSTRAINT recovery_code_user_id_fkey FOREIGN KEY (user_id) REFERENCES chat_db."user"(id);
+
+-- DROP TABLE chat_db.two_factor_challenge;
+
+CREATE TABLE chat_db.two_factor_challenge (
+	id varchar(64) NOT NULL,
+	user_id varchar(100) NOT NULL,
+	attempts int4 NOT NULL DEFAULT 0,
+	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	expires_at timestamp NOT NULL,
+	CONSTRAINT two_factor_challenge_pkey PRIMARY KEY (id)
+);
+
+-- chat_db.two_factor_challenge foreign keys
+
+ALTER TABLE chat_db.two_factor_challenge ADD CONSTRAINT two_factor_challenge_user_id_fkey FOREIGN KEY (user_id) REFERENCES chat_db."user"(id);
+
+-- DROP TABLE chat_db.password_reset;
+
+CREATE TABLE chat_db.password_reset (
+	id varchar(64) NOT NULL,
+	user_id varchar(100) NOT NULL,
+	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	expires_at timestamp NOT NULL,
+	CONSTRAINT password_reset_pkey PRIMARY KEY (id),
+	CONSTRAINT password_reset_user_id_key UNIQUE (user_id)
+);
+
+-- chat_db.password_reset foreign keys
+
+ALTER TABLE chat_db.password_reset ADD CONSTRAINT password_reset_user_id_fkey FOREIGN KEY (user_id) REFERENCES chat_db."user"(id);
+
+-- DROP TABLE chat_db.user_session;
+
+CREATE TABLE chat_db.user_session (
+	id varchar(64) NOT NULL,
+	user_id varchar(100) NOT NULL,
+	device_name varchar(100) NULL,
+	user_agent varchar(255) NULL,
+	ip varchar(45) NULL,
+	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	last_seen_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	revoked_at timestamp NULL,
+	CONSTRAINT user_session_pkey PRIMARY KEY (id)
+);
+CREATE INDEX user_session_user_id_idx ON chat_db.user_session USING btree (user_id);
+
+-- chat_db.user_session foreign keys
+
+ALTER TABLE chat_db.user_session ADD CONSTRAINT user_session_user_id_fkey FOREIGN KEY (user_id) REFERENCES chat_db."user"(id);
+
+-- DROP TABLE chat_db.oauth_client;
+
+CREATE TABLE chat_db.oauth_client (
+	id varchar(100) NOT NULL,
+	"name" varchar(255) NOT NULL,
+	secret_hash varchar(64) NOT NULL,
+	"scope" text NOT NULL,
+	created_by varchar(100) NOT NULL,
+	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	revoked_at timestamp NULL,
+	CONSTRAINT oauth_client_pkey PRIMARY KEY (id)
+);
+
+-- chat_db.oauth_client foreign keys
+
+ALTER TABLE chat_db.oauth_client ADD CONSTRAINT oauth_client_id_fkey FOREIGN KEY (id) REFERENCES chat_db."user"(id);
+
+-- DROP TABLE chat_db.webhook;
+
+CREATE TABLE chat_db.webhook (
+	client_id varchar(100) NOT NULL,
+	url text NOT NULL,
+	secret varchar(100) NOT NULL,
+	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT webhook_pkey PRIMARY KEY (client_id)
+);
+
+-- chat_db.webhook foreign keys
+
+ALTER TABLE chat_db.webhook ADD CONSTRAINT webhook_client_id_fkey FOREIGN KEY (client_id) REFERENCES chat_db.oauth_client(id);
+
+-- DROP TABLE chat_db.user_deletion;
+
+CREATE TABLE chat_db.user_deletion (
+	user_id varchar(100) NOT NULL,
+	requested_at timestamp NOT NULL,
+	completed_at timestamp NULL,
+	CONSTRAINT user_deletion_pkey PRIMARY KEY (user_id)
+);
+
+-- DROP TABLE chat_db.user_deletion_step;
+
+CREATE TABLE chat_db.user_deletion_step (
+	user_id varchar(100) NOT NULL,
+	service varchar(50) NOT NULL,
+	completed_at timestamp NULL,
+	CONSTRAINT user_deletion_step_pkey PRIMARY KEY (user_id, service)
+);
+
+-- chat_db.user_deletion_step foreign keys
+
+ALTER TABLE chat_db.user_deletion_step ADD CONSTRAINT user_deletion_step_user_id_fkey FOREIGN KEY (user_id) REFERENCES chat_db.user_deletion(user_id) ON DELETE CASCADE;
+
 -- DROP TABLE chat_db.contact;
 
 CREATE TABLE chat_db.contact (
@@ -75,6 +246,7 @@ ALTER TABLE chat_db.online_user ADD CONSTRAINT user_online_user_id_fkey FOREIGN
 CREATE TABLE chat_db.blocked_user (
 	user_id varchar(100) NOT NULL,
 	blocked_user_id varchar(100) NOT NULL,
+	reason varchar(255) NULL,
 	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
 	CONSTRAINT blocked_user_pkey PRIMARY KEY (user_id, blocked_user_id)
 );
@@ -84,6 +256,18 @@ CREATE TABLE chat_db.blocked_user (
 ALTER TABLE chat_db.blocked_user ADD CONSTRAINT contact_block_contact_id_fkey FOREIGN KEY (blocked_user_id) REFERENCES chat_db."user"(id);
 ALTER TABLE chat_db.blocked_user ADD CONSTRAINT contact_block_user_id_fkey FOREIGN KEY (user_id) REFERENCES chat_db."user"(id);
 
+-- DROP TABLE chat_db.abuse_report;
+
+CREATE TABLE chat_db.abuse_report (
+	id bigserial NOT NULL,
+	user_id varchar(100) NOT NULL,
+	reported_user_id varchar(100) NOT NULL,
+	reason varchar(255) NOT NULL,
+	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT abuse_report_pkey PRIMARY KEY (id)
+);
+CREATE INDEX abuse_report_reported_user_id_idx ON chat_db.abuse_report USING btree (reported_user_id, created_at);
+
 -- DROP TABLE chat_db."group";
 
 CREATE TABLE chat_db."group" (
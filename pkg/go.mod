@@ -0,0 +1,23 @@
+module github.com/tsmweb/chat-server/pkg
+
+go 1.19
+
+require (
+	github.com/go-redis/redis/v8 v8.11.5
+	github.com/golang-jwt/jwt/v4 v4.4.2
+	github.com/stretchr/testify v1.8.0
+	github.com/tsmweb/go-helper-api v1.4.2
+	golang.org/x/sync v0.0.0-20220907140024-f12130a52804
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.1.2 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/felixge/httpsnoop v1.0.3 // indirect
+	github.com/gorilla/handlers v1.5.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/stretchr/objx v0.4.0 // indirect
+	github.com/xi2/httpgzip v0.0.0-20190509075255-932ab5e254ae // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,158 @@
+package jwks
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// ErrUnknownKey is returned when the kid is not in the set published by auth-service.
+var ErrUnknownKey = errors.New("jwks: unknown signing key")
+
+// MinRefreshInterval limits the fetches of the set caused by unknown kids, so that forged
+// tokens do not flood auth-service.
+const MinRefreshInterval = 10 * time.Second
+
+// Cache keeps the public keys of the set published by auth-service. The set is fetched
+// again when the cache expires or when a token is signed by an unknown key, such as a
+// new key after a rotation.
+type Cache struct {
+	urls   []string
+	ttl    time.Duration
+	client *http.Client
+	group  singleflight.Group
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+	triedAt   time.Time
+}
+
+// NewCache returns a Cache of the set fetched from the first of the urls that responds,
+// such as the auth-service replicas, kept for ttl.
+func NewCache(urls []string, ttl time.Duration) *Cache {
+	return &Cache{
+		urls:   urls,
+		ttl:    ttl,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Key returns the public key of the kid. An empty kid, of the tokens issued before the key
+// rotation support, is accepted while the set holds a single key.
+func (c *Cache) Key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	c.mu.Lock()
+	key, ok := c.lookup(kid)
+	expired := time.Since(c.fetchedAt) >= c.ttl
+	c.mu.Unlock()
+
+	if !ok || expired {
+		// the concurrent requests share a single fetch, made without the lock so that the
+		// keys cached meanwhile are not blocked by auth-service.
+		c.group.Do("refresh", func() (interface{}, error) {
+			c.refresh(ctx)
+			return nil, nil
+		})
+
+		c.mu.Lock()
+		key, ok = c.lookup(kid)
+		c.mu.Unlock()
+	}
+
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return key, nil
+}
+
+// refresh fetches the set again, at most once in MinRefreshInterval.
+func (c *Cache) refresh(ctx context.Context) {
+	c.mu.Lock()
+	now := time.Now()
+	if now.Sub(c.triedAt) < MinRefreshInterval {
+		c.mu.Unlock()
+		return
+	}
+	c.triedAt = now
+	c.mu.Unlock()
+
+	keys, err := c.fetch(ctx)
+	if err != nil {
+		// keeps the cached keys while auth-service is unavailable.
+		log.Printf("[WARN] jwks: %s\n", err.Error())
+		return
+	}
+
+	c.mu.Lock()
+	c.keys = keys
+	c.fetchedAt = now
+	c.mu.Unlock()
+}
+
+// lookup must be called with the lock held.
+func (c *Cache) lookup(kid string) (*rsa.PublicKey, bool) {
+	if kid == "" {
+		if len(c.keys) != 1 {
+			return nil, false
+		}
+		for _, key := range c.keys {
+			return key, true
+		}
+	}
+	key, ok := c.keys[kid]
+	return key, ok
+}
+
+func (c *Cache) fetch(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	err := errors.New("no JWKS URL")
+	for _, url := range c.urls {
+		var keys map[string]*rsa.PublicKey
+		if keys, err = c.fetchURL(ctx, url); err == nil {
+			return keys, nil
+		}
+	}
+	return nil, err
+}
+
+func (c *Cache) fetchURL(ctx context.Context, url string) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: status %d", url, res.StatusCode)
+	}
+
+	var set Set
+	if err = json.NewDecoder(res.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("GET %s: %w", url, err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.PublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("GET %s: kid %q: %w", url, k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+	return keys, nil
+}
@@ -0,0 +1,204 @@
+package jwks
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func newJWK(kid string, key *rsa.PrivateKey) JWK {
+	return JWK{
+		Kty: "RSA",
+		Use: "sig",
+		Alg: "RS256",
+		Kid: kid,
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+// jwksServer serves the set of keys, counting the requests. While hold is set, the
+// responses wait for it to be closed.
+type jwksServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	set      Set
+	requests int
+	hold     chan struct{}
+}
+
+func newJWKSServer(t *testing.T, keys ...JWK) *jwksServer {
+	s := &jwksServer{set: Set{Keys: keys}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests++
+		hold := s.hold
+		s.mu.Unlock()
+
+		if hold != nil {
+			<-hold
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		json.NewEncoder(w).Encode(&s.set)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *jwksServer) publish(keys ...JWK) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set.Keys = keys
+}
+
+func (s *jwksServer) holdResponses() chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hold = make(chan struct{})
+	return s.hold
+}
+
+func (s *jwksServer) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func TestCache_Key(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+	oldKey, newKey := newKey(t), newKey(t)
+
+	t.Run("when the set is cached", func(t *testing.T) {
+		//t.Parallel()
+		srv := newJWKSServer(t, newJWK("old", oldKey))
+		c := NewCache([]string{srv.URL}, time.Hour)
+
+		key, err := c.Key(ctx, "old")
+		assert.Nil(t, err)
+		assert.True(t, oldKey.PublicKey.Equal(key))
+
+		// tokens without kid are accepted while the set holds a single key.
+		key, err = c.Key(ctx, "")
+		assert.Nil(t, err)
+		assert.True(t, oldKey.PublicKey.Equal(key))
+		assert.Equal(t, 1, srv.count())
+	})
+
+	t.Run("when the first url fails", func(t *testing.T) {
+		//t.Parallel()
+		srv := newJWKSServer(t, newJWK("old", oldKey))
+		c := NewCache([]string{"http://127.0.0.1:1/jwks.json", srv.URL}, time.Hour)
+
+		_, err := c.Key(ctx, "old")
+		assert.Nil(t, err)
+	})
+
+	t.Run("when the key is unknown", func(t *testing.T) {
+		//t.Parallel()
+		srv := newJWKSServer(t, newJWK("old", oldKey))
+		c := NewCache([]string{srv.URL}, time.Hour)
+
+		_, err := c.Key(ctx, "old")
+		assert.Nil(t, err)
+
+		// the refresh caused by unknown kids is limited.
+		srv.publish(newJWK("old", oldKey), newJWK("new", newKey))
+		_, err = c.Key(ctx, "new")
+		assert.Equal(t, ErrUnknownKey, err)
+		assert.Equal(t, 1, srv.count())
+
+		c.triedAt = time.Time{}
+		key, err := c.Key(ctx, "new")
+		assert.Nil(t, err)
+		assert.True(t, newKey.PublicKey.Equal(key))
+		assert.Equal(t, 2, srv.count())
+
+		_, err = c.Key(ctx, "")
+		assert.Equal(t, ErrUnknownKey, err)
+	})
+
+	t.Run("when auth-service is unavailable", func(t *testing.T) {
+		//t.Parallel()
+		srv := newJWKSServer(t, newJWK("old", oldKey))
+		c := NewCache([]string{srv.URL}, time.Hour)
+
+		_, err := c.Key(ctx, "old")
+		assert.Nil(t, err)
+
+		// the cached keys are kept after the cache expires.
+		srv.Close()
+		c.fetchedAt, c.triedAt = time.Time{}, time.Time{}
+		_, err = c.Key(ctx, "old")
+		assert.Nil(t, err)
+	})
+	t.Run("when the set is fetched concurrently", func(t *testing.T) {
+		//t.Parallel()
+		srv := newJWKSServer(t, newJWK("old", oldKey))
+		c := NewCache([]string{srv.URL}, time.Hour)
+
+		_, err := c.Key(ctx, "old")
+		assert.Nil(t, err)
+
+		srv.publish(newJWK("old", oldKey), newJWK("new", newKey))
+		hold := srv.holdResponses()
+		c.mu.Lock()
+		c.triedAt = time.Time{}
+		c.mu.Unlock()
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 10)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := c.Key(ctx, "new")
+				errs <- err
+			}()
+		}
+		for srv.count() < 2 {
+			time.Sleep(time.Millisecond)
+		}
+
+		// the cached keys are not blocked by the fetch.
+		done := make(chan error, 1)
+		go func() {
+			_, err := c.Key(ctx, "old")
+			done <- err
+		}()
+		select {
+		case err = <-done:
+			assert.Nil(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("cached key blocked by the fetch")
+		}
+
+		close(hold)
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			assert.Nil(t, err)
+		}
+		assert.Equal(t, 2, srv.count())
+	})
+}
@@ -0,0 +1,50 @@
+// Package jwks verifies the access tokens issued by auth-service with the public keys of its
+// JSON Web Key Set (RFC 7517), fetched from auth-service and cached, instead of a copy of the
+// key file.
+package jwks
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"math/big"
+)
+
+// ErrInvalidKey is returned when a key of the set is not a valid RSA public key.
+var ErrInvalidKey = errors.New("jwks: invalid RSA public key")
+
+// JWK is the JSON Web Key of a RSA public key.
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// Set is a JSON Web Key Set.
+type Set struct {
+	Keys []JWK `json:"keys"`
+}
+
+// PublicKey decodes the RSA public key.
+func (k JWK) PublicKey() (*rsa.PublicKey, error) {
+	if k.Kty != "RSA" {
+		return nil, ErrInvalidKey
+	}
+
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil || len(n) == 0 {
+		return nil, ErrInvalidKey
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil || len(e) == 0 || len(e) > 4 {
+		return nil, ErrInvalidKey
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
@@ -0,0 +1,22 @@
+package jwks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWK_PublicKey(t *testing.T) {
+	//t.Parallel()
+	key := newKey(t)
+
+	pub, err := newJWK("2022-07", key).PublicKey()
+	assert.Nil(t, err)
+	assert.True(t, key.PublicKey.Equal(pub))
+
+	_, err = JWK{Kty: "EC", Kid: "2022-07", N: "AQAB", E: "AQAB"}.PublicKey()
+	assert.Equal(t, ErrInvalidKey, err)
+
+	_, err = JWK{Kty: "RSA", Kid: "2022-07", N: "!", E: "AQAB"}.PublicKey()
+	assert.Equal(t, ErrInvalidKey, err)
+}
@@ -0,0 +1,58 @@
+package jwks
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/golang-jwt/jwt/v4/request"
+	"github.com/tsmweb/go-helper-api/auth"
+)
+
+// ErrSigningNotSupported is returned by GenerateToken, only auth-service signs tokens.
+var ErrSigningNotSupported = errors.New("jwks: tokens are signed by auth-service")
+
+type jwtAuth struct {
+	cache *Cache
+}
+
+// NewJWT returns an auth.JWT that verifies the tokens with the keys of the cache.
+func NewJWT(cache *Cache) auth.JWT {
+	return &jwtAuth{cache: cache}
+}
+
+// GenerateToken is not supported.
+func (j *jwtAuth) GenerateToken(payload map[string]interface{}, exp int) (string, error) {
+	return "", ErrSigningNotSupported
+}
+
+// ExtractToken returns the token of the request if it is valid.
+func (j *jwtAuth) ExtractToken(r *http.Request) (string, error) {
+	token, err := j.parse(r)
+	if err != nil {
+		return "", err
+	}
+	return token.Raw, nil
+}
+
+// GetDataToken returns the claim of the valid token of the request.
+func (j *jwtAuth) GetDataToken(r *http.Request, key string) (interface{}, error) {
+	token, err := j.parse(r)
+	if err != nil {
+		return nil, err
+	}
+	return token.Claims.(jwt.MapClaims)[key], nil
+}
+
+func (j *jwtAuth) parse(r *http.Request) (*jwt.Token, error) {
+	return request.ParseFromRequest(r, request.OAuth2Extractor,
+		func(token *jwt.Token) (interface{}, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+				return nil, fmt.Errorf("jwks: unexpected signing method %v", token.Header["alg"])
+			}
+			kid, _ := token.Header["kid"].(string)
+			return j.cache.Key(r.Context(), kid)
+		},
+		request.WithClaims(jwt.MapClaims{}))
+}
@@ -0,0 +1,69 @@
+package jwks
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRequest(token string) *http.Request {
+	r, _ := http.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+	return r
+}
+
+func TestJWT(t *testing.T) {
+	//t.Parallel()
+	key, otherKey := newKey(t), newKey(t)
+	srv := newJWKSServer(t, newJWK("2022-07", key))
+	j := NewJWT(NewCache([]string{srv.URL}, time.Hour))
+
+	sign := func(kid string, exp time.Time) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"id":  "+5518999999999",
+			"exp": exp.Unix(),
+		})
+		token.Header["kid"] = kid
+		signed, err := token.SignedString(key)
+		assert.Nil(t, err)
+		return signed
+	}
+
+	t.Run("when the token is valid", func(t *testing.T) {
+		//t.Parallel()
+		token := sign("2022-07", time.Now().Add(time.Hour))
+
+		raw, err := j.ExtractToken(newRequest(token))
+		assert.Nil(t, err)
+		assert.Equal(t, token, raw)
+
+		id, err := j.GetDataToken(newRequest(token), "id")
+		assert.Nil(t, err)
+		assert.Equal(t, "+5518999999999", id)
+	})
+
+	t.Run("when the token is invalid", func(t *testing.T) {
+		//t.Parallel()
+		_, err := j.ExtractToken(newRequest(sign("2022-07", time.Now().Add(-time.Hour))))
+		assert.NotNil(t, err)
+
+		_, err = j.ExtractToken(newRequest(sign("2022-01", time.Now().Add(time.Hour))))
+		assert.NotNil(t, err)
+
+		forged := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"id": "+5518999999999"})
+		forged.Header["kid"] = "2022-07"
+		token, err := forged.SignedString(otherKey)
+		assert.Nil(t, err)
+		_, err = j.ExtractToken(newRequest(token))
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when signing a token", func(t *testing.T) {
+		//t.Parallel()
+		_, err := j.GenerateToken(map[string]interface{}{"id": "+5518999999999"}, 1)
+		assert.Equal(t, ErrSigningNotSupported, err)
+	})
+}
@@ -0,0 +1,56 @@
+// Package netguard refuses the requests of the services to the addresses of the internal
+// network, such as the webhooks and callbacks informed by the users, against server-side
+// request forgery.
+package netguard
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+)
+
+// ErrForbiddenAddress is returned for the loopback, private, link-local and other addresses
+// that are not reachable from the internet.
+var ErrForbiddenAddress = errors.New("netguard: forbidden address")
+
+// Allowed returns true if the IP is a public unicast address.
+func Allowed(ip net.IP) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !isShared(ip)
+}
+
+// shared is the address space of the carrier-grade NAT (RFC 6598).
+var shared = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+func isShared(ip net.IP) bool {
+	return shared.Contains(ip)
+}
+
+// CheckHost returns ErrForbiddenAddress if the host of a URL is an address not allowed or
+// localhost. The other host names are only resolved when dialing, so they must also be
+// checked by the dialer.
+func CheckHost(host string) error {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrForbiddenAddress
+	}
+	if ip := net.ParseIP(strings.Trim(host, "[]")); ip != nil && !Allowed(ip) {
+		return ErrForbiddenAddress
+	}
+	return nil
+}
+
+// Control can be used as the Control of a net.Dialer to refuse the connections to the
+// addresses not allowed. It is called after the host name is resolved, so it also refuses
+// the public names resolving to internal addresses.
+func Control(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !Allowed(ip) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, address)
+	}
+	return nil
+}
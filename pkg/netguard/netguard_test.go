@@ -0,0 +1,67 @@
+package netguard
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllowed(t *testing.T) {
+	//t.Parallel()
+	for _, addr := range []string{"8.8.8.8", "2001:4860:4860::8888"} {
+		assert.True(t, Allowed(net.ParseIP(addr)), addr)
+	}
+
+	for _, addr := range []string{
+		"127.0.0.1",
+		"::1",
+		"10.0.0.1",
+		"172.16.0.1",
+		"192.168.0.1",
+		"169.254.169.254",
+		"fe80::1",
+		"fd00::1",
+		"100.64.0.1",
+		"0.0.0.0",
+		"::",
+		"224.0.0.1",
+		"::ffff:127.0.0.1",
+	} {
+		assert.False(t, Allowed(net.ParseIP(addr)), addr)
+	}
+}
+
+func TestCheckHost(t *testing.T) {
+	//t.Parallel()
+	assert.Nil(t, CheckHost("example.com"))
+	assert.Nil(t, CheckHost("8.8.8.8"))
+
+	assert.Equal(t, ErrForbiddenAddress, CheckHost("localhost"))
+	assert.Equal(t, ErrForbiddenAddress, CheckHost("api.LOCALHOST."))
+	assert.Equal(t, ErrForbiddenAddress, CheckHost("127.0.0.1"))
+	assert.Equal(t, ErrForbiddenAddress, CheckHost("[::1]"))
+	assert.Equal(t, ErrForbiddenAddress, CheckHost("169.254.169.254"))
+}
+
+func TestControl(t *testing.T) {
+	//t.Parallel()
+	assert.Nil(t, Control("tcp4", "8.8.8.8:443", nil))
+	assert.True(t, errors.Is(Control("tcp4", "127.0.0.1:80", nil), ErrForbiddenAddress))
+	assert.True(t, errors.Is(Control("tcp6", "[fe80::1%eth0]:80", nil), ErrForbiddenAddress))
+	assert.NotNil(t, Control("tcp4", "127.0.0.1", nil))
+
+	t.Run("when dialing a loopback server", func(t *testing.T) {
+		//t.Parallel()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer srv.Close()
+
+		d := &net.Dialer{Control: Control}
+		_, err := d.DialContext(context.Background(), "tcp", srv.Listener.Addr().String())
+		assert.True(t, errors.Is(err, ErrForbiddenAddress))
+	})
+}
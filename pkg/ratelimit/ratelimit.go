@@ -0,0 +1,97 @@
+// Package ratelimit limits the requests of each key, such as the ID of a user, to a number
+// of requests in a fixed window, sharing the counts through Redis between the replicas of a
+// service.
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const keyPrefix = "ratelimit:"
+
+// Limiter counts the requests of each key.
+type Limiter interface {
+	// Allow records a request of the key, reporting whether it is within the limit of
+	// requests of the window, which starts at the first request of the key.
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, error)
+}
+
+// allowScript increments the requests of the key, starting the window at the first one.
+var allowScript = redis.NewScript(`
+local n = redis.call("INCR", KEYS[1])
+if n == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return n
+`)
+
+// redisLimiter implements the Limiter interface.
+type redisLimiter struct {
+	db *redis.Client
+}
+
+// NewRedisLimiter returns a Limiter that counts the requests in Redis.
+func NewRedisLimiter(addr string, password string) Limiter {
+	db := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: password,
+		DB:       0, // use default DB
+	})
+
+	return &redisLimiter{
+		db: db,
+	}
+}
+
+func (l *redisLimiter) Allow(ctx context.Context, key string, limit int,
+	window time.Duration) (bool, error) {
+	n, err := allowScript.Run(ctx, l.db, []string{keyPrefix + key}, window.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return n <= limit, nil
+}
+
+// memoryLimiter implements the Limiter interface in memory, for a single process.
+type memoryLimiter struct {
+	mu      sync.Mutex
+	windows map[string]*memoryWindow
+}
+
+type memoryWindow struct {
+	requests int
+	endsAt   time.Time
+}
+
+// NewMemoryLimiter returns a Limiter that counts the requests in memory, to be shared by
+// services running in the same process.
+func NewMemoryLimiter() Limiter {
+	return &memoryLimiter{
+		windows: make(map[string]*memoryWindow),
+	}
+}
+
+func (l *memoryLimiter) Allow(_ context.Context, key string, limit int,
+	window time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	w, ok := l.windows[key]
+	if !ok || !now.Before(w.endsAt) {
+		for k, w := range l.windows {
+			if !now.Before(w.endsAt) {
+				delete(l.windows, k)
+			}
+		}
+		w = &memoryWindow{endsAt: now.Add(window)}
+		l.windows[key] = w
+	}
+
+	w.requests++
+	return w.requests <= limit, nil
+}
@@ -0,0 +1,47 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryLimiter_Allow(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+
+	t.Run("when limit is reached", func(t *testing.T) {
+		//t.Parallel()
+		l := NewMemoryLimiter()
+
+		for i := 0; i < 2; i++ {
+			ok, err := l.Allow(ctx, "import:+5518977777777", 2, time.Hour)
+			assert.Nil(t, err)
+			assert.True(t, ok)
+		}
+
+		ok, _ := l.Allow(ctx, "import:+5518977777777", 2, time.Hour)
+		assert.False(t, ok)
+
+		// the keys are counted apart.
+		ok, _ = l.Allow(ctx, "import:+5518966666666", 2, time.Hour)
+		assert.True(t, ok)
+	})
+
+	t.Run("when window ends", func(t *testing.T) {
+		//t.Parallel()
+		l := NewMemoryLimiter()
+
+		ok, _ := l.Allow(ctx, "import:+5518977777777", 1, 50*time.Millisecond)
+		assert.True(t, ok)
+		ok, _ = l.Allow(ctx, "import:+5518977777777", 1, 50*time.Millisecond)
+		assert.False(t, ok)
+
+		time.Sleep(60 * time.Millisecond)
+
+		ok, _ = l.Allow(ctx, "import:+5518977777777", 1, 50*time.Millisecond)
+		assert.True(t, ok)
+	})
+}
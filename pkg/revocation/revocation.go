@@ -0,0 +1,343 @@
+// Package revocation records the access tokens revoked by auth-service and checks them in
+// the services that authorize requests with those tokens, sharing the revocations through
+// Redis. A token is revoked either by its ID (the "jti" claim), on logout, by its session
+// (the "sid" claim), when the user revokes a device, or by a "tokens before" date of the
+// user (the "iat" claim), on logout everywhere and password change.
+package revocation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/tsmweb/go-helper-api/auth"
+	"github.com/tsmweb/go-helper-api/httputil"
+	"github.com/tsmweb/go-helper-api/middleware"
+)
+
+var (
+	ErrRevokedToken = errors.New("revoked token")
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+const (
+	tokenKeyPrefix   = "revoked:token:"
+	sessionKeyPrefix = "revoked:session:"
+	userKeyPrefix    = "revoked:user:"
+)
+
+// Token is the data of an access token used to check its revocation.
+type Token struct {
+	ID        string
+	SessionID string
+	UserID    string
+	IssuedAt  time.Time
+}
+
+// FromRequest returns the data of the access token authorized in the request.
+func FromRequest(jwt auth.JWT, r *http.Request) (*Token, error) {
+	data, err := jwt.GetDataToken(r, "id")
+	if err != nil {
+		return nil, err
+	}
+	userID, ok := data.(string)
+	if !ok || userID == "" {
+		return nil, ErrInvalidToken
+	}
+
+	// Tokens issued before logout support do not carry an ID.
+	data, _ = jwt.GetDataToken(r, "jti")
+	tokenID, _ := data.(string)
+
+	// Tokens issued before session support do not carry a session ID.
+	data, _ = jwt.GetDataToken(r, "sid")
+	sessionID, _ := data.(string)
+
+	issuedAt, err := dataTime(jwt, r, "iat")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Token{
+		ID:        tokenID,
+		SessionID: sessionID,
+		UserID:    userID,
+		IssuedAt:  issuedAt,
+	}, nil
+}
+
+func dataTime(jwt auth.JWT, r *http.Request, key string) (time.Time, error) {
+	data, err := jwt.GetDataToken(r, key)
+	if err != nil {
+		return time.Time{}, nil
+	}
+
+	// the issue dates have a fraction of second since IssuedAt.
+	var sec float64
+	switch v := data.(type) {
+	case nil:
+		return time.Time{}, nil
+	case float64:
+		sec = v
+	case int64:
+		sec = float64(v)
+	case json.Number:
+		if sec, err = v.Float64(); err != nil {
+			return time.Time{}, ErrInvalidToken
+		}
+	case string:
+		if v == "" {
+			return time.Time{}, nil
+		}
+		if sec, err = strconv.ParseFloat(v, 64); err != nil {
+			return time.Time{}, ErrInvalidToken
+		}
+	default:
+		return time.Time{}, ErrInvalidToken
+	}
+
+	return time.UnixMilli(int64(math.Round(sec * 1e3))).UTC(), nil
+}
+
+// Checker reports whether access tokens were revoked.
+type Checker interface {
+	IsRevoked(ctx context.Context, t *Token) (bool, error)
+}
+
+// The CheckerFunc type is an adapter to allow the use of ordinary functions as checkers of
+// access tokens.
+// If f is a function with the appropriate signature, CheckerFunc(f) is a Checker that calls f.
+type CheckerFunc func(ctx context.Context, t *Token) (bool, error)
+
+// IsRevoked calls f(ctx, t).
+func (f CheckerFunc) IsRevoked(ctx context.Context, t *Token) (bool, error) {
+	return f(ctx, t)
+}
+
+// Store records and checks the revocation of access tokens.
+type Store interface {
+	Checker
+
+	// RevokeToken revokes the token with the ID until it expires.
+	RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+
+	// RevokeSession revokes the tokens of the session. The revocation is kept for ttl, which
+	// must be the lifetime of the access tokens.
+	RevokeSession(ctx context.Context, sessionID string, ttl time.Duration) error
+
+	// RevokeUser revokes the tokens of the user issued before revokedAt. The revocation is
+	// kept for ttl, which must be the lifetime of the access tokens.
+	RevokeUser(ctx context.Context, userID string, revokedAt time.Time, ttl time.Duration) error
+}
+
+// IssuedAt returns the "iat" claim of a token issued at t, in seconds with the precision of
+// a millisecond, so that the tokens issued right after a revocation of the user are told
+// apart from the tokens it revokes.
+func IssuedAt(t time.Time) float64 {
+	return float64(t.UnixMilli()) / 1e3
+}
+
+// IsRevokedAt reports whether a token issued at issuedAt is revoked by a revocation of its
+// user at revokedAt, that is if it was issued until then. Tokens without issue date are
+// revoked by any revocation of the user.
+func IsRevokedAt(issuedAt, revokedAt time.Time) bool {
+	return !issuedAt.After(revokedAt)
+}
+
+// isRevokedBy reports whether the token was issued until the revocation date of the user.
+// The revocation dates are kept with the precision of a millisecond, as the "iat" claim, so
+// the user can log in again right after revoking their tokens. The tokens issued before the
+// claim had a fraction of second, in the second of a revocation, are revoked by it.
+func isRevokedBy(t *Token, revokedAt time.Time) bool {
+	return IsRevokedAt(t.IssuedAt, revokedAt)
+}
+
+// redisStore implements the Store interface.
+type redisStore struct {
+	db *redis.Client
+}
+
+// NewRedisStore returns a Store that keeps the revocations in Redis.
+func NewRedisStore(addr string, password string) Store {
+	db := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: password,
+		DB:       0, // use default DB
+	})
+
+	return &redisStore{
+		db: db,
+	}
+}
+
+func (s *redisStore) IsRevoked(ctx context.Context, t *Token) (bool, error) {
+	if t.ID != "" {
+		n, err := s.db.Exists(ctx, tokenKeyPrefix+t.ID).Result()
+		if err != nil {
+			return false, err
+		}
+		if n > 0 {
+			return true, nil
+		}
+	}
+
+	if t.SessionID != "" {
+		n, err := s.db.Exists(ctx, sessionKeyPrefix+t.SessionID).Result()
+		if err != nil {
+			return false, err
+		}
+		if n > 0 {
+			return true, nil
+		}
+	}
+
+	// the revocations stored before they had a fraction of second are read as well.
+	revokedAt, err := s.db.Get(ctx, userKeyPrefix+t.UserID).Float64()
+	if err != nil {
+		if err == redis.Nil {
+			return false, nil
+		}
+		return false, err
+	}
+	return isRevokedBy(t, time.UnixMilli(int64(math.Round(revokedAt*1e3)))), nil
+}
+
+func (s *redisStore) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	return s.db.Set(ctx, tokenKeyPrefix+tokenID, 1, ttl).Err()
+}
+
+func (s *redisStore) RevokeSession(ctx context.Context, sessionID string,
+	ttl time.Duration) error {
+	return s.db.Set(ctx, sessionKeyPrefix+sessionID, 1, ttl).Err()
+}
+
+func (s *redisStore) RevokeUser(ctx context.Context, userID string, revokedAt time.Time,
+	ttl time.Duration) error {
+	return s.db.Set(ctx, userKeyPrefix+userID, IssuedAt(revokedAt), ttl).Err()
+}
+
+// memoryStore implements the Store interface in memory, for a single process.
+type memoryStore struct {
+	mu       sync.RWMutex
+	tokens   map[string]time.Time // expiration by token ID
+	sessions map[string]time.Time // expiration by session ID
+	users    map[string]time.Time // revocation date by user ID
+}
+
+// NewMemoryStore returns a Store that keeps the revocations in memory, to be shared by
+// services running in the same process.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		tokens:   make(map[string]time.Time),
+		sessions: make(map[string]time.Time),
+		users:    make(map[string]time.Time),
+	}
+}
+
+func (s *memoryStore) IsRevoked(_ context.Context, t *Token) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if expiresAt, ok := s.tokens[t.ID]; ok && t.ID != "" && time.Now().Before(expiresAt) {
+		return true, nil
+	}
+	if expiresAt, ok := s.sessions[t.SessionID]; ok && t.SessionID != "" &&
+		time.Now().Before(expiresAt) {
+		return true, nil
+	}
+	if revokedAt, ok := s.users[t.UserID]; ok {
+		return isRevokedBy(t, revokedAt), nil
+	}
+	return false, nil
+}
+
+func (s *memoryStore) RevokeToken(_ context.Context, tokenID string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for id, exp := range s.tokens {
+		if !now.Before(exp) {
+			delete(s.tokens, id)
+		}
+	}
+	s.tokens[tokenID] = expiresAt
+	return nil
+}
+
+func (s *memoryStore) RevokeSession(_ context.Context, sessionID string,
+	ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for id, exp := range s.sessions {
+		if !now.Before(exp) {
+			delete(s.sessions, id)
+		}
+	}
+	s.sessions[sessionID] = now.Add(ttl)
+	return nil
+}
+
+func (s *memoryStore) RevokeUser(_ context.Context, userID string, revokedAt time.Time,
+	_ time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.users[userID] = revokedAt.Truncate(time.Millisecond)
+	return nil
+}
+
+// authRevocation implements the middleware.Auth interface.
+type authRevocation struct {
+	auth    middleware.Auth
+	jwt     auth.JWT
+	checker Checker
+}
+
+// NewAuth returns a middleware.Auth that rejects the revoked tokens, after they are
+// authorized by auth.
+func NewAuth(auth middleware.Auth, jwt auth.JWT, checker Checker) middleware.Auth {
+	return &authRevocation{
+		auth:    auth,
+		jwt:     jwt,
+		checker: checker,
+	}
+}
+
+func (a *authRevocation) RequireTokenAuth(w http.ResponseWriter, r *http.Request,
+	next http.HandlerFunc) {
+	a.auth.RequireTokenAuth(w, r, func(w http.ResponseWriter, r *http.Request) {
+		t, err := FromRequest(a.jwt, r)
+		if err != nil {
+			httputil.RespondWithError(w, http.StatusUnauthorized, ErrInvalidToken.Error())
+			return
+		}
+
+		revoked, err := a.checker.IsRevoked(r.Context(), t)
+		if err != nil {
+			log.Printf("[ERROR] revocation: %s\n", err.Error())
+			httputil.RespondWithError(w, http.StatusInternalServerError,
+				http.StatusText(http.StatusInternalServerError))
+			return
+		}
+		if revoked {
+			httputil.RespondWithError(w, http.StatusUnauthorized, ErrRevokedToken.Error())
+			return
+		}
+
+		next(w, r)
+	})
+}
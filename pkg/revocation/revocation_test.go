@@ -0,0 +1,216 @@
+package revocation
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	t.Run("when token is revoked", func(t *testing.T) {
+		//t.Parallel()
+		s := NewMemoryStore()
+		assert.Nil(t, s.RevokeToken(ctx, "A1B2C3", now.Add(time.Hour)))
+		assert.Nil(t, s.RevokeToken(ctx, "D4E5F6", now.Add(-time.Minute)))
+
+		revoked, err := s.IsRevoked(ctx, &Token{ID: "A1B2C3", UserID: "+5518977777777", IssuedAt: now})
+		assert.Nil(t, err)
+		assert.True(t, revoked)
+
+		revoked, _ = s.IsRevoked(ctx, &Token{ID: "D4E5F6", UserID: "+5518977777777", IssuedAt: now})
+		assert.False(t, revoked)
+
+		revoked, _ = s.IsRevoked(ctx, &Token{UserID: "+5518977777777", IssuedAt: now})
+		assert.False(t, revoked)
+	})
+
+	t.Run("when session is revoked", func(t *testing.T) {
+		//t.Parallel()
+		s := NewMemoryStore()
+		assert.Nil(t, s.RevokeSession(ctx, "S1S2S3", time.Hour))
+
+		revoked, err := s.IsRevoked(ctx, &Token{ID: "A1B2C3", SessionID: "S1S2S3",
+			UserID: "+5518977777777", IssuedAt: now})
+		assert.Nil(t, err)
+		assert.True(t, revoked)
+
+		revoked, _ = s.IsRevoked(ctx, &Token{ID: "D4E5F6", SessionID: "S4S5S6",
+			UserID: "+5518977777777", IssuedAt: now})
+		assert.False(t, revoked)
+
+		revoked, _ = s.IsRevoked(ctx, &Token{ID: "D4E5F6", UserID: "+5518977777777", IssuedAt: now})
+		assert.False(t, revoked)
+	})
+
+	t.Run("when user is revoked", func(t *testing.T) {
+		//t.Parallel()
+		s := NewMemoryStore()
+		assert.Nil(t, s.RevokeUser(ctx, "+5518977777777", now, time.Hour))
+
+		revoked, err := s.IsRevoked(ctx, &Token{ID: "A1B2C3", UserID: "+5518977777777",
+			IssuedAt: now.Add(-time.Second)})
+		assert.Nil(t, err)
+		assert.True(t, revoked)
+
+		revoked, _ = s.IsRevoked(ctx, &Token{UserID: "+5518977777777"})
+		assert.True(t, revoked)
+
+		// the issue date of the token is in milliseconds.
+		issuedAt := now.Truncate(time.Millisecond)
+
+		// issued in the same second, before or at the revocation
+		revoked, _ = s.IsRevoked(ctx, &Token{UserID: "+5518977777777",
+			IssuedAt: issuedAt.Add(-time.Millisecond)})
+		assert.True(t, revoked)
+
+		revoked, _ = s.IsRevoked(ctx, &Token{UserID: "+5518977777777", IssuedAt: issuedAt})
+		assert.True(t, revoked)
+
+		// logged in again right after the revocation
+		revoked, _ = s.IsRevoked(ctx, &Token{UserID: "+5518977777777",
+			IssuedAt: issuedAt.Add(time.Millisecond)})
+		assert.False(t, revoked)
+
+		revoked, _ = s.IsRevoked(ctx, &Token{UserID: "+5518977777777", IssuedAt: now.Add(time.Second)})
+		assert.False(t, revoked)
+
+		revoked, _ = s.IsRevoked(ctx, &Token{UserID: "+5518966666666", IssuedAt: now})
+		assert.False(t, revoked)
+	})
+}
+
+func TestFromRequest(t *testing.T) {
+	//t.Parallel()
+	req := httptest.NewRequest(http.MethodGet, "/v1/user", nil)
+
+	t.Run("when JWT fails", func(t *testing.T) {
+		//t.Parallel()
+		_, err := FromRequest(&fakeJWT{err: errors.New("error")}, req)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when token has no id", func(t *testing.T) {
+		//t.Parallel()
+		_, err := FromRequest(&fakeJWT{claims: map[string]interface{}{}}, req)
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+
+	t.Run("when token is valid", func(t *testing.T) {
+		//t.Parallel()
+		tk, err := FromRequest(&fakeJWT{claims: map[string]interface{}{
+			"id":  "+5518977777777",
+			"jti": "A1B2C3",
+			"sid": "S1S2S3",
+			"iat": float64(1600000000),
+		}}, req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "A1B2C3", tk.ID)
+		assert.Equal(t, "S1S2S3", tk.SessionID)
+		assert.Equal(t, "+5518977777777", tk.UserID)
+		assert.Equal(t, time.Unix(1600000000, 0).UTC(), tk.IssuedAt)
+	})
+
+	t.Run("when token is issued with fraction of second", func(t *testing.T) {
+		//t.Parallel()
+		issuedAt := time.UnixMilli(1600000000123).UTC()
+		tk, err := FromRequest(&fakeJWT{claims: map[string]interface{}{
+			"id":  "+5518977777777",
+			"iat": IssuedAt(issuedAt),
+		}}, req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, issuedAt, tk.IssuedAt)
+	})
+}
+
+func TestAuth_RequireTokenAuth(t *testing.T) {
+	//t.Parallel()
+	jwt := &fakeJWT{claims: map[string]interface{}{
+		"id":  "+5518977777777",
+		"jti": "A1B2C3",
+		"iat": float64(time.Now().Unix()),
+	}}
+	next := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	serve := func(a *fakeAuth, checker Checker) int {
+		req := httptest.NewRequest(http.MethodGet, "/v1/user", nil)
+		rr := httptest.NewRecorder()
+		NewAuth(a, jwt, checker).RequireTokenAuth(rr, req, next)
+		return rr.Code
+	}
+
+	t.Run("when token is not authorized", func(t *testing.T) {
+		//t.Parallel()
+		code := serve(&fakeAuth{deny: true}, NewMemoryStore())
+		assert.Equal(t, http.StatusUnauthorized, code)
+	})
+
+	t.Run("when checker fails", func(t *testing.T) {
+		//t.Parallel()
+		code := serve(&fakeAuth{}, CheckerFunc(func(context.Context, *Token) (bool, error) {
+			return false, errors.New("error")
+		}))
+		assert.Equal(t, http.StatusInternalServerError, code)
+	})
+
+	t.Run("when token is revoked", func(t *testing.T) {
+		//t.Parallel()
+		s := NewMemoryStore()
+		_ = s.RevokeToken(context.Background(), "A1B2C3", time.Now().Add(time.Hour))
+
+		code := serve(&fakeAuth{}, s)
+		assert.Equal(t, http.StatusUnauthorized, code)
+	})
+
+	t.Run("when token is valid", func(t *testing.T) {
+		//t.Parallel()
+		code := serve(&fakeAuth{}, NewMemoryStore())
+		assert.Equal(t, http.StatusOK, code)
+	})
+}
+
+// fakeAuth authorizes the requests unless deny is set.
+type fakeAuth struct {
+	deny bool
+}
+
+func (f *fakeAuth) RequireTokenAuth(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	if f.deny {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	next(w, r)
+}
+
+// fakeJWT returns the claims of a token already validated.
+type fakeJWT struct {
+	claims map[string]interface{}
+	err    error
+}
+
+func (f *fakeJWT) GenerateToken(payload map[string]interface{}, exp int) (string, error) {
+	return "", nil
+}
+
+func (f *fakeJWT) ExtractToken(r *http.Request) (string, error) {
+	return "", f.err
+}
+
+func (f *fakeJWT) GetDataToken(r *http.Request, key string) (interface{}, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.claims[key], nil
+}
@@ -0,0 +1,191 @@
+// Package scope restricts what the access tokens of service accounts are allowed to do. The
+// scopes are carried by the "scope" claim, separated by spaces as in OAuth2. Tokens without
+// the claim, issued to the users on login, are not restricted.
+package scope
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/tsmweb/go-helper-api/auth"
+	"github.com/tsmweb/go-helper-api/httputil"
+)
+
+var (
+	ErrInsufficientScope = errors.New("insufficient scope")
+	ErrInvalidScope      = errors.New("invalid scope")
+)
+
+const (
+	// MessagesSend allows sending messages to any user or group.
+	MessagesSend = "messages:send"
+	// ContactsRead allows reading the contacts and their presence.
+	ContactsRead = "contacts:read"
+	// ContactsWrite allows creating, updating, deleting and blocking contacts.
+	ContactsWrite = "contacts:write"
+	// GroupsRead allows reading the groups.
+	GroupsRead = "groups:read"
+	// GroupsWrite allows creating, updating and deleting groups and their members.
+	GroupsWrite = "groups:write"
+	// WebhookWrite allows registering and removing the webhook of the service account.
+	WebhookWrite = "webhook:write"
+
+	// groupPrefix restricts MessagesSend to a group, e.g. "messages:send:group:<id>".
+	groupPrefix = MessagesSend + ":group:"
+	// userPrefix restricts MessagesSend to a user, e.g. "messages:send:user:<id>".
+	userPrefix = MessagesSend + ":user:"
+)
+
+var known = map[string]bool{
+	MessagesSend:  true,
+	ContactsRead:  true,
+	ContactsWrite: true,
+	GroupsRead:    true,
+	GroupsWrite:   true,
+	WebhookWrite:  true,
+}
+
+// Group returns the scope that allows sending messages only to the group.
+func Group(groupID string) string {
+	return groupPrefix + groupID
+}
+
+// User returns the scope that allows sending messages only to the user.
+func User(userID string) string {
+	return userPrefix + userID
+}
+
+// Valid reports whether s is a known scope.
+func Valid(s string) bool {
+	if known[s] {
+		return true
+	}
+	if strings.HasPrefix(s, groupPrefix) {
+		return len(s) > len(groupPrefix)
+	}
+	if strings.HasPrefix(s, userPrefix) {
+		return len(s) > len(userPrefix)
+	}
+	return false
+}
+
+// Set is the set of scopes granted to a token. A nil Set is not restricted.
+type Set map[string]bool
+
+// Parse returns the set of scopes separated by spaces.
+func Parse(s string) Set {
+	set := Set{}
+	for _, f := range strings.Fields(s) {
+		set[f] = true
+	}
+	return set
+}
+
+// Restricted reports whether the set restricts the token, i.e. it is not nil.
+func (s Set) Restricted() bool {
+	return s != nil
+}
+
+// Has reports whether the scope is granted.
+func (s Set) Has(scope string) bool {
+	return s == nil || s[scope]
+}
+
+// AllowsMessage reports whether a message can be sent to the user or to the group.
+func (s Set) AllowsMessage(to, group string) bool {
+	if s.Has(MessagesSend) {
+		return true
+	}
+	if group != "" {
+		return s[Group(group)]
+	}
+	return s[User(to)]
+}
+
+// String returns the scopes separated by spaces, in order.
+func (s Set) String() string {
+	scopes := make([]string, 0, len(s))
+	for scope := range s {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	return strings.Join(scopes, " ")
+}
+
+// FromRequest returns the scopes of the access token authorized in the request, or nil
+// when the token is not restricted.
+func FromRequest(jwt auth.JWT, r *http.Request) (Set, error) {
+	data, err := jwt.GetDataToken(r, "scope")
+	if err != nil {
+		return nil, err
+	}
+	return FromClaim(data)
+}
+
+// FromClaim returns the scopes of the "scope" claim, or nil when it is absent.
+func FromClaim(data interface{}) (Set, error) {
+	switch v := data.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return Parse(v), nil
+	default:
+		return nil, ErrInvalidScope
+	}
+}
+
+// Require is a middleware that only allows the request to proceed if the access token is
+// granted all the scopes, responding 403 Forbidden otherwise. It must follow the
+// authentication of the token.
+func Require(jwt auth.JWT, scopes ...string) func(http.ResponseWriter, *http.Request,
+	http.HandlerFunc) {
+	return func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+		if Authorize(jwt, w, r, scopes...) {
+			next(w, r)
+		}
+	}
+}
+
+// RequireUnrestricted is a middleware that only allows the request to proceed if the access
+// token is not restricted by scopes, as those of the users, responding 403 Forbidden
+// otherwise. It protects the management of the account from the service accounts. It must
+// follow the authentication of the token.
+func RequireUnrestricted(jwt auth.JWT) func(http.ResponseWriter, *http.Request,
+	http.HandlerFunc) {
+	return func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+		set, err := FromRequest(jwt, r)
+		if err != nil {
+			log.Printf("[ERROR] scope: %s\n", err.Error())
+			httputil.RespondWithError(w, http.StatusUnauthorized, ErrInvalidScope.Error())
+			return
+		}
+		if set.Restricted() {
+			httputil.RespondWithError(w, http.StatusForbidden, ErrInsufficientScope.Error())
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// Authorize reports whether the access token is granted all the scopes, responding like
+// Require otherwise. It is used by the handlers whose scopes depend on the request.
+func Authorize(jwt auth.JWT, w http.ResponseWriter, r *http.Request, scopes ...string) bool {
+	set, err := FromRequest(jwt, r)
+	if err != nil {
+		log.Printf("[ERROR] scope: %s\n", err.Error())
+		httputil.RespondWithError(w, http.StatusUnauthorized, ErrInvalidScope.Error())
+		return false
+	}
+
+	for _, scope := range scopes {
+		if !set.Has(scope) {
+			httputil.RespondWithError(w, http.StatusForbidden, ErrInsufficientScope.Error())
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,146 @@
+package scope
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValid(t *testing.T) {
+	//t.Parallel()
+	assert.True(t, Valid(MessagesSend))
+	assert.True(t, Valid(GroupsWrite))
+	assert.True(t, Valid(Group("G1")))
+	assert.True(t, Valid(User("+5518999999999")))
+	assert.False(t, Valid(Group("")))
+	assert.False(t, Valid("messages:delete"))
+	assert.False(t, Valid(""))
+}
+
+func TestSet(t *testing.T) {
+	//t.Parallel()
+	t.Run("when set is not restricted", func(t *testing.T) {
+		//t.Parallel()
+		var s Set
+		assert.False(t, s.Restricted())
+		assert.True(t, s.Has(ContactsWrite))
+		assert.True(t, s.AllowsMessage("+5518999999999", ""))
+		assert.True(t, s.AllowsMessage("", "G1"))
+	})
+
+	t.Run("when set allows sending to any addressee", func(t *testing.T) {
+		//t.Parallel()
+		s := Parse(MessagesSend)
+		assert.True(t, s.Restricted())
+		assert.False(t, s.Has(ContactsRead))
+		assert.True(t, s.AllowsMessage("+5518999999999", ""))
+		assert.True(t, s.AllowsMessage("", "G1"))
+	})
+
+	t.Run("when set allows sending to some addressees", func(t *testing.T) {
+		//t.Parallel()
+		s := Parse(Group("G1") + "  " + User("+5518999999999") + " " + ContactsRead)
+		assert.True(t, s.Has(ContactsRead))
+		assert.True(t, s.AllowsMessage("", "G1"))
+		assert.False(t, s.AllowsMessage("", "G2"))
+		assert.True(t, s.AllowsMessage("+5518999999999", ""))
+		assert.False(t, s.AllowsMessage("+5518977777777", ""))
+		assert.Equal(t, "contacts:read messages:send:group:G1 messages:send:user:+5518999999999",
+			s.String())
+	})
+}
+
+func TestRequire(t *testing.T) {
+	//t.Parallel()
+	serve := func(jwt *fakeJWT) int {
+		req := httptest.NewRequest(http.MethodGet, "/v1/contact", nil)
+		rec := httptest.NewRecorder()
+
+		Require(jwt, ContactsRead)(rec, req, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+		return rec.Code
+	}
+
+	t.Run("when JWT fails", func(t *testing.T) {
+		//t.Parallel()
+		assert.Equal(t, http.StatusUnauthorized, serve(&fakeJWT{err: errors.New("error")}))
+	})
+
+	t.Run("when scope claim is invalid", func(t *testing.T) {
+		//t.Parallel()
+		jwt := &fakeJWT{claims: map[string]interface{}{"scope": 1}}
+		assert.Equal(t, http.StatusUnauthorized, serve(jwt))
+	})
+
+	t.Run("when scope is not granted", func(t *testing.T) {
+		//t.Parallel()
+		jwt := &fakeJWT{claims: map[string]interface{}{"scope": GroupsRead}}
+		assert.Equal(t, http.StatusForbidden, serve(jwt))
+	})
+
+	t.Run("when scope is granted", func(t *testing.T) {
+		//t.Parallel()
+		jwt := &fakeJWT{claims: map[string]interface{}{"scope": GroupsRead + " " + ContactsRead}}
+		assert.Equal(t, http.StatusOK, serve(jwt))
+	})
+
+	t.Run("when token is not restricted", func(t *testing.T) {
+		//t.Parallel()
+		assert.Equal(t, http.StatusOK, serve(&fakeJWT{}))
+	})
+}
+
+func TestRequireUnrestricted(t *testing.T) {
+	//t.Parallel()
+	serve := func(jwt *fakeJWT) int {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/user", nil)
+		rec := httptest.NewRecorder()
+
+		RequireUnrestricted(jwt)(rec, req, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+		return rec.Code
+	}
+
+	t.Run("when JWT fails", func(t *testing.T) {
+		//t.Parallel()
+		assert.Equal(t, http.StatusUnauthorized, serve(&fakeJWT{err: errors.New("error")}))
+	})
+
+	t.Run("when token is restricted", func(t *testing.T) {
+		//t.Parallel()
+		jwt := &fakeJWT{claims: map[string]interface{}{"scope": MessagesSend + " " + ContactsWrite}}
+		assert.Equal(t, http.StatusForbidden, serve(jwt))
+		jwt = &fakeJWT{claims: map[string]interface{}{"scope": ""}}
+		assert.Equal(t, http.StatusForbidden, serve(jwt))
+	})
+
+	t.Run("when token is not restricted", func(t *testing.T) {
+		//t.Parallel()
+		assert.Equal(t, http.StatusOK, serve(&fakeJWT{}))
+	})
+}
+
+type fakeJWT struct {
+	claims map[string]interface{}
+	err    error
+}
+
+func (f *fakeJWT) GenerateToken(payload map[string]interface{}, exp int) (string, error) {
+	return "", nil
+}
+
+func (f *fakeJWT) ExtractToken(r *http.Request) (string, error) {
+	return "", f.err
+}
+
+func (f *fakeJWT) GetDataToken(r *http.Request, key string) (interface{}, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.claims[key], nil
+}
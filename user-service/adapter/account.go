@@ -0,0 +1,30 @@
+package adapter
+
+import (
+	"github.com/tsmweb/user-service/app/account"
+	"github.com/tsmweb/user-service/infra/protobuf"
+	"google.golang.org/protobuf/proto"
+	"time"
+)
+
+// AccountEventUnmarshal is a protobuf.AccountEvent decoder for account.Event.
+func AccountEventUnmarshal(in []byte, e *account.Event) error {
+	epb := new(protobuf.AccountEvent)
+	if err := proto.Unmarshal(in, epb); err != nil {
+		return err
+	}
+	e.UserID = epb.GetUserId()
+	e.Event = epb.GetEvent().String()
+	e.EventDate = time.Unix(epb.GetEventDate(), 0)
+	return nil
+}
+
+// DeletionReportMarshal is a account.DeletionReport encoder for protobuf.DeletionReport.
+func DeletionReportMarshal(r *account.DeletionReport) ([]byte, error) {
+	rpb := &protobuf.DeletionReport{
+		UserId:      r.UserID,
+		Service:     r.Service,
+		CompletedAt: r.CompletedAt.Unix(),
+	}
+	return proto.Marshal(rpb)
+}
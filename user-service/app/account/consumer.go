@@ -0,0 +1,61 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/tsmweb/go-helper-api/kafka"
+	"github.com/tsmweb/user-service/common/service"
+)
+
+// Consumer consumes the events of the user account, executing the DeleteUseCase for each
+// EventUserDeleted.
+type Consumer struct {
+	tag           string
+	consumer      kafka.Consumer
+	decoder       EventDecoder
+	deleteUseCase DeleteUseCase
+}
+
+// NewConsumer create a new instance of Consumer.
+func NewConsumer(
+	consumer kafka.Consumer,
+	decoder EventDecoder,
+	deleteUseCase DeleteUseCase,
+) *Consumer {
+	return &Consumer{
+		tag:           "account::Consumer",
+		consumer:      consumer,
+		decoder:       decoder,
+		deleteUseCase: deleteUseCase,
+	}
+}
+
+// Start consumes the events until the ctx is done.
+func (c *Consumer) Start(ctx context.Context) {
+	defer func() {
+		c.consumer.Close()
+		log.Printf("[STOP] %s\n", c.tag)
+	}()
+
+	callbackFn := func(event *kafka.Event, err error) {
+		if err != nil {
+			service.Error("", c.tag, fmt.Errorf("kafka::Consumer: %s", err.Error()))
+			return
+		}
+
+		var evt Event
+		if err = c.decoder.Unmarshal(event.Value, &evt); err != nil {
+			service.Error(string(event.Key), c.tag, err)
+			return
+		}
+
+		if evt.Event == EventUserDeleted {
+			// the errors are reported by the use case.
+			c.deleteUseCase.Execute(ctx, evt.UserID)
+		}
+	}
+
+	c.consumer.Subscribe(ctx, callbackFn)
+}
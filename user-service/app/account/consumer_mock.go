@@ -0,0 +1,38 @@
+package account
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/go-helper-api/kafka"
+)
+
+// mockKafkaConsumer injects mock kafka.Consumer dependency.
+type mockKafkaConsumer struct {
+	mock.Mock
+	events []*kafka.Event
+}
+
+// Subscribe represents the simulated method for the Subscribe feature in the kafka.Consumer
+// layer, delivering the events informed to the callback.
+func (m *mockKafkaConsumer) Subscribe(ctx context.Context, callbackFn func(event *kafka.Event, err error)) {
+	for _, e := range m.events {
+		callbackFn(e, nil)
+	}
+}
+
+// Close represents the simulated method for the Close feature in the kafka.Consumer layer.
+func (m *mockKafkaConsumer) Close() {
+	m.Called()
+}
+
+// mockDeleteUseCase injects mock DeleteUseCase dependency.
+type mockDeleteUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the DeleteUseCase.
+func (m *mockDeleteUseCase) Execute(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,38 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/go-helper-api/kafka"
+)
+
+func TestConsumer_Start(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+	decoder := EventDecoderFunc(func(in []byte, e *Event) error {
+		if string(in) == "" {
+			return errors.New("error")
+		}
+		e.UserID = "+5518999999999"
+		e.Event = string(in)
+		return nil
+	})
+
+	c := &mockKafkaConsumer{events: []*kafka.Event{
+		{Value: []byte("UserCreated")},
+		{Value: []byte("")},
+		{Value: []byte(EventUserDeleted)},
+	}}
+	c.On("Close").Return().Once()
+	uc := new(mockDeleteUseCase)
+	uc.On("Execute", mock.Anything, "+5518999999999").
+		Return(nil).
+		Once()
+
+	NewConsumer(c, decoder, uc).Start(ctx)
+	uc.AssertExpectations(t)
+	c.AssertExpectations(t)
+}
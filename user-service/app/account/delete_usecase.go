@@ -0,0 +1,64 @@
+package account
+
+import (
+	"context"
+
+	"github.com/tsmweb/go-helper-api/kafka"
+	"github.com/tsmweb/user-service/app/contact"
+	"github.com/tsmweb/user-service/app/group"
+	"github.com/tsmweb/user-service/common/service"
+)
+
+// DeleteUseCase removes the contacts, blocks and group memberships of a deleted user and
+// reports it to auth-service, otherwise an error is returned.
+type DeleteUseCase interface {
+	Execute(ctx context.Context, userID string) error
+}
+
+type deleteUseCase struct {
+	tag            string
+	removeContacts contact.RemoveUserUseCase
+	removeGroups   group.RemoveUserUseCase
+	encoder        ReportEncoder
+	producer       kafka.Producer
+}
+
+// NewDeleteUseCase create a new instance of DeleteUseCase.
+func NewDeleteUseCase(
+	removeContacts contact.RemoveUserUseCase,
+	removeGroups group.RemoveUserUseCase,
+	encoder ReportEncoder,
+	producer kafka.Producer,
+) DeleteUseCase {
+	return &deleteUseCase{
+		tag:            "account::DeleteUseCase",
+		removeContacts: removeContacts,
+		removeGroups:   removeGroups,
+		encoder:        encoder,
+		producer:       producer,
+	}
+}
+
+// Execute performs the delete use case.
+func (u *deleteUseCase) Execute(ctx context.Context, userID string) error {
+	// the errors are reported by the use cases.
+	if err := u.removeContacts.Execute(ctx, userID); err != nil {
+		return err
+	}
+	if err := u.removeGroups.Execute(ctx, userID); err != nil {
+		return err
+	}
+
+	rpb, err := u.encoder.Marshal(NewDeletionReport(userID))
+	if err != nil {
+		service.Error(userID, u.tag, err)
+		return err
+	}
+	if err = u.producer.Publish(ctx, []byte(userID), rpb); err != nil {
+		service.Error(userID, u.tag, err)
+		return err
+	}
+
+	service.Info(userID, u.tag, "account data removed")
+	return nil
+}
@@ -0,0 +1,102 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/user-service/common"
+)
+
+func TestDeleteUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+	userID := "+5518999999999"
+
+	encoder := ReportEncoderFunc(func(r *DeletionReport) ([]byte, error) {
+		return []byte(r.Service), nil
+	})
+
+	t.Run("when removing the contacts fails", func(t *testing.T) {
+		//t.Parallel()
+		contacts := new(mockRemoveUserUseCase)
+		contacts.On("Execute", mock.Anything, userID).
+			Return(errors.New("error")).
+			Once()
+		groups := new(mockRemoveUserUseCase)
+		producer := new(common.MockKafkaProducer)
+
+		uc := NewDeleteUseCase(contacts, groups, encoder, producer)
+		err := uc.Execute(ctx, userID)
+
+		assert.NotNil(t, err)
+		groups.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+		producer.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when removing the groups fails", func(t *testing.T) {
+		//t.Parallel()
+		contacts := new(mockRemoveUserUseCase)
+		contacts.On("Execute", mock.Anything, userID).
+			Return(nil).
+			Once()
+		groups := new(mockRemoveUserUseCase)
+		groups.On("Execute", mock.Anything, userID).
+			Return(errors.New("error")).
+			Once()
+		producer := new(common.MockKafkaProducer)
+
+		uc := NewDeleteUseCase(contacts, groups, encoder, producer)
+		err := uc.Execute(ctx, userID)
+
+		assert.NotNil(t, err)
+		producer.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when publishing the report fails", func(t *testing.T) {
+		//t.Parallel()
+		contacts := new(mockRemoveUserUseCase)
+		contacts.On("Execute", mock.Anything, userID).
+			Return(nil).
+			Once()
+		groups := new(mockRemoveUserUseCase)
+		groups.On("Execute", mock.Anything, userID).
+			Return(nil).
+			Once()
+		producer := new(common.MockKafkaProducer)
+		producer.On("Publish", mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Once()
+
+		uc := NewDeleteUseCase(contacts, groups, encoder, producer)
+		err := uc.Execute(ctx, userID)
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when use case succeeds", func(t *testing.T) {
+		//t.Parallel()
+		contacts := new(mockRemoveUserUseCase)
+		contacts.On("Execute", mock.Anything, userID).
+			Return(nil).
+			Once()
+		groups := new(mockRemoveUserUseCase)
+		groups.On("Execute", mock.Anything, userID).
+			Return(nil).
+			Once()
+		producer := new(common.MockKafkaProducer)
+		producer.On("Publish", mock.Anything, []byte(userID), [][]byte{[]byte(ServiceName)}).
+			Return(nil).
+			Once()
+
+		uc := NewDeleteUseCase(contacts, groups, encoder, producer)
+		err := uc.Execute(ctx, userID)
+
+		assert.Nil(t, err)
+		contacts.AssertExpectations(t)
+		groups.AssertExpectations(t)
+		producer.AssertExpectations(t)
+	})
+}
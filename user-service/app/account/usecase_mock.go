@@ -0,0 +1,19 @@
+package account
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// mockRemoveUserUseCase injects mock contact.RemoveUserUseCase and group.RemoveUserUseCase
+// dependency.
+type mockRemoveUserUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the RemoveUserUseCase.
+func (m *mockRemoveUserUseCase) Execute(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,72 @@
+package contact
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+// MaxReasonLength is the maximum length of the reason of a block or abuse report.
+const MaxReasonLength = 255
+
+var (
+	ErrReasonValidateModel       = &cerror.ErrValidateModel{Msg: "reason exceeds 255 characters"}
+	ErrReportReasonValidateModel = &cerror.ErrValidateModel{Msg: "required reason"}
+	ErrReportSelfValidateModel   = &cerror.ErrValidateModel{Msg: "cannot report yourself"}
+)
+
+// BlockedUser data model of a user blocked by the user, with the name of his profile.
+type BlockedUser struct {
+	ID        string
+	Name      string
+	LastName  string
+	Reason    string
+	CreatedAt time.Time
+}
+
+// Report data model of an abuse report reviewed by the moderators.
+type Report struct {
+	UserID         string
+	ReportedUserID string
+	Reason         string
+	CreatedAt      time.Time
+}
+
+// NewReport create a new Report
+func NewReport(userID, reportedUserID, reason string) (*Report, error) {
+	r := &Report{
+		UserID:         userID,
+		ReportedUserID: reportedUserID,
+		Reason:         reason,
+		CreatedAt:      time.Now().UTC(),
+	}
+
+	if err := r.Validate(); err != nil {
+		return r, err
+	}
+
+	return r, nil
+}
+
+// Validate model Report
+func (r Report) Validate() error {
+	if r.ReportedUserID == "" {
+		return ErrIDValidateModel
+	}
+	if r.ReportedUserID == r.UserID {
+		return ErrReportSelfValidateModel
+	}
+	if r.Reason == "" {
+		return ErrReportReasonValidateModel
+	}
+	return validateReason(r.Reason)
+}
+
+// validateReason checks the length of the reason of a block or abuse report.
+func validateReason(reason string) error {
+	if utf8.RuneCountInString(reason) > MaxReasonLength {
+		return ErrReasonValidateModel
+	}
+	return nil
+}
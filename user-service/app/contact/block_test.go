@@ -0,0 +1,56 @@
+package contact
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReport_Validate(t *testing.T) {
+	//t.Parallel()
+	type test struct {
+		userID         string
+		reportedUserID string
+		reason         string
+		want           error
+	}
+
+	tests := []test{
+		{
+			userID:         "+5518999999999",
+			reportedUserID: "+5518977777777",
+			reason:         "spam",
+			want:           nil,
+		},
+		{
+			userID:         "+5518999999999",
+			reportedUserID: "",
+			reason:         "spam",
+			want:           ErrIDValidateModel,
+		},
+		{
+			userID:         "+5518999999999",
+			reportedUserID: "+5518999999999",
+			reason:         "spam",
+			want:           ErrReportSelfValidateModel,
+		},
+		{
+			userID:         "+5518999999999",
+			reportedUserID: "+5518977777777",
+			reason:         "",
+			want:           ErrReportReasonValidateModel,
+		},
+		{
+			userID:         "+5518999999999",
+			reportedUserID: "+5518977777777",
+			reason:         strings.Repeat("a", MaxReasonLength+1),
+			want:           ErrReasonValidateModel,
+		},
+	}
+
+	for _, tc := range tests {
+		_, err := NewReport(tc.userID, tc.reportedUserID, tc.reason)
+		assert.Equal(t, tc.want, err)
+	}
+}
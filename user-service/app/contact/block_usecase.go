@@ -10,9 +10,9 @@ import (
 	"github.com/tsmweb/user-service/common/service"
 )
 
-// BlockUseCase blocks a contact, otherwise an error is returned.
+// BlockUseCase blocks a contact with an optional reason, otherwise an error is returned.
 type BlockUseCase interface {
-	Execute(ctx context.Context, userID, blockedUserID string) error
+	Execute(ctx context.Context, userID, blockedUserID, reason string) error
 }
 
 type blockUseCase struct {
@@ -37,7 +37,11 @@ func NewBlockUseCase(
 }
 
 // Execute perform the block use case.
-func (u *blockUseCase) Execute(ctx context.Context, userID, blockedUserID string) error {
+func (u *blockUseCase) Execute(ctx context.Context, userID, blockedUserID, reason string) error {
+	if err := validateReason(reason); err != nil {
+		return err
+	}
+
 	ok, err := u.repository.ExistsUser(ctx, blockedUserID)
 	if err != nil {
 		service.Error(userID, u.tag, err)
@@ -47,7 +51,7 @@ func (u *blockUseCase) Execute(ctx context.Context, userID, blockedUserID string
 		return ErrUserNotFound
 	}
 
-	err = u.repository.Block(ctx, userID, blockedUserID, time.Now().UTC())
+	err = u.repository.Block(ctx, userID, blockedUserID, reason, time.Now().UTC())
 	if err != nil {
 		if errors.Is(err, cerror.ErrRecordAlreadyRegistered) {
 			return ErrContactAlreadyBlocked
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/tsmweb/go-helper-api/cerror"
 	"github.com/tsmweb/user-service/common"
+	"strings"
 	"testing"
 )
 
@@ -22,6 +23,16 @@ func TestBlockUseCase_Execute(t *testing.T) {
 	producer.On("Publish", mock.Anything, mock.Anything, mock.Anything).
 		Return(nil)
 
+	t.Run("when use case fails with ErrReasonValidateModel", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+
+		uc := NewBlockUseCase(r, encode, producer)
+		err := uc.Execute(ctx, "+5518999999999", "+5518977777777", strings.Repeat("a", MaxReasonLength+1))
+
+		assert.Equal(t, ErrReasonValidateModel, err)
+	})
+
 	t.Run("when use case fails with ErrUserNotFound", func(t *testing.T) {
 		//t.Parallel()
 		r := new(mockRepository)
@@ -30,7 +41,7 @@ func TestBlockUseCase_Execute(t *testing.T) {
 			Once()
 
 		uc := NewBlockUseCase(r, encode, producer)
-		err := uc.Execute(ctx, "+5518999999999", "+5518977777777")
+		err := uc.Execute(ctx, "+5518999999999", "+5518977777777", "")
 
 		assert.Equal(t, ErrUserNotFound, err)
 	})
@@ -41,12 +52,12 @@ func TestBlockUseCase_Execute(t *testing.T) {
 		r.On("ExistsUser", mock.Anything, mock.Anything).
 			Return(true, nil).
 			Once()
-		r.On("Block", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		r.On("Block", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(cerror.ErrRecordAlreadyRegistered).
 			Once()
 
 		uc := NewBlockUseCase(r, encode, producer)
-		err := uc.Execute(ctx, "+5518999999999", "+5518977777777")
+		err := uc.Execute(ctx, "+5518999999999", "+5518977777777", "")
 
 		assert.Equal(t, ErrContactAlreadyBlocked, err)
 	})
@@ -59,19 +70,19 @@ func TestBlockUseCase_Execute(t *testing.T) {
 			Once()
 
 		uc := NewBlockUseCase(r, encode, producer)
-		err := uc.Execute(ctx, "+5518999999999", "+5518977777777")
+		err := uc.Execute(ctx, "+5518999999999", "+5518977777777", "")
 		assert.NotNil(t, err)
 
 		r.On("ExistsUser", mock.Anything, mock.Anything).
 			Return(true, nil)
-		r.On("Block", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		r.On("Block", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(errors.New("error")).
 			Once()
 
-		err = uc.Execute(ctx, "+5518999999999", "+5518977777777")
+		err = uc.Execute(ctx, "+5518999999999", "+5518977777777", "")
 		assert.NotNil(t, err)
 
-		r.On("Block", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		r.On("Block", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(nil).
 			Once()
 		p := new(common.MockKafkaProducer)
@@ -80,7 +91,7 @@ func TestBlockUseCase_Execute(t *testing.T) {
 			Once()
 
 		uc = NewBlockUseCase(r, encode, p)
-		err = uc.Execute(ctx, "+5518999999999", "+5518977777777")
+		err = uc.Execute(ctx, "+5518999999999", "+5518977777777", "")
 		assert.NotNil(t, err)
 	})
 
@@ -90,12 +101,12 @@ func TestBlockUseCase_Execute(t *testing.T) {
 		r.On("ExistsUser", mock.Anything, mock.Anything).
 			Return(true, nil).
 			Once()
-		r.On("Block", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		r.On("Block", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(nil).
 			Once()
 
 		uc := NewBlockUseCase(r, encode, producer)
-		err := uc.Execute(ctx, "+5518999999999", "+5518977777777")
+		err := uc.Execute(ctx, "+5518999999999", "+5518977777777", "spam")
 
 		assert.Nil(t, err)
 	})
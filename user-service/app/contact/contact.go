@@ -3,6 +3,7 @@ package contact
 import (
 	"context"
 	"github.com/tsmweb/go-helper-api/cerror"
+	"github.com/tsmweb/user-service/pkg/paging"
 	"time"
 )
 
@@ -53,12 +54,25 @@ func (c Contact) Validate() error {
 // Repository interface for contact data source.
 type Repository interface {
 	Get(ctx context.Context, userID, contactID string) (*Contact, error)
-	GetAll(ctx context.Context, userID string) ([]*Contact, error)
+	GetAll(ctx context.Context, userID string, q *paging.Query) ([]*Contact, error)
 	ExistsUser(ctx context.Context, ID string) (bool, error)
+	// ExistsUsers returns the IDs of the registered users by key, the ID or, if hashed, the
+	// SHA-256 of it in hex, omitting the keys not found.
+	ExistsUsers(ctx context.Context, keys []string, hashed bool) (map[string]string, error)
 	GetPresence(ctx context.Context, userID, contactID string) (PresenceType, error)
 	Create(ctx context.Context, contact *Contact) error
+	// CreateAll creates the contacts of the user in a single transaction, skipping the ones
+	// already registered, and returns the IDs of the contacts created.
+	CreateAll(ctx context.Context, userID string, contacts []*Contact) ([]string, error)
 	Update(ctx context.Context, contact *Contact) (bool, error)
 	Delete(ctx context.Context, userID, contactID string) (bool, error)
-	Block(ctx context.Context, userID, blockedUserID string, createdAt time.Time) error
+	Block(ctx context.Context, userID, blockedUserID, reason string, createdAt time.Time) error
+	// GetAllBlocked returns a page of the users blocked by the user, with one user more than
+	// the query limit if there are more pages.
+	GetAllBlocked(ctx context.Context, userID string, q *paging.Query) ([]*BlockedUser, error)
+	// Report records the abuse report and blocks the reported user, in a single transaction,
+	// keeping the block if the user is already blocked.
+	Report(ctx context.Context, report *Report) error
 	Unblock(ctx context.Context, userID, blockedUserID string) (bool, error)
+	DeleteAll(ctx context.Context, userID string) error
 }
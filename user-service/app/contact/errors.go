@@ -9,6 +9,8 @@ var (
 	ErrContactNotFound       = errors.New("contact not found")
 	ErrContactAlreadyBlocked = errors.New("contact already blocked")
 	ErrContactAlreadyExists  = errors.New("contact already exists")
+	ErrTooManyImports        = errors.New("too many contact imports")
+	ErrTooManyReports        = errors.New("too many abuse reports")
 )
 
 // ErrEventNotification error thrown if publishing an event results in an error.
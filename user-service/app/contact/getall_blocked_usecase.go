@@ -0,0 +1,48 @@
+package contact
+
+import (
+	"context"
+
+	"github.com/tsmweb/user-service/common/service"
+	"github.com/tsmweb/user-service/pkg/paging"
+)
+
+// GetAllBlockedUseCase returns a page of the users blocked by userID selected by the query,
+// otherwise an error is returned.
+type GetAllBlockedUseCase interface {
+	Execute(ctx context.Context, userID string, q *paging.Query) ([]*BlockedUser, *paging.Page, error)
+}
+
+type getAllBlockedUseCase struct {
+	tag        string
+	repository Repository
+}
+
+// NewGetAllBlockedUseCase create a new instance of GetAllBlockedUseCase.
+func NewGetAllBlockedUseCase(r Repository) GetAllBlockedUseCase {
+	return &getAllBlockedUseCase{
+		tag:        "contact::GetAllBlockedUseCase",
+		repository: r,
+	}
+}
+
+// Execute performs the use case to get all blocked users.
+func (u *getAllBlockedUseCase) Execute(ctx context.Context, userID string, q *paging.Query) (
+	[]*BlockedUser, *paging.Page, error) {
+	users, err := u.repository.GetAllBlocked(ctx, userID, q)
+	if err != nil {
+		service.Error(userID, u.tag, err)
+		return nil, nil, err
+	}
+
+	// The repository returns one item more than the limit if there are more pages.
+	// A block is never updated, so it is sorted by the date of the block for both dates.
+	page := &paging.Page{Limit: q.Limit}
+	if len(users) > q.Limit {
+		users = users[:q.Limit]
+		last := users[len(users)-1]
+		page.Next = q.CursorAt(last.ID, last.Name, last.CreatedAt, last.CreatedAt)
+	}
+
+	return users, page, nil
+}
@@ -0,0 +1,69 @@
+package contact
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/user-service/pkg/paging"
+	"testing"
+	"time"
+)
+
+func TestGetAllBlockedUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("GetAllBlocked", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, errors.New("error")).
+			Once()
+
+		uc := NewGetAllBlockedUseCase(r)
+		_, _, err := uc.Execute(ctx, "+5518999999999", paging.NewQuery())
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when there are more pages", func(t *testing.T) {
+		//t.Parallel()
+		blockedAt := time.Date(2022, 10, 1, 10, 0, 0, 0, time.UTC)
+		users := []*BlockedUser{
+			{ID: "+5518977777777", Name: "Bill", Reason: "spam", CreatedAt: blockedAt},
+			{ID: "+5518966666666", Name: "Steve", CreatedAt: blockedAt.Add(time.Hour)},
+			{ID: "+5518955555555", Name: "Linus", CreatedAt: blockedAt.Add(2 * time.Hour)},
+		}
+		q := paging.NewQuery()
+		q.Limit = 2
+		q.Sort = paging.SortCreatedAt
+
+		r := new(mockRepository)
+		r.On("GetAllBlocked", mock.Anything, "+5518999999999", q).
+			Return(users, nil).
+			Once()
+
+		uc := NewGetAllBlockedUseCase(r)
+		us, page, err := uc.Execute(ctx, "+5518999999999", q)
+		assert.Nil(t, err)
+		assert.Equal(t, users[:2], us)
+		assert.True(t, page.HasMore())
+		assert.Equal(t, "+5518966666666", page.Next.ID)
+	})
+
+	t.Run("when use case succeeds", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("GetAllBlocked", mock.Anything, mock.Anything, mock.Anything).
+			Return([]*BlockedUser{}, nil).
+			Once()
+
+		uc := NewGetAllBlockedUseCase(r)
+		us, page, err := uc.Execute(ctx, "+5518999999999", paging.NewQuery())
+
+		assert.Nil(t, err)
+		assert.Empty(t, us)
+		assert.False(t, page.HasMore())
+	})
+}
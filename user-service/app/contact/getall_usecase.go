@@ -6,11 +6,13 @@ import (
 
 	"github.com/tsmweb/go-helper-api/cerror"
 	"github.com/tsmweb/user-service/common/service"
+	"github.com/tsmweb/user-service/pkg/paging"
 )
 
-// GetAllUseCase returns a list of contacts by userID, otherwise an error is returned.
+// GetAllUseCase returns a page of contacts by userID selected by the query, otherwise an error
+// is returned.
 type GetAllUseCase interface {
-	Execute(ctx context.Context, userID string) ([]*Contact, error)
+	Execute(ctx context.Context, userID string, q *paging.Query) ([]*Contact, *paging.Page, error)
 }
 
 type getAllUseCase struct {
@@ -27,18 +29,24 @@ func NewGetAllUseCase(r Repository) GetAllUseCase {
 }
 
 // Execute performs the use case to get all.
-func (u *getAllUseCase) Execute(ctx context.Context, userID string) ([]*Contact, error) {
-	contacts, err := u.repository.GetAll(ctx, userID)
+func (u *getAllUseCase) Execute(ctx context.Context, userID string, q *paging.Query) (
+	[]*Contact, *paging.Page, error) {
+	contacts, err := u.repository.GetAll(ctx, userID, q)
 	if err != nil {
 		if errors.Is(err, cerror.ErrNotFound) {
-			return nil, ErrContactNotFound
+			return nil, nil, ErrContactNotFound
 		}
 		service.Error(userID, u.tag, err)
-		return nil, err
+		return nil, nil, err
 	}
-	if len(contacts) == 0 {
-		return nil, ErrContactNotFound
+
+	// The repository returns one item more than the limit if there are more pages.
+	page := &paging.Page{Limit: q.Limit}
+	if len(contacts) > q.Limit {
+		contacts = contacts[:q.Limit]
+		last := contacts[len(contacts)-1]
+		page.Next = q.CursorAt(last.ID, last.Name, last.CreatedAt, last.UpdatedAt)
 	}
 
-	return contacts, nil
+	return contacts, page, nil
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/tsmweb/go-helper-api/cerror"
+	"github.com/tsmweb/user-service/pkg/paging"
 	"testing"
 )
 
@@ -16,12 +17,12 @@ func TestGetAllUseCase_Execute(t *testing.T) {
 	t.Run("when use case fails with ErrContactNotFound", func(t *testing.T) {
 		//t.Parallel()
 		r := new(mockRepository)
-		r.On("GetAll", mock.Anything, mock.Anything).
+		r.On("GetAll", mock.Anything, mock.Anything, mock.Anything).
 			Return(nil, cerror.ErrNotFound).
 			Once()
 
 		uc := NewGetAllUseCase(r)
-		_, err := uc.Execute(ctx, "+5518999999999")
+		_, _, err := uc.Execute(ctx, "+5518999999999", paging.NewQuery())
 
 		assert.Equal(t, ErrContactNotFound, err)
 	})
@@ -29,12 +30,12 @@ func TestGetAllUseCase_Execute(t *testing.T) {
 	t.Run("when use case fails with Error", func(t *testing.T) {
 		//t.Parallel()
 		r := new(mockRepository)
-		r.On("GetAll", mock.Anything, mock.Anything).
+		r.On("GetAll", mock.Anything, mock.Anything, mock.Anything).
 			Return(nil, errors.New("error")).
 			Once()
 
 		uc := NewGetAllUseCase(r)
-		_, err := uc.Execute(ctx, "+5518999999999")
+		_, _, err := uc.Execute(ctx, "+5518999999999", paging.NewQuery())
 
 		assert.NotNil(t, err)
 	})
@@ -57,14 +58,39 @@ func TestGetAllUseCase_Execute(t *testing.T) {
 		}
 
 		r := new(mockRepository)
-		r.On("GetAll", mock.Anything, mock.Anything).
+		r.On("GetAll", mock.Anything, mock.Anything, mock.Anything).
 			Return(contacts, nil).
 			Once()
 
 		uc := NewGetAllUseCase(r)
-		cs, err := uc.Execute(ctx, "+5518999999999")
+		cs, page, err := uc.Execute(ctx, "+5518999999999", paging.NewQuery())
 
 		assert.Nil(t, err)
 		assert.Equal(t, contacts, cs)
+		assert.Equal(t, paging.DefaultLimit, page.Limit)
+		assert.False(t, page.HasMore())
+	})
+
+	t.Run("when there are more pages", func(t *testing.T) {
+		//t.Parallel()
+		contacts := []*Contact{
+			{ID: "1", Name: "A"},
+			{ID: "2", Name: "B"},
+			{ID: "3", Name: "C"},
+		}
+		q := paging.NewQuery()
+		q.Limit = 2
+
+		r := new(mockRepository)
+		r.On("GetAll", mock.Anything, "+5518999999999", q).
+			Return(contacts, nil).
+			Once()
+
+		uc := NewGetAllUseCase(r)
+		cs, page, err := uc.Execute(ctx, "+5518999999999", q)
+		assert.Nil(t, err)
+		assert.Equal(t, contacts[:2], cs)
+		assert.True(t, page.HasMore())
+		assert.Equal(t, &paging.Cursor{Sort: paging.SortName, Value: "B", ID: "2"}, page.Next)
 	})
 }
@@ -0,0 +1,74 @@
+package contact
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+// MaxImportEntries is the maximum number of phone-book entries of an import.
+const MaxImportEntries = 1000
+
+var (
+	ErrImportEntriesValidateModel = &cerror.ErrValidateModel{Msg: "required contacts"}
+	ErrImportLimitValidateModel   = &cerror.ErrValidateModel{
+		Msg: fmt.Sprintf("at most %d contacts per import", MaxImportEntries),
+	}
+	ErrImportHashValidateModel = &cerror.ErrValidateModel{Msg: "id must be a hex SHA-256 hash"}
+)
+
+// ImportEntry is a phone-book entry to be looked up among the users. Key is the phone number,
+// or the SHA-256 of it in hex when the entries are hashed.
+type ImportEntry struct {
+	Key      string
+	Name     string
+	LastName string
+}
+
+// ImportResult is an entry found to be a registered user, added as contact or not.
+type ImportResult struct {
+	Key    string
+	UserID string
+	Added  bool
+}
+
+// normalizeImportEntries validates the entries, returning them without the blank and
+// repeated keys and with the hashes in lower case.
+func normalizeImportEntries(entries []*ImportEntry, hashed bool) ([]*ImportEntry, error) {
+	if len(entries) == 0 {
+		return nil, ErrImportEntriesValidateModel
+	}
+	if len(entries) > MaxImportEntries {
+		return nil, ErrImportLimitValidateModel
+	}
+
+	seen := make(map[string]bool, len(entries))
+	normalized := make([]*ImportEntry, 0, len(entries))
+
+	for _, e := range entries {
+		key := strings.TrimSpace(e.Key)
+		if hashed {
+			key = strings.ToLower(key)
+			if b, err := hex.DecodeString(key); err != nil || len(b) != 32 {
+				return nil, ErrImportHashValidateModel
+			}
+		}
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		normalized = append(normalized, &ImportEntry{
+			Key:      key,
+			Name:     strings.TrimSpace(e.Name),
+			LastName: strings.TrimSpace(e.LastName),
+		})
+	}
+
+	if len(normalized) == 0 {
+		return nil, ErrImportEntriesValidateModel
+	}
+	return normalized, nil
+}
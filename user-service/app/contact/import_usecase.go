@@ -0,0 +1,102 @@
+package contact
+
+import (
+	"context"
+	"time"
+
+	"github.com/tsmweb/chat-server/pkg/ratelimit"
+	"github.com/tsmweb/user-service/common/service"
+	"github.com/tsmweb/user-service/config"
+)
+
+// ImportUseCase looks up the phone-book entries of the user among the registered users,
+// optionally adding them as contacts, otherwise an error is returned.
+type ImportUseCase interface {
+	Execute(ctx context.Context, userID string, entries []*ImportEntry, hashed, add bool) (
+		[]*ImportResult, error)
+}
+
+type importUseCase struct {
+	tag        string
+	repository Repository
+	limiter    ratelimit.Limiter
+}
+
+// NewImportUseCase create a new instance of ImportUseCase.
+func NewImportUseCase(r Repository, limiter ratelimit.Limiter) ImportUseCase {
+	return &importUseCase{
+		tag:        "contact::ImportUseCase",
+		repository: r,
+		limiter:    limiter,
+	}
+}
+
+// Execute performs the import use case. The users are looked up in a single query and,
+// if add is true, the ones not yet contacts are added in a single transaction. The imports
+// of the user are limited to config.ImportMaxRequests in the window of config.ImportWindow,
+// as each one may look up many phone numbers, returning ErrTooManyImports beyond the limit.
+func (u *importUseCase) Execute(ctx context.Context, userID string, entries []*ImportEntry,
+	hashed, add bool) ([]*ImportResult, error) {
+	entries, err := normalizeImportEntries(entries, hashed)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := u.limiter.Allow(ctx, "contact:import:"+userID, config.ImportMaxRequests(),
+		time.Duration(config.ImportWindow())*time.Minute)
+	if err != nil {
+		service.Error(userID, u.tag, err)
+		return nil, err
+	}
+	if !allowed {
+		return nil, ErrTooManyImports
+	}
+
+	keys := make([]string, 0, len(entries))
+	for _, e := range entries {
+		keys = append(keys, e.Key)
+	}
+
+	users, err := u.repository.ExistsUsers(ctx, keys, hashed)
+	if err != nil {
+		service.Error(userID, u.tag, err)
+		return nil, err
+	}
+
+	results := make([]*ImportResult, 0, len(users))
+	contacts := make([]*Contact, 0, len(users))
+
+	for _, e := range entries {
+		id, ok := users[e.Key]
+		if !ok || id == userID {
+			continue
+		}
+		results = append(results, &ImportResult{Key: e.Key, UserID: id})
+
+		c, err := NewContact(id, e.Name, e.LastName, userID)
+		if err != nil {
+			return nil, err
+		}
+		contacts = append(contacts, c)
+	}
+
+	if !add || len(contacts) == 0 {
+		return results, nil
+	}
+
+	added, err := u.repository.CreateAll(ctx, userID, contacts)
+	if err != nil {
+		service.Error(userID, u.tag, err)
+		return nil, err
+	}
+
+	addedIDs := make(map[string]bool, len(added))
+	for _, id := range added {
+		addedIDs[id] = true
+	}
+	for _, r := range results {
+		r.Added = addedIDs[r.UserID]
+	}
+
+	return results, nil
+}
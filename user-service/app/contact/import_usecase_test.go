@@ -0,0 +1,168 @@
+package contact
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestImportUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+	userID := "+5518999999999"
+	entries := []*ImportEntry{
+		{Key: "+5518977777777", Name: "Bill", LastName: "Gates"},
+		{Key: " +5518977777777 ", Name: "Bill"},
+		{Key: "+5518966666666", Name: "Steve", LastName: "Jobs"},
+		{Key: "+5518955555555", Name: "Not", LastName: "Registered"},
+		{Key: userID, Name: "Me"},
+	}
+	keys := []string{"+5518977777777", "+5518966666666", "+5518955555555", userID}
+	users := map[string]string{
+		"+5518977777777": "+5518977777777",
+		"+5518966666666": "+5518966666666",
+		userID:           userID,
+	}
+
+	t.Run("when use case fails with ErrImportEntriesValidateModel", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		l := newAllowLimiter(true)
+		uc := NewImportUseCase(r, l)
+
+		_, err := uc.Execute(ctx, userID, nil, false, false)
+		assert.Equal(t, ErrImportEntriesValidateModel, err)
+
+		_, err = uc.Execute(ctx, userID, []*ImportEntry{{Key: " "}}, false, false)
+		assert.Equal(t, ErrImportEntriesValidateModel, err)
+	})
+
+	t.Run("when use case fails with ErrImportLimitValidateModel", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		l := newAllowLimiter(true)
+		uc := NewImportUseCase(r, l)
+
+		_, err := uc.Execute(ctx, userID, make([]*ImportEntry, MaxImportEntries+1), false, false)
+		assert.Equal(t, ErrImportLimitValidateModel, err)
+	})
+
+	t.Run("when use case fails with ErrImportHashValidateModel", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		l := newAllowLimiter(true)
+		uc := NewImportUseCase(r, l)
+
+		_, err := uc.Execute(ctx, userID, entries, true, false)
+		assert.Equal(t, ErrImportHashValidateModel, err)
+	})
+
+	t.Run("when use case fails with ErrTooManyImports", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		l := newAllowLimiter(false)
+		uc := NewImportUseCase(r, l)
+
+		_, err := uc.Execute(ctx, userID, entries, false, false)
+		assert.Equal(t, ErrTooManyImports, err)
+		r.AssertNotCalled(t, "ExistsUsers", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		l := newAllowLimiter(true)
+		r.On("ExistsUsers", mock.Anything, keys, false).
+			Return(nil, errors.New("error")).
+			Once()
+
+		uc := NewImportUseCase(r, l)
+		_, err := uc.Execute(ctx, userID, entries, false, true)
+		assert.NotNil(t, err)
+
+		r.On("ExistsUsers", mock.Anything, keys, false).
+			Return(users, nil).
+			Once()
+		r.On("CreateAll", mock.Anything, userID, mock.Anything).
+			Return(nil, errors.New("error")).
+			Once()
+
+		_, err = uc.Execute(ctx, userID, entries, false, true)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when use case looks up the users", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		l := newAllowLimiter(true)
+		r.On("ExistsUsers", mock.Anything, keys, false).
+			Return(users, nil).
+			Once()
+
+		uc := NewImportUseCase(r, l)
+		results, err := uc.Execute(ctx, userID, entries, false, false)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []*ImportResult{
+			{Key: "+5518977777777", UserID: "+5518977777777"},
+			{Key: "+5518966666666", UserID: "+5518966666666"},
+		}, results)
+		r.AssertNotCalled(t, "CreateAll", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case adds the contacts", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		l := newAllowLimiter(true)
+		r.On("ExistsUsers", mock.Anything, keys, false).
+			Return(users, nil).
+			Once()
+		r.On("CreateAll", mock.Anything, userID, mock.MatchedBy(func(contacts []*Contact) bool {
+			return len(contacts) == 2 &&
+				contacts[0].ID == "+5518977777777" && contacts[0].Name == "Bill" &&
+				contacts[0].LastName == "Gates" && contacts[0].UserID == userID &&
+				contacts[1].ID == "+5518966666666" && contacts[1].Name == "Steve"
+		})).
+			Return([]string{"+5518966666666"}, nil).
+			Once()
+
+		uc := NewImportUseCase(r, l)
+		results, err := uc.Execute(ctx, userID, entries, false, true)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []*ImportResult{
+			{Key: "+5518977777777", UserID: "+5518977777777", Added: false},
+			{Key: "+5518966666666", UserID: "+5518966666666", Added: true},
+		}, results)
+		r.AssertExpectations(t)
+	})
+
+	t.Run("when use case looks up hashed entries", func(t *testing.T) {
+		//t.Parallel()
+		hash := "3f6b1b1b4f0e5c1e9c2a8d6e7f8a9b0c1d2e3f4a5b6c7d8e9f0a1b2c3d4e5f6a"
+		r := new(mockRepository)
+		l := newAllowLimiter(true)
+		r.On("ExistsUsers", mock.Anything, []string{hash}, true).
+			Return(map[string]string{hash: "+5518977777777"}, nil).
+			Once()
+
+		uc := NewImportUseCase(r, l)
+		results, err := uc.Execute(ctx, userID,
+			[]*ImportEntry{{Key: strings.ToUpper(hash), Name: "Bill"}}, true, false)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []*ImportResult{{Key: hash, UserID: "+5518977777777"}}, results)
+	})
+}
+
+func newAllowLimiter(allowed bool) *mockLimiter {
+	l := new(mockLimiter)
+	l.On("Allow", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("int"),
+		mock.AnythingOfType("time.Duration")).
+		Return(allowed, nil)
+	return l
+}
@@ -0,0 +1,20 @@
+package contact
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// mockLimiter injects mock ratelimit.Limiter dependency.
+type mockLimiter struct {
+	mock.Mock
+}
+
+// Allow represents the simulated method for the Allow feature in the ratelimit.Limiter layer.
+func (m *mockLimiter) Allow(ctx context.Context, key string, limit int,
+	window time.Duration) (bool, error) {
+	args := m.Called(ctx, key, limit, window)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,35 @@
+package contact
+
+import (
+	"context"
+
+	"github.com/tsmweb/user-service/common/service"
+)
+
+// RemoveUserUseCase removes the contacts of a deleted user and the blocks from and to the user,
+// otherwise an error is returned.
+type RemoveUserUseCase interface {
+	Execute(ctx context.Context, userID string) error
+}
+
+type removeUserUseCase struct {
+	tag        string
+	repository Repository
+}
+
+// NewRemoveUserUseCase create a new instance of RemoveUserUseCase.
+func NewRemoveUserUseCase(r Repository) RemoveUserUseCase {
+	return &removeUserUseCase{
+		tag:        "contact::RemoveUserUseCase",
+		repository: r,
+	}
+}
+
+// Execute performs the remove user use case.
+func (u *removeUserUseCase) Execute(ctx context.Context, userID string) error {
+	if err := u.repository.DeleteAll(ctx, userID); err != nil {
+		service.Error(userID, u.tag, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package contact
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestRemoveUserUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("DeleteAll", mock.Anything, "+5518999999999").
+			Return(errors.New("error")).
+			Once()
+		uc := NewRemoveUserUseCase(r)
+		err := uc.Execute(ctx, "+5518999999999")
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when use case succeeds", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("DeleteAll", mock.Anything, "+5518999999999").
+			Return(nil).
+			Once()
+		uc := NewRemoveUserUseCase(r)
+		err := uc.Execute(ctx, "+5518999999999")
+
+		assert.Nil(t, err)
+		r.AssertExpectations(t)
+	})
+}
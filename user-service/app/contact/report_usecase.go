@@ -0,0 +1,95 @@
+package contact
+
+import (
+	"context"
+	"time"
+
+	"github.com/tsmweb/chat-server/pkg/ratelimit"
+	"github.com/tsmweb/go-helper-api/kafka"
+	"github.com/tsmweb/user-service/common/service"
+	"github.com/tsmweb/user-service/config"
+)
+
+// ReportUseCase records an abuse report of a user for the moderators and blocks the user,
+// otherwise an error is returned.
+type ReportUseCase interface {
+	Execute(ctx context.Context, userID, reportedUserID, reason string) error
+}
+
+type reportUseCase struct {
+	tag        string
+	repository Repository
+	encoder    EventEncoder
+	producer   kafka.Producer
+	limiter    ratelimit.Limiter
+}
+
+// NewReportUseCase create a new instance of ReportUseCase.
+func NewReportUseCase(
+	repository Repository,
+	encoder EventEncoder,
+	producer kafka.Producer,
+	limiter ratelimit.Limiter,
+) ReportUseCase {
+	return &reportUseCase{
+		tag:        "contact::ReportUseCase",
+		repository: repository,
+		encoder:    encoder,
+		producer:   producer,
+		limiter:    limiter,
+	}
+}
+
+// Execute perform the report use case. The reports of the user are limited to
+// config.ReportMaxRequests in the window of config.ReportWindow, so that the moderators are
+// not flooded, returning ErrTooManyReports beyond the limit.
+func (u *reportUseCase) Execute(ctx context.Context, userID, reportedUserID, reason string) error {
+	report, err := NewReport(userID, reportedUserID, reason)
+	if err != nil {
+		return err
+	}
+
+	allowed, err := u.limiter.Allow(ctx, "contact:report:"+userID, config.ReportMaxRequests(),
+		time.Duration(config.ReportWindow())*time.Minute)
+	if err != nil {
+		service.Error(userID, u.tag, err)
+		return err
+	}
+	if !allowed {
+		return ErrTooManyReports
+	}
+
+	ok, err := u.repository.ExistsUser(ctx, reportedUserID)
+	if err != nil {
+		service.Error(userID, u.tag, err)
+		return err
+	}
+	if !ok {
+		return ErrUserNotFound
+	}
+
+	if err = u.repository.Report(ctx, report); err != nil {
+		service.Error(userID, u.tag, err)
+		return err
+	}
+
+	if err = u.notify(ctx, userID, reportedUserID); err != nil {
+		service.Error(userID, u.tag, err)
+		return &ErrEventNotification{Msg: err.Error()}
+	}
+
+	return nil
+}
+
+func (u *reportUseCase) notify(ctx context.Context, userID, contactID string) error {
+	event := NewEvent(userID, contactID, EventBlockUser)
+	epb, err := u.encoder.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err = u.producer.Publish(ctx, []byte(userID), epb); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package contact
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/user-service/common"
+	"testing"
+)
+
+func TestReportUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+
+	encode := new(mockEventEncoder)
+	encode.On("Marshal", mock.Anything).
+		Return([]byte{}, nil)
+
+	producer := new(common.MockKafkaProducer)
+	producer.On("Publish", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	t.Run("when use case fails with ErrReportReasonValidateModel", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+
+		uc := NewReportUseCase(r, encode, producer, newAllowLimiter(true))
+		err := uc.Execute(ctx, "+5518999999999", "+5518977777777", "")
+
+		assert.Equal(t, ErrReportReasonValidateModel, err)
+	})
+
+	t.Run("when use case fails with ErrReportSelfValidateModel", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+
+		uc := NewReportUseCase(r, encode, producer, newAllowLimiter(true))
+		err := uc.Execute(ctx, "+5518999999999", "+5518999999999", "spam")
+
+		assert.Equal(t, ErrReportSelfValidateModel, err)
+		r.AssertNotCalled(t, "Report", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with ErrTooManyReports", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+
+		uc := NewReportUseCase(r, encode, producer, newAllowLimiter(false))
+		err := uc.Execute(ctx, "+5518999999999", "+5518977777777", "spam")
+
+		assert.Equal(t, ErrTooManyReports, err)
+		r.AssertNotCalled(t, "Report", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with ErrUserNotFound", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("ExistsUser", mock.Anything, mock.Anything).
+			Return(false, nil).
+			Once()
+
+		uc := NewReportUseCase(r, encode, producer, newAllowLimiter(true))
+		err := uc.Execute(ctx, "+5518999999999", "+5518977777777", "spam")
+
+		assert.Equal(t, ErrUserNotFound, err)
+	})
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("ExistsUser", mock.Anything, mock.Anything).
+			Return(true, nil)
+		r.On("Report", mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Once()
+
+		uc := NewReportUseCase(r, encode, producer, newAllowLimiter(true))
+		err := uc.Execute(ctx, "+5518999999999", "+5518977777777", "spam")
+		assert.NotNil(t, err)
+
+		r.On("Report", mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		p := new(common.MockKafkaProducer)
+		p.On("Publish", mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Once()
+
+		uc = NewReportUseCase(r, encode, p, newAllowLimiter(true))
+		err = uc.Execute(ctx, "+5518999999999", "+5518977777777", "spam")
+		assert.IsType(t, &ErrEventNotification{}, err)
+	})
+
+	t.Run("when use case succeeds", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("ExistsUser", mock.Anything, mock.Anything).
+			Return(true, nil).
+			Once()
+		r.On("Report", mock.Anything, mock.MatchedBy(func(report *Report) bool {
+			return report.UserID == "+5518999999999" &&
+				report.ReportedUserID == "+5518977777777" &&
+				report.Reason == "spam"
+		})).
+			Return(nil).
+			Once()
+
+		uc := NewReportUseCase(r, encode, producer, newAllowLimiter(true))
+		err := uc.Execute(ctx, "+5518999999999", "+5518977777777", "spam")
+
+		assert.Nil(t, err)
+		producer.AssertCalled(t, "Publish", mock.Anything, []byte("+5518999999999"), mock.Anything)
+	})
+}
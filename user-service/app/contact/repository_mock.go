@@ -3,6 +3,7 @@ package contact
 import (
 	"context"
 	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/user-service/pkg/paging"
 	"time"
 )
 
@@ -21,8 +22,8 @@ func (m *mockRepository) Get(ctx context.Context, profileID, contactID string) (
 }
 
 // GetAll represents the simulated method for the GetAll feature in the Repository layer.
-func (m *mockRepository) GetAll(ctx context.Context, profileID string) ([]*Contact, error) {
-	args := m.Called(ctx, profileID)
+func (m *mockRepository) GetAll(ctx context.Context, profileID string, q *paging.Query) ([]*Contact, error) {
+	args := m.Called(ctx, profileID, q)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -38,6 +39,15 @@ func (m *mockRepository) ExistsUser(ctx context.Context, ID string) (bool, error
 	return args.Get(0).(bool), nil
 }
 
+// ExistsUsers represents the simulated method for the ExistsUsers feature in the Repository layer.
+func (m *mockRepository) ExistsUsers(ctx context.Context, keys []string, hashed bool) (map[string]string, error) {
+	args := m.Called(ctx, keys, hashed)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]string), nil
+}
+
 // GetPresence represents the simulated method for the GetPresence feature in the Repository layer.
 func (m *mockRepository) GetPresence(ctx context.Context, profileID, contactID string) (PresenceType, error) {
 	args := m.Called(ctx, profileID, contactID)
@@ -53,6 +63,15 @@ func (m *mockRepository) Create(ctx context.Context, c *Contact) error {
 	return args.Error(0)
 }
 
+// CreateAll represents the simulated method for the CreateAll feature in the Repository layer.
+func (m *mockRepository) CreateAll(ctx context.Context, userID string, contacts []*Contact) ([]string, error) {
+	args := m.Called(ctx, userID, contacts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), nil
+}
+
 // Update represents the simulated method for the Update feature in the Repository layer.
 func (m *mockRepository) Update(ctx context.Context, c *Contact) (bool, error) {
 	args := m.Called(ctx, c)
@@ -72,8 +91,23 @@ func (m *mockRepository) Delete(ctx context.Context, userID, contactID string) (
 }
 
 // Block represents the simulated method for the Block feature in the Repository layer.
-func (m *mockRepository) Block(ctx context.Context, profileID, blockedUserID string, createdAt time.Time) error {
-	args := m.Called(ctx, profileID, blockedUserID, createdAt)
+func (m *mockRepository) Block(ctx context.Context, profileID, blockedUserID, reason string, createdAt time.Time) error {
+	args := m.Called(ctx, profileID, blockedUserID, reason, createdAt)
+	return args.Error(0)
+}
+
+// GetAllBlocked represents the simulated method for the GetAllBlocked feature in the Repository layer.
+func (m *mockRepository) GetAllBlocked(ctx context.Context, userID string, q *paging.Query) ([]*BlockedUser, error) {
+	args := m.Called(ctx, userID, q)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*BlockedUser), nil
+}
+
+// Report represents the simulated method for the Report feature in the Repository layer.
+func (m *mockRepository) Report(ctx context.Context, report *Report) error {
+	args := m.Called(ctx, report)
 	return args.Error(0)
 }
 
@@ -85,3 +119,9 @@ func (m *mockRepository) Unblock(ctx context.Context, profileID, blockedUserID s
 	}
 	return args.Get(0).(bool), nil
 }
+
+// DeleteAll represents the simulated method for the DeleteAll feature in the Repository layer.
+func (m *mockRepository) DeleteAll(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
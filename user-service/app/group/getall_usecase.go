@@ -6,11 +6,13 @@ import (
 
 	"github.com/tsmweb/go-helper-api/cerror"
 	"github.com/tsmweb/user-service/common/service"
+	"github.com/tsmweb/user-service/pkg/paging"
 )
 
-// GetAllUseCase returns a list of groups by userID, otherwise an error is returned.
+// GetAllUseCase returns a page of groups by userID selected by the query, otherwise an error
+// is returned.
 type GetAllUseCase interface {
-	Execute(ctx context.Context, userID string) ([]*Group, error)
+	Execute(ctx context.Context, userID string, q *paging.Query) ([]*Group, *paging.Page, error)
 }
 
 type getAllUseCase struct {
@@ -27,18 +29,24 @@ func NewGetAllUseCase(r Repository) GetAllUseCase {
 }
 
 // Execute performs the use case to get all.
-func (u *getAllUseCase) Execute(ctx context.Context, userID string) ([]*Group, error) {
-	groups, err := u.repository.GetAll(ctx, userID)
+func (u *getAllUseCase) Execute(ctx context.Context, userID string, q *paging.Query) (
+	[]*Group, *paging.Page, error) {
+	groups, err := u.repository.GetAll(ctx, userID, q)
 	if err != nil {
 		if errors.Is(err, cerror.ErrNotFound) {
-			return nil, ErrGroupNotFound
+			return nil, nil, ErrGroupNotFound
 		}
 		service.Error(userID, u.tag, err)
-		return nil, err
+		return nil, nil, err
 	}
-	if len(groups) == 0 {
-		return nil, ErrGroupNotFound
+
+	// The repository returns one item more than the limit if there are more pages.
+	page := &paging.Page{Limit: q.Limit}
+	if len(groups) > q.Limit {
+		groups = groups[:q.Limit]
+		last := groups[len(groups)-1]
+		page.Next = q.CursorAt(last.ID, last.Name, last.CreatedAt, last.UpdatedAt)
 	}
 
-	return groups, nil
+	return groups, page, nil
 }
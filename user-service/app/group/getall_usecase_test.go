@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/tsmweb/go-helper-api/cerror"
 	"github.com/tsmweb/user-service/common"
+	"github.com/tsmweb/user-service/pkg/paging"
 	"testing"
 )
 
@@ -17,24 +18,24 @@ func TestGetAllUseCase_Execute(t *testing.T) {
 	t.Run("when use case fails with ErrGroupNotFound", func(t *testing.T) {
 		//t.Parallel()
 		r := new(mockRepository)
-		r.On("GetAll", mock.Anything, mock.Anything).
+		r.On("GetAll", mock.Anything, mock.Anything, mock.Anything).
 			Return(nil, cerror.ErrNotFound).
 			Once()
 
 		uc := NewGetAllUseCase(r)
-		_, err := uc.Execute(ctx, "+5518999999999")
+		_, _, err := uc.Execute(ctx, "+5518999999999", paging.NewQuery())
 		assert.Equal(t, ErrGroupNotFound, err)
 	})
 
 	t.Run("when use case fails with Error", func(t *testing.T) {
 		//t.Parallel()
 		r := new(mockRepository)
-		r.On("GetAll", mock.Anything, mock.Anything).
+		r.On("GetAll", mock.Anything, mock.Anything, mock.Anything).
 			Return(nil, errors.New("error")).
 			Once()
 
 		uc := NewGetAllUseCase(r)
-		_, err := uc.Execute(ctx, "+5518999999999")
+		_, _, err := uc.Execute(ctx, "+5518999999999", paging.NewQuery())
 		assert.NotNil(t, err)
 	})
 
@@ -56,13 +57,38 @@ func TestGetAllUseCase_Execute(t *testing.T) {
 		}
 
 		r := new(mockRepository)
-		r.On("GetAll", mock.Anything, mock.Anything).
+		r.On("GetAll", mock.Anything, mock.Anything, mock.Anything).
 			Return(groups, nil).
 			Once()
 
 		uc := NewGetAllUseCase(r)
-		gs, err := uc.Execute(ctx, "+5518999999999")
+		gs, page, err := uc.Execute(ctx, "+5518999999999", paging.NewQuery())
 		assert.Nil(t, err)
 		assert.Equal(t, groups, gs)
+		assert.Equal(t, paging.DefaultLimit, page.Limit)
+		assert.False(t, page.HasMore())
+	})
+
+	t.Run("when there are more pages", func(t *testing.T) {
+		//t.Parallel()
+		groups := []*Group{
+			{ID: "1", Name: "A"},
+			{ID: "2", Name: "B"},
+			{ID: "3", Name: "C"},
+		}
+		q := paging.NewQuery()
+		q.Limit = 2
+
+		r := new(mockRepository)
+		r.On("GetAll", mock.Anything, "+5518999999999", q).
+			Return(groups, nil).
+			Once()
+
+		uc := NewGetAllUseCase(r)
+		gs, page, err := uc.Execute(ctx, "+5518999999999", q)
+		assert.Nil(t, err)
+		assert.Equal(t, groups[:2], gs)
+		assert.True(t, page.HasMore())
+		assert.Equal(t, &paging.Cursor{Sort: paging.SortName, Value: "B", ID: "2"}, page.Next)
 	})
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/tsmweb/go-helper-api/cerror"
 	"github.com/tsmweb/go-helper-api/util/hashutil"
+	"github.com/tsmweb/user-service/pkg/paging"
 	"strconv"
 	"time"
 )
@@ -64,10 +65,37 @@ func (g *Group) Validate(op Operation) error {
 	return nil
 }
 
+// Successor returns the member that takes over the group when the owner leaves it, the oldest
+// administrator or else the oldest member, or nil if the owner is the only member.
+func (g *Group) Successor() *Member {
+	var successor *Member
+	for _, m := range g.Members {
+		if m.UserID == g.Owner {
+			continue
+		}
+		if successor == nil ||
+			(m.Admin && !successor.Admin) ||
+			(m.Admin == successor.Admin && m.CreatedAt.Before(successor.CreatedAt)) {
+			successor = m
+		}
+	}
+	return successor
+}
+
+// Member returns the member of the group by userID, or nil if the user is not a member.
+func (g *Group) Member(userID string) *Member {
+	for _, m := range g.Members {
+		if m.UserID == userID {
+			return m
+		}
+	}
+	return nil
+}
+
 // Repository interface for Group data source.
 type Repository interface {
 	Get(ctx context.Context, groupID, userID string) (*Group, error)
-	GetAll(ctx context.Context, userID string) ([]*Group, error)
+	GetAll(ctx context.Context, userID string, q *paging.Query) ([]*Group, error)
 	ExistsUser(ctx context.Context, userID string) (bool, error)
 	ExistsGroup(ctx context.Context, groupID string) (bool, error)
 	IsGroupAdmin(ctx context.Context, groupID, userID string) (bool, error)
@@ -78,4 +106,7 @@ type Repository interface {
 	AddMember(ctx context.Context, member *Member) error
 	SetAdmin(ctx context.Context, member *Member) (bool, error)
 	RemoveMember(ctx context.Context, groupID, userID string) (bool, error)
+	SetOwner(ctx context.Context, member *Member) (bool, error)
+	HandOver(ctx context.Context, successor *Member, ownerID string) (bool, error)
+	RemoveMemberNotify(ctx context.Context, userID string) error
 }
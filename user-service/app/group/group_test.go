@@ -3,6 +3,7 @@ package group
 import (
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestNewGroup(t *testing.T) {
@@ -50,3 +51,42 @@ func TestGroup_Validate(t *testing.T) {
 		assert.Equal(t, err, tc.want)
 	}
 }
+
+func TestGroup_Successor(t *testing.T) {
+	//t.Parallel()
+	now := time.Now().UTC()
+	owner := &Member{UserID: "+5518999999999", Admin: true, CreatedAt: now.Add(-3 * time.Hour)}
+	member := &Member{UserID: "+5518977777777", CreatedAt: now.Add(-2 * time.Hour)}
+	admin := &Member{UserID: "+5518966666666", Admin: true, CreatedAt: now.Add(-time.Hour)}
+	newAdmin := &Member{UserID: "+5518955555555", Admin: true, CreatedAt: now}
+
+	t.Run("when the owner is the only member", func(t *testing.T) {
+		//t.Parallel()
+		g := &Group{Owner: owner.UserID, Members: []*Member{owner}}
+		assert.Nil(t, g.Successor())
+	})
+
+	t.Run("when there is no administrator", func(t *testing.T) {
+		//t.Parallel()
+		g := &Group{Owner: owner.UserID, Members: []*Member{owner, member}}
+		assert.Equal(t, member, g.Successor())
+	})
+
+	t.Run("when the oldest administrator takes over", func(t *testing.T) {
+		//t.Parallel()
+		g := &Group{Owner: owner.UserID, Members: []*Member{newAdmin, owner, member, admin}}
+		assert.Equal(t, admin, g.Successor())
+	})
+}
+
+func TestGroup_Member(t *testing.T) {
+	//t.Parallel()
+	member := &Member{UserID: "+5518977777777"}
+	g := &Group{
+		Owner:   "+5518999999999",
+		Members: []*Member{{UserID: "+5518999999999", Admin: true}, member},
+	}
+
+	assert.Equal(t, member, g.Member("+5518977777777"))
+	assert.Nil(t, g.Member("+5518966666666"))
+}
@@ -0,0 +1,142 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tsmweb/go-helper-api/cerror"
+	"github.com/tsmweb/go-helper-api/kafka"
+	"github.com/tsmweb/user-service/common"
+	"github.com/tsmweb/user-service/common/service"
+)
+
+// LeaveGroupUseCase removes the authenticated user from the group, otherwise an error is
+// returned. When the owner leaves, the group is taken over by the Successor, or deleted when
+// the owner is its only member.
+type LeaveGroupUseCase interface {
+	Execute(ctx context.Context, groupID string) error
+}
+
+type leaveGroupUseCase struct {
+	tag        string
+	repository Repository
+	encoder    EventEncoder
+	producer   kafka.Producer
+}
+
+// NewLeaveGroupUseCase create a new instance of LeaveGroupUseCase.
+func NewLeaveGroupUseCase(
+	repository Repository,
+	encoder EventEncoder,
+	producer kafka.Producer,
+) LeaveGroupUseCase {
+	return newLeaveGroupUseCase(repository, encoder, producer)
+}
+
+func newLeaveGroupUseCase(
+	repository Repository,
+	encoder EventEncoder,
+	producer kafka.Producer,
+) *leaveGroupUseCase {
+	return &leaveGroupUseCase{
+		tag:        "group::LeaveGroupUseCase",
+		repository: repository,
+		encoder:    encoder,
+		producer:   producer,
+	}
+}
+
+// Execute performs the leave group use case.
+func (u *leaveGroupUseCase) Execute(ctx context.Context, groupID string) error {
+	authID := ctx.Value(common.AuthContextKey).(string)
+
+	owner, err := u.repository.IsGroupOwner(ctx, groupID, authID)
+	if err != nil {
+		service.Error(authID, u.tag, err)
+		return err
+	}
+
+	if err = u.leave(ctx, groupID, authID, owner); err != nil {
+		if errors.Is(err, ErrMemberNotFound) || errors.Is(err, ErrGroupNotFound) {
+			return err
+		}
+		service.Error(authID, u.tag, err)
+		return err
+	}
+
+	return nil
+}
+
+// leave removes the user from the group, handing the group over first if the user owns it.
+func (u *leaveGroupUseCase) leave(ctx context.Context, groupID, userID string, owner bool) error {
+	if owner {
+		return u.leaveOwnedGroup(ctx, groupID, userID)
+	}
+	return u.leaveGroup(ctx, groupID, userID)
+}
+
+func (u *leaveGroupUseCase) leaveOwnedGroup(ctx context.Context, groupID, userID string) error {
+	g, err := u.repository.Get(ctx, groupID, userID)
+	if err != nil {
+		if errors.Is(err, cerror.ErrNotFound) {
+			return ErrGroupNotFound
+		}
+		return err
+	}
+
+	successor := g.Successor()
+	if successor == nil {
+		if _, err = u.repository.Delete(ctx, groupID); err != nil {
+			return err
+		}
+		return u.notify(ctx, groupID, "", EventDeleteGroup)
+	}
+
+	// the group is handed over and left at once, so that it is never left without an owner,
+	// and the members are notified only once both are done.
+	successor.UpdatedBy = userID
+	successor.UpdatedAt = time.Now().UTC()
+	ok, err := u.repository.HandOver(ctx, successor, userID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrGroupNotFound
+	}
+	if !successor.Admin {
+		if err = u.notify(ctx, groupID, successor.UserID, EventAddAdmin); err != nil {
+			return err
+		}
+	}
+
+	return u.notify(ctx, groupID, userID, EventRemoveMember)
+}
+
+func (u *leaveGroupUseCase) leaveGroup(ctx context.Context, groupID, userID string) error {
+	ok, err := u.repository.RemoveMember(ctx, groupID, userID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrMemberNotFound
+	}
+	return u.notify(ctx, groupID, userID, EventRemoveMember)
+}
+
+func (u *leaveGroupUseCase) notify(ctx context.Context, groupID, memberID string, event EventType) error {
+	epb, err := u.encoder.Marshal(NewEvent(groupID, memberID, event))
+	if err != nil {
+		return &ErrEventNotification{Msg: err.Error()}
+	}
+
+	key := groupID
+	if memberID != "" {
+		key = fmt.Sprintf("%s:%s", groupID, memberID)
+	}
+	if err = u.producer.Publish(ctx, []byte(key), epb); err != nil {
+		return &ErrEventNotification{Msg: err.Error()}
+	}
+	return nil
+}
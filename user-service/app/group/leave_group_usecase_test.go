@@ -0,0 +1,182 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/user-service/common"
+	"testing"
+	"time"
+)
+
+func TestLeaveGroupUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	userID := "+5518999999999"
+	ctx := context.WithValue(context.Background(), common.AuthContextKey, userID)
+	now := time.Now().UTC()
+
+	encode := new(mockEventEncoder)
+	encode.On("Marshal", mock.Anything).
+		Return([]byte{}, nil)
+
+	newProducer := func() *common.MockKafkaProducer {
+		producer := new(common.MockKafkaProducer)
+		producer.On("Publish", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+		return producer
+	}
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("IsGroupOwner", mock.Anything, "group1", userID).
+			Return(false, errors.New("error")).
+			Once()
+
+		uc := NewLeaveGroupUseCase(r, encode, newProducer())
+		err := uc.Execute(ctx, "group1")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when use case fails with ErrMemberNotFound", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("IsGroupOwner", mock.Anything, "group1", userID).
+			Return(false, nil).
+			Once()
+		r.On("RemoveMember", mock.Anything, "group1", userID).
+			Return(false, nil).
+			Once()
+		producer := newProducer()
+
+		uc := NewLeaveGroupUseCase(r, encode, producer)
+		err := uc.Execute(ctx, "group1")
+		assert.Equal(t, ErrMemberNotFound, err)
+		producer.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when use case fails with ErrEventNotification", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("IsGroupOwner", mock.Anything, "group1", userID).
+			Return(false, nil).
+			Once()
+		r.On("RemoveMember", mock.Anything, "group1", userID).
+			Return(true, nil).
+			Once()
+		p := new(common.MockKafkaProducer)
+		p.On("Publish", mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Once()
+
+		uc := NewLeaveGroupUseCase(r, encode, p)
+		err := uc.Execute(ctx, "group1")
+		assert.IsType(t, &ErrEventNotification{}, err)
+	})
+
+	t.Run("when a member leaves", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("IsGroupOwner", mock.Anything, "group1", userID).
+			Return(false, nil).
+			Once()
+		r.On("RemoveMember", mock.Anything, "group1", userID).
+			Return(true, nil).
+			Once()
+		producer := newProducer()
+
+		uc := NewLeaveGroupUseCase(r, encode, producer)
+		err := uc.Execute(ctx, "group1")
+		assert.Nil(t, err)
+		r.AssertExpectations(t)
+		producer.AssertCalled(t, "Publish", mock.Anything, []byte("group1:"+userID), mock.Anything)
+	})
+
+	t.Run("when the owner is the only member", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("IsGroupOwner", mock.Anything, "group1", userID).
+			Return(true, nil).
+			Once()
+		r.On("Get", mock.Anything, "group1", userID).
+			Return(&Group{
+				ID:      "group1",
+				Owner:   userID,
+				Members: []*Member{{GroupID: "group1", UserID: userID, Admin: true}},
+			}, nil).
+			Once()
+		r.On("Delete", mock.Anything, "group1").
+			Return(true, nil).
+			Once()
+		producer := newProducer()
+
+		uc := NewLeaveGroupUseCase(r, encode, producer)
+		err := uc.Execute(ctx, "group1")
+		assert.Nil(t, err)
+		r.AssertExpectations(t)
+		r.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything, mock.Anything)
+		producer.AssertCalled(t, "Publish", mock.Anything, []byte("group1"), mock.Anything)
+	})
+
+	t.Run("when the owner leaves to the oldest admin", func(t *testing.T) {
+		//t.Parallel()
+		admin := &Member{GroupID: "group1", UserID: "+5518966666666", Admin: true, CreatedAt: now}
+		r := new(mockRepository)
+		r.On("IsGroupOwner", mock.Anything, "group1", userID).
+			Return(true, nil).
+			Once()
+		r.On("Get", mock.Anything, "group1", userID).
+			Return(&Group{
+				ID:    "group1",
+				Owner: userID,
+				Members: []*Member{
+					{GroupID: "group1", UserID: userID, Admin: true, CreatedAt: now.Add(-2 * time.Hour)},
+					{GroupID: "group1", UserID: "+5518977777777", CreatedAt: now.Add(-time.Hour)},
+					admin,
+				},
+			}, nil).
+			Once()
+		r.On("HandOver", mock.Anything, admin, userID).
+			Return(true, nil).
+			Once()
+		producer := newProducer()
+
+		uc := NewLeaveGroupUseCase(r, encode, producer)
+		err := uc.Execute(ctx, "group1")
+		assert.Nil(t, err)
+		assert.Equal(t, userID, admin.UpdatedBy)
+		r.AssertExpectations(t)
+		producer.AssertNotCalled(t, "Publish", mock.Anything, []byte("group1:+5518966666666"), mock.Anything)
+		producer.AssertCalled(t, "Publish", mock.Anything, []byte("group1:"+userID), mock.Anything)
+	})
+
+	t.Run("when the group is not handed over", func(t *testing.T) {
+		//t.Parallel()
+		member := &Member{GroupID: "group1", UserID: "+5518977777777", CreatedAt: now}
+		r := new(mockRepository)
+		r.On("IsGroupOwner", mock.Anything, "group1", userID).
+			Return(true, nil).
+			Once()
+		r.On("Get", mock.Anything, "group1", userID).
+			Return(&Group{
+				ID:    "group1",
+				Owner: userID,
+				Members: []*Member{
+					{GroupID: "group1", UserID: userID, Admin: true, CreatedAt: now.Add(-time.Hour)},
+					member,
+				},
+			}, nil).
+			Once()
+		r.On("HandOver", mock.Anything, member, userID).
+			Return(false, nil).
+			Once()
+		producer := newProducer()
+
+		uc := NewLeaveGroupUseCase(r, encode, producer)
+		err := uc.Execute(ctx, "group1")
+		assert.Equal(t, ErrGroupNotFound, err)
+		r.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything, mock.Anything)
+		producer.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,77 @@
+package group
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tsmweb/go-helper-api/kafka"
+	"github.com/tsmweb/user-service/common/service"
+	"github.com/tsmweb/user-service/pkg/paging"
+)
+
+// RemoveUserUseCase removes a deleted user from all its groups, otherwise an error is returned.
+// The groups owned by the user are taken over by the Successor, or deleted when the user is
+// their only member.
+type RemoveUserUseCase interface {
+	Execute(ctx context.Context, userID string) error
+}
+
+type removeUserUseCase struct {
+	tag        string
+	repository Repository
+	leaver     *leaveGroupUseCase
+}
+
+// NewRemoveUserUseCase create a new instance of RemoveUserUseCase.
+func NewRemoveUserUseCase(
+	repository Repository,
+	encoder EventEncoder,
+	producer kafka.Producer,
+) RemoveUserUseCase {
+	return &removeUserUseCase{
+		tag:        "group::RemoveUserUseCase",
+		repository: repository,
+		leaver:     newLeaveGroupUseCase(repository, encoder, producer),
+	}
+}
+
+// Execute performs the remove user use case.
+func (u *removeUserUseCase) Execute(ctx context.Context, userID string) error {
+	q := paging.NewQuery()
+	q.Limit = paging.MaxLimit
+
+	for {
+		groups, err := u.repository.GetAll(ctx, userID, q)
+		if err != nil {
+			service.Error(userID, u.tag, err)
+			return err
+		}
+
+		more := len(groups) > q.Limit
+		if more {
+			groups = groups[:q.Limit]
+		}
+
+		for _, g := range groups {
+			err = u.leaver.leave(ctx, g.ID, userID, g.Owner == userID)
+			// the group may have been left or deleted in the meantime.
+			if err != nil && !errors.Is(err, ErrMemberNotFound) && !errors.Is(err, ErrGroupNotFound) {
+				service.Error(userID, u.tag, err)
+				return err
+			}
+		}
+
+		if !more {
+			break
+		}
+		last := groups[len(groups)-1]
+		q.After = q.CursorAt(last.ID, last.Name, last.CreatedAt, last.UpdatedAt)
+	}
+
+	if err := u.repository.RemoveMemberNotify(ctx, userID); err != nil {
+		service.Error(userID, u.tag, err)
+		return err
+	}
+
+	return nil
+}
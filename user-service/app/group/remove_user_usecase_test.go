@@ -0,0 +1,125 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/user-service/common"
+	"testing"
+	"time"
+)
+
+func TestRemoveUserUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ctx := context.Background()
+	userID := "+5518999999999"
+	now := time.Now().UTC()
+
+	encode := new(mockEventEncoder)
+	encode.On("Marshal", mock.Anything).
+		Return([]byte{}, nil)
+
+	newProducer := func() *common.MockKafkaProducer {
+		producer := new(common.MockKafkaProducer)
+		producer.On("Publish", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+		return producer
+	}
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("GetAll", mock.Anything, userID, mock.Anything).
+			Return(nil, errors.New("error")).
+			Once()
+
+		uc := NewRemoveUserUseCase(r, encode, newProducer())
+		err := uc.Execute(ctx, userID)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when the user is a member", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("GetAll", mock.Anything, userID, mock.Anything).
+			Return([]*Group{{ID: "group1", Owner: "+5518977777777"}}, nil).
+			Once()
+		r.On("RemoveMember", mock.Anything, "group1", userID).
+			Return(true, nil).
+			Once()
+		r.On("RemoveMemberNotify", mock.Anything, userID).
+			Return(nil).
+			Once()
+		producer := newProducer()
+
+		uc := NewRemoveUserUseCase(r, encode, producer)
+		err := uc.Execute(ctx, userID)
+		assert.Nil(t, err)
+		r.AssertExpectations(t)
+		producer.AssertCalled(t, "Publish", mock.Anything, []byte("group1:"+userID), mock.Anything)
+	})
+
+	t.Run("when the user is the only member of the group", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("GetAll", mock.Anything, userID, mock.Anything).
+			Return([]*Group{{ID: "group1", Owner: userID}}, nil).
+			Once()
+		r.On("Get", mock.Anything, "group1", userID).
+			Return(&Group{
+				ID:      "group1",
+				Owner:   userID,
+				Members: []*Member{{GroupID: "group1", UserID: userID, Admin: true}},
+			}, nil).
+			Once()
+		r.On("Delete", mock.Anything, "group1").
+			Return(true, nil).
+			Once()
+		r.On("RemoveMemberNotify", mock.Anything, userID).
+			Return(nil).
+			Once()
+		producer := newProducer()
+
+		uc := NewRemoveUserUseCase(r, encode, producer)
+		err := uc.Execute(ctx, userID)
+		assert.Nil(t, err)
+		r.AssertExpectations(t)
+		r.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything, mock.Anything)
+		producer.AssertCalled(t, "Publish", mock.Anything, []byte("group1"), mock.Anything)
+	})
+
+	t.Run("when the ownership is transferred", func(t *testing.T) {
+		//t.Parallel()
+		successor := &Member{GroupID: "group1", UserID: "+5518977777777", CreatedAt: now}
+		r := new(mockRepository)
+		r.On("GetAll", mock.Anything, userID, mock.Anything).
+			Return([]*Group{{ID: "group1", Owner: userID}}, nil).
+			Once()
+		r.On("Get", mock.Anything, "group1", userID).
+			Return(&Group{
+				ID:    "group1",
+				Owner: userID,
+				Members: []*Member{
+					{GroupID: "group1", UserID: userID, Admin: true},
+					successor,
+				},
+			}, nil).
+			Once()
+		r.On("HandOver", mock.Anything, successor, userID).
+			Return(true, nil).
+			Once()
+		r.On("RemoveMemberNotify", mock.Anything, userID).
+			Return(nil).
+			Once()
+		producer := newProducer()
+
+		uc := NewRemoveUserUseCase(r, encode, producer)
+		err := uc.Execute(ctx, userID)
+		assert.Nil(t, err)
+		assert.Equal(t, userID, successor.UpdatedBy)
+		r.AssertExpectations(t)
+		producer.AssertCalled(t, "Publish", mock.Anything, []byte("group1:+5518977777777"), mock.Anything)
+		producer.AssertCalled(t, "Publish", mock.Anything, []byte("group1:"+userID), mock.Anything)
+	})
+}
@@ -3,6 +3,7 @@ package group
 import (
 	"context"
 	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/user-service/pkg/paging"
 )
 
 // mockRepository injects mock dependency into UserCase layer.
@@ -20,8 +21,8 @@ func (m *mockRepository) Get(ctx context.Context, groupID, userID string) (*Grou
 }
 
 // GetAll represents the simulated method for the GetAll feature in the Repository layer.
-func (m *mockRepository) GetAll(ctx context.Context, userID string) ([]*Group, error) {
-	args := m.Called(ctx, userID)
+func (m *mockRepository) GetAll(ctx context.Context, userID string, q *paging.Query) ([]*Group, error) {
+	args := m.Called(ctx, userID, q)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -111,3 +112,27 @@ func (m *mockRepository) RemoveMember(ctx context.Context, groupID, userID strin
 	}
 	return args.Get(0).(bool), nil
 }
+
+// SetOwner represents the simulated method for the SetOwner feature in the Repository layer.
+func (m *mockRepository) SetOwner(ctx context.Context, mb *Member) (bool, error) {
+	args := m.Called(ctx, mb)
+	if args.Error(1) != nil {
+		return false, args.Error(1)
+	}
+	return args.Get(0).(bool), nil
+}
+
+// HandOver represents the simulated method for the HandOver feature in the Repository layer.
+func (m *mockRepository) HandOver(ctx context.Context, successor *Member, ownerID string) (bool, error) {
+	args := m.Called(ctx, successor, ownerID)
+	if args.Error(1) != nil {
+		return false, args.Error(1)
+	}
+	return args.Get(0).(bool), nil
+}
+
+// RemoveMemberNotify represents the simulated method for the RemoveMemberNotify feature in the Repository layer.
+func (m *mockRepository) RemoveMemberNotify(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,117 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tsmweb/go-helper-api/cerror"
+	"github.com/tsmweb/go-helper-api/kafka"
+	"github.com/tsmweb/user-service/common"
+	"github.com/tsmweb/user-service/common/service"
+)
+
+// TransferOwnershipUseCase hands the group over from the authenticated owner to a member, who
+// becomes an administrator, otherwise an error is returned. The former owner stays in the group
+// as an administrator.
+type TransferOwnershipUseCase interface {
+	Execute(ctx context.Context, member *Member) error
+}
+
+type transferOwnershipUseCase struct {
+	tag        string
+	repository Repository
+	encoder    EventEncoder
+	producer   kafka.Producer
+}
+
+// NewTransferOwnershipUseCase create a new instance of TransferOwnershipUseCase.
+func NewTransferOwnershipUseCase(
+	repository Repository,
+	encoder EventEncoder,
+	producer kafka.Producer,
+) TransferOwnershipUseCase {
+	return &transferOwnershipUseCase{
+		tag:        "group::TransferOwnershipUseCase",
+		repository: repository,
+		encoder:    encoder,
+		producer:   producer,
+	}
+}
+
+// Execute performs the transfer ownership use case.
+func (u *transferOwnershipUseCase) Execute(ctx context.Context, member *Member) error {
+	err := member.Validate()
+	if err != nil {
+		return err
+	}
+
+	authID := ctx.Value(common.AuthContextKey).(string)
+	// the owner cannot hand the group over to himself.
+	if authID == member.UserID {
+		service.Warn(authID, u.tag, ErrOperationNotAllowed.Error())
+		return ErrOperationNotAllowed
+	}
+
+	g, err := u.repository.Get(ctx, member.GroupID, authID)
+	if err != nil {
+		if errors.Is(err, cerror.ErrNotFound) {
+			return ErrGroupNotFound
+		}
+		service.Error(authID, u.tag, err)
+		return err
+	}
+
+	// only the group owner can hand the group over.
+	if g.Owner != authID {
+		service.Warn(authID, u.tag, ErrOperationNotAllowed.Error())
+		return ErrOperationNotAllowed
+	}
+
+	successor := g.Member(member.UserID)
+	if successor == nil {
+		return ErrMemberNotFound
+	}
+
+	successor.UpdatedBy = authID
+	successor.UpdatedAt = time.Now().UTC()
+
+	ok, err := u.repository.SetOwner(ctx, successor)
+	if err != nil {
+		service.Error(authID, u.tag, err)
+		return err
+	}
+	if !ok {
+		return ErrGroupNotFound
+	}
+
+	if err = u.notify(ctx, g.ID, successor); err != nil {
+		service.Error(authID, u.tag, err)
+		return &ErrEventNotification{Msg: err.Error()}
+	}
+
+	return nil
+}
+
+func (u *transferOwnershipUseCase) notify(ctx context.Context, groupID string, successor *Member) error {
+	if !successor.Admin {
+		if err := u.publish(ctx, groupID, successor.UserID, EventAddAdmin); err != nil {
+			return err
+		}
+	}
+	return u.publish(ctx, groupID, "", EventUpdateGroup)
+}
+
+func (u *transferOwnershipUseCase) publish(ctx context.Context, groupID, memberID string, event EventType) error {
+	epb, err := u.encoder.Marshal(NewEvent(groupID, memberID, event))
+	if err != nil {
+		return err
+	}
+
+	key := groupID
+	if memberID != "" {
+		key = fmt.Sprintf("%s:%s", groupID, memberID)
+	}
+	return u.producer.Publish(ctx, []byte(key), epb)
+}
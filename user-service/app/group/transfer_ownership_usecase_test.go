@@ -0,0 +1,124 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsmweb/go-helper-api/cerror"
+	"github.com/tsmweb/user-service/common"
+	"testing"
+)
+
+func TestTransferOwnershipUseCase_Execute(t *testing.T) {
+	//t.Parallel()
+	ownerID := "+5518999999999"
+	ctx := context.WithValue(context.Background(), common.AuthContextKey, ownerID)
+
+	encode := new(mockEventEncoder)
+	encode.On("Marshal", mock.Anything).
+		Return([]byte{}, nil)
+
+	newProducer := func() *common.MockKafkaProducer {
+		producer := new(common.MockKafkaProducer)
+		producer.On("Publish", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+		return producer
+	}
+
+	newGroup := func(owner string) *Group {
+		return &Group{
+			ID:    "group1",
+			Owner: owner,
+			Members: []*Member{
+				{GroupID: "group1", UserID: ownerID, Admin: true},
+				{GroupID: "group1", UserID: "+5518977777777"},
+			},
+		}
+	}
+
+	t.Run("when use case fails with ErrValidateModel", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+
+		uc := NewTransferOwnershipUseCase(r, encode, newProducer())
+		err := uc.Execute(ctx, &Member{GroupID: "group1"})
+		assert.Equal(t, ErrUserIDValidateModel, err)
+	})
+
+	t.Run("when use case fails with ErrOperationNotAllowed", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+
+		uc := NewTransferOwnershipUseCase(r, encode, newProducer())
+		err := uc.Execute(ctx, &Member{GroupID: "group1", UserID: ownerID})
+		assert.Equal(t, ErrOperationNotAllowed, err)
+
+		r.On("Get", mock.Anything, "group1", ownerID).
+			Return(newGroup("+5518966666666"), nil).
+			Once()
+
+		err = uc.Execute(ctx, &Member{GroupID: "group1", UserID: "+5518977777777"})
+		assert.Equal(t, ErrOperationNotAllowed, err)
+	})
+
+	t.Run("when use case fails with ErrGroupNotFound", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "group1", ownerID).
+			Return(nil, cerror.ErrNotFound).
+			Once()
+
+		uc := NewTransferOwnershipUseCase(r, encode, newProducer())
+		err := uc.Execute(ctx, &Member{GroupID: "group1", UserID: "+5518977777777"})
+		assert.Equal(t, ErrGroupNotFound, err)
+	})
+
+	t.Run("when use case fails with ErrMemberNotFound", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "group1", ownerID).
+			Return(newGroup(ownerID), nil).
+			Once()
+
+		uc := NewTransferOwnershipUseCase(r, encode, newProducer())
+		err := uc.Execute(ctx, &Member{GroupID: "group1", UserID: "+5518955555555"})
+		assert.Equal(t, ErrMemberNotFound, err)
+	})
+
+	t.Run("when use case fails with Error", func(t *testing.T) {
+		//t.Parallel()
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "group1", ownerID).
+			Return(newGroup(ownerID), nil).
+			Once()
+		r.On("SetOwner", mock.Anything, mock.Anything).
+			Return(false, errors.New("error")).
+			Once()
+
+		uc := NewTransferOwnershipUseCase(r, encode, newProducer())
+		err := uc.Execute(ctx, &Member{GroupID: "group1", UserID: "+5518977777777"})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when use case succeeds", func(t *testing.T) {
+		//t.Parallel()
+		g := newGroup(ownerID)
+		r := new(mockRepository)
+		r.On("Get", mock.Anything, "group1", ownerID).
+			Return(g, nil).
+			Once()
+		r.On("SetOwner", mock.Anything, g.Members[1]).
+			Return(true, nil).
+			Once()
+		producer := newProducer()
+
+		uc := NewTransferOwnershipUseCase(r, encode, producer)
+		err := uc.Execute(ctx, &Member{GroupID: "group1", UserID: "+5518977777777"})
+		assert.Nil(t, err)
+		assert.Equal(t, ownerID, g.Members[1].UpdatedBy)
+		r.AssertExpectations(t)
+		producer.AssertCalled(t, "Publish", mock.Anything, []byte("group1:+5518977777777"), mock.Anything)
+		producer.AssertCalled(t, "Publish", mock.Anything, []byte("group1"), mock.Anything)
+	})
+}
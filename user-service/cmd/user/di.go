@@ -1,11 +1,17 @@
 package main
 
 import (
+	"time"
+
 	"github.com/gorilla/mux"
+	"github.com/tsmweb/chat-server/pkg/jwks"
+	"github.com/tsmweb/chat-server/pkg/ratelimit"
+	"github.com/tsmweb/chat-server/pkg/revocation"
 	"github.com/tsmweb/go-helper-api/auth"
 	"github.com/tsmweb/go-helper-api/kafka"
 	"github.com/tsmweb/go-helper-api/middleware"
 	"github.com/tsmweb/user-service/adapter"
+	"github.com/tsmweb/user-service/app/account"
 	"github.com/tsmweb/user-service/app/contact"
 	"github.com/tsmweb/user-service/app/group"
 	"github.com/tsmweb/user-service/config"
@@ -15,10 +21,12 @@ import (
 )
 
 type Provider struct {
-	jwt      auth.JWT
-	mAuth    middleware.Auth
-	dataBase db.Database
-	kafka    kafka.Kafka
+	jwt             auth.JWT
+	mAuth           middleware.Auth
+	revocationStore revocation.Store
+	limiter         ratelimit.Limiter
+	dataBase        db.Database
+	kafka           kafka.Kafka
 }
 
 func CreateProvider() *Provider {
@@ -39,6 +47,9 @@ func (p *Provider) ContactRouter(mr *mux.Router) {
 	deleteUseCase := contact.NewDeleteUseCase(repo)
 	blockUseCase := contact.NewBlockUseCase(repo, encoder, producer)
 	unblockUseCase := contact.NewUnblockUseCase(repo, encoder, producer)
+	importUseCase := contact.NewImportUseCase(repo, p.LimiterProvider())
+	getAllBlockedUseCase := contact.NewGetAllBlockedUseCase(repo)
+	reportUseCase := contact.NewReportUseCase(repo, encoder, producer, p.LimiterProvider())
 
 	handler.MakeContactRouters(
 		mr,
@@ -51,7 +62,10 @@ func (p *Provider) ContactRouter(mr *mux.Router) {
 		updateUseCase,
 		deleteUseCase,
 		blockUseCase,
-		unblockUseCase)
+		unblockUseCase,
+		importUseCase,
+		getAllBlockedUseCase,
+		reportUseCase)
 }
 
 func (p *Provider) GroupRouter(mr *mux.Router) {
@@ -68,6 +82,8 @@ func (p *Provider) GroupRouter(mr *mux.Router) {
 	addMemberUseCase := group.NewAddMemberUseCase(repo, encoder, producer)
 	removeMemberUseCase := group.NewRemoveMemberUseCase(repo, encoder, producer)
 	setAdminUseCase := group.NewSetAdminUseCase(repo, encoder, producer)
+	leaveGroupUseCase := group.NewLeaveGroupUseCase(repo, encoder, producer)
+	transferOwnershipUseCase := group.NewTransferOwnershipUseCase(repo, encoder, producer)
 
 	handler.MakeGroupRouters(
 		mr,
@@ -80,7 +96,29 @@ func (p *Provider) GroupRouter(mr *mux.Router) {
 		deleteUseCase,
 		addMemberUseCase,
 		removeMemberUseCase,
-		setAdminUseCase)
+		setAdminUseCase,
+		leaveGroupUseCase,
+		transferOwnershipUseCase)
+}
+
+func (p *Provider) AccountConsumerProvider() *account.Consumer {
+	database := p.DatabaseProvider()
+	groupEncoder := group.EventEncoderFunc(adapter.GroupEventMarshal)
+	groupProducer := p.KafkaProvider().NewProducer(config.KafkaGroupEventTopic())
+
+	removeContacts := contact.NewRemoveUserUseCase(repository.NewContactRepositoryPostgres(database))
+	removeGroups := group.NewRemoveUserUseCase(repository.NewGroupRepositoryPostgres(database),
+		groupEncoder, groupProducer)
+	deleteUseCase := account.NewDeleteUseCase(
+		removeContacts,
+		removeGroups,
+		account.ReportEncoderFunc(adapter.DeletionReportMarshal),
+		p.KafkaProvider().NewProducer(config.KafkaDeletionReportTopic()))
+
+	return account.NewConsumer(
+		p.KafkaProvider().NewConsumer(config.KafkaGroupID(), config.KafkaAccountEventTopic()),
+		account.EventDecoderFunc(adapter.AccountEventUnmarshal),
+		deleteUseCase)
 }
 
 func (p *Provider) NewKafkaProducer(topic string) kafka.Producer {
@@ -89,18 +127,34 @@ func (p *Provider) NewKafkaProducer(topic string) kafka.Producer {
 
 func (p *Provider) JwtProvider() auth.JWT {
 	if p.jwt == nil {
-		p.jwt = auth.NewJWT(config.KeySecureFile(), config.PubSecureFile())
+		ttl := time.Duration(config.JWKSCacheTTL()) * time.Second
+		p.jwt = jwks.NewJWT(jwks.NewCache(config.JWKSURLs(), ttl))
 	}
 	return p.jwt
 }
 
 func (p *Provider) AuthProvider() middleware.Auth {
 	if p.mAuth == nil {
-		p.mAuth = middleware.NewAuth(p.JwtProvider())
+		mAuth := middleware.NewAuth(p.JwtProvider())
+		p.mAuth = revocation.NewAuth(mAuth, p.JwtProvider(), p.RevocationProvider())
 	}
 	return p.mAuth
 }
 
+func (p *Provider) RevocationProvider() revocation.Store {
+	if p.revocationStore == nil {
+		p.revocationStore = revocation.NewRedisStore(config.RedisHost(), config.RedisPassword())
+	}
+	return p.revocationStore
+}
+
+func (p *Provider) LimiterProvider() ratelimit.Limiter {
+	if p.limiter == nil {
+		p.limiter = ratelimit.NewRedisLimiter(config.RedisHost(), config.RedisPassword())
+	}
+	return p.limiter
+}
+
 func (p *Provider) DatabaseProvider() db.Database {
 	if p.dataBase == nil {
 		p.dataBase = db.NewPostgresDatabase()
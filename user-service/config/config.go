@@ -5,27 +5,38 @@ import (
 	"os"
 	"path"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
 var (
-	hostID                 string
-	dbHost                 string
-	dbPort                 int
-	dbUser                 string
-	dbPassword             string
-	dbName                 string
-	dbSchema               string
-	serverPort             int
-	keySecureFile          string
-	pubSecureFile          string
-	certSecureFile         string
-	kafkaBootstrapServers  string
-	kafkaClientID          string
-	kafkaGroupEventTopic   string
-	kafkaContactEventTopic string
-	kafkaEventsTopic       string
+	hostID                   string
+	dbHost                   string
+	dbPort                   int
+	dbUser                   string
+	dbPassword               string
+	dbName                   string
+	dbSchema                 string
+	serverPort               int
+	keySecureFile            string
+	jwksURLs                 []string
+	jwksCacheTTL             int
+	certSecureFile           string
+	redisHost                string
+	redisPassword            string
+	importMaxRequests        int
+	importWindow             int
+	reportMaxRequests        int
+	reportWindow             int
+	kafkaBootstrapServers    string
+	kafkaClientID            string
+	kafkaGroupID             string
+	kafkaGroupEventTopic     string
+	kafkaContactEventTopic   string
+	kafkaAccountEventTopic   string
+	kafkaDeletionReportTopic string
+	kafkaEventsTopic         string
 )
 
 func Load(workDir string) error {
@@ -53,13 +64,47 @@ func Load(workDir string) error {
 	dbSchema = os.Getenv("DB_SCHEMA")
 
 	keySecureFile = workDir + "/config/cert/server.pem"
-	pubSecureFile = workDir + "/config/cert/server.pub"
 	certSecureFile = workDir + "/config/cert/server.crt"
 
+	jwksURLs = nil
+	for _, url := range strings.Split(os.Getenv("JWKS_URL"), ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			jwksURLs = append(jwksURLs, url)
+		}
+	}
+	jwksCacheTTL, err = strconv.Atoi(os.Getenv("JWKS_CACHE_TTL")) // second
+	if err != nil {
+		jwksCacheTTL = 300
+	}
+
+	redisHost = os.Getenv("REDIS_HOST")
+	redisPassword = os.Getenv("REDIS_PASSWORD")
+
+	importMaxRequests, err = strconv.Atoi(os.Getenv("IMPORT_MAX_REQUESTS"))
+	if err != nil {
+		importMaxRequests = 10
+	}
+	importWindow, err = strconv.Atoi(os.Getenv("IMPORT_WINDOW")) // minute
+	if err != nil {
+		importWindow = 60
+	}
+
+	reportMaxRequests, err = strconv.Atoi(os.Getenv("REPORT_MAX_REQUESTS"))
+	if err != nil {
+		reportMaxRequests = 10
+	}
+	reportWindow, err = strconv.Atoi(os.Getenv("REPORT_WINDOW")) // minute
+	if err != nil {
+		reportWindow = 60
+	}
+
 	kafkaBootstrapServers = os.Getenv("KAFKA_BOOTSTRAP_SERVERS")
 	kafkaClientID = os.Getenv("KAFKA_CLIENT_ID")
+	kafkaGroupID = os.Getenv("KAFKA_GROUP_ID")
 	kafkaGroupEventTopic = os.Getenv("KAFKA_GROUP_EVENT_TOPIC")
 	kafkaContactEventTopic = os.Getenv("KAFKA_CONTACT_EVENT_TOPIC")
+	kafkaAccountEventTopic = os.Getenv("KAFKA_ACCOUNT_EVENT_TOPIC")
+	kafkaDeletionReportTopic = os.Getenv("KAFKA_DELETION_REPORT_TOPIC")
 	kafkaEventsTopic = os.Getenv("KAFKA_EVENTS_TOPIC")
 
 	return nil
@@ -73,8 +118,12 @@ func KeySecureFile() string {
 	return keySecureFile
 }
 
-func PubSecureFile() string {
-	return pubSecureFile
+func JWKSURLs() []string {
+	return jwksURLs
+}
+
+func JWKSCacheTTL() int {
+	return jwksCacheTTL
 }
 
 func CertSecureFile() string {
@@ -109,6 +158,34 @@ func DBSchema() string {
 	return dbSchema
 }
 
+func RedisHost() string {
+	return redisHost
+}
+
+func RedisPassword() string {
+	return redisPassword
+}
+
+// ImportMaxRequests is the limit of contact imports of a user in the window of ImportWindow
+// minutes, which stops the enumeration of the registered phone numbers.
+func ImportMaxRequests() int {
+	return importMaxRequests
+}
+
+func ImportWindow() int {
+	return importWindow
+}
+
+// ReportMaxRequests is the limit of abuse reports of a user in the window of ReportWindow
+// minutes.
+func ReportMaxRequests() int {
+	return reportMaxRequests
+}
+
+func ReportWindow() int {
+	return reportWindow
+}
+
 func KafkaBootstrapServers() string {
 	return kafkaBootstrapServers
 }
@@ -117,6 +194,10 @@ func KafkaClientID() string {
 	return kafkaClientID
 }
 
+func KafkaGroupID() string {
+	return kafkaGroupID
+}
+
 func KafkaGroupEventTopic() string {
 	return kafkaGroupEventTopic
 }
@@ -125,6 +206,14 @@ func KafkaContactEventTopic() string {
 	return kafkaContactEventTopic
 }
 
+func KafkaAccountEventTopic() string {
+	return kafkaAccountEventTopic
+}
+
+func KafkaDeletionReportTopic() string {
+	return kafkaDeletionReportTopic
+}
+
 func KafkaEventsTopic() string {
 	return kafkaEventsTopic
 }
@@ -2,7 +2,7 @@
 // versions:
 // 	protoc-gen-go v1.26.0
 // 	protoc        v3.14.0
-// source: contact.proto
+// source: user/contact.proto
 
 package protobuf
 
@@ -50,11 +50,11 @@ func (x ContactEventType) String() string {
 }
 
 func (ContactEventType) Descriptor() protoreflect.EnumDescriptor {
-	return file_contact_proto_enumTypes[0].Descriptor()
+	return file_user_contact_proto_enumTypes[0].Descriptor()
 }
 
 func (ContactEventType) Type() protoreflect.EnumType {
-	return &file_contact_proto_enumTypes[0]
+	return &file_user_contact_proto_enumTypes[0]
 }
 
 func (x ContactEventType) Number() protoreflect.EnumNumber {
@@ -63,7 +63,7 @@ func (x ContactEventType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ContactEventType.Descriptor instead.
 func (ContactEventType) EnumDescriptor() ([]byte, []int) {
-	return file_contact_proto_rawDescGZIP(), []int{0}
+	return file_user_contact_proto_rawDescGZIP(), []int{0}
 }
 
 type ContactEvent struct {
@@ -80,7 +80,7 @@ type ContactEvent struct {
 func (x *ContactEvent) Reset() {
 	*x = ContactEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_contact_proto_msgTypes[0]
+		mi := &file_user_contact_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -93,7 +93,7 @@ func (x *ContactEvent) String() string {
 func (*ContactEvent) ProtoMessage() {}
 
 func (x *ContactEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_contact_proto_msgTypes[0]
+	mi := &file_user_contact_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -106,7 +106,7 @@ func (x *ContactEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ContactEvent.ProtoReflect.Descriptor instead.
 func (*ContactEvent) Descriptor() ([]byte, []int) {
-	return file_contact_proto_rawDescGZIP(), []int{0}
+	return file_user_contact_proto_rawDescGZIP(), []int{0}
 }
 
 func (x *ContactEvent) GetUserId() string {
@@ -137,46 +137,49 @@ func (x *ContactEvent) GetEventDate() int64 {
 	return 0
 }
 
-var File_contact_proto protoreflect.FileDescriptor
-
-var file_contact_proto_rawDesc = []byte{
-	0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x22, 0x96, 0x01, 0x0a, 0x0c, 0x43, 0x6f, 0x6e,
-	0x74, 0x61, 0x63, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65,
-	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72,
-	0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x5f, 0x69, 0x64,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x49,
-	0x64, 0x12, 0x2f, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x19, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61,
-	0x63, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x65, 0x76, 0x65,
-	0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x65,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x44, 0x61, 0x74,
-	0x65, 0x2a, 0x32, 0x0a, 0x10, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x55, 0x73,
-	0x65, 0x72, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x6e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x55,
-	0x73, 0x65, 0x72, 0x10, 0x01, 0x42, 0x0b, 0x5a, 0x09, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+var File_user_contact_proto protoreflect.FileDescriptor
+
+var file_user_contact_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x75, 0x73, 0x65, 0x72, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x61,
+	0x63, 0x74, 0x22, 0x9b, 0x01, 0x0a, 0x0c, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
+	0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x49, 0x64, 0x12, 0x34, 0x0a, 0x05, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x75, 0x73, 0x65,
+	0x72, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63,
+	0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x44, 0x61, 0x74, 0x65,
+	0x2a, 0x32, 0x0a, 0x10, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x55, 0x73, 0x65,
+	0x72, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x6e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x55, 0x73,
+	0x65, 0x72, 0x10, 0x01, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x74, 0x73, 0x6d, 0x77, 0x65, 0x62, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x2d, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x69, 0x6e, 0x66, 0x72, 0x61, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
-	file_contact_proto_rawDescOnce sync.Once
-	file_contact_proto_rawDescData = file_contact_proto_rawDesc
+	file_user_contact_proto_rawDescOnce sync.Once
+	file_user_contact_proto_rawDescData = file_user_contact_proto_rawDesc
 )
 
-func file_contact_proto_rawDescGZIP() []byte {
-	file_contact_proto_rawDescOnce.Do(func() {
-		file_contact_proto_rawDescData = protoimpl.X.CompressGZIP(file_contact_proto_rawDescData)
+func file_user_contact_proto_rawDescGZIP() []byte {
+	file_user_contact_proto_rawDescOnce.Do(func() {
+		file_user_contact_proto_rawDescData = protoimpl.X.CompressGZIP(file_user_contact_proto_rawDescData)
 	})
-	return file_contact_proto_rawDescData
+	return file_user_contact_proto_rawDescData
 }
 
-var file_contact_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_contact_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
-var file_contact_proto_goTypes = []interface{}{
+var file_user_contact_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_user_contact_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_user_contact_proto_goTypes = []interface{}{
 	(ContactEventType)(0), // 0: contact.ContactEventType
 	(*ContactEvent)(nil),  // 1: contact.ContactEvent
 }
-var file_contact_proto_depIdxs = []int32{
+var file_user_contact_proto_depIdxs = []int32{
 	0, // 0: contact.ContactEvent.event:type_name -> contact.ContactEventType
 	1, // [1:1] is the sub-list for method output_type
 	1, // [1:1] is the sub-list for method input_type
@@ -185,13 +188,13 @@ var file_contact_proto_depIdxs = []int32{
 	0, // [0:1] is the sub-list for field type_name
 }
 
-func init() { file_contact_proto_init() }
-func file_contact_proto_init() {
-	if File_contact_proto != nil {
+func init() { file_user_contact_proto_init() }
+func file_user_contact_proto_init() {
+	if File_user_contact_proto != nil {
 		return
 	}
 	if !protoimpl.UnsafeEnabled {
-		file_contact_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+		file_user_contact_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ContactEvent); i {
 			case 0:
 				return &v.state
@@ -208,19 +211,19 @@ func file_contact_proto_init() {
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_contact_proto_rawDesc,
+			RawDescriptor: file_user_contact_proto_rawDesc,
 			NumEnums:      1,
 			NumMessages:   1,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
-		GoTypes:           file_contact_proto_goTypes,
-		DependencyIndexes: file_contact_proto_depIdxs,
-		EnumInfos:         file_contact_proto_enumTypes,
-		MessageInfos:      file_contact_proto_msgTypes,
+		GoTypes:           file_user_contact_proto_goTypes,
+		DependencyIndexes: file_user_contact_proto_depIdxs,
+		EnumInfos:         file_user_contact_proto_enumTypes,
+		MessageInfos:      file_user_contact_proto_msgTypes,
 	}.Build()
-	File_contact_proto = out.File
-	file_contact_proto_rawDesc = nil
-	file_contact_proto_goTypes = nil
-	file_contact_proto_depIdxs = nil
+	File_user_contact_proto = out.File
+	file_user_contact_proto_rawDesc = nil
+	file_user_contact_proto_goTypes = nil
+	file_user_contact_proto_depIdxs = nil
 }
@@ -2,7 +2,7 @@
 // versions:
 // 	protoc-gen-go v1.26.0
 // 	protoc        v3.14.0
-// source: group.proto
+// source: user/group.proto
 
 package protobuf
 
@@ -62,11 +62,11 @@ func (x GroupEventType) String() string {
 }
 
 func (GroupEventType) Descriptor() protoreflect.EnumDescriptor {
-	return file_group_proto_enumTypes[0].Descriptor()
+	return file_user_group_proto_enumTypes[0].Descriptor()
 }
 
 func (GroupEventType) Type() protoreflect.EnumType {
-	return &file_group_proto_enumTypes[0]
+	return &file_user_group_proto_enumTypes[0]
 }
 
 func (x GroupEventType) Number() protoreflect.EnumNumber {
@@ -75,7 +75,7 @@ func (x GroupEventType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use GroupEventType.Descriptor instead.
 func (GroupEventType) EnumDescriptor() ([]byte, []int) {
-	return file_group_proto_rawDescGZIP(), []int{0}
+	return file_user_group_proto_rawDescGZIP(), []int{0}
 }
 
 type GroupEvent struct {
@@ -92,7 +92,7 @@ type GroupEvent struct {
 func (x *GroupEvent) Reset() {
 	*x = GroupEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_group_proto_msgTypes[0]
+		mi := &file_user_group_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -105,7 +105,7 @@ func (x *GroupEvent) String() string {
 func (*GroupEvent) ProtoMessage() {}
 
 func (x *GroupEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_group_proto_msgTypes[0]
+	mi := &file_user_group_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -118,7 +118,7 @@ func (x *GroupEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupEvent.ProtoReflect.Descriptor instead.
 func (*GroupEvent) Descriptor() ([]byte, []int) {
-	return file_group_proto_rawDescGZIP(), []int{0}
+	return file_user_group_proto_rawDescGZIP(), []int{0}
 }
 
 func (x *GroupEvent) GetGroupId() string {
@@ -149,49 +149,53 @@ func (x *GroupEvent) GetEventDate() int64 {
 	return 0
 }
 
-var File_group_proto protoreflect.FileDescriptor
-
-var file_group_proto_rawDesc = []byte{
-	0x0a, 0x0b, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x67,
-	0x72, 0x6f, 0x75, 0x70, 0x22, 0x90, 0x01, 0x0a, 0x0a, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x45, 0x76,
-	0x65, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x1b,
-	0x0a, 0x09, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x05, 0x65,
-	0x76, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x67, 0x72, 0x6f,
-	0x75, 0x70, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70,
-	0x65, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e,
-	0x74, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x76,
-	0x65, 0x6e, 0x74, 0x44, 0x61, 0x74, 0x65, 0x2a, 0x72, 0x0a, 0x0e, 0x47, 0x72, 0x6f, 0x75, 0x70,
-	0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x41,
-	0x64, 0x64, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x65, 0x6d,
-	0x6f, 0x76, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x41, 0x64,
-	0x64, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x10, 0x04, 0x12, 0x10, 0x0a, 0x0c, 0x52, 0x65, 0x6d,
-	0x6f, 0x76, 0x65, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x10, 0x05, 0x42, 0x0b, 0x5a, 0x09, 0x2f,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+var File_user_group_proto protoreflect.FileDescriptor
+
+var file_user_group_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x75, 0x73, 0x65, 0x72, 0x2f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x22, 0x95,
+	0x01, 0x0a, 0x0a, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x19, 0x0a,
+	0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x65, 0x6d,
+	0x62, 0x65, 0x72, 0x49, 0x64, 0x12, 0x30, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x44, 0x61, 0x74, 0x65, 0x2a, 0x72, 0x0a, 0x0e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x41, 0x64,
+	0x64, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x41, 0x64, 0x64,
+	0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x10, 0x04, 0x12, 0x10, 0x0a, 0x0c, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x10, 0x05, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x73, 0x6d, 0x77, 0x65, 0x62, 0x2f,
+	0x75, 0x73, 0x65, 0x72, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x69, 0x6e, 0x66,
+	0x72, 0x61, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
-	file_group_proto_rawDescOnce sync.Once
-	file_group_proto_rawDescData = file_group_proto_rawDesc
+	file_user_group_proto_rawDescOnce sync.Once
+	file_user_group_proto_rawDescData = file_user_group_proto_rawDesc
 )
 
-func file_group_proto_rawDescGZIP() []byte {
-	file_group_proto_rawDescOnce.Do(func() {
-		file_group_proto_rawDescData = protoimpl.X.CompressGZIP(file_group_proto_rawDescData)
+func file_user_group_proto_rawDescGZIP() []byte {
+	file_user_group_proto_rawDescOnce.Do(func() {
+		file_user_group_proto_rawDescData = protoimpl.X.CompressGZIP(file_user_group_proto_rawDescData)
 	})
-	return file_group_proto_rawDescData
+	return file_user_group_proto_rawDescData
 }
 
-var file_group_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_group_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
-var file_group_proto_goTypes = []interface{}{
+var file_user_group_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_user_group_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_user_group_proto_goTypes = []interface{}{
 	(GroupEventType)(0), // 0: group.GroupEventType
 	(*GroupEvent)(nil),  // 1: group.GroupEvent
 }
-var file_group_proto_depIdxs = []int32{
+var file_user_group_proto_depIdxs = []int32{
 	0, // 0: group.GroupEvent.event:type_name -> group.GroupEventType
 	1, // [1:1] is the sub-list for method output_type
 	1, // [1:1] is the sub-list for method input_type
@@ -200,13 +204,13 @@ var file_group_proto_depIdxs = []int32{
 	0, // [0:1] is the sub-list for field type_name
 }
 
-func init() { file_group_proto_init() }
-func file_group_proto_init() {
-	if File_group_proto != nil {
+func init() { file_user_group_proto_init() }
+func file_user_group_proto_init() {
+	if File_user_group_proto != nil {
 		return
 	}
 	if !protoimpl.UnsafeEnabled {
-		file_group_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+		file_user_group_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GroupEvent); i {
 			case 0:
 				return &v.state
@@ -223,19 +227,19 @@ func file_group_proto_init() {
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_group_proto_rawDesc,
+			RawDescriptor: file_user_group_proto_rawDesc,
 			NumEnums:      1,
 			NumMessages:   1,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
-		GoTypes:           file_group_proto_goTypes,
-		DependencyIndexes: file_group_proto_depIdxs,
-		EnumInfos:         file_group_proto_enumTypes,
-		MessageInfos:      file_group_proto_msgTypes,
+		GoTypes:           file_user_group_proto_goTypes,
+		DependencyIndexes: file_user_group_proto_depIdxs,
+		EnumInfos:         file_user_group_proto_enumTypes,
+		MessageInfos:      file_user_group_proto_msgTypes,
 	}.Build()
-	File_group_proto = out.File
-	file_group_proto_rawDesc = nil
-	file_group_proto_goTypes = nil
-	file_group_proto_depIdxs = nil
+	File_user_group_proto = out.File
+	file_user_group_proto_rawDesc = nil
+	file_user_group_proto_goTypes = nil
+	file_user_group_proto_depIdxs = nil
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/tsmweb/go-helper-api/cerror"
 	"github.com/tsmweb/user-service/app/contact"
 	"github.com/tsmweb/user-service/infra/db"
+	"github.com/tsmweb/user-service/pkg/paging"
 	"time"
 )
 
@@ -55,8 +56,16 @@ func (r *contactRepositoryPostgres) Get(ctx context.Context, userID, contactID s
 	return &contact, nil
 }
 
-// GetAll returns all contacts by userID.
-func (r *contactRepositoryPostgres) GetAll(ctx context.Context, userID string) ([]*contact.Contact, error) {
+// GetAll returns a page of contacts by userID, with one contact more than the query limit
+// if there are more pages.
+func (r *contactRepositoryPostgres) GetAll(ctx context.Context, userID string, q *paging.Query) ([]*contact.Contact, error) {
+	where, order, args := pagingClause(q, pagingColumns{
+		ID:        "c.contact_id",
+		Name:      "c.name",
+		CreatedAt: "c.created_at",
+		UpdatedAt: "COALESCE(c.updated_at, c.created_at)",
+	}, []interface{}{userID})
+
 	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
 			SELECT c.user_id, 
 				c.contact_id, 
@@ -65,7 +74,7 @@ func (r *contactRepositoryPostgres) GetAll(ctx context.Context, userID string) (
 				c.created_at, 
 				COALESCE(c.updated_at, c.created_at, c.updated_at) AS updated_at
 			FROM contact c 
-			WHERE c.user_id = $1`)
+			WHERE c.user_id = $1`+where+order)
 	if err != nil {
 		return nil, err
 	}
@@ -73,7 +82,7 @@ func (r *contactRepositoryPostgres) GetAll(ctx context.Context, userID string) (
 
 	contacts := make([]*contact.Contact, 0)
 
-	rows, err := stmt.QueryContext(ctx, userID)
+	rows, err := stmt.QueryContext(ctx, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -107,7 +116,7 @@ func (r *contactRepositoryPostgres) GetAll(ctx context.Context, userID string) (
 
 // ExistsUser checks if the contact exists in the database.
 func (r *contactRepositoryPostgres) ExistsUser(ctx context.Context, ID string) (bool, error) {
-	stmt, err := r.dataBase.DB().PrepareContext(ctx, `SELECT id FROM "user" WHERE id = $1`)
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `SELECT id FROM "user" WHERE id = $1 AND deleted_at IS NULL`)
 	if err != nil {
 		return false, err
 	}
@@ -122,6 +131,48 @@ func (r *contactRepositoryPostgres) ExistsUser(ctx context.Context, ID string) (
 	return userID == ID, nil
 }
 
+// ExistsUsers returns the IDs of the users found by key, the ID or its SHA-256 in hex if hashed.
+// The hashes are looked up in the user_id_sha256_idx index.
+func (r *contactRepositoryPostgres) ExistsUsers(ctx context.Context, keys []string, hashed bool) (map[string]string, error) {
+	key := "u.id"
+	if hashed {
+		key = "sha256_hex(u.id)"
+	}
+
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+			SELECT `+key+` AS key, 
+				u.id
+			FROM "user" u
+			WHERE `+key+` = ANY($1) 
+			  AND u.deleted_at IS NULL`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, pq.Array(keys))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make(map[string]string)
+
+	for rows.Next() {
+		var key, userID string
+		if err = rows.Scan(&key, &userID); err != nil {
+			return nil, err
+		}
+		users[key] = userID
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
 // GetPresence returns the presence status of the contact.
 func (r *contactRepositoryPostgres) GetPresence(ctx context.Context, userID, contactID string) (contact.PresenceType, error) {
 	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
@@ -190,6 +241,70 @@ func (r *contactRepositoryPostgres) Create(ctx context.Context, contact *contact
 	return nil
 }
 
+// CreateAll creates the contacts of the user in the database in a single transaction,
+// skipping those already registered, and returns the IDs of the contacts created.
+func (r *contactRepositoryPostgres) CreateAll(ctx context.Context, userID string, contacts []*contact.Contact) ([]string, error) {
+	ids := make([]string, 0, len(contacts))
+	names := make([]string, 0, len(contacts))
+	lastnames := make([]string, 0, len(contacts))
+	createdAt := make([]string, 0, len(contacts))
+	for _, c := range contacts {
+		ids = append(ids, c.ID)
+		names = append(names, c.Name)
+		lastnames = append(lastnames, c.LastName)
+		createdAt = append(createdAt, c.CreatedAt.Format("2006-01-02 15:04:05.999999"))
+	}
+
+	txn, err := r.dataBase.DB().Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, err := txn.PrepareContext(ctx, `
+		INSERT INTO contact(user_id, contact_id, name, lastname, created_at) 
+		SELECT $1, c.contact_id, c.name, c.lastname, c.created_at
+		FROM unnest($2::varchar[], $3::varchar[], $4::varchar[], $5::timestamp[]) 
+			AS c(contact_id, name, lastname, created_at)
+		ON CONFLICT (user_id, contact_id) DO NOTHING
+		RETURNING contact_id`)
+	if err != nil {
+		txn.Rollback()
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, userID, pq.Array(ids), pq.Array(names),
+		pq.Array(lastnames), pq.Array(createdAt))
+	if err != nil {
+		txn.Rollback()
+		return nil, err
+	}
+
+	added := make([]string, 0, len(contacts))
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			rows.Close()
+			txn.Rollback()
+			return nil, err
+		}
+		added = append(added, id)
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		txn.Rollback()
+		return nil, err
+	}
+
+	if err = txn.Commit(); err != nil {
+		txn.Rollback()
+		return nil, err
+	}
+
+	return added, nil
+}
+
 // Update updates the contact data in the database.
 func (r *contactRepositoryPostgres) Update(ctx context.Context, contact *contact.Contact) (bool, error) {
 	txn, err := r.dataBase.DB().Begin()
@@ -267,21 +382,21 @@ func (r *contactRepositoryPostgres) Delete(ctx context.Context, userID, contactI
 }
 
 // Block adds a contact to the blocked contacts database.
-func (r *contactRepositoryPostgres) Block(ctx context.Context, userID, blockedUserID string, createdAt time.Time) error {
+func (r *contactRepositoryPostgres) Block(ctx context.Context, userID, blockedUserID, reason string, createdAt time.Time) error {
 	txn, err := r.dataBase.DB().Begin()
 	if err != nil {
 		return err
 	}
 
 	stmt, err := txn.PrepareContext(ctx, `
-		INSERT INTO blocked_user(user_id, blocked_user_id, created_at) 
-		VALUES($1, $2, $3)`)
+		INSERT INTO blocked_user(user_id, blocked_user_id, reason, created_at) 
+		VALUES($1, $2, NULLIF($3, ''), $4)`)
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, userID, blockedUserID, createdAt)
+	_, err = stmt.ExecContext(ctx, userID, blockedUserID, reason, createdAt)
 	if err != nil {
 		txn.Rollback()
 		// "23505": "unique_violation"
@@ -300,6 +415,92 @@ func (r *contactRepositoryPostgres) Block(ctx context.Context, userID, blockedUs
 	return nil
 }
 
+// GetAllBlocked returns a page of the users blocked by userID, with one user more than the
+// query limit if there are more pages.
+func (r *contactRepositoryPostgres) GetAllBlocked(ctx context.Context, userID string, q *paging.Query) ([]*contact.BlockedUser, error) {
+	where, order, args := pagingClause(q, pagingColumns{
+		ID:        "b.blocked_user_id",
+		Name:      "u.name",
+		CreatedAt: "b.created_at",
+		UpdatedAt: "b.created_at",
+	}, []interface{}{userID})
+
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+			SELECT b.blocked_user_id, 
+				u.name, 
+				COALESCE(u.lastname, ''), 
+				COALESCE(b.reason, ''), 
+				b.created_at
+			FROM blocked_user b 
+			INNER JOIN "user" u ON u.id = b.blocked_user_id
+			WHERE b.user_id = $1`+where+order)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	users := make([]*contact.BlockedUser, 0)
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var user contact.BlockedUser
+		err = rows.Scan(
+			&user.ID,
+			&user.Name,
+			&user.LastName,
+			&user.Reason,
+			&user.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, &user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// Report records the abuse report for the moderators and blocks the reported user, keeping
+// the block if the user is already blocked.
+func (r *contactRepositoryPostgres) Report(ctx context.Context, report *contact.Report) error {
+	txn, err := r.dataBase.DB().Begin()
+	if err != nil {
+		return err
+	}
+
+	queries := []string{
+		`INSERT INTO abuse_report(user_id, reported_user_id, reason, created_at) 
+		VALUES($1, $2, $3, $4)`,
+		`INSERT INTO blocked_user(user_id, blocked_user_id, reason, created_at) 
+		VALUES($1, $2, $3, $4) 
+		ON CONFLICT (user_id, blocked_user_id) DO NOTHING`,
+	}
+	for _, query := range queries {
+		_, err = txn.ExecContext(ctx, query,
+			report.UserID, report.ReportedUserID, report.Reason, report.CreatedAt)
+		if err != nil {
+			txn.Rollback()
+			return err
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		txn.Rollback()
+		return err
+	}
+
+	return nil
+}
+
 // Unblock removes a contact from the blocked contacts database.
 func (r *contactRepositoryPostgres) Unblock(ctx context.Context, userID, blockedUserID string) (bool, error) {
 	txn, err := r.dataBase.DB().Begin()
@@ -335,3 +536,31 @@ func (r *contactRepositoryPostgres) Unblock(ctx context.Context, userID, blocked
 
 	return true, nil
 }
+
+// DeleteAll removes the contacts of the user, the blocks from and to the user and the abuse
+// reports made by the user. The reports against the user are kept for the moderators.
+func (r *contactRepositoryPostgres) DeleteAll(ctx context.Context, userID string) error {
+	txn, err := r.dataBase.DB().Begin()
+	if err != nil {
+		return err
+	}
+
+	queries := []string{
+		`DELETE FROM contact WHERE user_id = $1`,
+		`DELETE FROM blocked_user WHERE user_id = $1 OR blocked_user_id = $1`,
+		`DELETE FROM abuse_report WHERE user_id = $1`,
+	}
+	for _, query := range queries {
+		if _, err = txn.ExecContext(ctx, query, userID); err != nil {
+			txn.Rollback()
+			return err
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		txn.Rollback()
+		return err
+	}
+
+	return nil
+}
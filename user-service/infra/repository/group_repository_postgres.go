@@ -7,6 +7,7 @@ import (
 	"github.com/tsmweb/go-helper-api/cerror"
 	"github.com/tsmweb/user-service/app/group"
 	"github.com/tsmweb/user-service/infra/db"
+	"github.com/tsmweb/user-service/pkg/paging"
 	"time"
 )
 
@@ -64,8 +65,16 @@ func (r *groupRepositoryPostgres) Get(ctx context.Context, groupID, userID strin
 	return &grp, nil
 }
 
-// GetAll returns groups by userID.
-func (r *groupRepositoryPostgres) GetAll(ctx context.Context, userID string) ([]*group.Group, error) {
+// GetAll returns a page of groups by userID, with one group more than the query limit if
+// there are more pages.
+func (r *groupRepositoryPostgres) GetAll(ctx context.Context, userID string, q *paging.Query) ([]*group.Group, error) {
+	where, order, args := pagingClause(q, pagingColumns{
+		ID:        "g.id",
+		Name:      "g.name",
+		CreatedAt: "g.created_at",
+		UpdatedAt: "COALESCE(g.updated_at, g.created_at)",
+	}, []interface{}{userID})
+
 	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
 		SELECT g.id,
 			g.owner_id,
@@ -76,7 +85,7 @@ func (r *groupRepositoryPostgres) GetAll(ctx context.Context, userID string) ([]
 			COALESCE(g.updated_by, '', g.updated_by) AS updated_by
 		FROM "group" g
 		INNER JOIN group_member gm ON g.id = gm.group_id
-		WHERE gm.user_id = $1`)
+		WHERE gm.user_id = $1`+where+order)
 	if err != nil {
 		return nil, err
 	}
@@ -84,7 +93,7 @@ func (r *groupRepositoryPostgres) GetAll(ctx context.Context, userID string) ([]
 
 	groups := make([]*group.Group, 0)
 
-	rows, err := stmt.QueryContext(ctx, userID)
+	rows, err := stmt.QueryContext(ctx, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -127,7 +136,8 @@ func (r *groupRepositoryPostgres) ExistsUser(ctx context.Context, userID string)
 	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
 		SELECT u.id 
 		FROM "user" u 
-		WHERE u.id = $1`)
+		WHERE u.id = $1
+		AND u.deleted_at IS NULL`)
 	if err != nil {
 		return false, err
 	}
@@ -409,25 +419,57 @@ func (r *groupRepositoryPostgres) RemoveMember(ctx context.Context, groupID, use
 		return false, err
 	}
 
-	stmt, err := txn.PrepareContext(ctx, `
-		DELETE FROM group_member
-		WHERE group_id = $1
-		AND user_id = $2`)
-	if err != nil {
+	ok, err := r.removeMember(ctx, txn, groupID, userID)
+	if err != nil || !ok {
+		txn.Rollback()
 		return false, err
 	}
-	defer stmt.Close()
 
-	result, err := stmt.ExecContext(ctx, groupID, userID)
+	if err = txn.Commit(); err != nil {
+		txn.Rollback()
+		return false, err
+	}
+
+	return true, nil
+}
+
+// SetOwner transfers the ownership of the group to the member, who becomes an administrator.
+func (r *groupRepositoryPostgres) SetOwner(ctx context.Context, member *group.Member) (bool, error) {
+	txn, err := r.dataBase.DB().Begin()
 	if err != nil {
+		return false, err
+	}
+
+	ok, err := r.setOwner(ctx, txn, member)
+	if err != nil || !ok {
 		txn.Rollback()
 		return false, err
 	}
 
-	ra, _ := result.RowsAffected()
-	if ra != 1 {
+	if err = txn.Commit(); err != nil {
 		txn.Rollback()
-		return false, nil
+		return false, err
+	}
+
+	return true, nil
+}
+
+// HandOver transfers the ownership of the group to the successor and removes the owner from
+// the group, in a single transaction.
+func (r *groupRepositoryPostgres) HandOver(ctx context.Context, successor *group.Member,
+	ownerID string) (bool, error) {
+	txn, err := r.dataBase.DB().Begin()
+	if err != nil {
+		return false, err
+	}
+
+	ok, err := r.setOwner(ctx, txn, successor)
+	if err == nil && ok {
+		ok, err = r.removeMember(ctx, txn, successor.GroupID, ownerID)
+	}
+	if err != nil || !ok {
+		txn.Rollback()
+		return false, err
 	}
 
 	if err = txn.Commit(); err != nil {
@@ -438,6 +480,20 @@ func (r *groupRepositoryPostgres) RemoveMember(ctx context.Context, groupID, use
 	return true, nil
 }
 
+// RemoveMemberNotify removes the pending notifications of the user about deleted groups.
+func (r *groupRepositoryPostgres) RemoveMemberNotify(ctx context.Context, userID string) error {
+	stmt, err := r.dataBase.DB().PrepareContext(ctx, `
+		DELETE FROM group_member_notify
+		WHERE user_id = $1`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, userID)
+	return err
+}
+
 func (r *groupRepositoryPostgres) addMember(ctx context.Context, txn *sql.Tx, member *group.Member) error {
 	stmt, err := txn.PrepareContext(ctx, `
 		INSERT INTO group_member(group_id, user_id, admin, created_at)
@@ -453,6 +509,70 @@ func (r *groupRepositoryPostgres) addMember(ctx context.Context, txn *sql.Tx, me
 	return err
 }
 
+func (r *groupRepositoryPostgres) removeMember(ctx context.Context, txn *sql.Tx, groupID, userID string) (bool, error) {
+	stmt, err := txn.PrepareContext(ctx, `
+		DELETE FROM group_member
+		WHERE group_id = $1
+		AND user_id = $2`)
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, groupID, userID)
+	if err != nil {
+		return false, err
+	}
+
+	ra, _ := result.RowsAffected()
+	return ra == 1, nil
+}
+
+func (r *groupRepositoryPostgres) setOwner(ctx context.Context, txn *sql.Tx, member *group.Member) (bool, error) {
+	stmt, err := txn.PrepareContext(ctx, `
+		UPDATE "group"
+		SET owner_id = $1,
+		    updated_at = $2,
+			updated_by = $3
+		WHERE id = $4`)
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx,
+		member.UserID, member.UpdatedAt, member.UpdatedBy, member.GroupID)
+	if err != nil {
+		return false, err
+	}
+
+	ra, _ := result.RowsAffected()
+	if ra != 1 {
+		return false, nil
+	}
+
+	stmtAdmin, err := txn.PrepareContext(ctx, `
+		UPDATE group_member
+		SET admin = true,
+		    updated_at = $1,
+			updated_by = $2
+		WHERE group_id = $3
+		AND user_id = $4`)
+	if err != nil {
+		return false, err
+	}
+	defer stmtAdmin.Close()
+
+	result, err = stmtAdmin.ExecContext(ctx,
+		member.UpdatedAt, member.UpdatedBy, member.GroupID, member.UserID)
+	if err != nil {
+		return false, err
+	}
+
+	ra, _ = result.RowsAffected()
+	return ra == 1, nil
+}
+
 func (r *groupRepositoryPostgres) removeAllMembers(ctx context.Context, txn *sql.Tx, groupID string) error {
 	stmt, err := txn.PrepareContext(ctx, `
 		DELETE FROM group_member
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tsmweb/user-service/pkg/paging"
+)
+
+// pagingColumns are the SQL expressions of the fields a listing is sorted and filtered by.
+type pagingColumns struct {
+	ID        string
+	Name      string
+	CreatedAt string
+	UpdatedAt string
+}
+
+// pagingClause returns the conditions, to be appended to the WHERE clause, and the ORDER BY
+// and LIMIT clauses that select the page of the query, appending their arguments to args.
+// One item more than the limit is selected to tell whether there are more pages.
+func pagingClause(q *paging.Query, cols pagingColumns, args []interface{}) (string, string, []interface{}) {
+	var where strings.Builder
+
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if q.NamePrefix != "" {
+		fmt.Fprintf(&where, " AND %s ILIKE %s", cols.Name, arg(escapeLike(q.NamePrefix)+"%"))
+	}
+	if !q.UpdatedSince.IsZero() {
+		fmt.Fprintf(&where, " AND %s > %s", cols.UpdatedAt, arg(q.UpdatedSince))
+	}
+
+	sortCol, cast := cols.Name, ""
+	switch q.Sort {
+	case paging.SortCreatedAt:
+		sortCol, cast = cols.CreatedAt, "::timestamp"
+	case paging.SortUpdatedAt:
+		sortCol, cast = cols.UpdatedAt, "::timestamp"
+	}
+
+	op, dir := ">", "ASC"
+	if q.Desc {
+		op, dir = "<", "DESC"
+	}
+
+	if q.After != nil {
+		fmt.Fprintf(&where, " AND (%s, %s) %s (%s%s, %s)", sortCol, cols.ID, op,
+			arg(q.After.Value), cast, arg(q.After.ID))
+	}
+
+	order := fmt.Sprintf(" ORDER BY %s %s, %s %s LIMIT %s", sortCol, dir, cols.ID, dir,
+		arg(q.Limit+1))
+
+	return where.String(), order, args
+}
+
+// escapeLike escapes the wildcards of the LIKE patterns.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
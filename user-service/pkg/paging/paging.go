@@ -0,0 +1,159 @@
+// Package paging provides the cursor-based pagination, sorting and filtering of the listings,
+// so that every listing endpoint accepts the same query parameters and returns the same
+// page metadata.
+//
+// A cursor is the position of the last item of a page in the sort order: the value of the
+// sort field and the ID of the item, which breaks the ties. The next page starts after it,
+// so pages are not shifted by items created or deleted in the meantime.
+package paging
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tsmweb/go-helper-api/cerror"
+)
+
+const (
+	// DefaultLimit is the number of items of a page when the limit is not informed.
+	DefaultLimit = 50
+	// MaxLimit is the maximum number of items of a page.
+	MaxLimit = 200
+)
+
+// Sort is a field the items can be sorted by.
+type Sort string
+
+const (
+	SortName      Sort = "name"
+	SortCreatedAt Sort = "created_at"
+	SortUpdatedAt Sort = "updated_at"
+)
+
+var (
+	ErrLimitValidateModel        = &cerror.ErrValidateModel{Msg: "limit must be between 1 and " + strconv.Itoa(MaxLimit)}
+	ErrSortValidateModel         = &cerror.ErrValidateModel{Msg: "sort must be name, created_at or updated_at, optionally prefixed by -"}
+	ErrUpdatedSinceValidateModel = &cerror.ErrValidateModel{Msg: "updated_since must be an RFC 3339 date"}
+	ErrCursorValidateModel       = &cerror.ErrValidateModel{Msg: "invalid cursor"}
+)
+
+// Query selects a page of items.
+type Query struct {
+	Limit int
+	Sort  Sort
+	Desc  bool
+	// NamePrefix, if not empty, selects the items whose name starts with it, ignoring case.
+	NamePrefix string
+	// UpdatedSince, if not zero, selects the items created or updated after it.
+	UpdatedSince time.Time
+	// After is the cursor of the last item of the previous page, nil for the first page.
+	After *Cursor
+}
+
+// NewQuery returns the query of the first page sorted by name.
+func NewQuery() *Query {
+	return &Query{Limit: DefaultLimit, Sort: SortName}
+}
+
+// FromRequest returns the query informed by the parameters limit, sort, name, updated_since
+// and cursor of the request. sort is a Sort, prefixed by "-" for the descending order.
+func FromRequest(r *http.Request) (*Query, error) {
+	q := NewQuery()
+	params := r.URL.Query()
+
+	if v := params.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > MaxLimit {
+			return nil, ErrLimitValidateModel
+		}
+		q.Limit = limit
+	}
+
+	if v := params.Get("sort"); v != "" {
+		q.Desc = strings.HasPrefix(v, "-")
+		q.Sort = Sort(strings.TrimPrefix(v, "-"))
+		if q.Sort != SortName && q.Sort != SortCreatedAt && q.Sort != SortUpdatedAt {
+			return nil, ErrSortValidateModel
+		}
+	}
+
+	q.NamePrefix = strings.TrimSpace(params.Get("name"))
+
+	if v := params.Get("updated_since"); v != "" {
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return nil, ErrUpdatedSinceValidateModel
+		}
+		q.UpdatedSince = t.UTC()
+	}
+
+	if v := params.Get("cursor"); v != "" {
+		c, err := DecodeCursor(v)
+		if err != nil || c.Sort != q.Sort || c.Desc != q.Desc {
+			return nil, ErrCursorValidateModel
+		}
+		q.After = c
+	}
+
+	return q, nil
+}
+
+// CursorAt returns the cursor of the item in the sort order of the query.
+func (q *Query) CursorAt(id, name string, createdAt, updatedAt time.Time) *Cursor {
+	c := &Cursor{Sort: q.Sort, Desc: q.Desc, ID: id}
+	switch q.Sort {
+	case SortCreatedAt:
+		c.Value = createdAt.UTC().Format(time.RFC3339Nano)
+	case SortUpdatedAt:
+		c.Value = updatedAt.UTC().Format(time.RFC3339Nano)
+	default:
+		c.Value = name
+	}
+	return c
+}
+
+// Cursor is the position of an item in the sort order.
+type Cursor struct {
+	Sort  Sort   `json:"s"`
+	Desc  bool   `json:"d,omitempty"`
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+// Encode returns the cursor as an opaque URL-safe string.
+func (c *Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor returns the cursor encoded by Encode.
+func DecodeCursor(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	c := &Cursor{}
+	if err = json.Unmarshal(b, c); err != nil {
+		return nil, err
+	}
+	if c.ID == "" {
+		return nil, ErrCursorValidateModel
+	}
+	return c, nil
+}
+
+// Page is the metadata of a page of items.
+type Page struct {
+	Limit int
+	// Next is the cursor of the next page, nil on the last page.
+	Next *Cursor
+}
+
+// HasMore reports whether there are items after the page.
+func (p *Page) HasMore() bool {
+	return p.Next != nil
+}
@@ -0,0 +1,75 @@
+package paging
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromRequest(t *testing.T) {
+	//t.Parallel()
+	t.Run("when no parameter is informed", func(t *testing.T) {
+		//t.Parallel()
+		q, err := FromRequest(httptest.NewRequest("GET", "/v1/contact", nil))
+		assert.Nil(t, err)
+		assert.Equal(t, NewQuery(), q)
+	})
+
+	t.Run("when parameters are informed", func(t *testing.T) {
+		//t.Parallel()
+		after := &Cursor{Sort: SortUpdatedAt, Desc: true, Value: "2021-06-01T10:00:00Z", ID: "+5518977777777"}
+		q, err := FromRequest(httptest.NewRequest("GET", "/v1/contact?limit=10&sort=-updated_at"+
+			"&name=Bi&updated_since=2021-06-01T00:00:00-03:00&cursor="+after.Encode(), nil))
+
+		assert.Nil(t, err)
+		assert.Equal(t, &Query{
+			Limit:        10,
+			Sort:         SortUpdatedAt,
+			Desc:         true,
+			NamePrefix:   "Bi",
+			UpdatedSince: time.Date(2021, 6, 1, 3, 0, 0, 0, time.UTC),
+			After:        after,
+		}, q)
+	})
+
+	t.Run("when parameters are invalid", func(t *testing.T) {
+		//t.Parallel()
+		tests := []struct {
+			query string
+			want  error
+		}{
+			{"limit=0", ErrLimitValidateModel},
+			{"limit=201", ErrLimitValidateModel},
+			{"limit=ten", ErrLimitValidateModel},
+			{"sort=owner", ErrSortValidateModel},
+			{"updated_since=yesterday", ErrUpdatedSinceValidateModel},
+			{"cursor=invalid", ErrCursorValidateModel},
+			{"sort=created_at&cursor=" + (&Cursor{Sort: SortName, ID: "1"}).Encode(), ErrCursorValidateModel},
+		}
+
+		for _, tc := range tests {
+			_, err := FromRequest(httptest.NewRequest("GET", "/v1/contact?"+tc.query, nil))
+			assert.Equal(t, tc.want, err, tc.query)
+		}
+	})
+}
+
+func TestQuery_CursorAt(t *testing.T) {
+	//t.Parallel()
+	createdAt := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+
+	q := NewQuery()
+	assert.Equal(t, &Cursor{Sort: SortName, Value: "Bill", ID: "1"},
+		q.CursorAt("1", "Bill", createdAt, updatedAt))
+
+	q.Sort, q.Desc = SortUpdatedAt, true
+	c := q.CursorAt("1", "Bill", createdAt, updatedAt)
+	assert.Equal(t, &Cursor{Sort: SortUpdatedAt, Desc: true, Value: "2021-06-01T11:00:00Z", ID: "1"}, c)
+
+	decoded, err := DecodeCursor(c.Encode())
+	assert.Nil(t, err)
+	assert.Equal(t, c, decoded)
+}
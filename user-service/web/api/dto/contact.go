@@ -53,3 +53,81 @@ func EntityToContactDTO(entities ...*contact.Contact) []*Contact {
 
 	return contacts
 }
+
+// ContactImport data
+type ContactImport struct {
+	Contacts []*ContactImportEntry `json:"contacts"`
+	Hashed   bool                  `json:"hashed"`
+	Add      bool                  `json:"add"`
+}
+
+// ContactImportEntry data
+type ContactImportEntry struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	LastName string `json:"lastname"`
+}
+
+// ToEntity mapper dto.ContactImport to []contact.ImportEntry
+func (c *ContactImport) ToEntity() []*contact.ImportEntry {
+	entries := make([]*contact.ImportEntry, 0, len(c.Contacts))
+
+	for _, e := range c.Contacts {
+		if e == nil {
+			continue
+		}
+		entries = append(entries, &contact.ImportEntry{
+			Key:      e.ID,
+			Name:     e.Name,
+			LastName: e.LastName,
+		})
+	}
+
+	return entries
+}
+
+// ContactImported data
+type ContactImported struct {
+	ID     string `json:"id"`
+	UserID string `json:"user_id"`
+	Added  bool   `json:"added"`
+}
+
+// EntityToContactImportedDTO mapper []contact.ImportResult to []dto.ContactImported
+func EntityToContactImportedDTO(entities ...*contact.ImportResult) []*ContactImported {
+	imported := make([]*ContactImported, 0, len(entities))
+
+	for _, result := range entities {
+		imported = append(imported, &ContactImported{
+			ID:     result.Key,
+			UserID: result.UserID,
+			Added:  result.Added,
+		})
+	}
+
+	return imported
+}
+
+// Block data
+type Block struct {
+	ID     string `json:"id"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// BlockedUser data
+type BlockedUser struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	LastName  string    `json:"lastname"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FromEntity mapper contact.BlockedUser to dto.BlockedUser
+func (b *BlockedUser) FromEntity(entity *contact.BlockedUser) {
+	b.ID = entity.ID
+	b.Name = entity.Name
+	b.LastName = entity.LastName
+	b.Reason = entity.Reason
+	b.CreatedAt = entity.CreatedAt
+}
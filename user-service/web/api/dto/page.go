@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"github.com/tsmweb/user-service/app/contact"
+	"github.com/tsmweb/user-service/app/group"
+	"github.com/tsmweb/user-service/pkg/paging"
+)
+
+// Page data
+type Page struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// FromEntity mapper paging.Page to dto.Page
+func (p *Page) FromEntity(entity *paging.Page) {
+	p.Limit = entity.Limit
+	p.HasMore = entity.HasMore()
+	if entity.Next != nil {
+		p.NextCursor = entity.Next.Encode()
+	}
+}
+
+// ContactPage data
+type ContactPage struct {
+	Data []*Contact `json:"data"`
+	Page *Page      `json:"page"`
+}
+
+// EntityToContactPageDTO mapper []contact.Contact and paging.Page to dto.ContactPage
+func EntityToContactPageDTO(page *paging.Page, entities ...*contact.Contact) *ContactPage {
+	p := &ContactPage{Data: make([]*Contact, 0, len(entities)), Page: &Page{}}
+	p.Data = append(p.Data, EntityToContactDTO(entities...)...)
+	p.Page.FromEntity(page)
+	return p
+}
+
+// BlockedUserPage data
+type BlockedUserPage struct {
+	Data []*BlockedUser `json:"data"`
+	Page *Page          `json:"page"`
+}
+
+// EntityToBlockedUserPageDTO mapper []contact.BlockedUser and paging.Page to dto.BlockedUserPage
+func EntityToBlockedUserPageDTO(page *paging.Page, entities ...*contact.BlockedUser) *BlockedUserPage {
+	p := &BlockedUserPage{Data: make([]*BlockedUser, 0, len(entities)), Page: &Page{}}
+	for _, entity := range entities {
+		b := &BlockedUser{}
+		b.FromEntity(entity)
+		p.Data = append(p.Data, b)
+	}
+	p.Page.FromEntity(page)
+	return p
+}
+
+// GroupPage data
+type GroupPage struct {
+	Data []*Group `json:"data"`
+	Page *Page    `json:"page"`
+}
+
+// EntityToGroupPageDTO mapper []group.Group and paging.Page to dto.GroupPage
+func EntityToGroupPageDTO(page *paging.Page, entities ...*group.Group) *GroupPage {
+	p := &GroupPage{Data: make([]*Group, 0, len(entities)), Page: &Page{}}
+	p.Data = append(p.Data, EntityToGroupDTO(entities...)...)
+	p.Page.FromEntity(page)
+	return p
+}
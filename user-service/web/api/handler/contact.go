@@ -5,11 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"github.com/gorilla/mux"
+	"github.com/tsmweb/chat-server/pkg/scope"
 	"github.com/tsmweb/go-helper-api/auth"
 	"github.com/tsmweb/go-helper-api/cerror"
 	"github.com/tsmweb/go-helper-api/httputil"
 	"github.com/tsmweb/go-helper-api/middleware"
 	"github.com/tsmweb/user-service/app/contact"
+	"github.com/tsmweb/user-service/pkg/paging"
 	"github.com/tsmweb/user-service/web/api/dto"
 	"github.com/urfave/negroni"
 	"log"
@@ -50,7 +52,8 @@ func GetContact(jwt auth.JWT, getUseCase contact.GetUseCase) http.Handler {
 	})
 }
 
-// GetAllContacts get all contacts from the profile.
+// GetAllContacts get a page of contacts from the profile, selected by the paging.FromRequest
+// query parameters.
 func GetAllContacts(jwt auth.JWT, getAllUseCase contact.GetAllUseCase) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		data, err := jwt.GetDataToken(r, "id")
@@ -61,7 +64,13 @@ func GetAllContacts(jwt auth.JWT, getAllUseCase contact.GetAllUseCase) http.Hand
 		}
 		userID := data.(string)
 
-		contacts, err := getAllUseCase.Execute(r.Context(), userID)
+		q, err := paging.FromRequest(r)
+		if err != nil {
+			httputil.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		contacts, page, err := getAllUseCase.Execute(r.Context(), userID, q)
 		if err != nil {
 			log.Println(err.Error())
 
@@ -74,7 +83,7 @@ func GetAllContacts(jwt auth.JWT, getAllUseCase contact.GetAllUseCase) http.Hand
 			return
 		}
 
-		vms := dto.EntityToContactDTO(contacts...)
+		vms := dto.EntityToContactPageDTO(page, contacts...)
 		httputil.RespondWithJSON(w, http.StatusOK, vms)
 	})
 }
@@ -167,6 +176,58 @@ func CreateContact(jwt auth.JWT, createUseCase contact.CreateUseCase) http.Handl
 	})
 }
 
+// ImportContacts looks up the phone-book entries among the users, adding them as contacts
+// if requested.
+func ImportContacts(jwt auth.JWT, importUseCase contact.ImportUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !httputil.HasContentType(r, httputil.MimeApplicationJSON) {
+			httputil.RespondWithError(w, http.StatusUnsupportedMediaType, http.StatusText(http.StatusUnsupportedMediaType))
+			return
+		}
+
+		data, err := jwt.GetDataToken(r, "id")
+		if err != nil || data == nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+		userID := data.(string)
+
+		input := &dto.ContactImport{}
+		err = json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusUnprocessableEntity, "Malformed JSON")
+			return
+		}
+
+		// Adding the contacts also requires the write scope.
+		if input.Add && !scope.Authorize(jwt, w, r, scope.ContactsWrite) {
+			return
+		}
+
+		results, err := importUseCase.Execute(r.Context(), userID, input.ToEntity(), input.Hashed, input.Add)
+		if err != nil {
+			log.Println(err.Error())
+
+			var errValidateModel *cerror.ErrValidateModel
+			if errors.As(err, &errValidateModel) {
+				httputil.RespondWithError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			if errors.Is(err, contact.ErrTooManyImports) {
+				httputil.RespondWithError(w, http.StatusTooManyRequests, err.Error())
+				return
+			}
+
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		httputil.RespondWithJSON(w, http.StatusOK, dto.EntityToContactImportedDTO(results...))
+	})
+}
+
 // UpdateContact updates contact data.
 func UpdateContact(jwt auth.JWT, updateUseCase contact.UpdateUseCase) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -246,7 +307,37 @@ func DeleteContact(jwt auth.JWT, deleteUseCase contact.DeleteUseCase) http.Handl
 	})
 }
 
-// BlockContact blocks a profile from receiving a message.
+// GetAllBlockedContacts get a page of the users blocked by the profile, selected by the
+// paging.FromRequest query parameters.
+func GetAllBlockedContacts(jwt auth.JWT, getAllBlockedUseCase contact.GetAllBlockedUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := jwt.GetDataToken(r, "id")
+		if err != nil || data == nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+		userID := data.(string)
+
+		q, err := paging.FromRequest(r)
+		if err != nil {
+			httputil.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		users, page, err := getAllBlockedUseCase.Execute(r.Context(), userID, q)
+		if err != nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		vms := dto.EntityToBlockedUserPageDTO(page, users...)
+		httputil.RespondWithJSON(w, http.StatusOK, vms)
+	})
+}
+
+// BlockContact blocks a profile from receiving a message, with an optional reason.
 func BlockContact(jwt auth.JWT, blockUseCase contact.BlockUseCase) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !httputil.HasContentType(r, httputil.MimeApplicationJSON) {
@@ -262,7 +353,7 @@ func BlockContact(jwt auth.JWT, blockUseCase contact.BlockUseCase) http.Handler
 		}
 		userID := data.(string)
 
-		input := &dto.Contact{}
+		input := &dto.Block{}
 		err = json.NewDecoder(r.Body).Decode(&input)
 		if err != nil {
 			log.Println(err.Error())
@@ -270,10 +361,16 @@ func BlockContact(jwt auth.JWT, blockUseCase contact.BlockUseCase) http.Handler
 			return
 		}
 
-		err = blockUseCase.Execute(r.Context(), userID, input.ID)
+		err = blockUseCase.Execute(r.Context(), userID, input.ID, input.Reason)
 		if err != nil {
 			log.Println(err.Error())
 
+			var errValidateModel *cerror.ErrValidateModel
+			if errors.As(err, &errValidateModel) {
+				httputil.RespondWithError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
 			if errors.Is(err, contact.ErrUserNotFound) {
 				httputil.RespondWithError(w, http.StatusNotFound, err.Error())
 				return
@@ -292,6 +389,58 @@ func BlockContact(jwt auth.JWT, blockUseCase contact.BlockUseCase) http.Handler
 	})
 }
 
+// ReportContact reports a profile to the moderators for abuse and blocks it.
+func ReportContact(jwt auth.JWT, reportUseCase contact.ReportUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !httputil.HasContentType(r, httputil.MimeApplicationJSON) {
+			httputil.RespondWithError(w, http.StatusUnsupportedMediaType, http.StatusText(http.StatusUnsupportedMediaType))
+			return
+		}
+
+		data, err := jwt.GetDataToken(r, "id")
+		if err != nil || data == nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+		userID := data.(string)
+
+		input := &dto.Block{}
+		err = json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusUnprocessableEntity, "Malformed JSON")
+			return
+		}
+
+		err = reportUseCase.Execute(r.Context(), userID, input.ID, input.Reason)
+		if err != nil {
+			log.Println(err.Error())
+
+			var errValidateModel *cerror.ErrValidateModel
+			if errors.As(err, &errValidateModel) {
+				httputil.RespondWithError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			if errors.Is(err, contact.ErrUserNotFound) {
+				httputil.RespondWithError(w, http.StatusNotFound, err.Error())
+				return
+			}
+
+			if errors.Is(err, contact.ErrTooManyReports) {
+				httputil.RespondWithError(w, http.StatusTooManyRequests, err.Error())
+				return
+			}
+
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
 // UnblockContact unblock a profile to receive message.
 func UnblockContact(jwt auth.JWT, unblockUseCase contact.UnblockUseCase) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -343,53 +492,86 @@ func MakeContactRouters(
 	updateUseCase contact.UpdateUseCase,
 	deleteUseCase contact.DeleteUseCase,
 	blockUseCase contact.BlockUseCase,
-	unblockUseCase contact.UnblockUseCase) {
+	unblockUseCase contact.UnblockUseCase,
+	importUseCase contact.ImportUseCase,
+	getAllBlockedUseCase contact.GetAllBlockedUseCase,
+	reportUseCase contact.ReportUseCase) {
+
+	// contact/block [GET]
+	// Registered before contact/{id}, which would match it otherwise.
+	r.Handle(fmt.Sprintf("%s/block", contactResource), negroni.New(
+		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.Require(jwt, scope.ContactsRead)),
+		negroni.Wrap(GetAllBlockedContacts(jwt, getAllBlockedUseCase))),
+	).Methods(http.MethodGet)
 
 	// contact/{id} [GET]
 	r.Handle(fmt.Sprintf("%s/{id}", contactResource), negroni.New(
 		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.Require(jwt, scope.ContactsRead)),
 		negroni.Wrap(GetContact(jwt, getUseCase))),
 	).Methods(http.MethodGet)
 
 	// contact [GET]
 	r.Handle(contactResource, negroni.New(
 		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.Require(jwt, scope.ContactsRead)),
 		negroni.Wrap(GetAllContacts(jwt, getAllUseCase))),
 	).Methods(http.MethodGet)
 
 	// contact/presence/{id} [GET]
 	r.Handle(fmt.Sprintf("%s/presence/{id}", contactResource), negroni.New(
 		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.Require(jwt, scope.ContactsRead)),
 		negroni.Wrap(GetContactPresence(jwt, getPresenceUseCase))),
 	).Methods(http.MethodGet)
 
 	// contact [POST]
 	r.Handle(contactResource, negroni.New(
 		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.Require(jwt, scope.ContactsWrite)),
 		negroni.Wrap(CreateContact(jwt, createUseCase))),
 	).Methods(http.MethodPost)
 
 	// contact [PUT]
 	r.Handle(contactResource, negroni.New(
 		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.Require(jwt, scope.ContactsWrite)),
 		negroni.Wrap(UpdateContact(jwt, updateUseCase))),
 	).Methods(http.MethodPut)
 
 	// contact/{id} [DELETE]
 	r.Handle(fmt.Sprintf("%s/{id}", contactResource), negroni.New(
 		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.Require(jwt, scope.ContactsWrite)),
 		negroni.Wrap(DeleteContact(jwt, deleteUseCase))),
 	).Methods(http.MethodDelete)
 
+	// contact/import [POST], adding the contacts also requires contacts:write
+	r.Handle(fmt.Sprintf("%s/import", contactResource), negroni.New(
+		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.Require(jwt, scope.ContactsRead)),
+		negroni.Wrap(ImportContacts(jwt, importUseCase))),
+	).Methods(http.MethodPost)
+
 	// contact/block [POST]
 	r.Handle(fmt.Sprintf("%s/block", contactResource), negroni.New(
 		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.Require(jwt, scope.ContactsWrite)),
 		negroni.Wrap(BlockContact(jwt, blockUseCase))),
 	).Methods(http.MethodPost)
 
 	// contact/block/{id} [DELETE]
 	r.Handle(fmt.Sprintf("%s/block/{id}", contactResource), negroni.New(
 		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.Require(jwt, scope.ContactsWrite)),
 		negroni.Wrap(UnblockContact(jwt, unblockUseCase))),
 	).Methods(http.MethodDelete)
+
+	// contact/report [POST]
+	r.Handle(fmt.Sprintf("%s/report", contactResource), negroni.New(
+		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.Require(jwt, scope.ContactsWrite)),
+		negroni.Wrap(ReportContact(jwt, reportUseCase))),
+	).Methods(http.MethodPost)
 }
@@ -10,10 +10,13 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/tsmweb/user-service/app/contact"
 	"github.com/tsmweb/user-service/common"
+	"github.com/tsmweb/user-service/pkg/paging"
 	"github.com/tsmweb/user-service/web/api/dto"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestHandler_GetContact(t *testing.T) {
@@ -151,8 +154,8 @@ func TestHandler_GetAllContacts(t *testing.T) {
 			Return("+5518999999999", nil).
 			Once()
 		mGetAllUseCase := new(mockContactGetAllUseCase)
-		mGetAllUseCase.On("Execute", mock.Anything, mock.Anything).
-			Return(nil, contact.ErrContactNotFound).
+		mGetAllUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, nil, contact.ErrContactNotFound).
 			Once()
 
 		GetAllContacts(mJWT, mGetAllUseCase).ServeHTTP(rec, req)
@@ -170,8 +173,8 @@ func TestHandler_GetAllContacts(t *testing.T) {
 			Return("+5518999999999", nil).
 			Once()
 		mGetAllUseCase := new(mockContactGetAllUseCase)
-		mGetAllUseCase.On("Execute", mock.Anything, mock.Anything).
-			Return(nil, errors.New("error")).
+		mGetAllUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, nil, errors.New("error")).
 			Once()
 
 		GetAllContacts(mJWT, mGetAllUseCase).ServeHTTP(rec, req)
@@ -179,6 +182,22 @@ func TestHandler_GetAllContacts(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, rec.Code)
 	})
 
+	t.Run("when handler.GetAllContacts return StatusBadRequest", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodGet, contactResource+"?sort=owner", nil)
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return("+5518999999999", nil).
+			Once()
+		mGetAllUseCase := new(mockContactGetAllUseCase)
+
+		GetAllContacts(mJWT, mGetAllUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
 	t.Run("when handler.GetAllContacts return StatusOK", func(t *testing.T) {
 		//t.Parallel()
 		contacts := []*contact.Contact{
@@ -194,7 +213,8 @@ func TestHandler_GetAllContacts(t *testing.T) {
 			},
 		}
 
-		p := dto.EntityToContactDTO(contacts...)
+		page := &paging.Page{Limit: paging.DefaultLimit}
+		p := dto.EntityToContactPageDTO(page, contacts...)
 		cj, err := json.Marshal(p)
 		assert.Nil(t, err)
 
@@ -206,8 +226,8 @@ func TestHandler_GetAllContacts(t *testing.T) {
 			Return("+5518999999999", nil).
 			Once()
 		mGetAllUseCase := new(mockContactGetAllUseCase)
-		mGetAllUseCase.On("Execute", mock.Anything, mock.Anything).
-			Return(contacts, nil).
+		mGetAllUseCase.On("Execute", mock.Anything, mock.Anything, paging.NewQuery()).
+			Return(contacts, page, nil).
 			Once()
 
 		GetAllContacts(mJWT, mGetAllUseCase).ServeHTTP(rec, req)
@@ -785,6 +805,97 @@ func TestHandler_DeleteContact(t *testing.T) {
 	})
 }
 
+func TestHandler_GetAllBlockedContacts(t *testing.T) {
+	//t.Parallel()
+	path := fmt.Sprintf("%s/block", contactResource)
+
+	t.Run("when JWT fails with ErrInternalServer", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return(nil, errors.New("error")).
+			Once()
+		mGetAllBlockedUseCase := new(mockContactGetAllBlockedUseCase)
+
+		GetAllBlockedContacts(mJWT, mGetAllBlockedUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("when handler.GetAllBlockedContacts return StatusBadRequest", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodGet, path+"?limit=0", nil)
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return("+5518999999999", nil).
+			Once()
+		mGetAllBlockedUseCase := new(mockContactGetAllBlockedUseCase)
+
+		GetAllBlockedContacts(mJWT, mGetAllBlockedUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("when handler.GetAllBlockedContacts return StatusInternalServerError", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return("+5518999999999", nil).
+			Once()
+		mGetAllBlockedUseCase := new(mockContactGetAllBlockedUseCase)
+		mGetAllBlockedUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, nil, errors.New("error")).
+			Once()
+
+		GetAllBlockedContacts(mJWT, mGetAllBlockedUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("when handler.GetAllBlockedContacts return StatusOK", func(t *testing.T) {
+		//t.Parallel()
+		users := []*contact.BlockedUser{
+			{
+				ID:        "+5518977777777",
+				Name:      "Bill",
+				LastName:  "Gates",
+				Reason:    "spam",
+				CreatedAt: time.Now().UTC(),
+			},
+		}
+
+		page := &paging.Page{Limit: paging.DefaultLimit}
+		p := dto.EntityToBlockedUserPageDTO(page, users...)
+		uj, err := json.Marshal(p)
+		assert.Nil(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return("+5518999999999", nil).
+			Once()
+		mGetAllBlockedUseCase := new(mockContactGetAllBlockedUseCase)
+		mGetAllBlockedUseCase.On("Execute", mock.Anything, "+5518999999999", paging.NewQuery()).
+			Return(users, page, nil).
+			Once()
+
+		GetAllBlockedContacts(mJWT, mGetAllBlockedUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, string(uj), rec.Body.String())
+	})
+}
+
 func TestHandler_BlockContact(t *testing.T) {
 	//t.Parallel()
 
@@ -839,9 +950,38 @@ func TestHandler_BlockContact(t *testing.T) {
 		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
 	})
 
+	t.Run("when handler.BlockContact return StatusBadRequest", func(t *testing.T) {
+		//t.Parallel()
+		p := &dto.Block{
+			ID:     "+5518977777777",
+			Reason: strings.Repeat("a", contact.MaxReasonLength+1),
+		}
+
+		pj, err := json.Marshal(p)
+		assert.Nil(t, err)
+
+		path := fmt.Sprintf("%s/block", contactResource)
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(pj))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return("+5518999999999", nil).
+			Once()
+		mBlockUseCase := new(mockContactBlockUseCase)
+		mBlockUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything, p.Reason).
+			Return(contact.ErrReasonValidateModel).
+			Once()
+
+		BlockContact(mJWT, mBlockUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
 	t.Run("when handler.BlockContact return StatusNotFound", func(t *testing.T) {
 		//t.Parallel()
-		p := &dto.Contact{
+		p := &dto.Block{
 			ID: "+5518977777777",
 		}
 
@@ -858,7 +998,7 @@ func TestHandler_BlockContact(t *testing.T) {
 			Return("+5518999999999", nil).
 			Once()
 		mBlockUseCase := new(mockContactBlockUseCase)
-		mBlockUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything).
+		mBlockUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(contact.ErrUserNotFound).
 			Once()
 
@@ -869,7 +1009,7 @@ func TestHandler_BlockContact(t *testing.T) {
 
 	t.Run("when handler.BlockContact return StatusConflict", func(t *testing.T) {
 		//t.Parallel()
-		p := &dto.Contact{
+		p := &dto.Block{
 			ID: "+5518977777777",
 		}
 
@@ -886,7 +1026,7 @@ func TestHandler_BlockContact(t *testing.T) {
 			Return("+5518999999999", nil).
 			Once()
 		mBlockUseCase := new(mockContactBlockUseCase)
-		mBlockUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything).
+		mBlockUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(contact.ErrContactAlreadyBlocked).
 			Once()
 
@@ -897,7 +1037,7 @@ func TestHandler_BlockContact(t *testing.T) {
 
 	t.Run("when handler.BlockContact return StatusInternalServerError", func(t *testing.T) {
 		//t.Parallel()
-		p := &dto.Contact{
+		p := &dto.Block{
 			ID: "+5518977777777",
 		}
 
@@ -914,7 +1054,7 @@ func TestHandler_BlockContact(t *testing.T) {
 			Return("+5518999999999", nil).
 			Once()
 		mBlockUseCase := new(mockContactBlockUseCase)
-		mBlockUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything).
+		mBlockUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(errors.New("error")).
 			Once()
 
@@ -925,7 +1065,7 @@ func TestHandler_BlockContact(t *testing.T) {
 
 	t.Run("when handler.BlockContact return StatusOK", func(t *testing.T) {
 		//t.Parallel()
-		p := &dto.Contact{
+		p := &dto.Block{
 			ID: "+5518977777777",
 		}
 
@@ -942,7 +1082,7 @@ func TestHandler_BlockContact(t *testing.T) {
 			Return("+5518999999999", nil).
 			Once()
 		mBlockUseCase := new(mockContactBlockUseCase)
-		mBlockUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything).
+		mBlockUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(nil).
 			Once()
 
@@ -952,6 +1092,129 @@ func TestHandler_BlockContact(t *testing.T) {
 	})
 }
 
+func TestHandler_ReportContact(t *testing.T) {
+	//t.Parallel()
+	path := fmt.Sprintf("%s/report", contactResource)
+
+	newRequest := func(t *testing.T, p *dto.Block) *http.Request {
+		pj, err := json.Marshal(p)
+		assert.Nil(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(pj))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("when handler.ReportContact return StatusUnsupportedMediaType", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader([]byte("{}")))
+		req.Header.Set("Content-Type", "text/plain")
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mReportUseCase := new(mockContactReportUseCase)
+
+		ReportContact(mJWT, mReportUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	})
+
+	t.Run("when handler.ReportContact return StatusBadRequest", func(t *testing.T) {
+		//t.Parallel()
+		req := newRequest(t, &dto.Block{ID: "+5518977777777"})
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return("+5518999999999", nil).
+			Once()
+		mReportUseCase := new(mockContactReportUseCase)
+		mReportUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything, "").
+			Return(contact.ErrReportReasonValidateModel).
+			Once()
+
+		ReportContact(mJWT, mReportUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("when handler.ReportContact return StatusNotFound", func(t *testing.T) {
+		//t.Parallel()
+		req := newRequest(t, &dto.Block{ID: "+5518977777777", Reason: "spam"})
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return("+5518999999999", nil).
+			Once()
+		mReportUseCase := new(mockContactReportUseCase)
+		mReportUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(contact.ErrUserNotFound).
+			Once()
+
+		ReportContact(mJWT, mReportUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("when handler.ReportContact return StatusTooManyRequests", func(t *testing.T) {
+		//t.Parallel()
+		req := newRequest(t, &dto.Block{ID: "+5518977777777", Reason: "spam"})
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return("+5518999999999", nil).
+			Once()
+		mReportUseCase := new(mockContactReportUseCase)
+		mReportUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(contact.ErrTooManyReports).
+			Once()
+
+		ReportContact(mJWT, mReportUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	})
+
+	t.Run("when handler.ReportContact return StatusInternalServerError", func(t *testing.T) {
+		//t.Parallel()
+		req := newRequest(t, &dto.Block{ID: "+5518977777777", Reason: "spam"})
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return("+5518999999999", nil).
+			Once()
+		mReportUseCase := new(mockContactReportUseCase)
+		mReportUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Once()
+
+		ReportContact(mJWT, mReportUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("when handler.ReportContact return StatusOK", func(t *testing.T) {
+		//t.Parallel()
+		req := newRequest(t, &dto.Block{ID: "+5518977777777", Reason: "spam"})
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return("+5518999999999", nil).
+			Once()
+		mReportUseCase := new(mockContactReportUseCase)
+		mReportUseCase.On("Execute", mock.Anything, "+5518999999999", "+5518977777777", "spam").
+			Return(nil).
+			Once()
+
+		ReportContact(mJWT, mReportUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
 func TestHandler_UnblockContact(t *testing.T) {
 	//t.Parallel()
 
@@ -1048,3 +1311,152 @@ func TestHandler_UnblockContact(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 	})
 }
+
+func TestHandler_ImportContacts(t *testing.T) {
+	//t.Parallel()
+	resource := fmt.Sprintf("%s/import", contactResource)
+
+	newRequest := func(add bool) *http.Request {
+		p := &dto.ContactImport{
+			Contacts: []*dto.ContactImportEntry{
+				{ID: "+5518977777777", Name: "Bill", LastName: "Gates"},
+				{ID: "+5518966666666", Name: "Steve", LastName: "Jobs"},
+			},
+			Add: add,
+		}
+		pj, _ := json.Marshal(p)
+
+		req := httptest.NewRequest(http.MethodPost, resource, bytes.NewReader(pj))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	newJWT := func(scopes string) *common.MockJWT {
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, "id").
+			Return("+5518999999999", nil)
+		mJWT.On("GetDataToken", mock.Anything, "scope").
+			Return(scopes, nil)
+		return mJWT
+	}
+
+	t.Run("when handler.ImportContacts return StatusUnsupportedMediaType", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodPost, resource, bytes.NewReader([]byte("{}")))
+		req.Header.Set("Content-Type", "text/plain")
+		rec := httptest.NewRecorder()
+
+		mImportUseCase := new(mockContactImportUseCase)
+
+		ImportContacts(new(common.MockJWT), mImportUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	})
+
+	t.Run("when handler.ImportContacts return StatusUnprocessableEntity", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodPost, resource, bytes.NewReader([]byte("{[}")))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		mImportUseCase := new(mockContactImportUseCase)
+
+		ImportContacts(newJWT(""), mImportUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("when handler.ImportContacts return StatusForbidden", func(t *testing.T) {
+		//t.Parallel()
+		rec := httptest.NewRecorder()
+
+		mImportUseCase := new(mockContactImportUseCase)
+
+		ImportContacts(newJWT("contacts:read"), mImportUseCase).ServeHTTP(rec, newRequest(true))
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		mImportUseCase.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything)
+	})
+
+	t.Run("when handler.ImportContacts return StatusUnauthorized", func(t *testing.T) {
+		//t.Parallel()
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, "id").
+			Return("+5518999999999", nil)
+		mJWT.On("GetDataToken", mock.Anything, "scope").
+			Return(nil, errors.New("error"))
+		mImportUseCase := new(mockContactImportUseCase)
+
+		ImportContacts(mJWT, mImportUseCase).ServeHTTP(rec, newRequest(true))
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		mImportUseCase.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything)
+	})
+
+	t.Run("when handler.ImportContacts return StatusBadRequest", func(t *testing.T) {
+		//t.Parallel()
+		rec := httptest.NewRecorder()
+
+		mImportUseCase := new(mockContactImportUseCase)
+		mImportUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, contact.ErrImportLimitValidateModel).
+			Once()
+
+		ImportContacts(newJWT(""), mImportUseCase).ServeHTTP(rec, newRequest(false))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("when handler.ImportContacts return StatusTooManyRequests", func(t *testing.T) {
+		//t.Parallel()
+		rec := httptest.NewRecorder()
+
+		mImportUseCase := new(mockContactImportUseCase)
+		mImportUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, contact.ErrTooManyImports).
+			Once()
+
+		ImportContacts(newJWT(""), mImportUseCase).ServeHTTP(rec, newRequest(false))
+
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	})
+
+	t.Run("when handler.ImportContacts return StatusInternalServerError", func(t *testing.T) {
+		//t.Parallel()
+		rec := httptest.NewRecorder()
+
+		mImportUseCase := new(mockContactImportUseCase)
+		mImportUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, errors.New("error")).
+			Once()
+
+		ImportContacts(newJWT(""), mImportUseCase).ServeHTTP(rec, newRequest(false))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("when handler.ImportContacts return StatusOK", func(t *testing.T) {
+		//t.Parallel()
+		rec := httptest.NewRecorder()
+
+		mImportUseCase := new(mockContactImportUseCase)
+		mImportUseCase.On("Execute", mock.Anything, "+5518999999999", mock.MatchedBy(func(entries []*contact.ImportEntry) bool {
+			return len(entries) == 2 && entries[0].Key == "+5518977777777" && entries[0].Name == "Bill"
+		}), false, true).
+			Return([]*contact.ImportResult{{Key: "+5518977777777", UserID: "+5518977777777", Added: true}}, nil).
+			Once()
+
+		ImportContacts(newJWT("contacts:read contacts:write"), mImportUseCase).ServeHTTP(rec, newRequest(true))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var imported []*dto.ContactImported
+		assert.Nil(t, json.NewDecoder(rec.Body).Decode(&imported))
+		assert.Equal(t, []*dto.ContactImported{{ID: "+5518977777777", UserID: "+5518977777777", Added: true}}, imported)
+		mImportUseCase.AssertExpectations(t)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/stretchr/testify/mock"
 	"github.com/tsmweb/user-service/app/contact"
+	"github.com/tsmweb/user-service/pkg/paging"
 )
 
 // mockContactGetUseCase injects mock dependency into Handler layer.
@@ -26,12 +27,12 @@ type mockContactGetAllUseCase struct {
 }
 
 // Execute represents the simulated method for the Execute feature in the UseCase layer.
-func (m *mockContactGetAllUseCase) Execute(ctx context.Context, userID string) ([]*contact.Contact, error) {
-	args := m.Called(ctx, userID)
+func (m *mockContactGetAllUseCase) Execute(ctx context.Context, userID string, q *paging.Query) ([]*contact.Contact, *paging.Page, error) {
+	args := m.Called(ctx, userID, q)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, nil, args.Error(2)
 	}
-	return args.Get(0).([]*contact.Contact), nil
+	return args.Get(0).([]*contact.Contact), args.Get(1).(*paging.Page), nil
 }
 
 // mockContactGetPresenceUseCase injects mock dependency into Handler layer.
@@ -87,8 +88,33 @@ type mockContactBlockUseCase struct {
 }
 
 // Execute represents the simulated method for the Execute feature in the UseCase layer.
-func (m *mockContactBlockUseCase) Execute(ctx context.Context, userID, contactID string) error {
-	args := m.Called(ctx, userID, contactID)
+func (m *mockContactBlockUseCase) Execute(ctx context.Context, userID, contactID, reason string) error {
+	args := m.Called(ctx, userID, contactID, reason)
+	return args.Error(0)
+}
+
+// mockContactGetAllBlockedUseCase injects mock dependency into Handler layer.
+type mockContactGetAllBlockedUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockContactGetAllBlockedUseCase) Execute(ctx context.Context, userID string, q *paging.Query) ([]*contact.BlockedUser, *paging.Page, error) {
+	args := m.Called(ctx, userID, q)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]*contact.BlockedUser), args.Get(1).(*paging.Page), nil
+}
+
+// mockContactReportUseCase injects mock dependency into Handler layer.
+type mockContactReportUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockContactReportUseCase) Execute(ctx context.Context, userID, contactID, reason string) error {
+	args := m.Called(ctx, userID, contactID, reason)
 	return args.Error(0)
 }
 
@@ -102,3 +128,18 @@ func (m *mockContactUnblockUseCase) Execute(ctx context.Context, userID, contact
 	args := m.Called(ctx, userID, contactID)
 	return args.Error(0)
 }
+
+// mockContactImportUseCase injects mock dependency into Handler layer.
+type mockContactImportUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockContactImportUseCase) Execute(ctx context.Context, userID string, entries []*contact.ImportEntry,
+	hashed, add bool) ([]*contact.ImportResult, error) {
+	args := m.Called(ctx, userID, entries, hashed, add)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*contact.ImportResult), nil
+}
@@ -6,12 +6,14 @@ import (
 	"errors"
 	"fmt"
 	"github.com/gorilla/mux"
+	"github.com/tsmweb/chat-server/pkg/scope"
 	"github.com/tsmweb/go-helper-api/auth"
 	"github.com/tsmweb/go-helper-api/cerror"
 	"github.com/tsmweb/go-helper-api/httputil"
 	"github.com/tsmweb/go-helper-api/middleware"
 	"github.com/tsmweb/user-service/app/group"
 	"github.com/tsmweb/user-service/common"
+	"github.com/tsmweb/user-service/pkg/paging"
 	"github.com/tsmweb/user-service/web/api/dto"
 	"github.com/urfave/negroni"
 	"log"
@@ -53,7 +55,8 @@ func GetGroup(jwt auth.JWT, getUseCase group.GetUseCase) http.Handler {
 	})
 }
 
-// GetAllGroups get all the groups that the user is a member of.
+// GetAllGroups get a page of the groups that the user is a member of, selected by the
+// paging.FromRequest query parameters.
 func GetAllGroups(jwt auth.JWT, getAllUseCase group.GetAllUseCase) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		data, err := jwt.GetDataToken(r, "id")
@@ -65,7 +68,13 @@ func GetAllGroups(jwt auth.JWT, getAllUseCase group.GetAllUseCase) http.Handler
 		userID := data.(string)
 		ctx := context.WithValue(r.Context(), common.AuthContextKey, userID)
 
-		groups, err := getAllUseCase.Execute(ctx, userID)
+		q, err := paging.FromRequest(r)
+		if err != nil {
+			httputil.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		groups, page, err := getAllUseCase.Execute(ctx, userID, q)
 		if err != nil {
 			log.Println(err.Error())
 
@@ -78,7 +87,7 @@ func GetAllGroups(jwt auth.JWT, getAllUseCase group.GetAllUseCase) http.Handler
 			return
 		}
 
-		groupsDto := dto.EntityToGroupDTO(groups...)
+		groupsDto := dto.EntityToGroupPageDTO(page, groups...)
 		httputil.RespondWithJSON(w, http.StatusOK, groupsDto)
 	})
 }
@@ -367,16 +376,103 @@ func SetGroupAdmin(jwt auth.JWT, setAdminUseCase group.SetAdminUseCase) http.Han
 	})
 }
 
+// LeaveGroup removes the profile from the group, handing the group over if the profile owns it.
+func LeaveGroup(jwt auth.JWT, leaveGroupUseCase group.LeaveGroupUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := jwt.GetDataToken(r, "id")
+		if err != nil || data == nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+		userID := data.(string)
+		ctx := context.WithValue(r.Context(), common.AuthContextKey, userID)
+
+		vars := mux.Vars(r)
+		groupID := vars["group"]
+
+		err = leaveGroupUseCase.Execute(ctx, groupID)
+		if err != nil {
+			log.Println(err.Error())
+
+			if errors.Is(err, group.ErrMemberNotFound) || errors.Is(err, group.ErrGroupNotFound) {
+				httputil.RespondWithError(w, http.StatusNotFound, err.Error())
+				return
+			}
+
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// TransferGroupOwnership hands the group over from the profile to a member.
+func TransferGroupOwnership(jwt auth.JWT, transferOwnershipUseCase group.TransferOwnershipUseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !httputil.HasContentType(r, httputil.MimeApplicationJSON) {
+			httputil.RespondWithError(w, http.StatusUnsupportedMediaType, http.StatusText(http.StatusUnsupportedMediaType))
+			return
+		}
+
+		data, err := jwt.GetDataToken(r, "id")
+		if err != nil || data == nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+		userID := data.(string)
+		ctx := context.WithValue(r.Context(), common.AuthContextKey, userID)
+
+		input := &dto.Member{}
+		err = json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			log.Println(err.Error())
+			httputil.RespondWithError(w, http.StatusUnprocessableEntity, "Malformed JSON")
+			return
+		}
+
+		err = transferOwnershipUseCase.Execute(ctx, input.ToEntity())
+		if err != nil {
+			log.Println(err.Error())
+
+			var errValidateModel *cerror.ErrValidateModel
+			if errors.As(err, &errValidateModel) {
+				httputil.RespondWithError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			if errors.Is(err, group.ErrOperationNotAllowed) {
+				httputil.RespondWithError(w, http.StatusUnauthorized, err.Error())
+				return
+			}
+
+			if errors.Is(err, group.ErrMemberNotFound) || errors.Is(err, group.ErrGroupNotFound) {
+				httputil.RespondWithError(w, http.StatusNotFound, err.Error())
+				return
+			}
+
+			httputil.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
 const groupApiVersion string = "v1"
 
 var (
 	groupResource  string
 	memberResource string
+	ownerResource  string
 )
 
 func init() {
 	groupResource = fmt.Sprintf("/%s/group", groupApiVersion)
 	memberResource = fmt.Sprintf("/%s/group/member", groupApiVersion)
+	ownerResource = fmt.Sprintf("/%s/group/owner", groupApiVersion)
 }
 
 // MakeGroupRouters creates a router for Group.
@@ -391,53 +487,77 @@ func MakeGroupRouters(
 	deleteUseCase group.DeleteUseCase,
 	addMemberUseCase group.AddMemberUseCase,
 	removeMemberUseCase group.RemoveMemberUseCase,
-	setAdminUseCase group.SetAdminUseCase) {
+	setAdminUseCase group.SetAdminUseCase,
+	leaveGroupUseCase group.LeaveGroupUseCase,
+	transferOwnershipUseCase group.TransferOwnershipUseCase) {
 
 	// group/{id} [GET]
 	r.Handle(fmt.Sprintf("%s/{id}", groupResource), negroni.New(
 		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.Require(jwt, scope.GroupsRead)),
 		negroni.Wrap(GetGroup(jwt, getUseCase))),
 	).Methods(http.MethodGet)
 
 	// group [GET]
 	r.Handle(groupResource, negroni.New(
 		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.Require(jwt, scope.GroupsRead)),
 		negroni.Wrap(GetAllGroups(jwt, getAllUseCase))),
 	).Methods(http.MethodGet)
 
 	// group [POST]
 	r.Handle(groupResource, negroni.New(
 		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.Require(jwt, scope.GroupsWrite)),
 		negroni.Wrap(CreateGroup(jwt, createUseCase))),
 	).Methods(http.MethodPost)
 
 	// group [PUT]
 	r.Handle(groupResource, negroni.New(
 		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.Require(jwt, scope.GroupsWrite)),
 		negroni.Wrap(UpdateGroup(jwt, updateUseCase))),
 	).Methods(http.MethodPut)
 
 	// group/{id} [DELETE]
 	r.Handle(fmt.Sprintf("%s/{id}", groupResource), negroni.New(
 		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.Require(jwt, scope.GroupsWrite)),
 		negroni.Wrap(DeleteGroup(jwt, deleteUseCase))),
 	).Methods(http.MethodDelete)
 
 	// group/member [POST]
 	r.Handle(memberResource, negroni.New(
 		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.Require(jwt, scope.GroupsWrite)),
 		negroni.Wrap(AddGroupMember(jwt, addMemberUseCase))),
 	).Methods(http.MethodPost)
 
 	// group/member/{group}/{user} [DELETE]
 	r.Handle(fmt.Sprintf("%s/{group}/{user}", memberResource), negroni.New(
 		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.Require(jwt, scope.GroupsWrite)),
 		negroni.Wrap(RemoveGroupMember(jwt, removeMemberUseCase))),
 	).Methods(http.MethodDelete)
 
 	// group/member [PUT]
 	r.Handle(memberResource, negroni.New(
 		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.Require(jwt, scope.GroupsWrite)),
 		negroni.Wrap(SetGroupAdmin(jwt, setAdminUseCase))),
 	).Methods(http.MethodPut)
+
+	// group/member/{group} [DELETE]
+	r.Handle(fmt.Sprintf("%s/{group}", memberResource), negroni.New(
+		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.Require(jwt, scope.GroupsWrite)),
+		negroni.Wrap(LeaveGroup(jwt, leaveGroupUseCase))),
+	).Methods(http.MethodDelete)
+
+	// group/owner [PUT]
+	r.Handle(ownerResource, negroni.New(
+		negroni.HandlerFunc(auth.RequireTokenAuth),
+		negroni.HandlerFunc(scope.Require(jwt, scope.GroupsWrite)),
+		negroni.Wrap(TransferGroupOwnership(jwt, transferOwnershipUseCase))),
+	).Methods(http.MethodPut)
 }
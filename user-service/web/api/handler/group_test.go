@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/tsmweb/user-service/app/group"
 	"github.com/tsmweb/user-service/common"
+	"github.com/tsmweb/user-service/pkg/paging"
 	"github.com/tsmweb/user-service/web/api/dto"
 	"net/http"
 	"net/http/httptest"
@@ -171,8 +172,8 @@ func TestHandler_GetAllGroups(t *testing.T) {
 			Return("+5518999999999", nil).
 			Once()
 		mGetAllUseCase := new(mockGroupGetAllUseCase)
-		mGetAllUseCase.On("Execute", mock.Anything, mock.Anything).
-			Return(nil, group.ErrGroupNotFound).
+		mGetAllUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, nil, group.ErrGroupNotFound).
 			Once()
 
 		GetAllGroups(mJWT, mGetAllUseCase).ServeHTTP(rec, req)
@@ -190,8 +191,8 @@ func TestHandler_GetAllGroups(t *testing.T) {
 			Return("+5518999999999", nil).
 			Once()
 		mGetAllUseCase := new(mockGroupGetAllUseCase)
-		mGetAllUseCase.On("Execute", mock.Anything, mock.Anything).
-			Return(nil, errors.New("error")).
+		mGetAllUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, nil, errors.New("error")).
 			Once()
 
 		GetAllGroups(mJWT, mGetAllUseCase).ServeHTTP(rec, req)
@@ -199,6 +200,22 @@ func TestHandler_GetAllGroups(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, rec.Code)
 	})
 
+	t.Run("when handler.GetAllGroups return StatusBadRequest", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodGet, groupResource+"?sort=owner", nil)
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return("+5518999999999", nil).
+			Once()
+		mGetAllUseCase := new(mockGroupGetAllUseCase)
+
+		GetAllGroups(mJWT, mGetAllUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
 	t.Run("when handler.GetAllGroups return StatusOK", func(t *testing.T) {
 		//t.Parallel()
 		groups := []*group.Group{
@@ -216,7 +233,8 @@ func TestHandler_GetAllGroups(t *testing.T) {
 			},
 		}
 
-		p := dto.EntityToGroupDTO(groups...)
+		page := &paging.Page{Limit: paging.DefaultLimit}
+		p := dto.EntityToGroupPageDTO(page, groups...)
 		gj, err := json.Marshal(p)
 		assert.Nil(t, err)
 
@@ -228,8 +246,8 @@ func TestHandler_GetAllGroups(t *testing.T) {
 			Return("+5518999999999", nil).
 			Once()
 		mGetAllUseCase := new(mockGroupGetAllUseCase)
-		mGetAllUseCase.On("Execute", mock.Anything, mock.Anything).
-			Return(groups, nil).
+		mGetAllUseCase.On("Execute", mock.Anything, mock.Anything, paging.NewQuery()).
+			Return(groups, page, nil).
 			Once()
 
 		GetAllGroups(mJWT, mGetAllUseCase).ServeHTTP(rec, req)
@@ -1250,3 +1268,190 @@ func TestHandler_SetGroupAdmin(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 	})
 }
+
+func TestHandler_LeaveGroup(t *testing.T) {
+	//t.Parallel()
+	path := fmt.Sprintf("%s/be49afd2ee890805c21ddd55879db1387aec9751", memberResource)
+
+	serve := func(mJWT *common.MockJWT, mLeaveGroupUseCase *mockGroupLeaveGroupUseCase) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodDelete, path, nil)
+		rec := httptest.NewRecorder()
+
+		router := mux.NewRouter()
+		router.Handle(fmt.Sprintf("%s/{group}", memberResource), LeaveGroup(mJWT, mLeaveGroupUseCase)).
+			Methods(http.MethodDelete)
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("when JWT fails with ErrInternalServer", func(t *testing.T) {
+		//t.Parallel()
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return(nil, errors.New("error")).
+			Once()
+		mLeaveGroupUseCase := new(mockGroupLeaveGroupUseCase)
+
+		rec := serve(mJWT, mLeaveGroupUseCase)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("when handler.LeaveGroup return StatusNotFound", func(t *testing.T) {
+		//t.Parallel()
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return("+5518999999999", nil).
+			Once()
+		mLeaveGroupUseCase := new(mockGroupLeaveGroupUseCase)
+		mLeaveGroupUseCase.On("Execute", mock.Anything, mock.Anything).
+			Return(group.ErrMemberNotFound).
+			Once()
+
+		rec := serve(mJWT, mLeaveGroupUseCase)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("when handler.LeaveGroup return StatusInternalServerError", func(t *testing.T) {
+		//t.Parallel()
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return("+5518999999999", nil).
+			Once()
+		mLeaveGroupUseCase := new(mockGroupLeaveGroupUseCase)
+		mLeaveGroupUseCase.On("Execute", mock.Anything, mock.Anything).
+			Return(errors.New("error")).
+			Once()
+
+		rec := serve(mJWT, mLeaveGroupUseCase)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("when handler.LeaveGroup return StatusOK", func(t *testing.T) {
+		//t.Parallel()
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return("+5518999999999", nil).
+			Once()
+		mLeaveGroupUseCase := new(mockGroupLeaveGroupUseCase)
+		mLeaveGroupUseCase.On("Execute", mock.Anything, "be49afd2ee890805c21ddd55879db1387aec9751").
+			Return(nil).
+			Once()
+
+		rec := serve(mJWT, mLeaveGroupUseCase)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestHandler_TransferGroupOwnership(t *testing.T) {
+	//t.Parallel()
+
+	newRequest := func(t *testing.T, p *dto.Member) *http.Request {
+		pj, err := json.Marshal(p)
+		assert.Nil(t, err)
+
+		req := httptest.NewRequest(http.MethodPut, ownerResource, bytes.NewReader(pj))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	member := &dto.Member{
+		GroupID: "be49afd2ee890805c21ddd55879db1387aec9751",
+		UserID:  "+5518977777777",
+	}
+
+	t.Run("when handler.TransferGroupOwnership return StatusUnsupportedMediaType", func(t *testing.T) {
+		//t.Parallel()
+		req := httptest.NewRequest(http.MethodPut, ownerResource, bytes.NewReader([]byte("{}")))
+		req.Header.Set("Content-Type", "text/plain")
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mTransferOwnershipUseCase := new(mockGroupTransferOwnershipUseCase)
+
+		TransferGroupOwnership(mJWT, mTransferOwnershipUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	})
+
+	t.Run("when handler.TransferGroupOwnership return StatusBadRequest", func(t *testing.T) {
+		//t.Parallel()
+		req := newRequest(t, &dto.Member{GroupID: member.GroupID})
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return("+5518999999999", nil).
+			Once()
+		mTransferOwnershipUseCase := new(mockGroupTransferOwnershipUseCase)
+		mTransferOwnershipUseCase.On("Execute", mock.Anything, mock.Anything).
+			Return(group.ErrUserIDValidateModel).
+			Once()
+
+		TransferGroupOwnership(mJWT, mTransferOwnershipUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("when handler.TransferGroupOwnership return StatusUnauthorized", func(t *testing.T) {
+		//t.Parallel()
+		req := newRequest(t, member)
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return("+5518999999999", nil).
+			Once()
+		mTransferOwnershipUseCase := new(mockGroupTransferOwnershipUseCase)
+		mTransferOwnershipUseCase.On("Execute", mock.Anything, mock.Anything).
+			Return(group.ErrOperationNotAllowed).
+			Once()
+
+		TransferGroupOwnership(mJWT, mTransferOwnershipUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("when handler.TransferGroupOwnership return StatusNotFound", func(t *testing.T) {
+		//t.Parallel()
+		req := newRequest(t, member)
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return("+5518999999999", nil).
+			Once()
+		mTransferOwnershipUseCase := new(mockGroupTransferOwnershipUseCase)
+		mTransferOwnershipUseCase.On("Execute", mock.Anything, mock.Anything).
+			Return(group.ErrMemberNotFound).
+			Once()
+
+		TransferGroupOwnership(mJWT, mTransferOwnershipUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("when handler.TransferGroupOwnership return StatusOK", func(t *testing.T) {
+		//t.Parallel()
+		req := newRequest(t, member)
+		rec := httptest.NewRecorder()
+
+		mJWT := new(common.MockJWT)
+		mJWT.On("GetDataToken", mock.Anything, mock.Anything).
+			Return("+5518999999999", nil).
+			Once()
+		mTransferOwnershipUseCase := new(mockGroupTransferOwnershipUseCase)
+		mTransferOwnershipUseCase.On("Execute", mock.Anything, mock.MatchedBy(func(m *group.Member) bool {
+			return m.GroupID == member.GroupID && m.UserID == member.UserID
+		})).
+			Return(nil).
+			Once()
+
+		TransferGroupOwnership(mJWT, mTransferOwnershipUseCase).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/stretchr/testify/mock"
 	"github.com/tsmweb/user-service/app/group"
+	"github.com/tsmweb/user-service/pkg/paging"
 )
 
 // mockGroupGetUseCase injects mock dependency into handler layer.
@@ -26,12 +27,12 @@ type mockGroupGetAllUseCase struct {
 }
 
 // Execute represents the simulated method for the Execute feature in the UseCase layer.
-func (m *mockGroupGetAllUseCase) Execute(ctx context.Context, userID string) ([]*group.Group, error) {
-	args := m.Called(ctx, userID)
+func (m *mockGroupGetAllUseCase) Execute(ctx context.Context, userID string, q *paging.Query) ([]*group.Group, *paging.Page, error) {
+	args := m.Called(ctx, userID, q)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, nil, args.Error(2)
 	}
-	return args.Get(0).([]*group.Group), nil
+	return args.Get(0).([]*group.Group), args.Get(1).(*paging.Page), nil
 }
 
 // mockGroupCreateUseCase injects mock dependency into handler layer.
@@ -102,3 +103,25 @@ func (m *mockGroupSetAdminUseCase) Execute(ctx context.Context, member *group.Me
 	args := m.Called(ctx, member)
 	return args.Error(0)
 }
+
+// mockGroupLeaveGroupUseCase injects mock dependency into handler layer.
+type mockGroupLeaveGroupUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockGroupLeaveGroupUseCase) Execute(ctx context.Context, groupID string) error {
+	args := m.Called(ctx, groupID)
+	return args.Error(0)
+}
+
+// mockGroupTransferOwnershipUseCase injects mock dependency into handler layer.
+type mockGroupTransferOwnershipUseCase struct {
+	mock.Mock
+}
+
+// Execute represents the simulated method for the Execute feature in the UseCase layer.
+func (m *mockGroupTransferOwnershipUseCase) Execute(ctx context.Context, member *group.Member) error {
+	args := m.Called(ctx, member)
+	return args.Error(0)
+}